@@ -596,6 +596,121 @@ const docTemplate = `{
                 }
             }
         },
+        "/exchange-rates": {
+            "get": {
+                "description": "get the exchange rates of the currencies prices can be shown in,\nthe rates are the value of one unit in the base currency",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "currencies"
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Slices-dtos_ExchangeRate"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "create or replace the exchange rate of a currency",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "currencies"
+                ],
+                "parameters": [
+                    {
+                        "description": "exchange rate",
+                        "name": "rate",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ExchangeRateUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.OK"
+                        }
+                    }
+                }
+            }
+        },
+        "/exchange-rates/import": {
+            "post": {
+                "description": "import the exchange rates of a CSV file with the columns\ncurrency_code and rate, all of them or none",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "currencies"
+                ],
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "exchange rates",
+                        "name": "rates",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ExchangeRateImport"
+                        }
+                    }
+                }
+            }
+        },
+        "/exchange-rates/{code}": {
+            "delete": {
+                "description": "delete the exchange rate of a currency",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "currencies"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "currency code",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.OK"
+                        }
+                    }
+                }
+            }
+        },
         "/favorite": {
             "get": {
                 "description": "get product from favorite",
@@ -800,6 +915,12 @@ const docTemplate = `{
                         "name": "id",
                         "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "currency to show the prices in",
+                        "name": "currency",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -1025,7 +1146,7 @@ const docTemplate = `{
         },
         "/payment": {
             "post": {
-                "description": "check payment service status",
+                "description": "create the payment of an order with a provider, the payment method of the order when no provider is given.\nThe amount is what is left to pay online on the order.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1042,23 +1163,29 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/payment.PaymentRequest"
+                            "$ref": "#/definitions/dtos.PaymentRequest"
                         }
+                    },
+                    {
+                        "type": "string",
+                        "description": "key to safely retry the request",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/payment.PaymentResponse"
+                            "$ref": "#/definitions/dtos.PaymentResponse"
                         }
                     }
                 }
             }
         },
-        "/payment/status": {
+        "/payment/ipn": {
             "get": {
-                "description": "check payment service status",
+                "description": "server to server notification of a VNPay payment, answered in the format VNPay expects.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1068,19 +1195,42 @@ const docTemplate = `{
                 "tags": [
                     "payment"
                 ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "order code",
+                        "name": "vnp_TxnRef",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "amount in VND times 100",
+                        "name": "vnp_Amount",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "checksum of the callback",
+                        "name": "vnp_SecureHash",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/payment.StatusResponse"
+                            "$ref": "#/definitions/dtos.PaymentIPN"
                         }
                     }
                 }
             }
         },
-        "/products": {
-            "get": {
-                "description": "get product information",
+        "/payment/ipn/{provider}": {
+            "post": {
+                "description": "server to server notification of a payment provider, answered in the format the provider expects.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1088,14 +1238,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "products"
+                    "payment"
                 ],
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "limit number of products",
-                        "name": "limit",
-                        "in": "query",
+                        "type": "string",
+                        "description": "payment provider: momo or zalopay",
+                        "name": "provider",
+                        "in": "path",
                         "required": true
                     }
                 ],
@@ -1103,13 +1253,18 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dtos.Slices-dtos_ProductResponse"
+                            "$ref": "#/definitions/zalopay.CallbackAck"
                         }
+                    },
+                    "204": {
+                        "description": "No Content"
                     }
                 }
-            },
-            "put": {
-                "description": "update product information",
+            }
+        },
+        "/payment/query": {
+            "get": {
+                "description": "ask the provider of the last payment of an order for its status.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1117,30 +1272,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "products"
+                    "payment"
                 ],
                 "parameters": [
                     {
-                        "description": "Product Information Request",
-                        "name": "product",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dtos.UpdateProductInfo"
-                        }
+                        "type": "string",
+                        "description": "order code",
+                        "name": "order",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dtos.OK"
+                            "$ref": "#/definitions/dtos.PaymentStatus"
                         }
                     }
                 }
-            },
-            "post": {
-                "description": "create new product",
+            }
+        },
+        "/payment/refunds": {
+            "get": {
+                "description": "list the refunds of an order with their status at the provider, oldest first.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1148,30 +1303,31 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "products"
+                    "payment"
                 ],
                 "parameters": [
                     {
-                        "description": "Product Request",
-                        "name": "product",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dtos.ProductRequest"
-                        }
+                        "type": "string",
+                        "description": "order code",
+                        "name": "order",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dtos.CreateProduct"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dtos.PaymentRefund"
+                            }
                         }
                     }
                 }
             },
-            "delete": {
-                "description": "delete product by id",
+            "post": {
+                "description": "pay back a paid order through the provider of its payment, the part not refunded yet when no amount is given. The refund is paid back by the worker.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1179,30 +1335,38 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "products"
+                    "payment"
                 ],
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "product id",
-                        "name": "pid",
-                        "in": "query",
-                        "required": true
+                        "description": "refund request",
+                        "name": "refund",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.PaymentRefundRequest"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "key to safely retry the request",
+                        "name": "Idempotency-Key",
+                        "in": "header"
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "202": {
+                        "description": "Accepted",
                         "schema": {
-                            "$ref": "#/definitions/dtos.OK"
+                            "$ref": "#/definitions/dtos.PaymentRefund"
                         }
                     }
                 }
             }
         },
-        "/products/details": {
+        "/payment/return": {
             "get": {
-                "description": "get product details",
+                "description": "check the payment the customer is redirected back with by VNPay and record it on the order.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1210,13 +1374,27 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "products"
+                    "payment"
                 ],
                 "parameters": [
                     {
-                        "type": "number",
-                        "description": "product id",
-                        "name": "id",
+                        "type": "string",
+                        "description": "order code",
+                        "name": "vnp_TxnRef",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "amount in VND times 100",
+                        "name": "vnp_Amount",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "checksum of the callback",
+                        "name": "vnp_SecureHash",
                         "in": "query",
                         "required": true
                     }
@@ -1225,37 +1403,30 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dtos.ProductDetail"
+                            "$ref": "#/definitions/dtos.PaymentResult"
                         }
                     }
                 }
             }
         },
-        "/products/images": {
-            "put": {
-                "description": "insert new product image",
+        "/payment/return/{provider}": {
+            "get": {
+                "description": "check the payment the customer is redirected back with by a provider and record it on the order.",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "products"
+                    "payment"
                 ],
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "id of product",
-                        "name": "id",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "file",
-                        "description": "image of product",
-                        "name": "img",
-                        "in": "formData",
+                        "description": "payment provider: vnpay, momo or zalopay",
+                        "name": "provider",
+                        "in": "path",
                         "required": true
                     }
                 ],
@@ -1263,21 +1434,15 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dtos.OK"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/dtos.Error"
+                            "$ref": "#/definitions/dtos.PaymentResult"
                         }
                     }
                 }
             }
         },
-        "/products/info": {
+        "/payment/status": {
             "get": {
-                "description": "get product information",
+                "description": "check payment service status",
                 "consumes": [
                     "application/json"
                 ],
@@ -1285,74 +1450,21 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "products"
-                ],
-                "parameters": [
-                    {
-                        "type": "integer",
-                        "description": "products id",
-                        "name": "id",
-                        "in": "query",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/dtos.ProductResponse"
-                        }
-                    }
-                }
-            }
-        },
-        "/products/thumbnail": {
-            "put": {
-                "description": "insert new product image",
-                "consumes": [
-                    "multipart/form-data"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "products"
-                ],
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "id of product",
-                        "name": "id",
-                        "in": "query",
-                        "required": true
-                    },
-                    {
-                        "type": "file",
-                        "description": "image of product",
-                        "name": "img",
-                        "in": "formData",
-                        "required": true
-                    }
+                    "payment"
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dtos.OK"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
-                        "schema": {
-                            "$ref": "#/definitions/dtos.Error"
+                            "$ref": "#/definitions/payment.StatusResponse"
                         }
                     }
                 }
             }
         },
-        "/products/{type}": {
+        "/payment/transactions": {
             "get": {
-                "description": "get product view",
+                "description": "audit the payment transactions of an order, oldest first.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1360,14 +1472,14 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "products"
+                    "payment"
                 ],
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "product type",
-                        "name": "type",
-                        "in": "path",
+                        "description": "order code",
+                        "name": "order",
+                        "in": "query",
                         "required": true
                     }
                 ],
@@ -1377,16 +1489,16 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/dtos.ProductDTO"
+                                "$ref": "#/definitions/dtos.PaymentTransaction"
                             }
                         }
                     }
                 }
             }
         },
-        "/purchase/admin/orders": {
+        "/products": {
             "get": {
-                "description": "get list of orders.",
+                "description": "get product information",
                 "consumes": [
                     "application/json"
                 ],
@@ -1394,31 +1506,34 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "purchase"
+                    "products"
                 ],
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "limit order",
+                        "type": "integer",
+                        "description": "limit number of products",
                         "name": "limit",
                         "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "currency to show the prices in",
+                        "name": "currency",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/dtos.OrderInfo"
-                            }
+                            "$ref": "#/definitions/dtos.Slices-dtos_ProductResponse"
                         }
                     }
                 }
             },
-            "post": {
-                "description": "create order.",
+            "put": {
+                "description": "update product information",
                 "consumes": [
                     "application/json"
                 ],
@@ -1426,16 +1541,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "delivery"
+                    "products"
                 ],
                 "parameters": [
                     {
-                        "description": "order delivery body request",
-                        "name": "order",
+                        "description": "Product Information Request",
+                        "name": "product",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dtos.OrderForm"
+                            "$ref": "#/definitions/dtos.UpdateProductInfo"
                         }
                     }
                 ],
@@ -1447,11 +1562,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/purchase/carts": {
-            "get": {
-                "description": "get list of items from carts",
+            },
+            "post": {
+                "description": "create new product",
                 "consumes": [
                     "application/json"
                 ],
@@ -1459,19 +1572,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "purchase"
+                    "products"
+                ],
+                "parameters": [
+                    {
+                        "description": "Product Request",
+                        "name": "product",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ProductRequest"
+                        }
+                    }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dtos.Carts"
+                            "$ref": "#/definitions/dtos.CreateProduct"
                         }
                     }
                 }
             },
-            "post": {
-                "description": "add item to carts.",
+            "delete": {
+                "description": "delete product by id",
                 "consumes": [
                     "application/json"
                 ],
@@ -1479,17 +1603,15 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "purchase"
+                    "products"
                 ],
                 "parameters": [
                     {
-                        "description": "cart insert request",
-                        "name": "login",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dtos.CartDTO"
-                        }
+                        "type": "integer",
+                        "description": "product id",
+                        "name": "pid",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
@@ -1502,9 +1624,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/purchase/carts/{id}": {
-            "delete": {
-                "description": "delete item from carts",
+        "/products/details": {
+            "get": {
+                "description": "get product details",
                 "consumes": [
                     "application/json"
                 ],
@@ -1512,53 +1634,80 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "purchase"
+                    "products"
                 ],
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "inventory id",
+                        "type": "number",
+                        "description": "product id",
                         "name": "id",
-                        "in": "path",
+                        "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "currency to show the prices in",
+                        "name": "currency",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dtos.OK"
+                            "$ref": "#/definitions/dtos.ProductDetail"
                         }
                     }
                 }
             }
         },
-        "/purchase/coupons": {
-            "get": {
-                "description": "get coupon.",
+        "/products/images": {
+            "put": {
+                "description": "insert new product image",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "purchase"
+                    "products"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "id of product",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "image of product",
+                        "name": "img",
+                        "in": "formData",
+                        "required": true
+                    }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/dtos.Coupon"
-                            }
+                            "$ref": "#/definitions/dtos.OK"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Error"
                         }
                     }
                 }
-            },
-            "post": {
-                "description": "create coupon.",
+            }
+        },
+        "/products/info": {
+            "get": {
+                "description": "get product information",
                 "consumes": [
                     "application/json"
                 ],
@@ -1566,61 +1715,80 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "purchase"
+                    "products"
                 ],
                 "parameters": [
                     {
-                        "description": "coupon request",
-                        "name": "coupon",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/dtos.CreateCoupon"
-                        }
+                        "type": "integer",
+                        "description": "products id",
+                        "name": "id",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "currency to show the prices in",
+                        "name": "currency",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dtos.OrderInfo"
+                            "$ref": "#/definitions/dtos.ProductResponse"
                         }
                     }
                 }
-            },
-            "delete": {
-                "description": "delete coupon by code.",
+            }
+        },
+        "/products/thumbnail": {
+            "put": {
+                "description": "insert new product image",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "purchase"
+                    "products"
                 ],
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "coupon code",
-                        "name": "code",
+                        "description": "id of product",
+                        "name": "id",
                         "in": "query",
                         "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "image of product",
+                        "name": "img",
+                        "in": "formData",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dtos.OrderInfo"
+                            "$ref": "#/definitions/dtos.OK"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Error"
                         }
                     }
                 }
             }
         },
-        "/purchase/orders": {
+        "/products/{type}": {
             "get": {
-                "description": "get list of orders.",
+                "description": "get product view",
                 "consumes": [
                     "application/json"
                 ],
@@ -1628,15 +1796,21 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "purchase"
+                    "products"
                 ],
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "limit order",
-                        "name": "limit",
-                        "in": "query",
+                        "description": "product type",
+                        "name": "type",
+                        "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "currency to show the prices in",
+                        "name": "currency",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -1645,14 +1819,61 @@ const docTemplate = `{
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/dtos.OrderInfo"
+                                "$ref": "#/definitions/dtos.ProductDTO"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/purchase/admin/bundles": {
+            "get": {
+                "description": "get the bundles, archived ones included.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "purchase"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "active or archived",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "bundles per page",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dtos.Bundle"
                             }
                         }
                     }
                 }
             },
             "post": {
-                "description": "create order.",
+                "description": "create a bundle of inventory items sold at a combo price.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1664,28 +1885,28 @@ const docTemplate = `{
                 ],
                 "parameters": [
                     {
-                        "description": "order insert request",
-                        "name": "login",
+                        "description": "bundle",
+                        "name": "bundle",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dtos.Order"
+                            "$ref": "#/definitions/dtos.CreateBundle"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/dtos.OrderResponse"
+                            "$ref": "#/definitions/dtos.OK"
                         }
                     }
                 }
             }
         },
-        "/purchase/orders/status": {
+        "/purchase/admin/bundles/{id}": {
             "put": {
-                "description": "update order status.",
+                "description": "replace a bundle and its items.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1697,12 +1918,19 @@ const docTemplate = `{
                 ],
                 "parameters": [
                     {
-                        "description": "order status request",
-                        "name": "status",
+                        "type": "integer",
+                        "description": "bundle id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "bundle",
+                        "name": "bundle",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dtos.OrderStatus"
+                            "$ref": "#/definitions/dtos.CreateBundle"
                         }
                     }
                 ],
@@ -1714,11 +1942,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/purchase/orders/{code}": {
-            "get": {
-                "description": "get order by code.",
+            },
+            "delete": {
+                "description": "delete a bundle, the orders keep their lines.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1726,13 +1952,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "delivery"
+                    "purchase"
                 ],
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "order code",
-                        "name": "code",
+                        "type": "integer",
+                        "description": "bundle id",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
@@ -1741,15 +1967,15 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dtos.OrderInfo"
+                            "$ref": "#/definitions/dtos.OK"
                         }
                     }
                 }
             }
         },
-        "/rating/{id}": {
-            "put": {
-                "description": "update inventory image",
+        "/purchase/admin/carts/reminders": {
+            "get": {
+                "description": "summary of the abandoned cart reminder emails sent",
                 "consumes": [
                     "application/json"
                 ],
@@ -1757,45 +1983,43 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "ratings"
-                ],
-                "parameters": [
-                    {
-                        "type": "number",
-                        "description": "id of product",
-                        "name": "star",
-                        "in": "query",
-                        "required": true
-                    }
+                    "purchase"
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dtos.OK"
+                            "$ref": "#/definitions/dtos.CartReminderStats"
                         }
                     }
                 }
             }
         },
-        "/search": {
-            "get": {
-                "description": "get product",
+        "/purchase/admin/cod/remittances": {
+            "post": {
+                "description": "import a carrier COD remittance report in CSV. The header\nnames the columns order_code, client_order_code, cod_amount\nand optionally remitted_at. The lines of a report already\nimported are skipped.",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "search"
+                    "purchase"
                 ],
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "keyword",
-                        "name": "keyword",
-                        "in": "query",
+                        "description": "remittance report code",
+                        "name": "reference",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "remittance report",
+                        "name": "report",
+                        "in": "formData",
                         "required": true
                     }
                 ],
@@ -1803,18 +2027,15 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/dtos.ProductResponse"
-                            }
+                            "$ref": "#/definitions/dtos.RemittanceImport"
                         }
                     }
                 }
             }
         },
-        "/search/details": {
-            "get": {
-                "description": "get product",
+        "/purchase/admin/cod/remittances/sync": {
+            "post": {
+                "description": "import the COD remittances transferred by GHN in a period,\nboth dates included.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1822,33 +2043,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "search"
+                    "purchase"
                 ],
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "keyword",
-                        "name": "key",
-                        "in": "query",
-                        "required": true
+                        "description": "period of the remittances",
+                        "name": "period",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.RemittanceSync"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/dtos.ProductDetail"
-                            }
+                            "$ref": "#/definitions/dtos.RemittanceImport"
                         }
                     }
                 }
             }
         },
-        "/status": {
+        "/purchase/admin/cod/unreconciled": {
             "get": {
-                "description": "health check api server.",
+                "description": "get the COD orders whose cash is not reconciled, oldest\nfirst: delivered orders nothing was remitted for, and the\norders remitted short or over.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1856,18 +2076,47 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "base"
+                    "purchase"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "days since delivery",
+                        "name": "older_than",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "orders per page",
+                        "name": "limit",
+                        "in": "query"
+                    }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK"
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dtos.CODOrder"
+                            }
+                        }
                     }
                 }
             }
         },
-        "/suppliers": {
+        "/purchase/admin/gift-cards": {
             "get": {
-                "description": "get suppliers information",
+                "description": "get the gift cards, newest first.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1875,28 +2124,44 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "suppliers"
+                    "purchase"
                 ],
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "active or voided",
+                        "name": "status",
+                        "in": "query"
+                    },
                     {
                         "type": "integer",
-                        "description": "limit number of suppliers",
+                        "default": 1,
+                        "description": "page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "gift cards per page",
                         "name": "limit",
-                        "in": "query",
-                        "required": true
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/dtos.Slices-entity_Supplier"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dtos.GiftCard"
+                            }
                         }
                     }
                 }
             },
             "post": {
-                "description": "insert new suppliers information",
+                "description": "issue a gift card. The code is generated, a card sold to a\ncustomer names the order that paid for it.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1904,16 +2169,16 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "suppliers"
+                    "purchase"
                 ],
                 "parameters": [
                     {
-                        "description": "Suppliers Request",
-                        "name": "Supplier",
+                        "description": "gift card",
+                        "name": "card",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dtos.Supplier"
+                            "$ref": "#/definitions/dtos.IssueGiftCard"
                         }
                     }
                 ],
@@ -1921,15 +2186,15 @@ const docTemplate = `{
                     "201": {
                         "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/dtos.OK"
+                            "$ref": "#/definitions/dtos.GiftCard"
                         }
                     }
                 }
             }
         },
-        "/users": {
+        "/purchase/admin/gift-cards/{code}": {
             "get": {
-                "description": "get information for users.",
+                "description": "get a gift card with its ledger, consistent is false when the\nledger does not add up to the balance.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1937,19 +2202,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "authentication"
+                    "purchase"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "gift card code",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/model.Users"
+                            "$ref": "#/definitions/dtos.GiftCardAudit"
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "update information for users.",
+            }
+        },
+        "/purchase/admin/gift-cards/{code}/void": {
+            "post": {
+                "description": "void a gift card, its balance is written off.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1957,16 +2233,22 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "authentication"
+                    "purchase"
                 ],
                 "parameters": [
                     {
-                        "description": "Update Users",
-                        "name": "UserSchema",
+                        "type": "string",
+                        "description": "gift card code",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "note",
+                        "name": "req",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/dtos.UserUpdate"
+                            "$ref": "#/definitions/dtos.VoidGiftCard"
                         }
                     }
                 ],
@@ -1980,9 +2262,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/image": {
-            "put": {
-                "description": "update information for users.",
+        "/purchase/admin/inventories/{id}/receive": {
+            "post": {
+                "description": "add the units received for an inventory item to its stock. They are\nallocated to the pre-orders waiting for them, oldest first, before they can be sold.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1990,15 +2272,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "authentication"
+                    "purchase"
                 ],
                 "parameters": [
                     {
-                        "type": "file",
-                        "description": "image of user",
-                        "name": "img",
-                        "in": "formData",
+                        "type": "integer",
+                        "description": "inventory id",
+                        "name": "id",
+                        "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "stock receipt",
+                        "name": "receipt",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.StockReceipt"
+                        }
                     }
                 ],
                 "responses": {
@@ -2011,9 +2302,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/worker": {
+        "/purchase/admin/orders": {
             "get": {
-                "description": "health check worker consume server.",
+                "description": "search orders, newest first unless sorted otherwise. Pass next_cursor\nof a page as cursor, with the same filters, to get the next page.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2021,975 +2312,3965 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "base"
+                    "purchase"
                 ],
-                "responses": {
-                    "200": {
-                        "description": "OK"
-                    }
-                }
-            }
-        }
-    },
-    "definitions": {
-        "dtos.Address": {
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "order status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "payment method",
+                        "name": "payment_method",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "orders placed from, RFC 3339 time or day (2006-01-02)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "orders placed until, RFC 3339 time or day (2006-01-02), inclusive",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "part of the customer email",
+                        "name": "email",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "part of the customer phone number",
+                        "name": "phone",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "minimum total amount",
+                        "name": "min_total",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "maximum total amount",
+                        "name": "max_total",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "coupon code used",
+                        "name": "coupon",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "-time",
+                        "description": "time, -time, total or -total",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "orders per page, up to 100",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.OrderPage"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "create order. Store credit and loyalty points are only spent when the\nrequest is signed in as the customer of the order.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "delivery"
+                ],
+                "parameters": [
+                    {
+                        "description": "order delivery body request",
+                        "name": "order",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.OrderForm"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "key to safely retry the request",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.OK"
+                        }
+                    }
+                }
+            }
+        },
+        "/purchase/admin/orders/export": {
+            "get": {
+                "description": "export the line items of orders to CSV or XLSX, oldest first. The file is\nstreamed in the response, or with async=true written to blob storage by the worker:\nthe response is then the export job, to be followed at /purchase/admin/orders/exports/{id}.",
+                "produces": [
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+                    "application/json"
+                ],
+                "tags": [
+                    "purchase"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "csv",
+                        "description": "csv or xlsx",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "order status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "payment method",
+                        "name": "payment_method",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "orders placed from, RFC 3339 time or day (2006-01-02)",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "orders placed until, RFC 3339 time or day (2006-01-02), inclusive",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "part of the customer email",
+                        "name": "email",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "part of the customer phone number",
+                        "name": "phone",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "run the export on the worker",
+                        "name": "async",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "202": {
+                        "description": "Accepted",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.OrderExportJob"
+                        }
+                    }
+                }
+            }
+        },
+        "/purchase/admin/orders/exports/{id}": {
+            "get": {
+                "description": "get the status of an order export, with its download link once done.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "purchase"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "export id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.OrderExportJob"
+                        }
+                    }
+                }
+            }
+        },
+        "/purchase/admin/orders/{code}/shipments": {
+            "post": {
+                "description": "pack some units of an order in a shipment with its own delivery.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "purchase"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "order code",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "shipment request",
+                        "name": "shipment",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ShipmentForm"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ShipmentResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/purchase/admin/returns": {
+            "get": {
+                "description": "get list of return requests.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "purchase"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "limit return requests",
+                        "name": "limit",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "requested, approved or rejected",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dtos.Return"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/purchase/admin/returns/{id}/approve": {
+            "post": {
+                "description": "approve a return request, record its refund and restock the units.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "purchase"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "return id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "return decision",
+                        "name": "decision",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ReturnDecision"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.OK"
+                        }
+                    }
+                }
+            }
+        },
+        "/purchase/admin/returns/{id}/receive": {
+            "post": {
+                "description": "mark the parcel of a return request as received.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "purchase"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "return id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.OK"
+                        }
+                    }
+                }
+            }
+        },
+        "/purchase/admin/returns/{id}/reject": {
+            "post": {
+                "description": "reject a return request.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "purchase"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "return id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "return decision",
+                        "name": "decision",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ReturnDecision"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.OK"
+                        }
+                    }
+                }
+            }
+        },
+        "/purchase/admin/returns/{id}/restock": {
+            "post": {
+                "description": "put the units of an approved return back into stock.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "purchase"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "return id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.OK"
+                        }
+                    }
+                }
+            }
+        },
+        "/purchase/admin/shipments/{id}": {
+            "put": {
+                "description": "set the carrier order code or the status of a shipment.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "purchase"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "shipment id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "shipment update",
+                        "name": "shipment",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ShipmentUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.OK"
+                        }
+                    }
+                }
+            }
+        },
+        "/purchase/admin/wallets/{id}": {
+            "get": {
+                "description": "get the store credit of a user with its ledger.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "purchase"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "user id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Wallet"
+                        }
+                    }
+                }
+            }
+        },
+        "/purchase/bundles": {
+            "get": {
+                "description": "get the bundles on sale.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "purchase"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "page",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "bundles per page",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dtos.Bundle"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/purchase/bundles/{id}": {
+            "get": {
+                "description": "get a bundle with its items, their list price and stock.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "purchase"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "bundle id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Bundle"
+                        }
+                    }
+                }
+            }
+        },
+        "/purchase/carts": {
+            "get": {
+                "description": "get list of items from carts. Without an Authorization header\nthe guest cart of the session cookie is returned.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "purchase"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "coupon code to preview",
+                        "name": "coupon",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Carts"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "add item to carts. Without an Authorization header the item\ngoes to the guest cart of the session cookie.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "purchase"
+                ],
+                "parameters": [
+                    {
+                        "description": "cart insert request",
+                        "name": "login",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.CartDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.OK"
+                        }
+                    }
+                }
+            }
+        },
+        "/purchase/carts/bundles": {
+            "post": {
+                "description": "add a bundle to the cart of the login user.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "purchase"
+                ],
+                "parameters": [
+                    {
+                        "description": "bundle and quantity",
+                        "name": "bundle",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.CartBundleDTO"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.OK"
+                        }
+                    }
+                }
+            }
+        },
+        "/purchase/carts/bundles/{id}": {
+            "put": {
+                "description": "change the quantity of a bundle in the cart of the login user.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "purchase"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "bundle id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "new quantity",
+                        "name": "req",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.CartQuantity"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.OK"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "remove a bundle from the cart of the login user.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "purchase"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "bundle id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.OK"
+                        }
+                    }
+                }
+            }
+        },
+        "/purchase/carts/merge": {
+            "post": {
+                "description": "merge a guest cart into the cart of the logged in user. The\nitems of the body and the guest cart of the session cookie are both merged.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "purchase"
+                ],
+                "parameters": [
+                    {
+                        "description": "guest cart items",
+                        "name": "req",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.CartMerge"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.OK"
+                        }
+                    }
+                }
+            }
+        },
+        "/purchase/carts/{id}": {
+            "put": {
+                "description": "change the quantity of an item in carts",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "purchase"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "inventory id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "new quantity",
+                        "name": "req",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.CartQuantity"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.OK"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "delete item from carts",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "purchase"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "inventory id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.OK"
+                        }
+                    }
+                }
+            }
+        },
+        "/purchase/coupons": {
+            "get": {
+                "description": "get coupon.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "purchase"
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dtos.Coupon"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "create coupon.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "purchase"
+                ],
+                "parameters": [
+                    {
+                        "description": "coupon request",
+                        "name": "coupon",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.CreateCoupon"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.OrderInfo"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "delete coupon by code.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "purchase"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "coupon code",
+                        "name": "code",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.OrderInfo"
+                        }
+                    }
+                }
+            }
+        },
+        "/purchase/coupons/validate": {
+            "post": {
+                "description": "preview the discount of a coupon for a cart.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "purchase"
+                ],
+                "parameters": [
+                    {
+                        "description": "coupon and cart items",
+                        "name": "coupon",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.CouponValidate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.CouponPreview"
+                        }
+                    }
+                }
+            }
+        },
+        "/purchase/gift-cards/{code}": {
+            "get": {
+                "description": "get the balance of a gift card.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "purchase"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "gift card code",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.GiftCard"
+                        }
+                    }
+                }
+            }
+        },
+        "/purchase/guest/orders": {
+            "post": {
+                "description": "create an order without an account. The ordered items are\nremoved from the guest cart, and the response carries the token to track the order.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "purchase"
+                ],
+                "parameters": [
+                    {
+                        "description": "order delivery body request",
+                        "name": "order",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.OrderForm"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "key to safely retry the request",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.OrderResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/purchase/orders": {
+            "get": {
+                "description": "get list of orders.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "purchase"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "limit order",
+                        "name": "limit",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dtos.OrderInfo"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "create order.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "purchase"
+                ],
+                "parameters": [
+                    {
+                        "description": "order insert request",
+                        "name": "login",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Order"
+                        }
+                    },
+                    {
+                        "type": "string",
+                        "description": "key to safely retry the request",
+                        "name": "Idempotency-Key",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.OrderResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/purchase/orders/status": {
+            "put": {
+                "description": "update order status.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "purchase"
+                ],
+                "parameters": [
+                    {
+                        "description": "order status request",
+                        "name": "status",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.OrderStatus"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.OK"
+                        }
+                    }
+                }
+            }
+        },
+        "/purchase/orders/{code}": {
+            "get": {
+                "description": "get order by code. The caller must be logged in as the owner\nof the order, or send the tracking token of a guest order.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "delivery"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "order code",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "tracking token of a guest order",
+                        "name": "token",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.OrderInfo"
+                        }
+                    }
+                }
+            }
+        },
+        "/purchase/orders/{code}/cancel": {
+            "post": {
+                "description": "cancel an order which is still pending or confirmed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "purchase"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "order code",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "cancel order request",
+                        "name": "reason",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.CancelOrder"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.OK"
+                        }
+                    }
+                }
+            }
+        },
+        "/purchase/orders/{code}/invoice": {
+            "get": {
+                "description": "download the invoice of an order as PDF (default) or HTML. The caller\nmust be logged in as the owner of the order, or send the tracking token of a guest order.",
+                "produces": [
+                    "application/pdf",
+                    "text/html"
+                ],
+                "tags": [
+                    "purchase"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "order code",
+                        "name": "code",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "pdf or html",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "tracking token of a guest order",
+                        "name": "token",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    }
+                }
+            }
+        },
+        "/purchase/returns": {
+            "get": {
+                "description": "get return requests of the current user.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "purchase"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "limit return requests",
+                        "name": "limit",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dtos.Return"
+                            }
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "request a return for some lines of a delivered order.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "purchase"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "order code",
+                        "name": "order_code",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "reason of the return",
+                        "name": "reason",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "json array of {item_id, quantity}",
+                        "name": "items",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "photos of the returned items",
+                        "name": "photos",
+                        "in": "formData"
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.ReturnResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/purchase/wallet": {
+            "get": {
+                "description": "get the store credit of the login user with its ledger.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "purchase"
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Wallet"
+                        }
+                    }
+                }
+            }
+        },
+        "/rating/{id}": {
+            "put": {
+                "description": "update inventory image",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ratings"
+                ],
+                "parameters": [
+                    {
+                        "type": "number",
+                        "description": "id of product",
+                        "name": "star",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.OK"
+                        }
+                    }
+                }
+            }
+        },
+        "/search": {
+            "get": {
+                "description": "get product",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "search"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "keyword",
+                        "name": "keyword",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "currency to show the prices in",
+                        "name": "currency",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dtos.ProductResponse"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/search/details": {
+            "get": {
+                "description": "get product",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "search"
+                ],
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "keyword",
+                        "name": "key",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "currency to show the prices in",
+                        "name": "currency",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/dtos.ProductDetail"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/status": {
+            "get": {
+                "description": "health check api server.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "base"
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    }
+                }
+            }
+        },
+        "/suppliers": {
+            "get": {
+                "description": "get suppliers information",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "suppliers"
+                ],
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "limit number of suppliers",
+                        "name": "limit",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Slices-entity_Supplier"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "insert new suppliers information",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "suppliers"
+                ],
+                "parameters": [
+                    {
+                        "description": "Suppliers Request",
+                        "name": "Supplier",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Supplier"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.OK"
+                        }
+                    }
+                }
+            }
+        },
+        "/users": {
+            "get": {
+                "description": "get information for users.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "authentication"
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/model.Users"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "update information for users.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "authentication"
+                ],
+                "parameters": [
+                    {
+                        "description": "Update Users",
+                        "name": "UserSchema",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.UserUpdate"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.OK"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/image": {
+            "put": {
+                "description": "update information for users.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "authentication"
+                ],
+                "parameters": [
+                    {
+                        "type": "file",
+                        "description": "image of user",
+                        "name": "img",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.OK"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/notifications": {
+            "put": {
+                "description": "turn the email notifications of the user on or off.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "authentication"
+                ],
+                "parameters": [
+                    {
+                        "description": "notification settings",
+                        "name": "req",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/dtos.Notifications"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/dtos.OK"
+                        }
+                    }
+                }
+            }
+        },
+        "/worker": {
+            "get": {
+                "description": "health check worker consume server.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "base"
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "dtos.Address": {
+            "type": "object",
+            "required": [
+                "city",
+                "district",
+                "id",
+                "street",
+                "ward"
+            ],
+            "properties": {
+                "city": {
+                    "type": "string"
+                },
+                "district": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "street": {
+                    "type": "string"
+                },
+                "ward": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.Bookmark": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string"
+                },
+                "color": {
+                    "description": "Color of product",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/dtos.BookmarkItem"
+                        }
+                    ]
+                },
+                "display": {
+                    "description": "Display Super AMOLED",
+                    "type": "string"
+                },
+                "image": {
+                    "description": "Image of product",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "description": "Name of product",
+                    "type": "string"
+                },
+                "price": {
+                    "type": "string"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "rating": {
+                    "type": "number"
+                },
+                "screen": {
+                    "description": "Screen 6.1 inch",
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.BookmarkItem": {
+            "type": "object",
+            "properties": {
+                "color_img": {
+                    "description": "ColorImage of color Nature Titanium",
+                    "type": "string"
+                },
+                "color_name": {
+                    "description": "ColorName Nature Titanium",
+                    "type": "string"
+                },
+                "images": {
+                    "description": "Img of product Nature Titanium",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "specs": {
+                    "$ref": "#/definitions/dtos.SpecsItem"
+                }
+            }
+        },
+        "dtos.Bundle": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "currency_code": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "image": {
+                    "type": "string"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.BundleItem"
+                    }
+                },
+                "list_price": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "out_of_stock": {
+                    "type": "boolean"
+                },
+                "preorder": {
+                    "type": "boolean"
+                },
+                "price": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.BundleItem": {
+            "type": "object",
+            "required": [
+                "inventory_id",
+                "quantity"
+            ],
+            "properties": {
+                "color": {
+                    "type": "string"
+                },
+                "inventory_id": {
+                    "type": "integer"
+                },
+                "item_code": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "string"
+                },
+                "product_name": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dtos.CODOrder": {
+            "type": "object",
+            "properties": {
+                "age_days": {
+                    "type": "integer"
+                },
+                "expected_amount": {
+                    "type": "string"
+                },
+                "order_code": {
+                    "type": "string"
+                },
+                "order_status": {
+                    "type": "string"
+                },
+                "outstanding": {
+                    "type": "string"
+                },
+                "remitted_amount": {
+                    "type": "string"
+                },
+                "since": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.CancelOrder": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.CardArticle": {
+            "type": "object",
+            "required": [
+                "category",
+                "title"
+            ],
+            "properties": {
+                "category": {
+                    "type": "string"
+                },
+                "content": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.CardContent"
+                    }
+                },
+                "src": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.CardContent": {
+            "type": "object",
+            "required": [
+                "content"
+            ],
+            "properties": {
+                "content": {
+                    "type": "string"
+                },
+                "src": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.Cart": {
+            "type": "object",
+            "properties": {
+                "added_price": {
+                    "type": "string"
+                },
+                "archived": {
+                    "type": "boolean"
+                },
+                "available": {
+                    "type": "integer"
+                },
+                "cart_id": {
+                    "type": "integer"
+                },
+                "category": {
+                    "type": "string"
+                },
+                "code": {
+                    "type": "string"
+                },
+                "color": {
+                    "type": "string"
+                },
+                "currency_code": {
+                    "type": "string"
+                },
+                "image": {
+                    "type": "string"
+                },
+                "inventory_id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "out_of_stock": {
+                    "type": "boolean"
+                },
+                "preorder": {
+                    "type": "boolean"
+                },
+                "price": {
+                    "type": "string"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer"
+                },
+                "repriced": {
+                    "type": "boolean"
+                },
+                "specs": {
+                    "$ref": "#/definitions/dtos.Specs"
+                }
+            }
+        },
+        "dtos.CartBundle": {
+            "type": "object",
+            "properties": {
+                "archived": {
+                    "type": "boolean"
+                },
+                "bundle_id": {
+                    "type": "integer"
+                },
+                "currency_code": {
+                    "type": "string"
+                },
+                "image": {
+                    "type": "string"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.BundleItem"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "out_of_stock": {
+                    "type": "boolean"
+                },
+                "preorder": {
+                    "type": "boolean"
+                },
+                "price": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dtos.CartBundleDTO": {
+            "type": "object",
+            "required": [
+                "bundle_id",
+                "quantity"
+            ],
+            "properties": {
+                "bundle_id": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dtos.CartDTO": {
+            "type": "object",
+            "required": [
+                "inventory_id",
+                "quantity"
+            ],
+            "properties": {
+                "inventory_id": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dtos.CartMerge": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.CartDTO"
+                    }
+                }
+            }
+        },
+        "dtos.CartQuantity": {
+            "type": "object",
+            "required": [
+                "quantity"
+            ],
+            "properties": {
+                "quantity": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dtos.CartReminderStats": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "integer"
+                },
+                "last_24h": {
+                    "type": "integer"
+                },
+                "last_sent_at": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dtos.Carts": {
+            "type": "object",
+            "properties": {
+                "bundles": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.CartBundle"
+                    }
+                },
+                "coupon_code": {
+                    "type": "string"
+                },
+                "coupon_error": {
+                    "type": "string"
+                },
+                "discount": {
+                    "type": "string"
+                },
+                "products": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.Cart"
+                    }
+                },
+                "subtotal": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dtos.Color": {
+            "type": "object",
+            "properties": {
+                "img": {
+                    "description": "ImageColor of color Nature Titanium",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "Name Nature Titanium",
+                    "type": "string"
+                },
+                "product": {
+                    "description": "Img of product Nature Titanium",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "specs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.SpecsItem"
+                    }
+                }
+            }
+        },
+        "dtos.Comment": {
+            "type": "object",
+            "required": [
+                "content",
+                "inventory_id",
+                "product_id",
+                "star",
+                "user_id"
+            ],
+            "properties": {
+                "content": {
+                    "type": "string"
+                },
+                "inventory_id": {
+                    "type": "integer"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "star": {
+                    "type": "integer"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dtos.Coupon": {
+            "type": "object",
+            "properties": {
+                "category_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "code": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "discount": {
+                    "type": "integer"
+                },
+                "discount_type": {
+                    "type": "string"
+                },
+                "expired_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "inventory_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "max_discount": {
+                    "type": "string"
+                },
+                "min_order_value": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.CouponPreview": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "discount": {
+                    "type": "string"
+                },
+                "subtotal": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.CouponValidate": {
+            "type": "object",
+            "required": [
+                "coupon_code",
+                "product"
+            ],
+            "properties": {
+                "coupon_code": {
+                    "type": "string"
+                },
+                "product": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/dtos.OrderFormProduct"
+                    }
+                }
+            }
+        },
+        "dtos.CreateBundle": {
+            "type": "object",
+            "required": [
+                "items",
+                "name",
+                "price"
+            ],
+            "properties": {
+                "currency_code": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "image": {
+                    "type": "string"
+                },
+                "items": {
+                    "type": "array",
+                    "minItems": 2,
+                    "items": {
+                        "$ref": "#/definitions/dtos.BundleItem"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "active",
+                        "archived"
+                    ]
+                }
+            }
+        },
+        "dtos.CreateCoupon": {
+            "type": "object",
+            "required": [
+                "description",
+                "discount",
+                "max_day",
+                "max_use",
+                "status"
+            ],
+            "properties": {
+                "category_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "description": {
+                    "type": "string"
+                },
+                "discount": {
+                    "type": "integer"
+                },
+                "discount_type": {
+                    "type": "string",
+                    "enum": [
+                        "percent",
+                        "fixed"
+                    ]
+                },
+                "inventory_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "max_day": {
+                    "type": "integer"
+                },
+                "max_discount": {
+                    "type": "string"
+                },
+                "max_use": {
+                    "type": "integer"
+                },
+                "min_order_value": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.CreateProduct": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "integer"
+                },
+                "msg": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.CreditEntry": {
+            "type": "object",
+            "properties": {
+                "actor": {
+                    "type": "string"
+                },
+                "amount": {
+                    "type": "string"
+                },
+                "balance": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "order_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dtos.DeliveryAddress": {
+            "type": "object",
+            "required": [
+                "city",
+                "district",
+                "street",
+                "user_id",
+                "ward"
+            ],
+            "properties": {
+                "city": {
+                    "type": "string"
+                },
+                "district": {
+                    "type": "string"
+                },
+                "street": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                },
+                "ward": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.DeliveryBody": {
+            "type": "object",
+            "required": [
+                "address_id",
+                "method",
+                "status",
+                "user_id"
+            ],
+            "properties": {
+                "address_id": {
+                    "type": "integer"
+                },
+                "method": {
+                    "type": "string"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "received_date": {
+                    "type": "string"
+                },
+                "sent_date": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dtos.Error": {
+            "type": "object",
+            "properties": {
+                "msg": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.ExchangeRate": {
+            "type": "object",
+            "properties": {
+                "currency_code": {
+                    "type": "string"
+                },
+                "rate": {
+                    "type": "string"
+                },
+                "source": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "updated_by": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.ExchangeRateImport": {
+            "type": "object",
+            "properties": {
+                "imported": {
+                    "type": "integer"
+                },
+                "rates": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.ExchangeRate"
+                    }
+                }
+            }
+        },
+        "dtos.ExchangeRateUpdate": {
+            "type": "object",
+            "required": [
+                "currency_code",
+                "rate"
+            ],
+            "properties": {
+                "currency_code": {
+                    "type": "string"
+                },
+                "rate": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.GiftCard": {
+            "type": "object",
+            "properties": {
+                "balance": {
+                    "type": "string"
+                },
+                "code": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "currency_code": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "initial_balance": {
+                    "type": "string"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "voided_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.GiftCardAudit": {
+            "type": "object",
+            "properties": {
+                "balance": {
+                    "type": "string"
+                },
+                "code": {
+                    "type": "string"
+                },
+                "consistent": {
+                    "type": "boolean"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "currency_code": {
+                    "type": "string"
+                },
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.CreditEntry"
+                    }
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "initial_balance": {
+                    "type": "string"
+                },
+                "ledger_balance": {
+                    "type": "string"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "voided_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.InvUpdate": {
+            "type": "object",
+            "required": [
+                "id"
+            ],
+            "properties": {
+                "available": {
+                    "type": "string"
+                },
+                "currency_code": {
+                    "type": "string"
+                },
+                "deposit_percent": {
+                    "type": "string"
+                },
+                "expected_ship_date": {
+                    "description": "ExpectedShipDate (YYYY-MM-DD) and DepositPercent describe a pre-order item",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "string"
+                },
+                "product_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "active",
+                        "draft",
+                        "archived",
+                        "preorder"
+                    ]
+                }
+            }
+        },
+        "dtos.Inventory": {
             "type": "object",
             "required": [
-                "city",
-                "district",
-                "id",
-                "street",
-                "ward"
+                "available",
+                "currency_code",
+                "price",
+                "product_id"
             ],
             "properties": {
-                "city": {
+                "available": {
                     "type": "string"
                 },
-                "district": {
+                "category": {
+                    "type": "string"
+                },
+                "color": {
+                    "type": "string"
+                },
+                "color_img": {
+                    "type": "string"
+                },
+                "currency_code": {
+                    "type": "string"
+                },
+                "deposit_percent": {
+                    "type": "string"
+                },
+                "expected_ship_date": {
+                    "description": "pre-order items only",
                     "type": "string"
                 },
                 "id": {
                     "type": "integer"
                 },
-                "street": {
+                "image": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "item_code": {
                     "type": "string"
                 },
-                "ward": {
+                "price": {
+                    "type": "string"
+                },
+                "product_id": {
+                    "type": "integer"
+                },
+                "product_img": {
+                    "type": "string"
+                },
+                "product_name": {
+                    "type": "string"
+                },
+                "specs": {
+                    "$ref": "#/definitions/dtos.Specs"
+                },
+                "status": {
                     "type": "string"
                 }
             }
         },
-        "dtos.Bookmark": {
+        "dtos.InventoryDetail": {
             "type": "object",
+            "required": [
+                "available",
+                "currency_code",
+                "price",
+                "product_id"
+            ],
             "properties": {
-                "category": {
+                "available": {
                     "type": "string"
                 },
                 "color": {
-                    "description": "Color of product",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/dtos.BookmarkItem"
-                        }
-                    ]
+                    "type": "string"
                 },
-                "display": {
-                    "description": "Display Super AMOLED",
+                "color_img": {
+                    "type": "string"
+                },
+                "currency_code": {
                     "type": "string"
                 },
                 "image": {
-                    "description": "Image of product",
                     "type": "array",
                     "items": {
                         "type": "string"
                     }
                 },
-                "name": {
-                    "description": "Name of product",
-                    "type": "string"
-                },
                 "price": {
                     "type": "string"
                 },
                 "product_id": {
                     "type": "integer"
                 },
-                "rating": {
-                    "type": "number"
+                "specs": {
+                    "$ref": "#/definitions/dtos.Specs"
                 },
-                "screen": {
-                    "description": "Screen 6.1 inch",
+                "status": {
                     "type": "string"
                 }
             }
         },
-        "dtos.BookmarkItem": {
+        "dtos.InventoryItems": {
             "type": "object",
             "properties": {
-                "color_img": {
-                    "description": "ColorImage of color Nature Titanium",
+                "header": {
+                    "$ref": "#/definitions/dtos.StockHeader"
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "stock": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.Inventory"
+                    }
+                }
+            }
+        },
+        "dtos.IssueGiftCard": {
+            "type": "object",
+            "required": [
+                "amount"
+            ],
+            "properties": {
+                "amount": {
                     "type": "string"
                 },
-                "color_name": {
-                    "description": "ColorName Nature Titanium",
+                "currency_code": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "note": {
+                    "type": "string"
+                },
+                "order_code": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.LoginRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "password"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "password": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.LoginResponse": {
+            "type": "object",
+            "required": [
+                "email",
+                "success",
+                "token"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
+                },
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.News": {
+            "type": "object",
+            "required": [
+                "cards",
+                "header"
+            ],
+            "properties": {
+                "cards": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.CardArticle"
+                    }
+                },
+                "header": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.NewsDTO": {
+            "type": "object",
+            "required": [
+                "cards",
+                "category",
+                "header"
+            ],
+            "properties": {
+                "cards": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.CardArticle"
+                    }
+                },
+                "category": {
+                    "type": "string"
+                },
+                "header": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.Notifications": {
+            "type": "object",
+            "properties": {
+                "cart_reminders": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "dtos.OK": {
+            "type": "object",
+            "properties": {
+                "msg": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.Order": {
+            "type": "object",
+            "required": [
+                "address",
+                "customer",
+                "delivery",
+                "gift_cards",
+                "payment_method"
+            ],
+            "properties": {
+                "address": {
+                    "$ref": "#/definitions/dtos.OrderFormAddress"
+                },
+                "bundles": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.OrderFormBundle"
+                    }
+                },
+                "coupon_code": {
+                    "type": "string"
+                },
+                "currency": {
                     "type": "string"
                 },
-                "images": {
-                    "description": "Img of product Nature Titanium",
+                "customer": {
+                    "$ref": "#/definitions/dtos.OrderFormCustomer"
+                },
+                "delivery": {
+                    "$ref": "#/definitions/dtos.OrderFormDelivery"
+                },
+                "gift_cards": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "payment_method": {
+                    "type": "string"
+                },
+                "points": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "product": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/dtos.OrderFormProduct"
                     }
                 },
-                "specs": {
-                    "$ref": "#/definitions/dtos.SpecsItem"
+                "store_credit": {
+                    "type": "string"
                 }
             }
         },
-        "dtos.CardArticle": {
+        "dtos.OrderExport": {
             "type": "object",
-            "required": [
-                "category",
-                "title"
-            ],
             "properties": {
-                "category": {
+                "email": {
                     "type": "string"
                 },
-                "content": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/dtos.CardContent"
-                    }
+                "format": {
+                    "type": "string",
+                    "enum": [
+                        "csv",
+                        "xlsx"
+                    ]
                 },
-                "src": {
+                "from": {
                     "type": "string"
                 },
-                "title": {
+                "payment_method": {
                     "type": "string"
-                }
-            }
-        },
-        "dtos.CardContent": {
-            "type": "object",
-            "required": [
-                "content"
-            ],
-            "properties": {
-                "content": {
+                },
+                "phone": {
                     "type": "string"
                 },
-                "src": {
+                "status": {
+                    "type": "string"
+                },
+                "to": {
                     "type": "string"
                 }
             }
         },
-        "dtos.Cart": {
+        "dtos.OrderExportJob": {
             "type": "object",
             "properties": {
-                "cart_id": {
-                    "type": "integer"
-                },
-                "category": {
+                "created_at": {
                     "type": "string"
                 },
-                "code": {
+                "error": {
                     "type": "string"
                 },
-                "color": {
-                    "type": "string"
+                "filter": {
+                    "$ref": "#/definitions/dtos.OrderExport"
                 },
-                "currency_code": {
+                "finished_at": {
                     "type": "string"
                 },
-                "image": {
+                "id": {
                     "type": "string"
                 },
-                "inventory_id": {
+                "rows": {
                     "type": "integer"
                 },
-                "name": {
+                "status": {
                     "type": "string"
                 },
-                "price": {
+                "url": {
                     "type": "string"
-                },
-                "product_id": {
-                    "type": "integer"
-                },
-                "quantity": {
-                    "type": "integer"
-                },
-                "specs": {
-                    "$ref": "#/definitions/dtos.Specs"
                 }
             }
         },
-        "dtos.CartDTO": {
+        "dtos.OrderForm": {
             "type": "object",
             "required": [
-                "inventory_id",
-                "quantity"
+                "address",
+                "customer",
+                "delivery",
+                "gift_cards",
+                "payment_method"
             ],
             "properties": {
-                "inventory_id": {
-                    "type": "integer"
+                "address": {
+                    "$ref": "#/definitions/dtos.OrderFormAddress"
                 },
-                "quantity": {
-                    "type": "integer"
-                }
-            }
-        },
-        "dtos.Carts": {
-            "type": "object",
-            "properties": {
-                "products": {
+                "bundles": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/dtos.Cart"
+                        "$ref": "#/definitions/dtos.OrderFormBundle"
                     }
                 },
-                "user_id": {
-                    "type": "integer"
-                }
-            }
-        },
-        "dtos.Color": {
-            "type": "object",
-            "properties": {
-                "img": {
-                    "description": "ImageColor of color Nature Titanium",
+                "coupon_code": {
                     "type": "string"
                 },
-                "name": {
-                    "description": "Name Nature Titanium",
+                "currency": {
                     "type": "string"
                 },
-                "product": {
-                    "description": "Img of product Nature Titanium",
+                "customer": {
+                    "$ref": "#/definitions/dtos.OrderFormCustomer"
+                },
+                "delivery": {
+                    "$ref": "#/definitions/dtos.OrderFormDelivery"
+                },
+                "gift_cards": {
                     "type": "array",
                     "items": {
                         "type": "string"
                     }
                 },
-                "specs": {
+                "payment_method": {
+                    "type": "string"
+                },
+                "points": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "product": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/dtos.SpecsItem"
+                        "$ref": "#/definitions/dtos.OrderFormProduct"
                     }
+                },
+                "store_credit": {
+                    "type": "string"
                 }
             }
         },
-        "dtos.Comment": {
+        "dtos.OrderFormAddress": {
             "type": "object",
             "required": [
-                "content",
-                "inventory_id",
-                "product_id",
-                "star",
-                "user_id"
+                "city",
+                "district",
+                "street",
+                "ward"
             ],
             "properties": {
-                "content": {
+                "city": {
                     "type": "string"
                 },
-                "inventory_id": {
-                    "type": "integer"
+                "district": {
+                    "type": "string"
                 },
-                "product_id": {
-                    "type": "integer"
+                "street": {
+                    "type": "string"
                 },
-                "star": {
+                "ward": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.OrderFormBundle": {
+            "type": "object",
+            "required": [
+                "bundle_id",
+                "quantity"
+            ],
+            "properties": {
+                "bundle_id": {
                     "type": "integer"
                 },
-                "user_id": {
+                "quantity": {
                     "type": "integer"
                 }
             }
         },
-        "dtos.Coupon": {
+        "dtos.OrderFormCustomer": {
             "type": "object",
+            "required": [
+                "email",
+                "first_name",
+                "last_name",
+                "phone"
+            ],
             "properties": {
-                "code": {
+                "email": {
                     "type": "string"
                 },
-                "description": {
+                "first_name": {
                     "type": "string"
                 },
-                "discount": {
-                    "type": "integer"
-                },
-                "expired_at": {
+                "last_name": {
                     "type": "string"
                 },
-                "id": {
-                    "type": "integer"
-                },
-                "status": {
+                "phone": {
                     "type": "string"
                 }
             }
         },
-        "dtos.CreateCoupon": {
+        "dtos.OrderFormDelivery": {
             "type": "object",
             "required": [
-                "description",
-                "discount",
-                "max_day",
-                "max_use",
+                "method",
                 "status"
             ],
             "properties": {
-                "description": {
+                "method": {
                     "type": "string"
                 },
-                "discount": {
-                    "type": "integer"
-                },
-                "max_day": {
-                    "type": "integer"
+                "note": {
+                    "type": "string"
                 },
-                "max_use": {
-                    "type": "integer"
+                "sent_date": {
+                    "type": "string"
                 },
                 "status": {
                     "type": "string"
                 }
             }
         },
-        "dtos.CreateProduct": {
+        "dtos.OrderFormProduct": {
             "type": "object",
+            "required": [
+                "code",
+                "quantity"
+            ],
             "properties": {
-                "id": {
-                    "type": "integer"
-                },
-                "msg": {
+                "code": {
                     "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
                 }
             }
         },
-        "dtos.DeliveryAddress": {
+        "dtos.OrderInfo": {
             "type": "object",
-            "required": [
-                "city",
-                "district",
-                "street",
-                "user_id",
-                "ward"
-            ],
             "properties": {
-                "city": {
+                "address": {
+                    "$ref": "#/definitions/dtos.OrderFormAddress"
+                },
+                "credit_amount": {
                     "type": "string"
                 },
-                "district": {
+                "currency": {
                     "type": "string"
                 },
-                "street": {
+                "delivery": {
+                    "$ref": "#/definitions/dtos.OrderFormDelivery"
+                },
+                "deposit_amount": {
                     "type": "string"
                 },
-                "user_id": {
-                    "type": "integer"
+                "discount_amount": {
+                    "type": "string"
                 },
-                "ward": {
+                "exchange_rate": {
                     "type": "string"
-                }
-            }
-        },
-        "dtos.DeliveryBody": {
-            "type": "object",
-            "required": [
-                "address_id",
-                "method",
-                "status",
-                "user_id"
-            ],
-            "properties": {
-                "address_id": {
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Order"
+                    }
+                },
+                "payment_method": {
+                    "type": "string"
+                },
+                "points_amount": {
+                    "type": "string"
+                },
+                "points_redeemed": {
                     "type": "integer"
                 },
-                "method": {
+                "shipments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.Shipment"
+                    }
+                },
+                "shipping_fee": {
                     "type": "string"
                 },
-                "note": {
+                "subtotal": {
                     "type": "string"
                 },
-                "received_date": {
+                "tax_amount": {
                     "type": "string"
                 },
-                "sent_date": {
+                "time": {
                     "type": "string"
                 },
-                "status": {
+                "timeline": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.OrderStatusEvent"
+                    }
+                },
+                "total_amount": {
                     "type": "string"
                 },
-                "user_id": {
-                    "type": "integer"
+                "user": {
+                    "$ref": "#/definitions/dtos.OrderFormCustomer"
+                },
+                "uuid": {
+                    "type": "string"
                 }
             }
         },
-        "dtos.Error": {
+        "dtos.OrderPage": {
             "type": "object",
             "properties": {
-                "msg": {
+                "next_cursor": {
                     "type": "string"
+                },
+                "orders": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.OrderSummary"
+                    }
                 }
             }
         },
-        "dtos.InvUpdate": {
+        "dtos.OrderResponse": {
+            "type": "object",
+            "properties": {
+                "order_code": {
+                    "type": "string"
+                },
+                "tracking_token": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.OrderStatus": {
             "type": "object",
             "required": [
-                "id"
+                "order_code",
+                "status"
             ],
             "properties": {
-                "available": {
+                "order_code": {
                     "type": "string"
                 },
-                "currency_code": {
+                "reason": {
                     "type": "string"
                 },
-                "id": {
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.OrderStatusEvent": {
+            "type": "object",
+            "properties": {
+                "actor": {
                     "type": "string"
                 },
-                "price": {
+                "from": {
                     "type": "string"
                 },
-                "product_id": {
+                "reason": {
                     "type": "string"
                 },
-                "status": {
+                "time": {
+                    "type": "string"
+                },
+                "to": {
                     "type": "string"
                 }
             }
         },
-        "dtos.Inventory": {
+        "dtos.OrderSummary": {
             "type": "object",
-            "required": [
-                "available",
-                "currency_code",
-                "price",
-                "product_id"
-            ],
             "properties": {
-                "available": {
-                    "type": "string"
-                },
-                "category": {
+                "code": {
                     "type": "string"
                 },
-                "color": {
+                "coupon_code": {
                     "type": "string"
                 },
-                "color_img": {
-                    "type": "string"
+                "customer": {
+                    "$ref": "#/definitions/dtos.OrderFormCustomer"
                 },
-                "currency_code": {
+                "discount_amount": {
                     "type": "string"
                 },
-                "id": {
+                "items": {
                     "type": "integer"
                 },
-                "image": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "payment_method": {
+                    "type": "string"
                 },
-                "item_code": {
+                "shipping_fee": {
                     "type": "string"
                 },
-                "price": {
+                "status": {
                     "type": "string"
                 },
-                "product_id": {
-                    "type": "integer"
+                "subtotal": {
+                    "type": "string"
                 },
-                "product_img": {
+                "tax_amount": {
                     "type": "string"
                 },
-                "product_name": {
+                "time": {
                     "type": "string"
                 },
-                "specs": {
-                    "$ref": "#/definitions/dtos.Specs"
+                "total_amount": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.PaymentIPN": {
+            "type": "object",
+            "properties": {
+                "Message": {
+                    "type": "string"
                 },
-                "status": {
+                "RspCode": {
                     "type": "string"
                 }
             }
         },
-        "dtos.InventoryDetail": {
+        "dtos.PaymentRefund": {
             "type": "object",
-            "required": [
-                "available",
-                "currency_code",
-                "price",
-                "product_id"
-            ],
             "properties": {
-                "available": {
+                "actor": {
                     "type": "string"
                 },
-                "color": {
+                "amount": {
                     "type": "string"
                 },
-                "color_img": {
+                "attempts": {
+                    "type": "integer"
+                },
+                "created_at": {
                     "type": "string"
                 },
-                "currency_code": {
+                "currency": {
                     "type": "string"
                 },
-                "image": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "full": {
+                    "type": "boolean"
                 },
-                "price": {
+                "id": {
+                    "type": "integer"
+                },
+                "message": {
                     "type": "string"
                 },
-                "product_id": {
-                    "type": "integer"
+                "order_code": {
+                    "type": "string"
                 },
-                "specs": {
-                    "$ref": "#/definitions/dtos.Specs"
+                "provider": {
+                    "type": "string"
                 },
-                "status": {
+                "reason": {
                     "type": "string"
-                }
-            }
-        },
-        "dtos.InventoryItems": {
-            "type": "object",
-            "properties": {
-                "header": {
-                    "$ref": "#/definitions/dtos.StockHeader"
                 },
-                "limit": {
-                    "type": "integer"
+                "refund_no": {
+                    "type": "string"
                 },
-                "page": {
-                    "type": "integer"
+                "response_code": {
+                    "type": "string"
                 },
-                "stock": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/dtos.Inventory"
-                    }
-                }
-            }
-        },
-        "dtos.LoginRequest": {
-            "type": "object",
-            "required": [
-                "email",
-                "password"
-            ],
-            "properties": {
-                "email": {
+                "status": {
                     "type": "string"
                 },
-                "password": {
+                "transaction_no": {
+                    "type": "string"
+                },
+                "updated_at": {
                     "type": "string"
                 }
             }
         },
-        "dtos.LoginResponse": {
+        "dtos.PaymentRefundRequest": {
             "type": "object",
             "required": [
-                "email",
-                "success",
-                "token"
+                "order_code"
             ],
             "properties": {
-                "email": {
+                "amount": {
                     "type": "string"
                 },
-                "success": {
-                    "type": "boolean"
+                "order_code": {
+                    "type": "string"
                 },
-                "token": {
+                "reason": {
                     "type": "string"
                 }
             }
         },
-        "dtos.News": {
+        "dtos.PaymentRequest": {
             "type": "object",
             "required": [
-                "cards",
-                "header"
+                "order_id"
             ],
             "properties": {
-                "cards": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/dtos.CardArticle"
-                    }
+                "bank_code": {
+                    "type": "string"
                 },
-                "header": {
+                "language": {
+                    "type": "string"
+                },
+                "order_desc": {
+                    "type": "string"
+                },
+                "order_id": {
+                    "type": "string"
+                },
+                "order_type": {
+                    "type": "string"
+                },
+                "provider": {
                     "type": "string"
                 }
             }
         },
-        "dtos.NewsDTO": {
+        "dtos.PaymentResponse": {
             "type": "object",
-            "required": [
-                "cards",
-                "category",
-                "header"
-            ],
             "properties": {
-                "cards": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/dtos.CardArticle"
-                    }
+                "message": {
+                    "type": "string"
                 },
-                "category": {
+                "payment_url": {
                     "type": "string"
                 },
-                "header": {
+                "provider": {
                     "type": "string"
+                },
+                "success": {
+                    "type": "boolean"
                 }
             }
         },
-        "dtos.OK": {
+        "dtos.PaymentResult": {
             "type": "object",
             "properties": {
-                "msg": {
+                "message": {
+                    "type": "string"
+                },
+                "order_code": {
+                    "type": "string"
+                },
+                "paid": {
+                    "type": "boolean"
+                },
+                "refund_due": {
+                    "type": "boolean"
+                },
+                "rsp_code": {
                     "type": "string"
                 }
             }
         },
-        "dtos.Order": {
+        "dtos.PaymentStatus": {
             "type": "object",
-            "required": [
-                "address",
-                "customer",
-                "delivery",
-                "payment_method",
-                "product"
-            ],
             "properties": {
-                "address": {
-                    "$ref": "#/definitions/dtos.OrderFormAddress"
+                "amount": {
+                    "type": "string"
                 },
-                "coupon_code": {
+                "message": {
+                    "type": "string"
+                },
+                "order_code": {
                     "type": "string"
                 },
-                "customer": {
-                    "$ref": "#/definitions/dtos.OrderFormCustomer"
+                "provider": {
+                    "type": "string"
                 },
-                "delivery": {
-                    "$ref": "#/definitions/dtos.OrderFormDelivery"
+                "response_code": {
+                    "type": "string"
                 },
-                "payment_method": {
+                "status": {
                     "type": "string"
                 },
-                "product": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/dtos.OrderFormProduct"
-                    }
+                "transaction_no": {
+                    "type": "string"
                 }
             }
         },
-        "dtos.OrderForm": {
+        "dtos.PaymentTransaction": {
             "type": "object",
-            "required": [
-                "address",
-                "customer",
-                "delivery",
-                "payment_method",
-                "product"
-            ],
             "properties": {
-                "address": {
-                    "$ref": "#/definitions/dtos.OrderFormAddress"
+                "amount": {
+                    "type": "string"
                 },
-                "coupon_code": {
+                "bank_code": {
                     "type": "string"
                 },
-                "customer": {
-                    "$ref": "#/definitions/dtos.OrderFormCustomer"
+                "created_at": {
+                    "type": "string"
                 },
-                "delivery": {
-                    "$ref": "#/definitions/dtos.OrderFormDelivery"
+                "currency": {
+                    "type": "string"
                 },
-                "payment_method": {
+                "event": {
                     "type": "string"
                 },
-                "product": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/dtos.OrderFormProduct"
-                    }
-                }
-            }
-        },
-        "dtos.OrderFormAddress": {
-            "type": "object",
-            "required": [
-                "city",
-                "district",
-                "street",
-                "ward"
-            ],
-            "properties": {
-                "city": {
+                "id": {
+                    "type": "integer"
+                },
+                "message": {
                     "type": "string"
                 },
-                "district": {
+                "order_code": {
                     "type": "string"
                 },
-                "street": {
+                "payload": {
+                    "type": "object"
+                },
+                "provider": {
                     "type": "string"
                 },
-                "ward": {
+                "reference": {
+                    "type": "string"
+                },
+                "response_code": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "transaction_no": {
                     "type": "string"
                 }
             }
         },
-        "dtos.OrderFormCustomer": {
+        "dtos.ProductDTO": {
             "type": "object",
-            "required": [
-                "email",
-                "first_name",
-                "last_name",
-                "phone"
-            ],
             "properties": {
-                "email": {
+                "category": {
                     "type": "string"
                 },
-                "first_name": {
+                "currency": {
                     "type": "string"
                 },
-                "last_name": {
+                "desc": {
                     "type": "string"
                 },
-                "phone": {
+                "id": {
+                    "type": "integer"
+                },
+                "image": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "price": {
                     "type": "string"
+                },
+                "rating": {
+                    "type": "number"
+                },
+                "specs": {
+                    "$ref": "#/definitions/dtos.ProductSpecs"
                 }
             }
         },
-        "dtos.OrderFormDelivery": {
+        "dtos.ProductDetail": {
             "type": "object",
-            "required": [
-                "method",
-                "status"
-            ],
             "properties": {
-                "method": {
+                "color": {
+                    "description": "Color of product",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.Color"
+                    }
+                },
+                "currency": {
+                    "description": "Currency of the prices when they are converted to another currency",
                     "type": "string"
                 },
-                "note": {
+                "display": {
+                    "description": "Display Super AMOLED",
                     "type": "string"
                 },
-                "sent_date": {
+                "image": {
+                    "description": "Image of product",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "description": "Name of product",
                     "type": "string"
                 },
-                "status": {
+                "price": {
+                    "type": "string"
+                },
+                "rating": {
+                    "type": "number"
+                },
+                "screen": {
+                    "description": "Screen 6.1 inch",
                     "type": "string"
                 }
             }
         },
-        "dtos.OrderFormProduct": {
+        "dtos.ProductRequest": {
             "type": "object",
             "required": [
-                "code",
-                "quantity"
+                "category_id",
+                "name",
+                "price",
+                "supplier_id"
             ],
             "properties": {
-                "code": {
+                "category_id": {
+                    "type": "integer"
+                },
+                "description": {
                     "type": "string"
                 },
-                "quantity": {
+                "name": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "string"
+                },
+                "specs": {
+                    "$ref": "#/definitions/dtos.ProductSpecs"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "supplier_id": {
                     "type": "integer"
                 }
             }
         },
-        "dtos.OrderInfo": {
+        "dtos.ProductResponse": {
             "type": "object",
             "properties": {
-                "address": {
-                    "$ref": "#/definitions/dtos.OrderFormAddress"
+                "category": {
+                    "type": "string"
                 },
-                "delivery": {
-                    "$ref": "#/definitions/dtos.OrderFormDelivery"
+                "created": {
+                    "type": "string"
                 },
-                "items": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/model.Order"
-                    }
+                "currency": {
+                    "description": "set when the price is converted to another currency",
+                    "type": "string"
                 },
-                "payment_method": {
+                "description": {
                     "type": "string"
                 },
-                "time": {
+                "id": {
+                    "type": "integer"
+                },
+                "image": {
                     "type": "string"
                 },
-                "total_amount": {
+                "name": {
                     "type": "string"
                 },
-                "user": {
-                    "$ref": "#/definitions/dtos.OrderFormCustomer"
+                "price": {
+                    "type": "string"
                 },
-                "uuid": {
+                "specs": {
+                    "$ref": "#/definitions/dtos.ProductSpecs"
+                },
+                "status": {
                     "type": "string"
                 }
             }
         },
-        "dtos.OrderResponse": {
+        "dtos.ProductSpecs": {
             "type": "object",
             "properties": {
-                "order_code": {
+                "RAM": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "SSD": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "display": {
+                    "type": "string"
+                },
+                "screen": {
                     "type": "string"
                 }
             }
         },
-        "dtos.OrderStatus": {
+        "dtos.RemittanceImport": {
             "type": "object",
-            "required": [
-                "order_code",
-                "status"
-            ],
             "properties": {
-                "order_code": {
+                "amount": {
                     "type": "string"
                 },
-                "status": {
-                    "type": "string"
+                "duplicates": {
+                    "type": "integer"
+                },
+                "lines": {
+                    "type": "integer"
+                },
+                "matched": {
+                    "type": "integer"
+                },
+                "over": {
+                    "type": "integer"
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.RemittanceLine"
+                    }
+                },
+                "short": {
+                    "type": "integer"
+                },
+                "unmatched": {
+                    "type": "integer"
                 }
             }
         },
-        "dtos.ProductDTO": {
+        "dtos.RemittanceLine": {
             "type": "object",
             "properties": {
-                "category": {
+                "amount": {
                     "type": "string"
                 },
-                "desc": {
+                "carrier_order_code": {
                     "type": "string"
                 },
-                "id": {
-                    "type": "integer"
+                "order_code": {
+                    "type": "string"
                 },
-                "image": {
+                "reference": {
                     "type": "string"
                 },
-                "name": {
+                "result": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.RemittanceSync": {
+            "type": "object",
+            "required": [
+                "from",
+                "to"
+            ],
+            "properties": {
+                "from": {
                     "type": "string"
                 },
-                "price": {
+                "to": {
                     "type": "string"
-                },
-                "rating": {
-                    "type": "number"
-                },
-                "specs": {
-                    "$ref": "#/definitions/dtos.ProductSpecs"
                 }
             }
         },
-        "dtos.ProductDetail": {
+        "dtos.Return": {
             "type": "object",
             "properties": {
-                "color": {
-                    "description": "Color of product",
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "items": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/dtos.Color"
+                        "$ref": "#/definitions/dtos.ReturnItemInfo"
                     }
                 },
-                "display": {
-                    "description": "Display Super AMOLED",
+                "note": {
                     "type": "string"
                 },
-                "image": {
-                    "description": "Image of product",
+                "order_code": {
+                    "type": "string"
+                },
+                "photos": {
                     "type": "array",
                     "items": {
                         "type": "string"
                     }
                 },
-                "name": {
-                    "description": "Name of product",
+                "reason": {
                     "type": "string"
                 },
-                "price": {
+                "received_at": {
                     "type": "string"
                 },
-                "rating": {
-                    "type": "number"
+                "refund_amount": {
+                    "type": "string"
                 },
-                "screen": {
-                    "description": "Screen 6.1 inch",
+                "status": {
                     "type": "string"
                 }
             }
         },
-        "dtos.ProductRequest": {
+        "dtos.ReturnDecision": {
             "type": "object",
-            "required": [
-                "category_id",
-                "name",
-                "price",
-                "supplier_id"
-            ],
             "properties": {
-                "category_id": {
-                    "type": "integer"
-                },
-                "description": {
+                "note": {
                     "type": "string"
                 },
-                "name": {
-                    "type": "string"
+                "skip_restock": {
+                    "type": "boolean"
                 },
-                "price": {
-                    "type": "string"
+                "store_credit": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "dtos.ReturnItemInfo": {
+            "type": "object",
+            "properties": {
+                "inventory_id": {
+                    "type": "integer"
                 },
-                "specs": {
-                    "$ref": "#/definitions/dtos.ProductSpecs"
+                "item_id": {
+                    "type": "integer"
                 },
-                "status": {
-                    "type": "string"
+                "quantity": {
+                    "type": "integer"
                 },
-                "supplier_id": {
+                "restocked": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "dtos.ReturnResponse": {
+            "type": "object",
+            "properties": {
+                "return_id": {
                     "type": "integer"
                 }
             }
         },
-        "dtos.ProductResponse": {
+        "dtos.Shipment": {
             "type": "object",
             "properties": {
-                "category": {
+                "carrier_order_code": {
                     "type": "string"
                 },
-                "created": {
+                "created_at": {
                     "type": "string"
                 },
-                "description": {
+                "delivered_at": {
                     "type": "string"
                 },
                 "id": {
                     "type": "integer"
                 },
-                "image": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.ShipmentItem"
+                    }
+                },
+                "method": {
                     "type": "string"
                 },
-                "name": {
+                "note": {
                     "type": "string"
                 },
-                "price": {
+                "shipped_at": {
                     "type": "string"
                 },
                 "status": {
                     "type": "string"
+                },
+                "tracking_url": {
+                    "type": "string"
                 }
             }
         },
-        "dtos.ProductSpecs": {
+        "dtos.ShipmentForm": {
             "type": "object",
+            "required": [
+                "items"
+            ],
             "properties": {
-                "RAM": {
-                    "type": "array",
-                    "items": {
-                        "type": "integer"
-                    }
+                "carrier_order_code": {
+                    "type": "string"
                 },
-                "SSD": {
+                "items": {
                     "type": "array",
+                    "minItems": 1,
                     "items": {
-                        "type": "integer"
+                        "$ref": "#/definitions/dtos.ShipmentFormItem"
                     }
                 },
-                "display": {
+                "method": {
                     "type": "string"
                 },
-                "screen": {
+                "note": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.ShipmentFormItem": {
+            "type": "object",
+            "required": [
+                "item_id",
+                "quantity"
+            ],
+            "properties": {
+                "item_id": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dtos.ShipmentItem": {
+            "type": "object",
+            "properties": {
+                "item_id": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "quantity": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dtos.ShipmentResponse": {
+            "type": "object",
+            "properties": {
+                "shipment_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dtos.ShipmentUpdate": {
+            "type": "object",
+            "properties": {
+                "carrier_order_code": {
                     "type": "string"
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "shipping",
+                        "delivered"
+                    ]
                 }
             }
         },
@@ -3035,6 +6316,17 @@ const docTemplate = `{
                 }
             }
         },
+        "dtos.Slices-dtos_ExchangeRate": {
+            "type": "object",
+            "properties": {
+                "body": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.ExchangeRate"
+                    }
+                }
+            }
+        },
         "dtos.Slices-dtos_ProductResponse": {
             "type": "object",
             "properties": {
@@ -3125,6 +6417,23 @@ const docTemplate = `{
                 },
                 "draft": {
                     "type": "integer"
+                },
+                "preorder": {
+                    "type": "integer"
+                }
+            }
+        },
+        "dtos.StockReceipt": {
+            "type": "object",
+            "required": [
+                "quantity"
+            ],
+            "properties": {
+                "inventory_id": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer"
                 }
             }
         },
@@ -3158,6 +6467,10 @@ const docTemplate = `{
                 },
                 "name": {
                     "type": "string"
+                },
+                "vat_rate": {
+                    "description": "VATRate in percent, unchanged when omitted",
+                    "type": "string"
                 }
             }
         },
@@ -3216,6 +6529,40 @@ const docTemplate = `{
                 }
             }
         },
+        "dtos.VoidGiftCard": {
+            "type": "object",
+            "properties": {
+                "note": {
+                    "type": "string"
+                }
+            }
+        },
+        "dtos.Wallet": {
+            "type": "object",
+            "properties": {
+                "balance": {
+                    "type": "string"
+                },
+                "consistent": {
+                    "type": "boolean"
+                },
+                "currency_code": {
+                    "type": "string"
+                },
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/dtos.CreditEntry"
+                    }
+                },
+                "ledger_balance": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
         "entity.Category": {
             "type": "object",
             "required": [
@@ -3231,6 +6578,9 @@ const docTemplate = `{
                 },
                 "name": {
                     "type": "string"
+                },
+                "vat_rate": {
+                    "type": "string"
                 }
             }
         },
@@ -3838,6 +7188,12 @@ const docTemplate = `{
         "model.Order": {
             "type": "object",
             "properties": {
+                "backordered": {
+                    "type": "integer"
+                },
+                "bundle_id": {
+                    "type": "integer"
+                },
                 "category_id": {
                     "type": "integer"
                 },
@@ -3847,6 +7203,9 @@ const docTemplate = `{
                 "currency_code": {
                     "type": "string"
                 },
+                "id": {
+                    "type": "integer"
+                },
                 "image": {
                     "type": "string"
                 },
@@ -3859,8 +7218,17 @@ const docTemplate = `{
                 "quantity": {
                     "type": "integer"
                 },
+                "tax_amount": {
+                    "type": "number"
+                },
                 "total_amount": {
                     "type": "number"
+                },
+                "unit_price": {
+                    "type": "number"
+                },
+                "vat_rate": {
+                    "type": "number"
                 }
             }
         },
@@ -3877,6 +7245,9 @@ const docTemplate = `{
                 "username"
             ],
             "properties": {
+                "cart_reminder_opt_out": {
+                    "type": "boolean"
+                },
                 "email": {
                     "type": "string"
                 },
@@ -3892,59 +7263,31 @@ const docTemplate = `{
                 "last_name": {
                     "type": "string"
                 },
-                "phone_number": {
-                    "type": "string"
-                },
-                "role": {
-                    "type": "string"
-                },
-                "username": {
-                    "type": "string"
-                }
-            }
-        },
-        "payment.PaymentRequest": {
-            "type": "object",
-            "properties": {
-                "amount": {
-                    "description": "Số tiền (VND)",
+                "lifetime_points": {
                     "type": "integer"
                 },
-                "bank_code": {
-                    "description": "Mã ngân hàng",
-                    "type": "string"
-                },
-                "ip_address": {
-                    "description": "Địa chỉ IP của client",
-                    "type": "string"
+                "loyalty_points": {
+                    "type": "integer"
                 },
-                "language": {
-                    "description": "Ngôn ngữ (vd: \"vn\" hoặc \"en\")",
+                "loyalty_tier": {
                     "type": "string"
                 },
-                "order_desc": {
-                    "description": "Mô tả đơn hàng",
+                "phone_number": {
                     "type": "string"
                 },
-                "order_id": {
-                    "description": "ID đơn hàng",
+                "role": {
                     "type": "string"
                 },
-                "order_type": {
-                    "description": "Loại đơn hàng",
+                "username": {
                     "type": "string"
                 }
             }
         },
-        "payment.PaymentResponse": {
+        "payment.StatusResponse": {
             "type": "object",
             "properties": {
                 "message": {
-                    "description": "Thông báo kết quả",
-                    "type": "string"
-                },
-                "payment_url": {
-                    "description": "URL thanh toán được xây dựng",
+                    "description": "Thông báo",
                     "type": "string"
                 },
                 "success": {
@@ -3953,16 +7296,14 @@ const docTemplate = `{
                 }
             }
         },
-        "payment.StatusResponse": {
+        "zalopay.CallbackAck": {
             "type": "object",
             "properties": {
-                "message": {
-                    "description": "Thông báo",
-                    "type": "string"
+                "return_code": {
+                    "type": "integer"
                 },
-                "success": {
-                    "description": "Trạng thái thành công hay thất bại",
-                    "type": "boolean"
+                "return_message": {
+                    "type": "string"
                 }
             }
         }
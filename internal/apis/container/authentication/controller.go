@@ -33,6 +33,7 @@ type IController interface {
 	UpdateUserImage(c echo.Context) error
 	CheckLoginEmail(c echo.Context) error
 	UpdateUserInfo(c echo.Context) error
+	UpdateNotifications(c echo.Context) error
 	OAuth2(c echo.Context) error
 }
 
@@ -211,6 +212,32 @@ func (auth *Controller) UpdateUserInfo(c echo.Context) error {
 
 }
 
+// UpdateNotifications .
+// @Description turn the email notifications of the user on or off.
+// @Tags authentication
+// @Accept json
+// @Produce json
+// @Param req body dtos.Notifications true "notification settings"
+// @Success 200 {object} dtos.OK
+// @Router /users/notifications [PUT]
+func (auth *Controller) UpdateNotifications(c echo.Context) error {
+	var request dtos.Notifications
+	if err := c.Bind(&request); err != nil {
+		return c.JSON(http.StatusBadRequest, dtos.Error{
+			Msg: err.Error(),
+		})
+	}
+	_, email, _ := crypto.Authenticate(c)
+	if err := auth.service.UpdateNotifications(c.Request().Context(), email, request); err != nil {
+		return c.JSON(http.StatusInternalServerError, dtos.Error{
+			Msg: err.Error(),
+		})
+	}
+	return c.JSON(http.StatusOK, dtos.OK{
+		Msg: "your notification settings have been updated",
+	})
+}
+
 // UpdateUserImage .
 // @Description update information for users.
 // @Tags authentication
@@ -33,6 +33,7 @@ func (r *Router) Routers(e *echo.Echo) {
 	e.GET("/users", r.controller.GetMe, middleware.Protected)
 	e.PUT("/users", r.controller.UpdateUserInfo)
 	e.PUT("/users/image", r.controller.UpdateUserImage, middleware.Protected)
+	e.PUT("/users/notifications", r.controller.UpdateNotifications, middleware.Protected)
 
 	// endpoint for authentication
 	e.POST("/auth", r.controller.Auth)
@@ -1,6 +1,7 @@
 package classify
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -114,6 +115,11 @@ func (classify *Controller) InsertCategory(c echo.Context) error {
 		})
 	}
 	if err := classify.Service.CreateCategory(c.Request().Context(), request); err != nil {
+		if isInvalidVATRate(err) {
+			return c.JSON(http.StatusBadRequest, dtos.Error{
+				Msg: err.Error(),
+			})
+		}
 		return c.JSON(http.StatusInternalServerError, dtos.Error{
 			Msg: fmt.Sprintf("category data invalid, %v", err),
 		})
@@ -204,7 +210,13 @@ func (classify *Controller) UpdateCategory(c echo.Context) error {
 		ID:          payload.ID,
 		Name:        payload.Name,
 		Description: payload.Description,
+		VATRate:     payload.VATRate,
 	}); err != nil {
+		if isInvalidVATRate(err) {
+			return c.JSON(http.StatusBadRequest, dtos.Error{
+				Msg: err.Error(),
+			})
+		}
 		return c.JSON(http.StatusInternalServerError, dtos.Error{
 			Msg: err.Error(),
 		})
@@ -214,3 +226,8 @@ func (classify *Controller) UpdateCategory(c echo.Context) error {
 		Msg: "your product has been updated successfully",
 	})
 }
+
+// isInvalidVATRate reports whether err is a category VAT rate validation error.
+func isInvalidVATRate(err error) bool {
+	return errors.Is(err, classify.ErrInvalidVATRate)
+}
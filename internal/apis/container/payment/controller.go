@@ -1,13 +1,20 @@
 package payment
 
 import (
+	"errors"
+	"io"
 	"net/http"
 
 	"github.com/labstack/echo/v4"
 	"github.com/swclabs/swipex/app"
 	"github.com/swclabs/swipex/internal/core/domain/dtos"
+	"github.com/swclabs/swipex/internal/core/domain/enum"
+	"github.com/swclabs/swipex/internal/core/domain/model"
+	"github.com/swclabs/swipex/internal/core/domain/x/zalopay"
 	pm "github.com/swclabs/swipex/internal/core/service/payment"
 	"github.com/swclabs/swipex/pkg/gen/payment"
+	"github.com/swclabs/swipex/pkg/lib/crypto"
+	"github.com/swclabs/swipex/pkg/lib/valid"
 )
 
 var _ = app.Controller(NewController)
@@ -24,6 +31,13 @@ type IController interface {
 	Status(c echo.Context) error
 	Payment(c echo.Context) error
 	PaymentReturn(c echo.Context) error
+	PaymentIPN(c echo.Context) error
+	ProviderReturn(c echo.Context) error
+	ProviderIPN(c echo.Context) error
+	QueryPayment(c echo.Context) error
+	GetTransactions(c echo.Context) error
+	Refund(c echo.Context) error
+	GetRefunds(c echo.Context) error
 }
 
 // Controller struct implementation of IArticle
@@ -31,27 +45,34 @@ type Controller struct {
 	Services *pm.Payment
 }
 
-// Status .
-// @Description check payment service status
+// Payment .
+// @Description create the payment of an order with a provider, the payment method of the order when no provider is given.
+// @Description The amount is what is left to pay online on the order.
 // @Tags payment
 // @Accept json
 // @Produce json
-// @Param payment body payment.PaymentRequest true "payment request"
-// @Success 200 {object} payment.PaymentResponse
+// @Param payment body dtos.PaymentRequest true "payment request"
+// @Param Idempotency-Key header string false "key to safely retry the request"
+// @Success 200 {object} dtos.PaymentResponse
 // @Router /payment [POST]
 func (pmc *Controller) Payment(c echo.Context) error {
-	var req payment.PaymentRequest
+	var req dtos.PaymentRequest
 	if err := c.Bind(&req); err != nil {
 		return c.JSON(http.StatusBadRequest, dtos.Error{
 			Msg: err.Error(),
 		})
 	}
+	if err := valid.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dtos.Error{
+			Msg: err.Error(),
+		})
+	}
 
-	req.IpAddress = c.RealIP()
+	req.IPAddress = c.RealIP()
 
-	resp, err := pmc.Services.ProcessPayment(c.Request().Context(), &req)
+	resp, err := pmc.Services.CreatePayment(c.Request().Context(), req)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, dtos.Error{
+		return c.JSON(paymentError(err), dtos.Error{
 			Msg: err.Error(),
 		})
 	}
@@ -59,9 +80,235 @@ func (pmc *Controller) Payment(c echo.Context) error {
 	return c.JSON(http.StatusOK, resp)
 }
 
-// PaymentReturn implements IController.
+// PaymentReturn .
+// @Description check the payment the customer is redirected back with by VNPay and record it on the order.
+// @Tags payment
+// @Accept json
+// @Produce json
+// @Param vnp_TxnRef query string true "order code"
+// @Param vnp_Amount query int true "amount in VND times 100"
+// @Param vnp_SecureHash query string true "checksum of the callback"
+// @Success 200 {object} dtos.PaymentResult
+// @Router /payment/return [GET]
 func (pmc *Controller) PaymentReturn(c echo.Context) error {
-	panic("unimplemented")
+	result, err := pmc.Services.PaymentReturn(c.Request().Context(), enum.PaymentReturn, pm.ProviderVNPay,
+		model.PaymentCallbackRequest{Query: c.QueryParams()})
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, dtos.Error{
+			Msg: err.Error(),
+		})
+	}
+	status := http.StatusBadRequest
+	switch result.RspCode {
+	case pm.RspConfirmed, pm.RspAlreadyConfirmed:
+		status = http.StatusOK
+	case pm.RspOrderNotFound:
+		status = http.StatusNotFound
+	}
+	return c.JSON(status, result)
+}
+
+// PaymentIPN .
+// @Description server to server notification of a VNPay payment, answered in the format VNPay expects.
+// @Tags payment
+// @Accept json
+// @Produce json
+// @Param vnp_TxnRef query string true "order code"
+// @Param vnp_Amount query int true "amount in VND times 100"
+// @Param vnp_SecureHash query string true "checksum of the callback"
+// @Success 200 {object} dtos.PaymentIPN
+// @Router /payment/ipn [GET]
+func (pmc *Controller) PaymentIPN(c echo.Context) error {
+	result, err := pmc.Services.PaymentReturn(c.Request().Context(), enum.PaymentIPN, pm.ProviderVNPay,
+		model.PaymentCallbackRequest{Query: c.QueryParams()})
+	if err != nil {
+		// VNPay retries the notification until it is confirmed
+		return c.JSON(http.StatusOK, dtos.PaymentIPN{
+			RspCode: pm.RspUnknownError,
+			Message: "Unknown error",
+		})
+	}
+	return c.JSON(http.StatusOK, dtos.PaymentIPN{
+		RspCode: result.RspCode,
+		Message: result.Message,
+	})
+}
+
+// ProviderReturn .
+// @Description check the payment the customer is redirected back with by a provider and record it on the order.
+// @Tags payment
+// @Accept json
+// @Produce json
+// @Param provider path string true "payment provider: vnpay, momo or zalopay"
+// @Success 200 {object} dtos.PaymentResult
+// @Router /payment/return/{provider} [GET]
+func (pmc *Controller) ProviderReturn(c echo.Context) error {
+	result, err := pmc.Services.PaymentReturn(c.Request().Context(), enum.PaymentReturn, c.Param("provider"),
+		model.PaymentCallbackRequest{Query: c.QueryParams()})
+	if err != nil {
+		return c.JSON(paymentError(err), dtos.Error{
+			Msg: err.Error(),
+		})
+	}
+	status := http.StatusBadRequest
+	switch result.RspCode {
+	case pm.RspConfirmed, pm.RspAlreadyConfirmed:
+		status = http.StatusOK
+	case pm.RspOrderNotFound:
+		status = http.StatusNotFound
+	}
+	return c.JSON(status, result)
+}
+
+// ProviderIPN .
+// @Description server to server notification of a payment provider, answered in the format the provider expects.
+// @Tags payment
+// @Accept json
+// @Produce json
+// @Param provider path string true "payment provider: momo or zalopay"
+// @Success 200 {object} zalopay.CallbackAck
+// @Success 204
+// @Router /payment/ipn/{provider} [POST]
+func (pmc *Controller) ProviderIPN(c echo.Context) error {
+	body, err := io.ReadAll(c.Request().Body)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dtos.Error{
+			Msg: err.Error(),
+		})
+	}
+	provider := c.Param("provider")
+	result, err := pmc.Services.PaymentReturn(c.Request().Context(), enum.PaymentIPN, provider,
+		model.PaymentCallbackRequest{Query: c.QueryParams(), Body: body})
+	if errors.Is(err, pm.ErrUnknownProvider) {
+		return c.JSON(http.StatusBadRequest, dtos.Error{
+			Msg: err.Error(),
+		})
+	}
+	// the providers retry the notification until it is acknowledged
+	processed := err == nil && result.RspCode != pm.RspUnknownError
+	switch provider {
+	case pm.ProviderZaloPay:
+		ack := zalopay.CallbackAck{ReturnCode: 1, ReturnMessage: "success"}
+		if !processed {
+			ack = zalopay.CallbackAck{ReturnCode: 0, ReturnMessage: "retry"}
+		} else if result.RspCode == pm.RspInvalidChecksum {
+			ack = zalopay.CallbackAck{ReturnCode: -1, ReturnMessage: "mac not equal"}
+		}
+		return c.JSON(http.StatusOK, ack)
+	default:
+		if !processed {
+			return c.NoContent(http.StatusInternalServerError)
+		}
+		return c.NoContent(http.StatusNoContent)
+	}
+}
+
+// QueryPayment .
+// @Description ask the provider of the last payment of an order for its status.
+// @Tags payment
+// @Accept json
+// @Produce json
+// @Param order query string true "order code"
+// @Success 200 {object} dtos.PaymentStatus
+// @Router /payment/query [GET]
+func (pmc *Controller) QueryPayment(c echo.Context) error {
+	orderCode := c.QueryParam("order")
+	if orderCode == "" {
+		return c.JSON(http.StatusBadRequest, dtos.Error{
+			Msg: "missing 'order' query parameter",
+		})
+	}
+	status, err := pmc.Services.QueryPayment(c.Request().Context(), orderCode)
+	if err != nil {
+		return c.JSON(paymentError(err), dtos.Error{
+			Msg: err.Error(),
+		})
+	}
+	return c.JSON(http.StatusOK, status)
+}
+
+// GetTransactions .
+// @Description audit the payment transactions of an order, oldest first.
+// @Tags payment
+// @Accept json
+// @Produce json
+// @Param order query string true "order code"
+// @Success 200 {object} []dtos.PaymentTransaction
+// @Router /payment/transactions [GET]
+func (pmc *Controller) GetTransactions(c echo.Context) error {
+	orderCode := c.QueryParam("order")
+	if orderCode == "" {
+		return c.JSON(http.StatusBadRequest, dtos.Error{
+			Msg: "missing 'order' query parameter",
+		})
+	}
+	transactions, err := pmc.Services.GetTransactions(c.Request().Context(), orderCode)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, dtos.Error{
+			Msg: err.Error(),
+		})
+	}
+	return c.JSON(http.StatusOK, transactions)
+}
+
+// Refund .
+// @Description pay back a paid order through the provider of its payment, the part not refunded yet when no amount is given. The refund is paid back by the worker.
+// @Tags payment
+// @Accept json
+// @Produce json
+// @Param refund body dtos.PaymentRefundRequest true "refund request"
+// @Param Idempotency-Key header string false "key to safely retry the request"
+// @Success 202 {object} dtos.PaymentRefund
+// @Router /payment/refunds [POST]
+func (pmc *Controller) Refund(c echo.Context) error {
+	var req dtos.PaymentRefundRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dtos.Error{
+			Msg: err.Error(),
+		})
+	}
+	if err := valid.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dtos.Error{
+			Msg: err.Error(),
+		})
+	}
+	_, actor, err := crypto.Authenticate(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, dtos.Error{
+			Msg: err.Error(),
+		})
+	}
+	refund, err := pmc.Services.CreateRefund(c.Request().Context(), actor, req)
+	if err != nil {
+		return c.JSON(paymentError(err), dtos.Error{
+			Msg: err.Error(),
+		})
+	}
+	return c.JSON(http.StatusAccepted, refund)
+}
+
+// GetRefunds .
+// @Description list the refunds of an order with their status at the provider, oldest first.
+// @Tags payment
+// @Accept json
+// @Produce json
+// @Param order query string true "order code"
+// @Success 200 {object} []dtos.PaymentRefund
+// @Router /payment/refunds [GET]
+func (pmc *Controller) GetRefunds(c echo.Context) error {
+	orderCode := c.QueryParam("order")
+	if orderCode == "" {
+		return c.JSON(http.StatusBadRequest, dtos.Error{
+			Msg: "missing 'order' query parameter",
+		})
+	}
+	refunds, err := pmc.Services.GetRefunds(c.Request().Context(), orderCode)
+	if err != nil {
+		return c.JSON(paymentError(err), dtos.Error{
+			Msg: err.Error(),
+		})
+	}
+	return c.JSON(http.StatusOK, refunds)
 }
 
 // Status .
@@ -81,3 +328,20 @@ func (pmc *Controller) Status(c echo.Context) error {
 
 	return c.JSON(http.StatusOK, resp)
 }
+
+// paymentError returns the HTTP status of an error of the payment service.
+func paymentError(err error) int {
+	switch {
+	case errors.Is(err, pm.ErrUnknownProvider):
+		return http.StatusBadRequest
+	case errors.Is(err, pm.ErrOrderNotFound), errors.Is(err, pm.ErrPaymentNotFound),
+		errors.Is(err, pm.ErrRefundNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, pm.ErrRefundNotAllowed), errors.Is(err, pm.ErrPaymentNotAllowed):
+		return http.StatusConflict
+	case errors.Is(err, pm.ErrProviderRejected):
+		return http.StatusBadGateway
+	default:
+		return http.StatusInternalServerError
+	}
+}
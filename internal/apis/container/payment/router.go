@@ -2,7 +2,10 @@ package payment
 
 import (
 	"github.com/swclabs/swipex/app"
+	"github.com/swclabs/swipex/internal/apis/middleware"
 	"github.com/swclabs/swipex/internal/apis/server"
+	"github.com/swclabs/swipex/internal/config"
+	"github.com/swclabs/swipex/pkg/infra/cache"
 
 	"github.com/labstack/echo/v4"
 )
@@ -10,9 +13,10 @@ import (
 var _ = app.Router(NewRouter)
 
 // NewArticle creates a new Article router object
-func NewRouter(controllers IController) IRouter {
+func NewRouter(controllers IController, cache cache.ICache) IRouter {
 	return &Router{
 		controller: controllers,
+		cache:      cache,
 	}
 }
 
@@ -24,11 +28,23 @@ type IRouter interface {
 // Article implements IArticle
 type Router struct {
 	controller IController
+	cache      cache.ICache
 }
 
 // Routers define route endpoints
 func (r *Router) Routers(e *echo.Echo) {
 	e.GET("/payment/status", r.controller.Status)
-	e.POST("/payment", r.controller.Payment)
-	e.POST("/payment/return", r.controller.PaymentReturn)
+	e.POST("/payment", r.controller.Payment,
+		middleware.Idempotency(r.cache, config.IdempotencyWindow))
+	e.GET("/payment/return", r.controller.PaymentReturn)
+	e.GET("/payment/ipn", r.controller.PaymentIPN)
+	e.GET("/payment/return/:provider", r.controller.ProviderReturn)
+	e.POST("/payment/ipn/:provider", r.controller.ProviderIPN)
+	e.GET("/payment/query", r.controller.QueryPayment)
+	e.GET("/payment/transactions", r.controller.GetTransactions, middleware.Admin)
+	// the token is checked first, so that a refused request does not keep
+	// its idempotency key
+	e.POST("/payment/refunds", r.controller.Refund,
+		middleware.Admin, middleware.Idempotency(r.cache, config.IdempotencyWindow))
+	e.GET("/payment/refunds", r.controller.GetRefunds, middleware.Admin)
 }
@@ -1,6 +1,7 @@
 package products
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
@@ -52,6 +53,11 @@ type IController interface {
 
 	AddBookmark(c echo.Context) error
 	GetBookmark(c echo.Context) error
+
+	GetExchangeRates(c echo.Context) error
+	SetExchangeRate(c echo.Context) error
+	DeleteExchangeRate(c echo.Context) error
+	ImportExchangeRates(c echo.Context) error
 }
 
 // Controller struct implementation of IProducts
@@ -65,6 +71,7 @@ type Controller struct {
 // @Accept json
 // @Produce json
 // @Param id query int true "products id"
+// @Param currency query string false "currency to show the prices in"
 // @Success 200 {object} dtos.ProductResponse
 // @Router /products/info [GET]
 func (p *Controller) GetProductInfo(c echo.Context) error {
@@ -74,8 +81,13 @@ func (p *Controller) GetProductInfo(c echo.Context) error {
 			Msg: "Invalid 'id' query parameter",
 		})
 	}
-	product, err := p.service.GetProductInfo(c.Request().Context(), int64(ID))
+	product, err := p.service.GetProductInfo(c.Request().Context(), int64(ID), queryCurrency(c))
 	if err != nil {
+		if errors.Is(err, products.ErrUnknownCurrency) {
+			return c.JSON(http.StatusBadRequest, dtos.Error{
+				Msg: err.Error(),
+			})
+		}
 		return c.JSON(http.StatusInternalServerError, dtos.Error{
 			Msg: err.Error(),
 		})
@@ -243,6 +255,7 @@ func (p *Controller) UploadProductShopImage(c echo.Context) error {
 // @Accept json
 // @Produce json
 // @Param key query string true "keyword"
+// @Param currency query string false "currency to show the prices in"
 // @Success 200 {object} []dtos.ProductDetail
 // @Router /search/details [GET]
 func (p *Controller) SearchDetails(c echo.Context) error {
@@ -253,8 +266,13 @@ func (p *Controller) SearchDetails(c echo.Context) error {
 		})
 	}
 	userID, _, _ := crypto.Authenticate(c)
-	product, err := p.service.SearchDetails(c.Request().Context(), userID, keyword)
+	product, err := p.service.SearchDetails(c.Request().Context(), userID, keyword, queryCurrency(c))
 	if err != nil {
+		if errors.Is(err, products.ErrUnknownCurrency) {
+			return c.JSON(http.StatusBadRequest, dtos.Error{
+				Msg: err.Error(),
+			})
+		}
 		return c.JSON(http.StatusInternalServerError, dtos.Error{
 			Msg: err.Error(),
 		})
@@ -268,6 +286,7 @@ func (p *Controller) SearchDetails(c echo.Context) error {
 // @Accept json
 // @Produce json
 // @Param keyword query string true "keyword"
+// @Param currency query string false "currency to show the prices in"
 // @Success 200 {object} []dtos.ProductResponse
 // @Router /search [GET]
 func (p *Controller) Search(c echo.Context) error {
@@ -277,8 +296,13 @@ func (p *Controller) Search(c echo.Context) error {
 			Msg: "missing 'keyword' query parameter",
 		})
 	}
-	product, err := p.service.Search(c.Request().Context(), keyword)
+	product, err := p.service.Search(c.Request().Context(), keyword, queryCurrency(c))
 	if err != nil {
+		if errors.Is(err, products.ErrUnknownCurrency) {
+			return c.JSON(http.StatusBadRequest, dtos.Error{
+				Msg: err.Error(),
+			})
+		}
 		if strings.Contains(err.Error(), fmt.Sprintf("[code %d]", http.StatusBadRequest)) {
 			return c.JSON(http.StatusBadRequest, dtos.Error{
 				Msg: err.Error(),
@@ -297,6 +321,7 @@ func (p *Controller) Search(c echo.Context) error {
 // @Accept json
 // @Produce json
 // @Param type path string true "product type"
+// @Param currency query string false "currency to show the prices in"
 // @Success 200 {object} []dtos.ProductDTO
 // @Router /products/{type} [GET]
 func (p *Controller) GetProductByType(c echo.Context) error {
@@ -306,8 +331,13 @@ func (p *Controller) GetProductByType(c echo.Context) error {
 			Msg: err.Error(),
 		})
 	}
-	product, err := p.service.ProductType(c.Request().Context(), types, 0)
+	product, err := p.service.ProductType(c.Request().Context(), types, 0, queryCurrency(c))
 	if err != nil {
+		if errors.Is(err, products.ErrUnknownCurrency) {
+			return c.JSON(http.StatusBadRequest, dtos.Error{
+				Msg: err.Error(),
+			})
+		}
 		if strings.Contains(err.Error(), fmt.Sprintf("[code %d]", http.StatusBadRequest)) {
 			return c.JSON(http.StatusBadRequest, dtos.Error{
 				Msg: err.Error(),
@@ -326,6 +356,7 @@ func (p *Controller) GetProductByType(c echo.Context) error {
 // @Accept json
 // @Produce json
 // @Param id query number true "product id"
+// @Param currency query string false "currency to show the prices in"
 // @Success 200 {object} dtos.ProductDetail
 // @Router /products/details [GET]
 func (p *Controller) GetProductDetails(c echo.Context) error {
@@ -336,8 +367,13 @@ func (p *Controller) GetProductDetails(c echo.Context) error {
 		})
 	}
 	userID, _, _ := crypto.Authenticate(c)
-	product, err := p.service.Detail(c.Request().Context(), userID, int64(ID))
+	product, err := p.service.Detail(c.Request().Context(), userID, int64(ID), queryCurrency(c))
 	if err != nil {
+		if errors.Is(err, products.ErrUnknownCurrency) {
+			return c.JSON(http.StatusBadRequest, dtos.Error{
+				Msg: err.Error(),
+			})
+		}
 		return c.JSON(http.StatusInternalServerError, dtos.Error{
 			Msg: err.Error(),
 		})
@@ -532,6 +568,7 @@ func (p *Controller) UpdateProductInfo(c echo.Context) error {
 // @Accept json
 // @Produce json
 // @Param id query number true "inventory id"
+// @Param currency query string false "currency to show the prices in"
 // @Success 200 {object} dtos.Inventory
 // @Router /inventories/details [GET]
 func (p *Controller) GetInvDetails(c echo.Context) error {
@@ -542,8 +579,13 @@ func (p *Controller) GetInvDetails(c echo.Context) error {
 		})
 	}
 
-	product, err := p.service.GetItem(c.Request().Context(), int64(ID))
+	product, err := p.service.GetItem(c.Request().Context(), int64(ID), queryCurrency(c))
 	if err != nil {
+		if errors.Is(err, products.ErrUnknownCurrency) {
+			return c.JSON(http.StatusBadRequest, dtos.Error{
+				Msg: err.Error(),
+			})
+		}
 		if strings.Contains(err.Error(), fmt.Sprintf("[code %d]", http.StatusBadRequest)) {
 			return c.JSON(http.StatusBadRequest, dtos.Error{
 				Msg: err.Error(),
@@ -562,6 +604,7 @@ func (p *Controller) GetInvDetails(c echo.Context) error {
 // @Accept json
 // @Produce json
 // @Param limit query int true "limit number of products"
+// @Param currency query string false "currency to show the prices in"
 // @Success 200 {object} dtos.Slices[dtos.ProductResponse]
 // @Router /products [GET]
 func (p *Controller) GetProductLimit(c echo.Context) error {
@@ -571,8 +614,13 @@ func (p *Controller) GetProductLimit(c echo.Context) error {
 			Msg: "Invalid 'limit' query parameter",
 		})
 	}
-	prd, err := p.service.GetProducts(c.Request().Context(), _limit)
+	prd, err := p.service.GetProducts(c.Request().Context(), _limit, queryCurrency(c))
 	if err != nil {
+		if errors.Is(err, products.ErrUnknownCurrency) {
+			return c.JSON(http.StatusBadRequest, dtos.Error{
+				Msg: err.Error(),
+			})
+		}
 		if strings.Contains(err.Error(), fmt.Sprintf("[code %d]", http.StatusBadRequest)) {
 			return c.JSON(http.StatusBadRequest, dtos.Error{
 				Msg: err.Error(),
@@ -756,3 +804,128 @@ func (p *Controller) InsertInv(c echo.Context) error {
 		ID:  inventoryID,
 	})
 }
+
+// GetExchangeRates .
+// @Description get the exchange rates of the currencies prices can be shown in,
+// @Description the rates are the value of one unit in the base currency
+// @Tags currencies
+// @Accept json
+// @Produce json
+// @Success 200 {object} dtos.Slices[dtos.ExchangeRate]
+// @Router /exchange-rates [GET]
+func (p *Controller) GetExchangeRates(c echo.Context) error {
+	rates, err := p.service.GetExchangeRates(c.Request().Context())
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, dtos.Error{
+			Msg: err.Error(),
+		})
+	}
+	return c.JSON(http.StatusOK, dtos.Slices[dtos.ExchangeRate]{
+		Body: rates,
+	})
+}
+
+// SetExchangeRate .
+// @Description create or replace the exchange rate of a currency
+// @Tags currencies
+// @Accept json
+// @Produce json
+// @Param rate body dtos.ExchangeRateUpdate true "exchange rate"
+// @Success 200 {object} dtos.OK
+// @Router /exchange-rates [PUT]
+func (p *Controller) SetExchangeRate(c echo.Context) error {
+	var req dtos.ExchangeRateUpdate
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dtos.Error{
+			Msg: err.Error(),
+		})
+	}
+	if err := valid.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dtos.Error{
+			Msg: err.Error(),
+		})
+	}
+	_, actor, err := crypto.Authenticate(c)
+	if err != nil {
+		actor = "admin"
+	}
+	if err := p.service.SetExchangeRate(c.Request().Context(), actor, req); err != nil {
+		if errors.Is(err, products.ErrInvalidExchangeRate) {
+			return c.JSON(http.StatusBadRequest, dtos.Error{
+				Msg: err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, dtos.Error{
+			Msg: err.Error(),
+		})
+	}
+	return c.JSON(http.StatusOK, dtos.OK{
+		Msg: "exchange rate has been set successfully",
+	})
+}
+
+// DeleteExchangeRate .
+// @Description delete the exchange rate of a currency
+// @Tags currencies
+// @Accept json
+// @Produce json
+// @Param code path string true "currency code"
+// @Success 200 {object} dtos.OK
+// @Router /exchange-rates/{code} [DELETE]
+func (p *Controller) DeleteExchangeRate(c echo.Context) error {
+	if err := p.service.DeleteExchangeRate(c.Request().Context(), c.Param("code")); err != nil {
+		return c.JSON(http.StatusInternalServerError, dtos.Error{
+			Msg: err.Error(),
+		})
+	}
+	return c.JSON(http.StatusOK, dtos.OK{
+		Msg: "exchange rate has been deleted successfully",
+	})
+}
+
+// ImportExchangeRates .
+// @Description import the exchange rates of a CSV file with the columns
+// @Description currency_code and rate, all of them or none
+// @Tags currencies
+// @Accept multipart/form-data
+// @Produce json
+// @Param rates formData file true "exchange rates"
+// @Success 200 {object} dtos.ExchangeRateImport
+// @Router /exchange-rates/import [POST]
+func (p *Controller) ImportExchangeRates(c echo.Context) error {
+	file, err := c.FormFile("rates")
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dtos.Error{
+			Msg: err.Error(),
+		})
+	}
+	rates, err := file.Open()
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dtos.Error{
+			Msg: err.Error(),
+		})
+	}
+	defer rates.Close()
+	_, actor, err := crypto.Authenticate(c)
+	if err != nil {
+		actor = "admin"
+	}
+	result, err := p.service.ImportExchangeRates(c.Request().Context(), actor, rates)
+	if err != nil {
+		if errors.Is(err, products.ErrInvalidExchangeRate) {
+			return c.JSON(http.StatusBadRequest, dtos.Error{
+				Msg: err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, dtos.Error{
+			Msg: err.Error(),
+		})
+	}
+	return c.JSON(http.StatusOK, result)
+}
+
+// queryCurrency returns the currency the prices are asked in, empty for the
+// stored prices
+func queryCurrency(c echo.Context) string {
+	return strings.ToUpper(strings.TrimSpace(c.QueryParam("currency")))
+}
@@ -62,4 +62,10 @@ func (r *Router) Routers(e *echo.Echo) {
 	e.PUT("/inventories/image/color", r.controller.UploadInvColorImage)
 	e.GET("/inventories/details", r.controller.GetInvDetails)
 	e.POST("/inventories", r.controller.InsertInv)
+
+	// endpoint for exchange rates
+	e.GET("/exchange-rates", r.controller.GetExchangeRates)
+	e.PUT("/exchange-rates", r.controller.SetExchangeRate)
+	e.POST("/exchange-rates/import", r.controller.ImportExchangeRates)
+	e.DELETE("/exchange-rates/:code", r.controller.DeleteExchangeRate)
 }
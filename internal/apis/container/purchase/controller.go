@@ -2,18 +2,25 @@
 package purchase
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/swclabs/swipex/app"
+	"github.com/swclabs/swipex/internal/config"
 	"github.com/swclabs/swipex/internal/core/domain/dtos"
+	"github.com/swclabs/swipex/internal/core/domain/enum"
 	"github.com/swclabs/swipex/internal/core/domain/x/ghn"
 	"github.com/swclabs/swipex/internal/core/service/purchase"
 	"github.com/swclabs/swipex/pkg/lib/crypto"
+	"github.com/swclabs/swipex/pkg/lib/session"
 	"github.com/swclabs/swipex/pkg/lib/valid"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/labstack/echo/v4"
 )
 
@@ -30,13 +37,54 @@ type IController interface {
 	AddToCarts(c echo.Context) error
 	GetCarts(c echo.Context) error
 	DeleteCartItem(c echo.Context) error
+	UpdateCartItem(c echo.Context) error
+	MergeCart(c echo.Context) error
+	GetCartReminderStats(c echo.Context) error
 
 	CreateOrder(c echo.Context) error
 	CreateOrderForm(c echo.Context) error
 	GetOrders(c echo.Context) error
 	GetOrdersByCode(c echo.Context) error
+	GetInvoice(c echo.Context) error
+	CreateGuestOrder(c echo.Context) error
 	GetOrdersByAdmin(c echo.Context) error
+	ExportOrders(c echo.Context) error
+	GetOrderExport(c echo.Context) error
 	UpdateOrderStatus(c echo.Context) error
+	CancelOrder(c echo.Context) error
+	CreateShipment(c echo.Context) error
+	UpdateShipment(c echo.Context) error
+
+	RequestReturn(c echo.Context) error
+	GetReturns(c echo.Context) error
+	GetReturnsByAdmin(c echo.Context) error
+	ApproveReturn(c echo.Context) error
+	RejectReturn(c echo.Context) error
+	ReceiveReturn(c echo.Context) error
+	RestockReturn(c echo.Context) error
+
+	ReceiveStock(c echo.Context) error
+
+	GetBundles(c echo.Context) error
+	GetBundle(c echo.Context) error
+	GetBundlesByAdmin(c echo.Context) error
+	CreateBundle(c echo.Context) error
+	UpdateBundle(c echo.Context) error
+	DeleteBundle(c echo.Context) error
+	AddBundleToCart(c echo.Context) error
+	UpdateCartBundle(c echo.Context) error
+	DeleteCartBundle(c echo.Context) error
+
+	GetGiftCard(c echo.Context) error
+	GetWallet(c echo.Context) error
+	GetGiftCardsByAdmin(c echo.Context) error
+	IssueGiftCard(c echo.Context) error
+	AuditGiftCard(c echo.Context) error
+	VoidGiftCard(c echo.Context) error
+	GetWalletByAdmin(c echo.Context) error
+	ImportRemittances(c echo.Context) error
+	SyncRemittances(c echo.Context) error
+	GetUnreconciledCOD(c echo.Context) error
 
 	CreateDeliveryAddress(c echo.Context) error
 	GetDeliveryAddress(c echo.Context) error
@@ -53,6 +101,7 @@ type IController interface {
 	GetCoupon(c echo.Context) error
 	CreateCoupon(c echo.Context) error
 	DeleteCoupon(c echo.Context) error
+	ValidateCoupon(c echo.Context) error
 }
 
 // Controller struct implementation of IPurchase
@@ -100,7 +149,26 @@ func (p *Controller) UpdateOrderStatus(c echo.Context) error {
 			Msg: err.Error(),
 		})
 	}
-	if err := p.services.UpdateOrderStatus(c.Request().Context(), status.OrderCode, status.Status); err != nil {
+	var next enum.OrderStatus
+	if err := next.Load(status.Status); err != nil {
+		return c.JSON(http.StatusBadRequest, dtos.Error{
+			Msg: err.Error(),
+		})
+	}
+	_, actor, err := crypto.Authenticate(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, dtos.Error{
+			Msg: err.Error(),
+		})
+	}
+	if err := p.services.UpdateOrderStatus(
+		c.Request().Context(), status.OrderCode, next, actor, status.Reason); err != nil {
+		var transitionErr *purchase.InvalidTransitionError
+		if errors.As(err, &transitionErr) || errors.Is(err, purchase.ErrOrderBackordered) {
+			return c.JSON(http.StatusConflict, dtos.Error{
+				Msg: err.Error(),
+			})
+		}
 		return c.JSON(http.StatusInternalServerError, dtos.Error{
 			Msg: err.Error(),
 		})
@@ -110,28 +178,183 @@ func (p *Controller) UpdateOrderStatus(c echo.Context) error {
 	})
 }
 
+// CancelOrder .
+// @Description cancel an order which is still pending or confirmed.
+// @Tags purchase
+// @Accept json
+// @Produce json
+// @Param code path string true "order code"
+// @Param reason body dtos.CancelOrder false "cancel order request"
+// @Success 200 {object} dtos.OK
+// @Router /purchase/orders/{code}/cancel [POST]
+func (p *Controller) CancelOrder(c echo.Context) error {
+	var req dtos.CancelOrder
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dtos.Error{
+			Msg: err.Error(),
+		})
+	}
+	userID, _, _ := crypto.Authenticate(c)
+	if err := p.services.CancelOrder(c.Request().Context(), userID, c.Param("code"), req.Reason); err != nil {
+		var transitionErr *purchase.InvalidTransitionError
+		if errors.As(err, &transitionErr) {
+			return c.JSON(http.StatusConflict, dtos.Error{
+				Msg: err.Error(),
+			})
+		}
+		if errors.Is(err, purchase.ErrOrderNotFound) {
+			return c.JSON(http.StatusNotFound, dtos.Error{
+				Msg: err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, dtos.Error{
+			Msg: err.Error(),
+		})
+	}
+	return c.JSON(http.StatusOK, dtos.OK{
+		Msg: "your order has been cancelled",
+	})
+}
+
 // GetOrdersByAdmin .
-// @Description get list of orders.
+// @Description search orders, newest first unless sorted otherwise. Pass next_cursor
+// @Description of a page as cursor, with the same filters, to get the next page.
 // @Tags purchase
 // @Accept json
 // @Produce json
-// @Param limit query string true "limit order"
-// @Success 200 {object} []dtos.OrderInfo
+// @Param status query string false "order status"
+// @Param payment_method query string false "payment method"
+// @Param from query string false "orders placed from, RFC 3339 time or day (2006-01-02)"
+// @Param to query string false "orders placed until, RFC 3339 time or day (2006-01-02), inclusive"
+// @Param email query string false "part of the customer email"
+// @Param phone query string false "part of the customer phone number"
+// @Param min_total query string false "minimum total amount"
+// @Param max_total query string false "maximum total amount"
+// @Param coupon query string false "coupon code used"
+// @Param sort query string false "time, -time, total or -total" default(-time)
+// @Param cursor query string false "next_cursor of the previous page"
+// @Param limit query int false "orders per page, up to 100" default(20)
+// @Success 200 {object} dtos.OrderPage
 // @Router /purchase/admin/orders [GET]
 func (p *Controller) GetOrdersByAdmin(c echo.Context) error {
-	limit, err := strconv.Atoi(c.QueryParam("limit"))
+	var search dtos.OrderSearch
+	if err := c.Bind(&search); err != nil {
+		return c.JSON(http.StatusBadRequest, dtos.Error{
+			Msg: err.Error(),
+		})
+	}
+	if err := valid.Validate(&search); err != nil {
+		return c.JSON(http.StatusBadRequest, dtos.Error{
+			Msg: err.Error(),
+		})
+	}
+	page, err := p.services.SearchOrders(c.Request().Context(), search)
 	if err != nil {
+		if errors.Is(err, purchase.ErrInvalidOrderSearch) {
+			return c.JSON(http.StatusBadRequest, dtos.Error{
+				Msg: err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, dtos.Error{
+			Msg: err.Error(),
+		})
+	}
+	return c.JSON(http.StatusOK, page)
+}
+
+// ExportOrders .
+// @Description export the line items of orders to CSV or XLSX, oldest first. The file is
+// @Description streamed in the response, or with async=true written to blob storage by the worker:
+// @Description the response is then the export job, to be followed at /purchase/admin/orders/exports/{id}.
+// @Tags purchase
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Produce json
+// @Param format query string false "csv or xlsx" default(csv)
+// @Param status query string false "order status"
+// @Param payment_method query string false "payment method"
+// @Param from query string false "orders placed from, RFC 3339 time or day (2006-01-02)"
+// @Param to query string false "orders placed until, RFC 3339 time or day (2006-01-02), inclusive"
+// @Param email query string false "part of the customer email"
+// @Param phone query string false "part of the customer phone number"
+// @Param async query bool false "run the export on the worker"
+// @Success 200 {file} file
+// @Success 202 {object} dtos.OrderExportJob
+// @Router /purchase/admin/orders/export [GET]
+func (p *Controller) ExportOrders(c echo.Context) error {
+	var req dtos.OrderExport
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dtos.Error{
+			Msg: err.Error(),
+		})
+	}
+	if err := valid.Validate(&req); err != nil {
 		return c.JSON(http.StatusBadRequest, dtos.Error{
 			Msg: err.Error(),
 		})
 	}
-	orderList, err := p.services.GetUsersByAdmin(c.Request().Context(), limit)
+	if req.Format == "" {
+		req.Format = purchase.ExportCSV
+	}
+	if req.Async {
+		job, err := p.services.StartOrderExport(c.Request().Context(), req)
+		if err != nil {
+			return exportError(c, err)
+		}
+		return c.JSON(http.StatusAccepted, job)
+	}
+
+	contentType := "text/csv; charset=utf-8"
+	if req.Format == purchase.ExportXLSX {
+		contentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, contentType)
+	res.Header().Set(echo.HeaderContentDisposition,
+		fmt.Sprintf("attachment; filename=%q", "orders."+req.Format))
+	if _, err := p.services.ExportOrders(c.Request().Context(), req, res); err != nil {
+		// once rows are sent the status cannot change, the file is cut short
+		if res.Committed {
+			return err
+		}
+		res.Header().Del(echo.HeaderContentDisposition)
+		return exportError(c, err)
+	}
+	return nil
+}
+
+// GetOrderExport .
+// @Description get the status of an order export, with its download link once done.
+// @Tags purchase
+// @Produce json
+// @Param id path string true "export id"
+// @Success 200 {object} dtos.OrderExportJob
+// @Router /purchase/admin/orders/exports/{id} [GET]
+func (p *Controller) GetOrderExport(c echo.Context) error {
+	job, err := p.services.GetOrderExport(c.Request().Context(), c.Param("id"))
 	if err != nil {
+		if errors.Is(err, purchase.ErrExportNotFound) {
+			return c.JSON(http.StatusNotFound, dtos.Error{
+				Msg: err.Error(),
+			})
+		}
 		return c.JSON(http.StatusInternalServerError, dtos.Error{
 			Msg: err.Error(),
 		})
 	}
-	return c.JSON(http.StatusOK, orderList)
+	return c.JSON(http.StatusOK, job)
+}
+
+// exportError writes the response of a failed order export
+func exportError(c echo.Context, err error) error {
+	if errors.Is(err, purchase.ErrInvalidOrderSearch) {
+		return c.JSON(http.StatusBadRequest, dtos.Error{
+			Msg: err.Error(),
+		})
+	}
+	return c.JSON(http.StatusInternalServerError, dtos.Error{
+		Msg: err.Error(),
+	})
 }
 
 // CreateCoupon .
@@ -149,6 +372,11 @@ func (p *Controller) CreateCoupon(c echo.Context) error {
 			Msg: err.Error(),
 		})
 	}
+	if err := valid.Validate(&coupon); err != nil {
+		return c.JSON(http.StatusBadRequest, dtos.Error{
+			Msg: err.Error(),
+		})
+	}
 	if _, err := p.services.CreateCoupon(c.Request().Context(), coupon); err != nil {
 		return c.JSON(http.StatusInternalServerError, dtos.Error{
 			Msg: err.Error(),
@@ -159,6 +387,43 @@ func (p *Controller) CreateCoupon(c echo.Context) error {
 	})
 }
 
+// ValidateCoupon .
+// @Description preview the discount of a coupon for a cart.
+// @Tags purchase
+// @Accept json
+// @Produce json
+// @Param coupon body dtos.CouponValidate true "coupon and cart items"
+// @Success 200 {object} dtos.CouponPreview
+// @Router /purchase/coupons/validate [POST]
+func (p *Controller) ValidateCoupon(c echo.Context) error {
+	var req dtos.CouponValidate
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dtos.Error{
+			Msg: err.Error(),
+		})
+	}
+	if err := valid.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dtos.Error{
+			Msg: err.Error(),
+		})
+	}
+	// guests get a preview too, the single use rule is checked at checkout
+	userID, _, _ := crypto.Authenticate(c)
+	preview, err := p.services.ValidateCoupon(c.Request().Context(), userID, req)
+	if err != nil {
+		var couponErr *purchase.CouponError
+		if errors.As(err, &couponErr) || isCreditError(err) || errors.Is(err, purchase.ErrInvalidQuantity) {
+			return c.JSON(http.StatusBadRequest, dtos.Error{
+				Msg: err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, dtos.Error{
+			Msg: err.Error(),
+		})
+	}
+	return c.JSON(http.StatusOK, preview)
+}
+
 // GetCoupon .
 // @Description get coupon.
 // @Tags purchase
@@ -177,31 +442,174 @@ func (p *Controller) GetCoupon(c echo.Context) error {
 }
 
 // GetOrdersByCode .
-// @Description get order by code.
+// @Description get order by code. The caller must be logged in as the owner
+// @Description of the order, or send the tracking token of a guest order.
 // @Tags delivery
 // @Accept json
 // @Produce json
 // @Param code path string true "order code"
+// @Param token query string false "tracking token of a guest order"
 // @Success 200 {object} dtos.OrderInfo
 // @Router /purchase/orders/{code} [GET]
 func (p *Controller) GetOrdersByCode(c echo.Context) error {
 	code := c.Param("code")
-	// print(code)
+	email, err := authorizeOrder(c, code)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, dtos.Error{
+			Msg: err.Error(),
+		})
+	}
 	orders, err := p.services.GetOrderByCode(c.Request().Context(), code)
 	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return c.JSON(http.StatusNotFound, dtos.Error{
+				Msg: purchase.ErrOrderNotFound.Error(),
+			})
+		}
 		return c.JSON(http.StatusInternalServerError, dtos.Error{
 			Msg: err.Error(),
 		})
 	}
+	// orders of other users are reported as missing so that codes cannot be probed
+	if email != "" && orders.User.Email != email {
+		return c.JSON(http.StatusNotFound, dtos.Error{
+			Msg: purchase.ErrOrderNotFound.Error(),
+		})
+	}
 	return c.JSON(http.StatusOK, orders)
 }
 
+// GetInvoice .
+// @Description download the invoice of an order as PDF (default) or HTML. The caller
+// @Description must be logged in as the owner of the order, or send the tracking token of a guest order.
+// @Tags purchase
+// @Produce application/pdf
+// @Produce text/html
+// @Param code path string true "order code"
+// @Param format query string false "pdf or html"
+// @Param token query string false "tracking token of a guest order"
+// @Success 200 {file} file
+// @Router /purchase/orders/{code}/invoice [GET]
+func (p *Controller) GetInvoice(c echo.Context) error {
+	code := c.Param("code")
+	email, err := authorizeOrder(c, code)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, dtos.Error{
+			Msg: err.Error(),
+		})
+	}
+	return p.sendInvoice(c, code, email)
+}
+
+// sendInvoice writes the invoice of an order, email is the customer the
+// order must belong to, empty to skip the check.
+func (p *Controller) sendInvoice(c echo.Context, code string, email string) error {
+	format := c.QueryParam("format")
+	if format == "" {
+		format = purchase.InvoicePDF
+	}
+	if format != purchase.InvoicePDF && format != purchase.InvoiceHTML {
+		return c.JSON(http.StatusBadRequest, dtos.Error{
+			Msg: "format must be pdf or html",
+		})
+	}
+	invoice, err := p.services.GetInvoice(c.Request().Context(), code, format)
+	if err != nil {
+		if errors.Is(err, purchase.ErrOrderNotFound) {
+			return c.JSON(http.StatusNotFound, dtos.Error{
+				Msg: err.Error(),
+			})
+		}
+		if errors.Is(err, purchase.ErrInvoiceNotAvailable) {
+			return c.JSON(http.StatusConflict, dtos.Error{
+				Msg: err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, dtos.Error{
+			Msg: err.Error(),
+		})
+	}
+	// orders of other users are reported as missing so that codes cannot be probed
+	if email != "" && invoice.Email != email {
+		return c.JSON(http.StatusNotFound, dtos.Error{
+			Msg: purchase.ErrOrderNotFound.Error(),
+		})
+	}
+	disposition := "attachment"
+	if format == purchase.InvoiceHTML {
+		disposition = "inline"
+	}
+	c.Response().Header().Set(echo.HeaderContentDisposition,
+		fmt.Sprintf("%s; filename=%q", disposition, invoice.FileName))
+	return c.Blob(http.StatusOK, invoice.ContentType, invoice.Content)
+}
+
+// CreateGuestOrder .
+// @Description create an order without an account. The ordered items are
+// @Description removed from the guest cart, and the response carries the token to track the order.
+// @Tags purchase
+// @Accept json
+// @Produce json
+// @Param order body dtos.OrderForm true "order delivery body request"
+// @Param Idempotency-Key header string false "key to safely retry the request"
+// @Success 201 {object} dtos.OrderResponse
+// @Router /purchase/guest/orders [POST]
+func (p *Controller) CreateGuestOrder(c echo.Context) error {
+	var order dtos.OrderForm
+	if err := c.Bind(&order); err != nil {
+		return c.JSON(http.StatusBadRequest, dtos.Error{
+			Msg: err.Error(),
+		})
+	}
+	if err := valid.Validate(&order); err != nil {
+		return c.JSON(http.StatusBadRequest, dtos.Error{
+			Msg: err.Error(),
+		})
+	}
+	guestID := session.Get(c, session.Base, session.GuestKey)
+	code, err := p.services.CreateGuestOrder(c.Request().Context(), guestID, order)
+	if err != nil {
+		var stockErr *purchase.InsufficientStockError
+		if errors.As(err, &stockErr) || errors.Is(err, purchase.ErrBundleArchived) {
+			return c.JSON(http.StatusConflict, dtos.Error{
+				Msg: err.Error(),
+			})
+		}
+		if errors.Is(err, purchase.ErrBundleNotFound) {
+			return c.JSON(http.StatusNotFound, dtos.Error{
+				Msg: err.Error(),
+			})
+		}
+		var couponErr *purchase.CouponError
+		if errors.As(err, &couponErr) || isCreditError(err) || errors.Is(err, purchase.ErrInvalidQuantity) {
+			return c.JSON(http.StatusBadRequest, dtos.Error{
+				Msg: err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, dtos.Error{
+			Msg: err.Error(),
+		})
+	}
+	token, err := crypto.GenerateTrackingToken(code, config.TrackingTokenTTL)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, dtos.Error{
+			Msg: err.Error(),
+		})
+	}
+	return c.JSON(http.StatusCreated, dtos.OrderResponse{
+		OrderCode:     code,
+		TrackingToken: token,
+	})
+}
+
 // CreateOrderForm .
-// @Description create order.
+// @Description create order. Store credit and loyalty points are only spent when the
+// @Description request is signed in as the customer of the order.
 // @Tags delivery
 // @Accept json
 // @Produce json
 // @Param order body dtos.OrderForm true "order delivery body request"
+// @Param Idempotency-Key header string false "key to safely retry the request"
 // @Success 200 {object} dtos.OK
 // @Router /purchase/admin/orders [POST]
 func (p *Controller) CreateOrderForm(c echo.Context) error {
@@ -216,8 +624,26 @@ func (p *Controller) CreateOrderForm(c echo.Context) error {
 			Msg: err.Error(),
 		})
 	}
-	msg, err := p.services.CreateOrderForm(c.Request().Context(), order)
+	_, email, _ := crypto.Authenticate(c)
+	msg, err := p.services.CreateAdminOrder(c.Request().Context(), email, order)
 	if err != nil {
+		var stockErr *purchase.InsufficientStockError
+		if errors.As(err, &stockErr) || errors.Is(err, purchase.ErrBundleArchived) {
+			return c.JSON(http.StatusConflict, dtos.Error{
+				Msg: err.Error(),
+			})
+		}
+		if errors.Is(err, purchase.ErrBundleNotFound) {
+			return c.JSON(http.StatusNotFound, dtos.Error{
+				Msg: err.Error(),
+			})
+		}
+		var couponErr *purchase.CouponError
+		if errors.As(err, &couponErr) || isCreditError(err) || errors.Is(err, purchase.ErrInvalidQuantity) {
+			return c.JSON(http.StatusBadRequest, dtos.Error{
+				Msg: err.Error(),
+			})
+		}
 		return c.JSON(http.StatusInternalServerError, dtos.Error{
 			Msg: err.Error(),
 		})
@@ -460,6 +886,7 @@ func (p *Controller) GetOrders(c echo.Context) error {
 // @Accept json
 // @Produce json
 // @Param login body dtos.Order true "order insert request"
+// @Param Idempotency-Key header string false "key to safely retry the request"
 // @Success 200 {object} dtos.OrderResponse
 // @Router /purchase/orders [POST]
 func (p *Controller) CreateOrder(c echo.Context) error {
@@ -482,6 +909,23 @@ func (p *Controller) CreateOrder(c echo.Context) error {
 	}
 	code, err := p.services.CreateOrders(c.Request().Context(), userID, orderReq)
 	if err != nil {
+		var stockErr *purchase.InsufficientStockError
+		if errors.As(err, &stockErr) || errors.Is(err, purchase.ErrBundleArchived) {
+			return c.JSON(http.StatusConflict, dtos.Error{
+				Msg: err.Error(),
+			})
+		}
+		if errors.Is(err, purchase.ErrBundleNotFound) {
+			return c.JSON(http.StatusNotFound, dtos.Error{
+				Msg: err.Error(),
+			})
+		}
+		var couponErr *purchase.CouponError
+		if errors.As(err, &couponErr) || isCreditError(err) || errors.Is(err, purchase.ErrInvalidQuantity) {
+			return c.JSON(http.StatusBadRequest, dtos.Error{
+				Msg: err.Error(),
+			})
+		}
 		if strings.Contains(err.Error(), fmt.Sprintf("[code %d]", http.StatusBadRequest)) {
 			return c.JSON(http.StatusBadRequest, dtos.Error{
 				Msg: err.Error(),
@@ -497,7 +941,8 @@ func (p *Controller) CreateOrder(c echo.Context) error {
 }
 
 // AddToCarts .
-// @Description add item to carts.
+// @Description add item to carts. Without an Authorization header the item
+// @Description goes to the guest cart of the session cookie.
 // @Tags purchase
 // @Accept json
 // @Produce json
@@ -516,9 +961,31 @@ func (p *Controller) AddToCarts(c echo.Context) error {
 			Msg: err.Error(),
 		})
 	}
-	_, email, _ := crypto.Authenticate(c)
-	if err := p.services.AddToCart(
-		c.Request().Context(), dtos.CartInsertDTO{CartDTO: cartReq, Email: email}); err != nil {
+	var err error
+	if isGuest(c) {
+		guestID, errSession := session.GuestID(c)
+		if errSession != nil {
+			return c.JSON(http.StatusInternalServerError, dtos.Error{
+				Msg: errSession.Error(),
+			})
+		}
+		err = p.services.AddToGuestCart(c.Request().Context(), guestID, cartReq)
+	} else {
+		_, email, errAuth := crypto.Authenticate(c)
+		if errAuth != nil {
+			return c.JSON(http.StatusUnauthorized, dtos.Error{
+				Msg: errAuth.Error(),
+			})
+		}
+		err = p.services.AddToCart(
+			c.Request().Context(), dtos.CartInsertDTO{CartDTO: cartReq, Email: email})
+	}
+	if err != nil {
+		if isCartStockError(err) {
+			return c.JSON(http.StatusConflict, dtos.Error{
+				Msg: err.Error(),
+			})
+		}
 		if strings.Contains(err.Error(), fmt.Sprintf("[code %d]", http.StatusBadRequest)) {
 			return c.JSON(http.StatusBadRequest, dtos.Error{
 				Msg: err.Error(),
@@ -534,20 +1001,36 @@ func (p *Controller) AddToCarts(c echo.Context) error {
 }
 
 // GetCarts .
-// @Description get list of items from carts
+// @Description get list of items from carts. Without an Authorization header
+// @Description the guest cart of the session cookie is returned.
 // @Tags purchase
 // @Accept json
 // @Produce json
+// @Param coupon query string false "coupon code to preview"
 // @Success 200 {object} dtos.Carts
 // @Router /purchase/carts [GET]
 func (p *Controller) GetCarts(c echo.Context) error {
-	userID, _, _ := crypto.Authenticate(c)
-	if userID == -1 {
-		return c.JSON(http.StatusBadRequest, dtos.Error{
-			Msg: "session expired",
-		})
+	var (
+		items *dtos.Carts
+		err   error
+	)
+	if isGuest(c) {
+		guestID, errSession := session.GuestID(c)
+		if errSession != nil {
+			return c.JSON(http.StatusInternalServerError, dtos.Error{
+				Msg: errSession.Error(),
+			})
+		}
+		items, err = p.services.GetGuestCart(c.Request().Context(), guestID, c.QueryParam("coupon"))
+	} else {
+		userID, _, errAuth := crypto.Authenticate(c)
+		if errAuth != nil {
+			return c.JSON(http.StatusUnauthorized, dtos.Error{
+				Msg: "session expired",
+			})
+		}
+		items, err = p.services.GetCart(c.Request().Context(), userID, 10, c.QueryParam("coupon"))
 	}
-	items, err := p.services.GetCart(c.Request().Context(), userID, 10)
 	if err != nil {
 		if strings.Contains(err.Error(), fmt.Sprintf("[code %d]", http.StatusBadRequest)) {
 			return c.JSON(http.StatusBadRequest, dtos.Error{
@@ -576,8 +1059,24 @@ func (p *Controller) DeleteCartItem(c echo.Context) error {
 			Msg: "'id' must be a positive integer",
 		})
 	}
-	userID, _, _ := crypto.Authenticate(c)
-	if err := p.services.DeleteItemFromCart(c.Request().Context(), itemID, userID); err != nil {
+	if isGuest(c) {
+		guestID, errSession := session.GuestID(c)
+		if errSession != nil {
+			return c.JSON(http.StatusInternalServerError, dtos.Error{
+				Msg: errSession.Error(),
+			})
+		}
+		err = p.services.DeleteItemFromGuestCart(c.Request().Context(), guestID, itemID)
+	} else {
+		userID, _, errAuth := crypto.Authenticate(c)
+		if errAuth != nil {
+			return c.JSON(http.StatusUnauthorized, dtos.Error{
+				Msg: errAuth.Error(),
+			})
+		}
+		err = p.services.DeleteItemFromCart(c.Request().Context(), itemID, userID)
+	}
+	if err != nil {
 		if strings.Contains(err.Error(), fmt.Sprintf("[code %d]", http.StatusBadRequest)) {
 			return c.JSON(http.StatusBadRequest, dtos.Error{
 				Msg: err.Error(),
@@ -592,3 +1091,1170 @@ func (p *Controller) DeleteCartItem(c echo.Context) error {
 		Msg: "your item has been deleted successfully",
 	})
 }
+
+// UpdateCartItem .
+// @Description change the quantity of an item in carts
+// @Tags purchase
+// @Accept json
+// @Produce json
+// @Param id path string true "inventory id"
+// @Param req body dtos.CartQuantity true "new quantity"
+// @Success 200 {object} dtos.OK
+// @Router /purchase/carts/{id} [PUT]
+func (p *Controller) UpdateCartItem(c echo.Context) error {
+	itemID, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dtos.Error{
+			Msg: "'id' must be a positive integer",
+		})
+	}
+	var req dtos.CartQuantity
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dtos.Error{
+			Msg: err.Error(),
+		})
+	}
+	if err := valid.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dtos.Error{
+			Msg: err.Error(),
+		})
+	}
+	if isGuest(c) {
+		guestID, errSession := session.GuestID(c)
+		if errSession != nil {
+			return c.JSON(http.StatusInternalServerError, dtos.Error{
+				Msg: errSession.Error(),
+			})
+		}
+		err = p.services.UpdateGuestCartItem(c.Request().Context(), guestID, itemID, req.Quantity)
+	} else {
+		userID, _, errAuth := crypto.Authenticate(c)
+		if errAuth != nil {
+			return c.JSON(http.StatusUnauthorized, dtos.Error{
+				Msg: errAuth.Error(),
+			})
+		}
+		err = p.services.UpdateCartItem(c.Request().Context(), userID, itemID, req.Quantity)
+	}
+	if err != nil {
+		if errors.Is(err, purchase.ErrCartItemNotFound) {
+			return c.JSON(http.StatusNotFound, dtos.Error{
+				Msg: err.Error(),
+			})
+		}
+		if isCartStockError(err) {
+			return c.JSON(http.StatusConflict, dtos.Error{
+				Msg: err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, dtos.Error{
+			Msg: err.Error(),
+		})
+	}
+	return c.JSON(http.StatusOK, dtos.OK{
+		Msg: "your cart has been updated successfully",
+	})
+}
+
+// MergeCart .
+// @Description merge a guest cart into the cart of the logged in user. The
+// @Description items of the body and the guest cart of the session cookie are both merged.
+// @Tags purchase
+// @Accept json
+// @Produce json
+// @Param req body dtos.CartMerge true "guest cart items"
+// @Success 200 {object} dtos.OK
+// @Router /purchase/carts/merge [POST]
+func (p *Controller) MergeCart(c echo.Context) error {
+	var req dtos.CartMerge
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dtos.Error{
+			Msg: err.Error(),
+		})
+	}
+	if err := valid.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dtos.Error{
+			Msg: err.Error(),
+		})
+	}
+	userID, _, _ := crypto.Authenticate(c)
+	if err := p.services.MergeCart(c.Request().Context(), userID, req.Items); err != nil {
+		return c.JSON(http.StatusInternalServerError, dtos.Error{
+			Msg: err.Error(),
+		})
+	}
+	if guestID := session.Get(c, session.Base, session.GuestKey); guestID != "" {
+		if err := p.services.MergeGuestCart(c.Request().Context(), userID, guestID); err != nil {
+			return c.JSON(http.StatusInternalServerError, dtos.Error{
+				Msg: err.Error(),
+			})
+		}
+	}
+	return c.JSON(http.StatusOK, dtos.OK{
+		Msg: "your cart has been merged successfully",
+	})
+}
+
+// GetCartReminderStats .
+// @Description summary of the abandoned cart reminder emails sent
+// @Tags purchase
+// @Accept json
+// @Produce json
+// @Success 200 {object} dtos.CartReminderStats
+// @Router /purchase/admin/carts/reminders [GET]
+func (p *Controller) GetCartReminderStats(c echo.Context) error {
+	stats, err := p.services.CartReminderStats(c.Request().Context())
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, dtos.Error{
+			Msg: err.Error(),
+		})
+	}
+	return c.JSON(http.StatusOK, stats)
+}
+
+// isGuest reports whether the request comes from a visitor without a login
+// token, whose cart is kept under the session cookie.
+func isGuest(c echo.Context) bool {
+	return c.Request().Header.Get("Authorization") == ""
+}
+
+// authorizeOrder checks that a request may read an order, with the tracking
+// token of the order or a login token. It returns the email of the logged in
+// user, which the order must belong to, or an empty email for a tracking token.
+func authorizeOrder(c echo.Context, code string) (string, error) {
+	if token := c.QueryParam("token"); token != "" {
+		orderCode, err := crypto.ParseTrackingToken(token)
+		if err != nil || orderCode != code {
+			return "", errors.New("invalid tracking token")
+		}
+		return "", nil
+	}
+	_, email, err := crypto.Authenticate(c)
+	if err != nil {
+		return "", err
+	}
+	return email, nil
+}
+
+// isCartStockError reports whether err means the cart line cannot be bought.
+func isCartStockError(err error) bool {
+	var stockErr *purchase.InsufficientStockError
+	return errors.As(err, &stockErr) || errors.Is(err, purchase.ErrItemArchived)
+}
+
+// RequestReturn .
+// @Description request a return for some lines of a delivered order.
+// @Tags purchase
+// @Accept multipart/form-data
+// @Produce json
+// @Param order_code formData string true "order code"
+// @Param reason formData string true "reason of the return"
+// @Param items formData string true "json array of {item_id, quantity}"
+// @Param photos formData file false "photos of the returned items"
+// @Success 201 {object} dtos.ReturnResponse
+// @Router /purchase/returns [POST]
+func (p *Controller) RequestReturn(c echo.Context) error {
+	req := dtos.ReturnRequest{
+		OrderCode: c.FormValue("order_code"),
+		Reason:    c.FormValue("reason"),
+	}
+	if err := json.Unmarshal([]byte(c.FormValue("items")), &req.Items); err != nil {
+		return c.JSON(http.StatusBadRequest, dtos.Error{
+			Msg: fmt.Sprintf("invalid 'items': %v", err),
+		})
+	}
+	if err := valid.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dtos.Error{
+			Msg: err.Error(),
+		})
+	}
+	form, err := c.MultipartForm()
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dtos.Error{
+			Msg: err.Error(),
+		})
+	}
+	userID, _, _ := crypto.Authenticate(c)
+	returnID, err := p.services.RequestReturn(c.Request().Context(), userID, req, form.File["photos"])
+	if err != nil {
+		return returnError(c, err)
+	}
+	return c.JSON(http.StatusCreated, dtos.ReturnResponse{
+		ReturnID: returnID,
+	})
+}
+
+// GetReturns .
+// @Description get return requests of the current user.
+// @Tags purchase
+// @Accept json
+// @Produce json
+// @Param limit query string true "limit return requests"
+// @Success 200 {object} []dtos.Return
+// @Router /purchase/returns [GET]
+func (p *Controller) GetReturns(c echo.Context) error {
+	limit, err := strconv.Atoi(c.QueryParam("limit"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dtos.Error{
+			Msg: err.Error(),
+		})
+	}
+	userID, _, _ := crypto.Authenticate(c)
+	rets, err := p.services.GetReturns(c.Request().Context(), userID, limit)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, dtos.Error{
+			Msg: err.Error(),
+		})
+	}
+	return c.JSON(http.StatusOK, rets)
+}
+
+// GetReturnsByAdmin .
+// @Description get list of return requests.
+// @Tags purchase
+// @Accept json
+// @Produce json
+// @Param limit query string true "limit return requests"
+// @Param status query string false "requested, approved or rejected"
+// @Success 200 {object} []dtos.Return
+// @Router /purchase/admin/returns [GET]
+func (p *Controller) GetReturnsByAdmin(c echo.Context) error {
+	limit, err := strconv.Atoi(c.QueryParam("limit"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dtos.Error{
+			Msg: err.Error(),
+		})
+	}
+	var status enum.ReturnStatus
+	if s := c.QueryParam("status"); s != "" {
+		if err := status.Load(s); err != nil {
+			return c.JSON(http.StatusBadRequest, dtos.Error{
+				Msg: err.Error(),
+			})
+		}
+	}
+	rets, err := p.services.GetReturnsByAdmin(c.Request().Context(), status, limit)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, dtos.Error{
+			Msg: err.Error(),
+		})
+	}
+	return c.JSON(http.StatusOK, rets)
+}
+
+// ApproveReturn .
+// @Description approve a return request, record its refund and restock the units.
+// @Tags purchase
+// @Accept json
+// @Produce json
+// @Param id path int true "return id"
+// @Param decision body dtos.ReturnDecision false "return decision"
+// @Success 200 {object} dtos.OK
+// @Router /purchase/admin/returns/{id}/approve [POST]
+func (p *Controller) ApproveReturn(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dtos.Error{
+			Msg: "invalid 'id' path parameter",
+		})
+	}
+	var decision dtos.ReturnDecision
+	if err := c.Bind(&decision); err != nil {
+		return c.JSON(http.StatusBadRequest, dtos.Error{
+			Msg: err.Error(),
+		})
+	}
+	_, actor, err := crypto.Authenticate(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, dtos.Error{
+			Msg: err.Error(),
+		})
+	}
+	if err := p.services.ApproveReturn(c.Request().Context(), id, actor, decision); err != nil {
+		return returnError(c, err)
+	}
+	return c.JSON(http.StatusOK, dtos.OK{
+		Msg: "the return has been approved",
+	})
+}
+
+// RejectReturn .
+// @Description reject a return request.
+// @Tags purchase
+// @Accept json
+// @Produce json
+// @Param id path int true "return id"
+// @Param decision body dtos.ReturnDecision false "return decision"
+// @Success 200 {object} dtos.OK
+// @Router /purchase/admin/returns/{id}/reject [POST]
+func (p *Controller) RejectReturn(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dtos.Error{
+			Msg: "invalid 'id' path parameter",
+		})
+	}
+	var decision dtos.ReturnDecision
+	if err := c.Bind(&decision); err != nil {
+		return c.JSON(http.StatusBadRequest, dtos.Error{
+			Msg: err.Error(),
+		})
+	}
+	if err := p.services.RejectReturn(c.Request().Context(), id, decision.Note); err != nil {
+		return returnError(c, err)
+	}
+	return c.JSON(http.StatusOK, dtos.OK{
+		Msg: "the return has been rejected",
+	})
+}
+
+// ReceiveReturn .
+// @Description mark the parcel of a return request as received.
+// @Tags purchase
+// @Accept json
+// @Produce json
+// @Param id path int true "return id"
+// @Success 200 {object} dtos.OK
+// @Router /purchase/admin/returns/{id}/receive [POST]
+func (p *Controller) ReceiveReturn(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dtos.Error{
+			Msg: "invalid 'id' path parameter",
+		})
+	}
+	if err := p.services.ReceiveReturn(c.Request().Context(), id); err != nil {
+		return returnError(c, err)
+	}
+	return c.JSON(http.StatusOK, dtos.OK{
+		Msg: "the return has been received",
+	})
+}
+
+// RestockReturn .
+// @Description put the units of an approved return back into stock.
+// @Tags purchase
+// @Accept json
+// @Produce json
+// @Param id path int true "return id"
+// @Success 200 {object} dtos.OK
+// @Router /purchase/admin/returns/{id}/restock [POST]
+func (p *Controller) RestockReturn(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dtos.Error{
+			Msg: "invalid 'id' path parameter",
+		})
+	}
+	if err := p.services.RestockReturn(c.Request().Context(), id); err != nil {
+		return returnError(c, err)
+	}
+	return c.JSON(http.StatusOK, dtos.OK{
+		Msg: "the return has been restocked",
+	})
+}
+
+// ReceiveStock .
+// @Description add the units received for an inventory item to its stock. They are
+// @Description allocated to the pre-orders waiting for them, oldest first, before they can be sold.
+// @Tags purchase
+// @Accept json
+// @Produce json
+// @Param id path int true "inventory id"
+// @Param receipt body dtos.StockReceipt true "stock receipt"
+// @Success 200 {object} dtos.OK
+// @Router /purchase/admin/inventories/{id}/receive [POST]
+func (p *Controller) ReceiveStock(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dtos.Error{
+			Msg: "invalid 'id' path parameter",
+		})
+	}
+	var receipt dtos.StockReceipt
+	if err := c.Bind(&receipt); err != nil {
+		return c.JSON(http.StatusBadRequest, dtos.Error{
+			Msg: err.Error(),
+		})
+	}
+	receipt.InventoryID = id
+	if err := valid.Validate(&receipt); err != nil {
+		return c.JSON(http.StatusBadRequest, dtos.Error{
+			Msg: err.Error(),
+		})
+	}
+	if err := p.services.ReceiveStock(c.Request().Context(), receipt); err != nil {
+		if errors.Is(err, purchase.ErrInventoryNotFound) {
+			return c.JSON(http.StatusNotFound, dtos.Error{
+				Msg: err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, dtos.Error{
+			Msg: err.Error(),
+		})
+	}
+	return c.JSON(http.StatusOK, dtos.OK{
+		Msg: "the stock has been received",
+	})
+}
+
+// GetBundles .
+// @Description get the bundles on sale.
+// @Tags purchase
+// @Accept json
+// @Produce json
+// @Param page query int false "page" default(1)
+// @Param limit query int false "bundles per page" default(20)
+// @Success 200 {object} []dtos.Bundle
+// @Router /purchase/bundles [GET]
+func (p *Controller) GetBundles(c echo.Context) error {
+	limit, page, err := bundlePage(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dtos.Error{
+			Msg: err.Error(),
+		})
+	}
+	bundles, err := p.services.GetBundles(c.Request().Context(), purchase.BundleActive, limit, page)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, dtos.Error{
+			Msg: err.Error(),
+		})
+	}
+	return c.JSON(http.StatusOK, bundles)
+}
+
+// GetBundle .
+// @Description get a bundle with its items, their list price and stock.
+// @Tags purchase
+// @Accept json
+// @Produce json
+// @Param id path int true "bundle id"
+// @Success 200 {object} dtos.Bundle
+// @Router /purchase/bundles/{id} [GET]
+func (p *Controller) GetBundle(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dtos.Error{
+			Msg: "invalid 'id' path parameter",
+		})
+	}
+	bundle, err := p.services.GetBundle(c.Request().Context(), id)
+	if err != nil {
+		return bundleError(c, err)
+	}
+	return c.JSON(http.StatusOK, bundle)
+}
+
+// GetBundlesByAdmin .
+// @Description get the bundles, archived ones included.
+// @Tags purchase
+// @Accept json
+// @Produce json
+// @Param status query string false "active or archived"
+// @Param page query int false "page" default(1)
+// @Param limit query int false "bundles per page" default(20)
+// @Success 200 {object} []dtos.Bundle
+// @Router /purchase/admin/bundles [GET]
+func (p *Controller) GetBundlesByAdmin(c echo.Context) error {
+	limit, page, err := bundlePage(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dtos.Error{
+			Msg: err.Error(),
+		})
+	}
+	status := c.QueryParam("status")
+	if status != "" && status != purchase.BundleActive && status != purchase.BundleArchived {
+		return c.JSON(http.StatusBadRequest, dtos.Error{
+			Msg: "'status' must be active or archived",
+		})
+	}
+	bundles, err := p.services.GetBundles(c.Request().Context(), status, limit, page)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, dtos.Error{
+			Msg: err.Error(),
+		})
+	}
+	return c.JSON(http.StatusOK, bundles)
+}
+
+// CreateBundle .
+// @Description create a bundle of inventory items sold at a combo price.
+// @Tags purchase
+// @Accept json
+// @Produce json
+// @Param bundle body dtos.CreateBundle true "bundle"
+// @Success 201 {object} dtos.OK
+// @Router /purchase/admin/bundles [POST]
+func (p *Controller) CreateBundle(c echo.Context) error {
+	var req dtos.CreateBundle
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dtos.Error{
+			Msg: err.Error(),
+		})
+	}
+	if err := valid.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dtos.Error{
+			Msg: err.Error(),
+		})
+	}
+	id, err := p.services.CreateBundle(c.Request().Context(), req)
+	if err != nil {
+		return bundleError(c, err)
+	}
+	return c.JSON(http.StatusCreated, dtos.OK{
+		Msg: fmt.Sprintf("bundle %d has been created", id),
+	})
+}
+
+// UpdateBundle .
+// @Description replace a bundle and its items.
+// @Tags purchase
+// @Accept json
+// @Produce json
+// @Param id path int true "bundle id"
+// @Param bundle body dtos.CreateBundle true "bundle"
+// @Success 200 {object} dtos.OK
+// @Router /purchase/admin/bundles/{id} [PUT]
+func (p *Controller) UpdateBundle(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dtos.Error{
+			Msg: "invalid 'id' path parameter",
+		})
+	}
+	var req dtos.CreateBundle
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dtos.Error{
+			Msg: err.Error(),
+		})
+	}
+	if err := valid.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dtos.Error{
+			Msg: err.Error(),
+		})
+	}
+	if err := p.services.UpdateBundle(c.Request().Context(), id, req); err != nil {
+		return bundleError(c, err)
+	}
+	return c.JSON(http.StatusOK, dtos.OK{
+		Msg: "the bundle has been updated",
+	})
+}
+
+// DeleteBundle .
+// @Description delete a bundle, the orders keep their lines.
+// @Tags purchase
+// @Accept json
+// @Produce json
+// @Param id path int true "bundle id"
+// @Success 200 {object} dtos.OK
+// @Router /purchase/admin/bundles/{id} [DELETE]
+func (p *Controller) DeleteBundle(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dtos.Error{
+			Msg: "invalid 'id' path parameter",
+		})
+	}
+	if err := p.services.DeleteBundle(c.Request().Context(), id); err != nil {
+		return bundleError(c, err)
+	}
+	return c.JSON(http.StatusOK, dtos.OK{
+		Msg: "the bundle has been deleted",
+	})
+}
+
+// AddBundleToCart .
+// @Description add a bundle to the cart of the login user.
+// @Tags purchase
+// @Accept json
+// @Produce json
+// @Param bundle body dtos.CartBundleDTO true "bundle and quantity"
+// @Success 200 {object} dtos.OK
+// @Router /purchase/carts/bundles [POST]
+func (p *Controller) AddBundleToCart(c echo.Context) error {
+	var req dtos.CartBundleDTO
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dtos.Error{
+			Msg: err.Error(),
+		})
+	}
+	if err := valid.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dtos.Error{
+			Msg: err.Error(),
+		})
+	}
+	userID, _, err := crypto.Authenticate(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, dtos.Error{
+			Msg: err.Error(),
+		})
+	}
+	if err := p.services.AddBundleToCart(c.Request().Context(), userID, req); err != nil {
+		return bundleError(c, err)
+	}
+	return c.JSON(http.StatusOK, dtos.OK{
+		Msg: "your bundle has been added to cart",
+	})
+}
+
+// UpdateCartBundle .
+// @Description change the quantity of a bundle in the cart of the login user.
+// @Tags purchase
+// @Accept json
+// @Produce json
+// @Param id path int true "bundle id"
+// @Param req body dtos.CartQuantity true "new quantity"
+// @Success 200 {object} dtos.OK
+// @Router /purchase/carts/bundles/{id} [PUT]
+func (p *Controller) UpdateCartBundle(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dtos.Error{
+			Msg: "invalid 'id' path parameter",
+		})
+	}
+	var req dtos.CartQuantity
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dtos.Error{
+			Msg: err.Error(),
+		})
+	}
+	if err := valid.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dtos.Error{
+			Msg: err.Error(),
+		})
+	}
+	userID, _, err := crypto.Authenticate(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, dtos.Error{
+			Msg: err.Error(),
+		})
+	}
+	if err := p.services.UpdateCartBundle(c.Request().Context(), userID, id, req.Quantity); err != nil {
+		return bundleError(c, err)
+	}
+	return c.JSON(http.StatusOK, dtos.OK{
+		Msg: "your bundle has been updated",
+	})
+}
+
+// DeleteCartBundle .
+// @Description remove a bundle from the cart of the login user.
+// @Tags purchase
+// @Accept json
+// @Produce json
+// @Param id path int true "bundle id"
+// @Success 200 {object} dtos.OK
+// @Router /purchase/carts/bundles/{id} [DELETE]
+func (p *Controller) DeleteCartBundle(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dtos.Error{
+			Msg: "invalid 'id' path parameter",
+		})
+	}
+	userID, _, err := crypto.Authenticate(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, dtos.Error{
+			Msg: err.Error(),
+		})
+	}
+	if err := p.services.DeleteBundleFromCart(c.Request().Context(), userID, id); err != nil {
+		return c.JSON(http.StatusInternalServerError, dtos.Error{
+			Msg: err.Error(),
+		})
+	}
+	return c.JSON(http.StatusOK, dtos.OK{
+		Msg: "your bundle has been removed from cart",
+	})
+}
+
+// bundlePage reads the optional page and limit query parameters.
+func bundlePage(c echo.Context) (limit int, page int, err error) {
+	limit, page = 20, 1
+	if s := c.QueryParam("limit"); s != "" {
+		if limit, err = strconv.Atoi(s); err != nil || limit < 1 || limit > 100 {
+			return 0, 0, fmt.Errorf("'limit' must be between 1 and 100")
+		}
+	}
+	if s := c.QueryParam("page"); s != "" {
+		if page, err = strconv.Atoi(s); err != nil || page < 1 {
+			return 0, 0, fmt.Errorf("'page' must be a positive integer")
+		}
+	}
+	return limit, page, nil
+}
+
+// bundleError maps the errors of bundles to their HTTP status.
+func bundleError(c echo.Context, err error) error {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, purchase.ErrBundleNotFound), errors.Is(err, purchase.ErrCartItemNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, purchase.ErrInvalidBundle):
+		status = http.StatusBadRequest
+	case errors.Is(err, purchase.ErrBundleArchived), isCartStockError(err):
+		status = http.StatusConflict
+	}
+	return c.JSON(status, dtos.Error{
+		Msg: err.Error(),
+	})
+}
+
+// GetGiftCard .
+// @Description get the balance of a gift card.
+// @Tags purchase
+// @Accept json
+// @Produce json
+// @Param code path string true "gift card code"
+// @Success 200 {object} dtos.GiftCard
+// @Router /purchase/gift-cards/{code} [GET]
+func (p *Controller) GetGiftCard(c echo.Context) error {
+	card, err := p.services.GetGiftCard(c.Request().Context(), c.Param("code"))
+	if err != nil {
+		return giftCardError(c, err)
+	}
+	return c.JSON(http.StatusOK, card)
+}
+
+// GetWallet .
+// @Description get the store credit of the login user with its ledger.
+// @Tags purchase
+// @Accept json
+// @Produce json
+// @Success 200 {object} dtos.Wallet
+// @Router /purchase/wallet [GET]
+func (p *Controller) GetWallet(c echo.Context) error {
+	userID, _, err := crypto.Authenticate(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, dtos.Error{
+			Msg: err.Error(),
+		})
+	}
+	wallet, err := p.services.GetWallet(c.Request().Context(), userID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, dtos.Error{
+			Msg: err.Error(),
+		})
+	}
+	return c.JSON(http.StatusOK, wallet)
+}
+
+// GetGiftCardsByAdmin .
+// @Description get the gift cards, newest first.
+// @Tags purchase
+// @Accept json
+// @Produce json
+// @Param status query string false "active or voided"
+// @Param page query int false "page" default(1)
+// @Param limit query int false "gift cards per page" default(20)
+// @Success 200 {object} []dtos.GiftCard
+// @Router /purchase/admin/gift-cards [GET]
+func (p *Controller) GetGiftCardsByAdmin(c echo.Context) error {
+	limit, page, err := bundlePage(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dtos.Error{
+			Msg: err.Error(),
+		})
+	}
+	status := c.QueryParam("status")
+	if status != "" && status != purchase.GiftCardActive && status != purchase.GiftCardVoided {
+		return c.JSON(http.StatusBadRequest, dtos.Error{
+			Msg: "'status' must be active or voided",
+		})
+	}
+	cards, err := p.services.GetGiftCards(c.Request().Context(), status, limit, page)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, dtos.Error{
+			Msg: err.Error(),
+		})
+	}
+	return c.JSON(http.StatusOK, cards)
+}
+
+// IssueGiftCard .
+// @Description issue a gift card. The code is generated, a card sold to a
+// @Description customer names the order that paid for it.
+// @Tags purchase
+// @Accept json
+// @Produce json
+// @Param card body dtos.IssueGiftCard true "gift card"
+// @Success 201 {object} dtos.GiftCard
+// @Router /purchase/admin/gift-cards [POST]
+func (p *Controller) IssueGiftCard(c echo.Context) error {
+	var req dtos.IssueGiftCard
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dtos.Error{
+			Msg: err.Error(),
+		})
+	}
+	if err := valid.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dtos.Error{
+			Msg: err.Error(),
+		})
+	}
+	_, actor, err := crypto.Authenticate(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, dtos.Error{
+			Msg: err.Error(),
+		})
+	}
+	card, err := p.services.IssueGiftCard(c.Request().Context(), actor, req)
+	if err != nil {
+		return giftCardError(c, err)
+	}
+	return c.JSON(http.StatusCreated, card)
+}
+
+// AuditGiftCard .
+// @Description get a gift card with its ledger, consistent is false when the
+// @Description ledger does not add up to the balance.
+// @Tags purchase
+// @Accept json
+// @Produce json
+// @Param code path string true "gift card code"
+// @Success 200 {object} dtos.GiftCardAudit
+// @Router /purchase/admin/gift-cards/{code} [GET]
+func (p *Controller) AuditGiftCard(c echo.Context) error {
+	audit, err := p.services.AuditGiftCard(c.Request().Context(), c.Param("code"))
+	if err != nil {
+		return giftCardError(c, err)
+	}
+	return c.JSON(http.StatusOK, audit)
+}
+
+// VoidGiftCard .
+// @Description void a gift card, its balance is written off.
+// @Tags purchase
+// @Accept json
+// @Produce json
+// @Param code path string true "gift card code"
+// @Param req body dtos.VoidGiftCard false "note"
+// @Success 200 {object} dtos.OK
+// @Router /purchase/admin/gift-cards/{code}/void [POST]
+func (p *Controller) VoidGiftCard(c echo.Context) error {
+	var req dtos.VoidGiftCard
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dtos.Error{
+			Msg: err.Error(),
+		})
+	}
+	_, actor, err := crypto.Authenticate(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, dtos.Error{
+			Msg: err.Error(),
+		})
+	}
+	if err := p.services.VoidGiftCard(c.Request().Context(), actor, c.Param("code"), req.Note); err != nil {
+		return giftCardError(c, err)
+	}
+	return c.JSON(http.StatusOK, dtos.OK{
+		Msg: "the gift card has been voided",
+	})
+}
+
+// GetWalletByAdmin .
+// @Description get the store credit of a user with its ledger.
+// @Tags purchase
+// @Accept json
+// @Produce json
+// @Param id path int true "user id"
+// @Success 200 {object} dtos.Wallet
+// @Router /purchase/admin/wallets/{id} [GET]
+func (p *Controller) GetWalletByAdmin(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dtos.Error{
+			Msg: "invalid 'id' path parameter",
+		})
+	}
+	wallet, err := p.services.GetWallet(c.Request().Context(), id)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, dtos.Error{
+			Msg: err.Error(),
+		})
+	}
+	return c.JSON(http.StatusOK, wallet)
+}
+
+// ImportRemittances .
+// @Description import a carrier COD remittance report in CSV. The header
+// @Description names the columns order_code, client_order_code, cod_amount
+// @Description and optionally remitted_at. The lines of a report already
+// @Description imported are skipped.
+// @Tags purchase
+// @Accept multipart/form-data
+// @Produce json
+// @Param reference formData string true "remittance report code"
+// @Param report formData file true "remittance report"
+// @Success 200 {object} dtos.RemittanceImport
+// @Router /purchase/admin/cod/remittances [POST]
+func (p *Controller) ImportRemittances(c echo.Context) error {
+	reference := strings.TrimSpace(c.FormValue("reference"))
+	if reference == "" {
+		return c.JSON(http.StatusBadRequest, dtos.Error{
+			Msg: "'reference' is required",
+		})
+	}
+	file, err := c.FormFile("report")
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dtos.Error{
+			Msg: err.Error(),
+		})
+	}
+	report, err := file.Open()
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dtos.Error{
+			Msg: err.Error(),
+		})
+	}
+	defer report.Close()
+	_, actor, err := crypto.Authenticate(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, dtos.Error{
+			Msg: err.Error(),
+		})
+	}
+	result, err := p.services.ImportRemittances(c.Request().Context(), actor, reference, report)
+	if err != nil {
+		return codError(c, err)
+	}
+	return c.JSON(http.StatusOK, result)
+}
+
+// SyncRemittances .
+// @Description import the COD remittances transferred by GHN in a period,
+// @Description both dates included.
+// @Tags purchase
+// @Accept json
+// @Produce json
+// @Param period body dtos.RemittanceSync true "period of the remittances"
+// @Success 200 {object} dtos.RemittanceImport
+// @Router /purchase/admin/cod/remittances/sync [POST]
+func (p *Controller) SyncRemittances(c echo.Context) error {
+	var req dtos.RemittanceSync
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dtos.Error{
+			Msg: err.Error(),
+		})
+	}
+	if err := valid.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, dtos.Error{
+			Msg: err.Error(),
+		})
+	}
+	from, _ := time.Parse(time.DateOnly, req.From)
+	to, _ := time.Parse(time.DateOnly, req.To)
+	if to.Before(from) {
+		return c.JSON(http.StatusBadRequest, dtos.Error{
+			Msg: "'to' must not be before 'from'",
+		})
+	}
+	_, actor, err := crypto.Authenticate(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, dtos.Error{
+			Msg: err.Error(),
+		})
+	}
+	result, err := p.services.SyncRemittances(c.Request().Context(), actor, from, to.Add(24*time.Hour))
+	if err != nil {
+		return codError(c, err)
+	}
+	return c.JSON(http.StatusOK, result)
+}
+
+// GetUnreconciledCOD .
+// @Description get the COD orders whose cash is not reconciled, oldest
+// @Description first: delivered orders nothing was remitted for, and the
+// @Description orders remitted short or over.
+// @Tags purchase
+// @Accept json
+// @Produce json
+// @Param older_than query int false "days since delivery" default(0)
+// @Param page query int false "page" default(1)
+// @Param limit query int false "orders per page" default(20)
+// @Success 200 {object} []dtos.CODOrder
+// @Router /purchase/admin/cod/unreconciled [GET]
+func (p *Controller) GetUnreconciledCOD(c echo.Context) error {
+	limit, page, err := bundlePage(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dtos.Error{
+			Msg: err.Error(),
+		})
+	}
+	days := 0
+	if s := c.QueryParam("older_than"); s != "" {
+		if days, err = strconv.Atoi(s); err != nil || days < 0 {
+			return c.JSON(http.StatusBadRequest, dtos.Error{
+				Msg: "'older_than' must be a number of days",
+			})
+		}
+	}
+	orders, err := p.services.GetUnreconciledCOD(c.Request().Context(),
+		time.Duration(days)*24*time.Hour, limit, page)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, dtos.Error{
+			Msg: err.Error(),
+		})
+	}
+	return c.JSON(http.StatusOK, orders)
+}
+
+// codError maps the errors of the COD reconciliation to their HTTP status.
+func codError(c echo.Context, err error) error {
+	status := http.StatusInternalServerError
+	if errors.Is(err, purchase.ErrInvalidRemittance) {
+		status = http.StatusBadRequest
+	}
+	return c.JSON(status, dtos.Error{
+		Msg: err.Error(),
+	})
+}
+
+// isCreditError reports whether err means the gift cards, the store
+// credit or the loyalty points of an order cannot pay for it, or that its
+// currency cannot be used.
+func isCreditError(err error) bool {
+	return errors.Is(err, purchase.ErrGiftCardNotFound) ||
+		errors.Is(err, purchase.ErrGiftCardUnusable) ||
+		errors.Is(err, purchase.ErrInsufficientCredit) ||
+		errors.Is(err, purchase.ErrStoreCreditGuest) ||
+		errors.Is(err, purchase.ErrStoreCreditOwner) ||
+		errors.Is(err, purchase.ErrInsufficientPoints) ||
+		errors.Is(err, purchase.ErrPointsGuest) ||
+		errors.Is(err, purchase.ErrPointsOwner) ||
+		errors.Is(err, purchase.ErrUnknownCurrency) ||
+		errors.Is(err, purchase.ErrCurrencyCredits)
+}
+
+// giftCardError maps the errors of gift cards to their HTTP status.
+func giftCardError(c echo.Context, err error) error {
+	status := http.StatusInternalServerError
+	switch {
+	case errors.Is(err, purchase.ErrGiftCardNotFound), errors.Is(err, purchase.ErrOrderNotFound):
+		status = http.StatusNotFound
+	case errors.Is(err, purchase.ErrInvalidGiftCard):
+		status = http.StatusBadRequest
+	case errors.Is(err, purchase.ErrGiftCardUnusable):
+		status = http.StatusConflict
+	}
+	return c.JSON(status, dtos.Error{
+		Msg: err.Error(),
+	})
+}
+
+// returnError maps the errors of the return workflow to their HTTP status.
+// CreateShipment .
+// @Description pack some units of an order in a shipment with its own delivery.
+// @Tags purchase
+// @Accept json
+// @Produce json
+// @Param code path string true "order code"
+// @Param shipment body dtos.ShipmentForm true "shipment request"
+// @Success 201 {object} dtos.ShipmentResponse
+// @Router /purchase/admin/orders/{code}/shipments [POST]
+func (p *Controller) CreateShipment(c echo.Context) error {
+	var form dtos.ShipmentForm
+	if err := c.Bind(&form); err != nil {
+		return c.JSON(http.StatusBadRequest, dtos.Error{
+			Msg: err.Error(),
+		})
+	}
+	if err := valid.Validate(&form); err != nil {
+		return c.JSON(http.StatusBadRequest, dtos.Error{
+			Msg: err.Error(),
+		})
+	}
+	_, actor, err := crypto.Authenticate(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, dtos.Error{
+			Msg: err.Error(),
+		})
+	}
+	shipmentID, err := p.services.CreateShipment(c.Request().Context(), actor, c.Param("code"), form)
+	if err != nil {
+		return shipmentError(c, err)
+	}
+	return c.JSON(http.StatusCreated, dtos.ShipmentResponse{
+		ShipmentID: shipmentID,
+	})
+}
+
+// UpdateShipment .
+// @Description set the carrier order code or the status of a shipment.
+// @Tags purchase
+// @Accept json
+// @Produce json
+// @Param id path int true "shipment id"
+// @Param shipment body dtos.ShipmentUpdate true "shipment update"
+// @Success 200 {object} dtos.OK
+// @Router /purchase/admin/shipments/{id} [PUT]
+func (p *Controller) UpdateShipment(c echo.Context) error {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, dtos.Error{
+			Msg: "invalid 'id' path parameter",
+		})
+	}
+	var update dtos.ShipmentUpdate
+	if err := c.Bind(&update); err != nil {
+		return c.JSON(http.StatusBadRequest, dtos.Error{
+			Msg: err.Error(),
+		})
+	}
+	if err := valid.Validate(&update); err != nil {
+		return c.JSON(http.StatusBadRequest, dtos.Error{
+			Msg: err.Error(),
+		})
+	}
+	_, actor, err := crypto.Authenticate(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, dtos.Error{
+			Msg: err.Error(),
+		})
+	}
+	if err := p.services.UpdateShipment(c.Request().Context(), actor, id, update); err != nil {
+		return shipmentError(c, err)
+	}
+	return c.JSON(http.StatusOK, dtos.OK{
+		Msg: "the shipment has been updated",
+	})
+}
+
+func shipmentError(c echo.Context, err error) error {
+	var (
+		transitionErr *purchase.InvalidTransitionError
+		status        = http.StatusInternalServerError
+	)
+	switch {
+	case errors.Is(err, purchase.ErrInvalidShipment):
+		status = http.StatusBadRequest
+	case errors.As(err, &transitionErr), errors.Is(err, purchase.ErrShipmentNotAllowed):
+		status = http.StatusConflict
+	case errors.Is(err, purchase.ErrOrderNotFound), errors.Is(err, purchase.ErrShipmentNotFound):
+		status = http.StatusNotFound
+	}
+	return c.JSON(status, dtos.Error{
+		Msg: err.Error(),
+	})
+}
+
+func returnError(c echo.Context, err error) error {
+	var (
+		quantityErr *purchase.ReturnQuantityError
+		stateErr    *purchase.ReturnStateError
+		status      = http.StatusInternalServerError
+	)
+	switch {
+	case errors.As(err, &quantityErr):
+		status = http.StatusBadRequest
+	case errors.As(err, &stateErr), errors.Is(err, purchase.ErrReturnNotAllowed):
+		status = http.StatusConflict
+	case errors.Is(err, purchase.ErrOrderNotFound), errors.Is(err, purchase.ErrReturnNotFound):
+		status = http.StatusNotFound
+	}
+	return c.JSON(status, dtos.Error{
+		Msg: err.Error(),
+	})
+}
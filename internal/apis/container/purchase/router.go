@@ -7,6 +7,8 @@ import (
 	"github.com/swclabs/swipex/app"
 	"github.com/swclabs/swipex/internal/apis/middleware"
 	"github.com/swclabs/swipex/internal/apis/server"
+	"github.com/swclabs/swipex/internal/config"
+	"github.com/swclabs/swipex/pkg/infra/cache"
 
 	"github.com/labstack/echo/v4"
 )
@@ -14,8 +16,8 @@ import (
 var _ = app.Router(NewRouter)
 
 // NewRouter returns a new Purchase router object
-func NewRouter(controllers IController) IRouter {
-	return &Router{controllers: controllers}
+func NewRouter(controllers IController, cache cache.ICache) IRouter {
+	return &Router{controllers: controllers, cache: cache}
 }
 
 // IRouter extends the IRouter interface
@@ -26,25 +28,69 @@ type IRouter interface {
 // Router is the router implementation for IPurchase
 type Router struct {
 	controllers IController
+	cache       cache.ICache
 }
 
 // Routers define route endpoint
 func (p *Router) Routers(e *echo.Echo) {
-	e.GET("/purchase/carts", p.controllers.GetCarts, middleware.Protected)
-	e.POST("/purchase/carts", p.controllers.AddToCarts, middleware.Protected)
-	e.DELETE("/purchase/carts/:id", p.controllers.DeleteCartItem, middleware.Protected)
+	idempotent := middleware.Idempotency(p.cache, config.IdempotencyWindow)
+
+	e.GET("/purchase/carts", p.controllers.GetCarts)
+	e.POST("/purchase/carts", p.controllers.AddToCarts)
+	e.DELETE("/purchase/carts/:id", p.controllers.DeleteCartItem)
+	e.PUT("/purchase/carts/:id", p.controllers.UpdateCartItem)
+	e.POST("/purchase/carts/merge", p.controllers.MergeCart, middleware.Protected)
+	e.POST("/purchase/carts/bundles", p.controllers.AddBundleToCart, middleware.Protected)
+	e.PUT("/purchase/carts/bundles/:id", p.controllers.UpdateCartBundle, middleware.Protected)
+	e.DELETE("/purchase/carts/bundles/:id", p.controllers.DeleteCartBundle, middleware.Protected)
+
+	e.GET("/purchase/bundles", p.controllers.GetBundles)
+	e.GET("/purchase/bundles/:id", p.controllers.GetBundle)
+
+	e.GET("/purchase/gift-cards/:code", p.controllers.GetGiftCard)
+	e.GET("/purchase/wallet", p.controllers.GetWallet, middleware.Protected)
 
 	e.GET("/purchase/orders", p.controllers.GetOrders, middleware.Protected)
 	e.GET("/purchase/orders/:code", p.controllers.GetOrdersByCode)
-	e.POST("/purchase/orders", p.controllers.CreateOrder, middleware.Protected)
-	e.PUT("/purchase/orders/status", p.controllers.UpdateOrderStatus)
+	e.GET("/purchase/orders/:code/invoice", p.controllers.GetInvoice)
+	e.POST("/purchase/orders", p.controllers.CreateOrder, middleware.Protected, idempotent)
+	e.PUT("/purchase/orders/status", p.controllers.UpdateOrderStatus, middleware.Admin)
+	e.POST("/purchase/orders/:code/cancel", p.controllers.CancelOrder, middleware.Protected)
+	e.POST("/purchase/guest/orders", p.controllers.CreateGuestOrder, idempotent)
+
+	e.GET("/purchase/returns", p.controllers.GetReturns, middleware.Protected)
+	e.POST("/purchase/returns", p.controllers.RequestReturn, middleware.Protected)
 
 	e.GET("/purchase/admin/orders", p.controllers.GetOrdersByAdmin)
-	e.POST("/purchase/admin/orders", p.controllers.CreateOrderForm)
+	e.GET("/purchase/admin/orders/export", p.controllers.ExportOrders, middleware.Admin)
+	e.GET("/purchase/admin/orders/exports/:id", p.controllers.GetOrderExport, middleware.Admin)
+	e.POST("/purchase/admin/orders", p.controllers.CreateOrderForm, idempotent)
+	e.POST("/purchase/admin/orders/:code/shipments", p.controllers.CreateShipment, middleware.Admin)
+	e.PUT("/purchase/admin/shipments/:id", p.controllers.UpdateShipment, middleware.Admin)
+	e.GET("/purchase/admin/carts/reminders", p.controllers.GetCartReminderStats, middleware.Admin)
+	e.GET("/purchase/admin/returns", p.controllers.GetReturnsByAdmin, middleware.Admin)
+	e.POST("/purchase/admin/returns/:id/approve", p.controllers.ApproveReturn, middleware.Admin)
+	e.POST("/purchase/admin/returns/:id/reject", p.controllers.RejectReturn, middleware.Admin)
+	e.POST("/purchase/admin/returns/:id/receive", p.controllers.ReceiveReturn, middleware.Admin)
+	e.POST("/purchase/admin/returns/:id/restock", p.controllers.RestockReturn, middleware.Admin)
+	e.POST("/purchase/admin/inventories/:id/receive", p.controllers.ReceiveStock, middleware.Admin)
+	e.GET("/purchase/admin/bundles", p.controllers.GetBundlesByAdmin, middleware.Admin)
+	e.POST("/purchase/admin/bundles", p.controllers.CreateBundle, middleware.Admin)
+	e.PUT("/purchase/admin/bundles/:id", p.controllers.UpdateBundle, middleware.Admin)
+	e.DELETE("/purchase/admin/bundles/:id", p.controllers.DeleteBundle, middleware.Admin)
+	e.GET("/purchase/admin/gift-cards", p.controllers.GetGiftCardsByAdmin, middleware.Admin)
+	e.POST("/purchase/admin/gift-cards", p.controllers.IssueGiftCard, middleware.Admin)
+	e.GET("/purchase/admin/gift-cards/:code", p.controllers.AuditGiftCard, middleware.Admin)
+	e.POST("/purchase/admin/gift-cards/:code/void", p.controllers.VoidGiftCard, middleware.Admin)
+	e.GET("/purchase/admin/wallets/:id", p.controllers.GetWalletByAdmin, middleware.Admin)
+	e.POST("/purchase/admin/cod/remittances", p.controllers.ImportRemittances, middleware.Admin)
+	e.POST("/purchase/admin/cod/remittances/sync", p.controllers.SyncRemittances, middleware.Admin)
+	e.GET("/purchase/admin/cod/unreconciled", p.controllers.GetUnreconciledCOD, middleware.Admin)
 
 	e.GET("/purchase/coupons", p.controllers.GetCoupon)
 	e.POST("/purchase/coupons", p.controllers.CreateCoupon)
 	e.DELETE("/purchase/coupons", p.controllers.DeleteCoupon)
+	e.POST("/purchase/coupons/validate", p.controllers.ValidateCoupon)
 
 	e.GET("/address", p.controllers.GetDeliveryAddress, middleware.Protected)
 	e.POST("/address", p.controllers.CreateDeliveryAddress)
@@ -0,0 +1,164 @@
+// Package middleware This file contains the middleware for idempotent requests.
+package middleware
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/swclabs/swipex/pkg/infra/cache"
+	"github.com/swclabs/swipex/pkg/lib/crypto"
+	"github.com/swclabs/swipex/pkg/lib/session"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	// HeaderIdempotencyKey is the request header carrying the client key
+	HeaderIdempotencyKey = "Idempotency-Key"
+
+	// HeaderIdempotentReplayed is set on responses replayed from the store
+	HeaderIdempotentReplayed = "Idempotent-Replayed"
+
+	keyIdempotency = "Idempotency:%s:%s:%s:%s"
+
+	// pendingWindow bounds how long a request that never finished blocks the
+	// retries of its key
+	pendingWindow = 2 * time.Minute
+)
+
+// idempotentResponse is the record kept in redis for an Idempotency-Key.
+// Done is false while the first request is still being handled.
+type idempotentResponse struct {
+	BodyHash    string `json:"body_hash"`
+	Done        bool   `json:"done"`
+	Status      int    `json:"status"`
+	ContentType string `json:"content_type"`
+	Body        []byte `json:"body"`
+}
+
+// responseRecorder copies everything written to the client into a buffer
+type responseRecorder struct {
+	http.ResponseWriter
+	body *bytes.Buffer
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
+
+// Idempotency middleware stores the first response of a request sent with an
+// Idempotency-Key header for window, and replays it to retries with the same
+// key and body from the same caller. A retry with the same key and another
+// body gets 422, and a retry arriving while the first request is still
+// running gets 409, for at most a few minutes. Requests without the header
+// are passed through.
+func Idempotency(store cache.ICache, window time.Duration) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			idempotencyKey := c.Request().Header.Get(HeaderIdempotencyKey)
+			if idempotencyKey == "" {
+				return next(c)
+			}
+
+			body, err := io.ReadAll(c.Request().Body)
+			if err != nil {
+				return c.JSON(http.StatusBadRequest, map[string]interface{}{
+					"msg": err.Error(),
+				})
+			}
+			c.Request().Body = io.NopCloser(bytes.NewReader(body))
+
+			var (
+				ctx = c.Request().Context()
+				// the key is released or completed even when the client is
+				// gone: once a key left pending expires, a retry would run again
+				storeCtx = context.WithoutCancel(ctx)
+				bodyHash = crypto.HashOf(string(body))
+				// keys are scoped to the caller so that clients cannot read each other's responses
+				key = crypto.HashOf(fmt.Sprintf(keyIdempotency,
+					c.Request().Method, c.Path(), caller(c), idempotencyKey))
+			)
+
+			pending, err := json.Marshal(idempotentResponse{BodyHash: bodyHash})
+			if err != nil {
+				return err
+			}
+			acquired, err := store.SetNX(ctx, key, string(pending), min(window, pendingWindow))
+			if err != nil {
+				return err
+			}
+			if !acquired {
+				stored, err := cache.Get[idempotentResponse](ctx, store, key)
+				if err != nil {
+					return err
+				}
+				return replay(c, stored, bodyHash)
+			}
+
+			// a handler that panics must not leave its key locked
+			defer func() {
+				if r := recover(); r != nil {
+					_ = store.Del(storeCtx, key)
+					panic(r)
+				}
+			}()
+
+			recorder := &responseRecorder{ResponseWriter: c.Response().Writer, body: &bytes.Buffer{}}
+			c.Response().Writer = recorder
+
+			if err := next(c); err != nil {
+				c.Error(err)
+			}
+
+			// server errors are not kept, the client may retry them
+			if c.Response().Status >= http.StatusInternalServerError {
+				return store.Del(storeCtx, key)
+			}
+			done, err := json.Marshal(idempotentResponse{
+				BodyHash:    bodyHash,
+				Done:        true,
+				Status:      c.Response().Status,
+				ContentType: c.Response().Header().Get(echo.HeaderContentType),
+				Body:        recorder.body.Bytes(),
+			})
+			if err != nil {
+				return err
+			}
+			return store.SetWithTTL(storeCtx, key, string(done), window)
+		}
+	}
+}
+
+// caller identifies who sent a request: the credentials of a signed in
+// user, else the session of a guest, else the address of the client.
+func caller(c echo.Context) string {
+	if auth := c.Request().Header.Get(echo.HeaderAuthorization); auth != "" {
+		return "auth:" + auth
+	}
+	if guestID := session.Guest(c); guestID != "" {
+		return "guest:" + guestID
+	}
+	return "ip:" + c.RealIP()
+}
+
+// replay answers a retried request with the response stored for its key.
+func replay(c echo.Context, stored *idempotentResponse, bodyHash string) error {
+	if stored.BodyHash != bodyHash {
+		return c.JSON(http.StatusUnprocessableEntity, map[string]interface{}{
+			"msg": "Idempotency-Key was already used with a different request body",
+		})
+	}
+	if !stored.Done {
+		return c.JSON(http.StatusConflict, map[string]interface{}{
+			"msg": "a request with this Idempotency-Key is still being processed",
+		})
+	}
+	c.Response().Header().Set(HeaderIdempotentReplayed, "true")
+	return c.Blob(stored.Status, stored.ContentType, stored.Body)
+}
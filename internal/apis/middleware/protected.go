@@ -3,6 +3,7 @@ package middleware
 
 import (
 	"net/http"
+	"strings"
 
 	"github.com/swclabs/swipex/pkg/lib/crypto"
 
@@ -30,3 +31,31 @@ func Protected(next echo.HandlerFunc) echo.HandlerFunc {
 		return next(c)
 	}
 }
+
+// Admin middleware, lets through the requests of a logged in admin only
+func Admin(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		authHeader := c.Request().Header.Get("Authorization")
+		if authHeader == "" {
+			return c.JSON(http.StatusUnauthorized, map[string]interface{}{
+				"msg":     "unauthorized",
+				"success": false,
+			})
+		}
+		role, err := crypto.ParseRole(authHeader)
+		if err != nil {
+			return c.JSON(http.StatusUnauthorized, map[string]interface{}{
+				"msg":     "unauthorized",
+				"success": false,
+			})
+		}
+		// the seeded admin account is stored as "Admin"
+		if !strings.EqualFold(role, "admin") {
+			return c.JSON(http.StatusForbidden, map[string]interface{}{
+				"msg":     "forbidden",
+				"success": false,
+			})
+		}
+		return next(c)
+	}
+}
@@ -4,6 +4,9 @@ package config
 import (
 	"os"
 	"strconv"
+	"time"
+
+	"github.com/shopspring/decimal"
 
 	_ "github.com/joho/godotenv/autoload" // load .env file automatically
 )
@@ -14,6 +17,84 @@ func init() {
 	if err != nil {
 		NumberOfWorker = Num // default
 	}
+	if window, err := time.ParseDuration(os.Getenv("IDEMPOTENCY_WINDOW")); err == nil {
+		IdempotencyWindow = window
+	}
+	if ttl, err := time.ParseDuration(os.Getenv("GUEST_CART_TTL")); err == nil {
+		GuestCartTTL = ttl
+	}
+	if ttl, err := time.ParseDuration(os.Getenv("TRACKING_TOKEN_TTL")); err == nil {
+		TrackingTokenTTL = ttl
+	}
+	if idle, err := time.ParseDuration(os.Getenv("CART_ABANDONED_AFTER")); err == nil {
+		CartAbandonedAfter = idle
+	}
+	if window, err := time.ParseDuration(os.Getenv("CART_REMINDER_WINDOW")); err == nil {
+		CartReminderWindow = window
+	}
+	if spec := os.Getenv("CART_REMINDER_SCHEDULE"); spec != "" {
+		CartReminderSchedule = spec
+	}
+	if ttl, err := time.ParseDuration(os.Getenv("ORDER_EXPORT_TTL")); err == nil {
+		OrderExportTTL = ttl
+	}
+	if timeout, err := time.ParseDuration(os.Getenv("ORDER_PAYMENT_TIMEOUT")); err == nil {
+		OrderPaymentTimeout = timeout
+	}
+	if spec := os.Getenv("ORDER_EXPIRY_SCHEDULE"); spec != "" {
+		OrderExpirySchedule = spec
+	}
+	if rate, err := decimal.NewFromString(os.Getenv("VAT_RATE")); err == nil {
+		VATRate = rate
+	}
+	if fee, err := decimal.NewFromString(os.Getenv("SHIPPING_FEE")); err == nil {
+		ShippingFee = fee
+	}
+	if threshold, err := decimal.NewFromString(os.Getenv("FREE_SHIPPING_THRESHOLD")); err == nil {
+		FreeShippingThreshold = threshold
+	}
+	if rate, err := decimal.NewFromString(os.Getenv("LOYALTY_EARN_RATE")); err == nil && rate.IsPositive() {
+		LoyaltyEarnRate = rate
+	}
+	if rate, err := decimal.NewFromString(os.Getenv("LOYALTY_BURN_RATE")); err == nil && rate.IsPositive() {
+		LoyaltyBurnRate = rate
+	}
+	if ttl, err := time.ParseDuration(os.Getenv("LOYALTY_POINTS_TTL")); err == nil {
+		LoyaltyPointsTTL = ttl
+	}
+	if spec := os.Getenv("LOYALTY_EXPIRY_SCHEDULE"); spec != "" {
+		LoyaltyExpirySchedule = spec
+	}
+	if points, err := strconv.ParseInt(os.Getenv("LOYALTY_SILVER_POINTS"), 10, 64); err == nil {
+		LoyaltySilverPoints = points
+	}
+	if points, err := strconv.ParseInt(os.Getenv("LOYALTY_GOLD_POINTS"), 10, 64); err == nil {
+		LoyaltyGoldPoints = points
+	}
+	if url := os.Getenv("DELIVERY_TRACKING_URL"); url != "" {
+		DeliveryTrackingURL = url
+	}
+	if currency := os.Getenv("BASE_CURRENCY"); currency != "" {
+		BaseCurrency = currency
+	}
+	if method := os.Getenv("COD_PAYMENT_METHOD"); method != "" {
+		CODPaymentMethod = method
+	}
+	if backend := os.Getenv("PAYMENT_BACKEND"); backend != "" {
+		PaymentBackend = backend
+	}
+	if url := os.Getenv("VNPAY_API_URL"); url != "" {
+		VNPayAPIURL = url
+	}
+	if url := os.Getenv("MOMO_ENDPOINT"); url != "" {
+		MoMoEndpoint = url
+	}
+	if url := os.Getenv("ZALOPAY_ENDPOINT"); url != "" {
+		ZaloPayEndpoint = url
+	}
+	if appID, err := strconv.ParseInt(os.Getenv("ZALOPAY_APP_ID"), 10, 64); err == nil {
+		ZaloPayAppID = appID
+	}
 }
 
 var (
@@ -75,12 +156,124 @@ var (
 	CloudinaryURL = os.Getenv("CLOUDINARY_URL")
 )
 
+// seller shown on invoices
+var (
+	SellerName    = os.Getenv("SELLER_NAME")
+	SellerAddress = os.Getenv("SELLER_ADDRESS")
+	SellerTaxCode = os.Getenv("SELLER_TAX_CODE")
+	SellerEmail   = os.Getenv("SELLER_EMAIL")
+	SellerPhone   = os.Getenv("SELLER_PHONE")
+)
+
 var (
 	DeliveryTokenAPI   = os.Getenv("DELIVERY_TOKEN_API")
 	DeliveryAddressAPI = os.Getenv("DELIVERY_ADDRESS_API")
+	// DeliveryRemittanceAPI lists the COD remittances of the carrier
+	DeliveryRemittanceAPI = os.Getenv("DELIVERY_REMITTANCE_API")
 )
 
+// DeliveryTrackingURL tracking page of a carrier order, %s is the order code
+var DeliveryTrackingURL = "https://donhang.ghn.vn/?order_code=%s"
+
 // NumberOfWorker Number of worker
 var NumberOfWorker = 10
 
+// IdempotencyWindow how long the response of a request sent with an
+// Idempotency-Key header is replayed to its retries
+var IdempotencyWindow = 24 * time.Hour
+
+// GuestCartTTL how long a guest cart and its cookie are kept since the last change
+var GuestCartTTL = 7 * 24 * time.Hour
+
+// TrackingTokenTTL how long the tracking token of a guest order is valid
+var TrackingTokenTTL = 30 * 24 * time.Hour
+
+// CartAbandonedAfter how long a cart stays untouched before it is considered abandoned
+var CartAbandonedAfter = 24 * time.Hour
+
+// CartReminderWindow a cart gets at most one reminder email in this window
+var CartReminderWindow = 7 * 24 * time.Hour
+
+// CartReminderSchedule cron spec of the abandoned cart job
+var CartReminderSchedule = "0 * * * *"
+
+// OrderExportTTL how long the status and link of an order export are kept
+var OrderExportTTL = 7 * 24 * time.Hour
+
+// OrderPaymentTimeout how long a new order left to pay online waits for its
+// payment before it expires and its stock is released
+var OrderPaymentTimeout = 24 * time.Hour
+
+// OrderExpirySchedule cron spec of the pending order expiry job
+var OrderExpirySchedule = "*/15 * * * *"
+
+// VATRate default VAT rate in percent of new categories
+var VATRate = decimal.NewFromInt(10)
+
+// ShippingFee flat shipping fee charged on every order
+var ShippingFee = decimal.NewFromInt(30000)
+
+// FreeShippingThreshold subtotal from which shipping is free, zero disables free shipping
+var FreeShippingThreshold = decimal.Zero
+
+// LoyaltyEarnRate amount spent on a delivered order for each loyalty point earned
+var LoyaltyEarnRate = decimal.NewFromInt(10000)
+
+// LoyaltyBurnRate amount a loyalty point pays for at checkout
+var LoyaltyBurnRate = decimal.NewFromInt(100)
+
+// LoyaltyPointsTTL how long earned loyalty points can be redeemed
+var LoyaltyPointsTTL = 365 * 24 * time.Hour
+
+// LoyaltyExpirySchedule cron spec of the loyalty points expiry job
+var LoyaltyExpirySchedule = "0 2 * * *"
+
+// LoyaltySilverPoints lifetime points from which a customer is silver
+var LoyaltySilverPoints int64 = 1000
+
+// LoyaltyGoldPoints lifetime points from which a customer is gold
+var LoyaltyGoldPoints int64 = 5000
+
+// BaseCurrency currency of the exchange rates, the shipping fee, coupons,
+// gift cards, store credit and loyalty points
+var BaseCurrency = "VND"
+
+// CODPaymentMethod payment method of the orders paid in cash on delivery,
+// their collection is reconciled against the carrier remittances
+var CODPaymentMethod = "cod"
+
 var PaymentService = os.Getenv("PAYMENT_SERVICE")
+
+// PaymentBackend implementation of the VNPay payment service: grpc calls
+// the service at PaymentService, native signs the payments in process
+var PaymentBackend = "grpc"
+
+// VNPay merchant account, used by the native payment backend and by the
+// merchant API that queries and refunds the payments
+var (
+	VNPayTmnCode    = os.Getenv("VNPAY_TMN_CODE")
+	VNPayHashSecret = os.Getenv("VNPAY_HASH_SECRET_KEY")
+	VNPayPaymentURL = os.Getenv("VNPAY_PAYMENT_URL")
+	VNPayReturnURL  = os.Getenv("VNPAY_RETURN_URL")
+	VNPayAPIURL     = "https://sandbox.vnpayment.vn/merchant_webapi/api/transaction"
+)
+
+// MoMo partner account of the MoMo payment provider
+var (
+	MoMoEndpoint    = "https://test-payment.momo.vn"
+	MoMoPartnerCode = os.Getenv("MOMO_PARTNER_CODE")
+	MoMoAccessKey   = os.Getenv("MOMO_ACCESS_KEY")
+	MoMoSecretKey   = os.Getenv("MOMO_SECRET_KEY")
+	MoMoRedirectURL = os.Getenv("MOMO_REDIRECT_URL")
+	MoMoIPNURL      = os.Getenv("MOMO_IPN_URL")
+)
+
+// ZaloPay merchant application of the ZaloPay payment provider
+var (
+	ZaloPayEndpoint          = "https://sb-openapi.zalopay.vn"
+	ZaloPayAppID       int64 = 0
+	ZaloPayKey1              = os.Getenv("ZALOPAY_KEY1")
+	ZaloPayKey2              = os.Getenv("ZALOPAY_KEY2")
+	ZaloPayRedirectURL       = os.Getenv("ZALOPAY_REDIRECT_URL")
+	ZaloPayCallbackURL       = os.Getenv("ZALOPAY_CALLBACK_URL")
+)
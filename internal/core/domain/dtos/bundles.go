@@ -0,0 +1,66 @@
+package dtos
+
+// BundleItem is a component of a bundle. The product fields are only set
+// in responses.
+type BundleItem struct {
+	InventoryID int64  `json:"inventory_id" validate:"required"`
+	Quantity    int64  `json:"quantity" validate:"required,gt=0"`
+	ItemCode    string `json:"item_code,omitempty"`
+	ProductName string `json:"product_name,omitempty"`
+	Color       string `json:"color,omitempty"`
+	Price       string `json:"price,omitempty"`
+}
+
+// CreateBundle request, also used to replace a bundle
+type CreateBundle struct {
+	Name         string       `json:"name" validate:"required"`
+	Description  string       `json:"description"`
+	Image        string       `json:"image"`
+	Price        string       `json:"price" validate:"required,numeric"`
+	CurrencyCode string       `json:"currency_code"`
+	Status       string       `json:"status" validate:"omitempty,oneof=active archived"`
+	Items        []BundleItem `json:"items" validate:"required,min=2,dive"`
+}
+
+// Bundle response. ListPrice is what the components cost on their own,
+// Preorder is set when some of them are sold before their stock arrives.
+type Bundle struct {
+	ID           int64        `json:"id"`
+	Name         string       `json:"name"`
+	Description  string       `json:"description"`
+	Image        string       `json:"image"`
+	Price        string       `json:"price"`
+	ListPrice    string       `json:"list_price"`
+	CurrencyCode string       `json:"currency_code"`
+	Status       string       `json:"status"`
+	OutOfStock   bool         `json:"out_of_stock"`
+	Preorder     bool         `json:"preorder"`
+	Items        []BundleItem `json:"items"`
+	CreatedAt    string       `json:"created_at"`
+}
+
+// CartBundleDTO request to put a bundle in the cart
+type CartBundleDTO struct {
+	BundleID int64 `json:"bundle_id" validate:"required"`
+	Quantity int64 `json:"quantity" validate:"required,gt=0"`
+}
+
+// CartBundle is a bundle in a cart, checked out as one line
+type CartBundle struct {
+	BundleID     int64        `json:"bundle_id"`
+	Name         string       `json:"name"`
+	Image        string       `json:"image"`
+	Price        string       `json:"price"`
+	CurrencyCode string       `json:"currency_code"`
+	Quantity     int64        `json:"quantity"`
+	OutOfStock   bool         `json:"out_of_stock"`
+	Preorder     bool         `json:"preorder"`
+	Archived     bool         `json:"archived"`
+	Items        []BundleItem `json:"items"`
+}
+
+// OrderFormBundle is a bundle bought in an order
+type OrderFormBundle struct {
+	BundleID int64 `json:"bundle_id" validate:"required"`
+	Quantity int64 `json:"quantity" validate:"required,gt=0"`
+}
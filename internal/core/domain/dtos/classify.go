@@ -1,5 +1,7 @@
 package dtos
 
+import "github.com/shopspring/decimal"
+
 // Supplier request, response
 type Supplier struct {
 	Name  string `json:"name" validate:"required"`
@@ -11,4 +13,6 @@ type UpdateCategories struct {
 	ID          int64  `json:"id" db:"id"`
 	Name        string `json:"name" db:"name" validate:"required"`
 	Description string `json:"description" db:"description" validate:"required"`
+	// VATRate in percent, unchanged when omitted
+	VATRate decimal.NullDecimal `json:"vat_rate" swaggertype:"string"`
 }
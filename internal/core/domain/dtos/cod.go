@@ -0,0 +1,42 @@
+package dtos
+
+// RemittanceSync request, the period of the carrier remittances to import
+type RemittanceSync struct {
+	From string `json:"from" validate:"required,datetime=2006-01-02"`
+	To   string `json:"to" validate:"required,datetime=2006-01-02"`
+}
+
+// RemittanceLine is the outcome of a line of a remittance report: matched,
+// short, over, unmatched, or duplicate when the line was already imported
+type RemittanceLine struct {
+	Reference        string `json:"reference"`
+	CarrierOrderCode string `json:"carrier_order_code"`
+	OrderCode        string `json:"order_code"`
+	Amount           string `json:"amount"`
+	Result           string `json:"result"`
+}
+
+// RemittanceImport response, Amount is the sum of the lines imported
+type RemittanceImport struct {
+	Lines      int              `json:"lines"`
+	Matched    int              `json:"matched"`
+	Short      int              `json:"short"`
+	Over       int              `json:"over"`
+	Unmatched  int              `json:"unmatched"`
+	Duplicates int              `json:"duplicates"`
+	Amount     string           `json:"amount"`
+	Results    []RemittanceLine `json:"results"`
+}
+
+// CODOrder response, a COD order whose cash is not reconciled yet.
+// Outstanding is negative when more than expected was remitted.
+type CODOrder struct {
+	OrderCode      string `json:"order_code"`
+	OrderStatus    string `json:"order_status"`
+	ExpectedAmount string `json:"expected_amount"`
+	RemittedAmount string `json:"remitted_amount"`
+	Outstanding    string `json:"outstanding"`
+	Status         string `json:"status"`
+	Since          string `json:"since"`
+	AgeDays        int    `json:"age_days"`
+}
@@ -1,18 +1,42 @@
 package dtos
 
 type CreateCoupon struct {
-	Status      string `json:"status" validate:"required"`
-	MaxUse      int    `json:"max_use" validate:"required"`
-	Discount    int    `json:"discount" validate:"required"`
-	Description string `json:"description" validate:"required"`
-	MaxDay      int    `json:"max_day" validate:"required"`
+	Status        string  `json:"status" validate:"required"`
+	MaxUse        int     `json:"max_use" validate:"required"`
+	Discount      int     `json:"discount" validate:"required"`
+	Description   string  `json:"description" validate:"required"`
+	MaxDay        int     `json:"max_day" validate:"required"`
+	DiscountType  string  `json:"discount_type" validate:"omitempty,oneof=percent fixed"`
+	MinOrderValue string  `json:"min_order_value" validate:"omitempty,numeric"`
+	MaxDiscount   string  `json:"max_discount" validate:"omitempty,numeric"`
+	CategoryIDs   []int64 `json:"category_ids"`
+	InventoryIDs  []int64 `json:"inventory_ids"`
 }
 
 type Coupon struct {
-	ID          int64  `json:"id"`
-	Code        string `json:"code"`
-	Discount    int    `json:"discount"`
-	ExpiredAt   string `json:"expired_at"`
-	Description string `json:"description"`
-	Status      string `json:"status"`
+	ID            int64   `json:"id"`
+	Code          string  `json:"code"`
+	Discount      int     `json:"discount"`
+	ExpiredAt     string  `json:"expired_at"`
+	Description   string  `json:"description"`
+	Status        string  `json:"status"`
+	DiscountType  string  `json:"discount_type"`
+	MinOrderValue string  `json:"min_order_value"`
+	MaxDiscount   string  `json:"max_discount"`
+	CategoryIDs   []int64 `json:"category_ids"`
+	InventoryIDs  []int64 `json:"inventory_ids"`
+}
+
+// CouponValidate request
+type CouponValidate struct {
+	CouponCode string             `json:"coupon_code" validate:"required"`
+	Product    []OrderFormProduct `json:"product" validate:"required,min=1,dive"`
+}
+
+// CouponPreview response
+type CouponPreview struct {
+	Code     string `json:"code"`
+	Subtotal string `json:"subtotal"`
+	Discount string `json:"discount"`
+	Total    string `json:"total"`
 }
@@ -0,0 +1,61 @@
+package dtos
+
+// IssueGiftCard request. A card sold to a customer points to the order
+// that paid for it.
+type IssueGiftCard struct {
+	Amount       string `json:"amount" validate:"required,numeric"`
+	CurrencyCode string `json:"currency_code"`
+	ExpiresAt    string `json:"expires_at" validate:"omitempty,datetime=2006-01-02"`
+	OrderCode    string `json:"order_code"`
+	Note         string `json:"note"`
+}
+
+// VoidGiftCard request
+type VoidGiftCard struct {
+	Note string `json:"note"`
+}
+
+// GiftCard response
+type GiftCard struct {
+	Code           string `json:"code"`
+	InitialBalance string `json:"initial_balance"`
+	Balance        string `json:"balance"`
+	CurrencyCode   string `json:"currency_code"`
+	Status         string `json:"status"`
+	ExpiresAt      string `json:"expires_at,omitempty"`
+	Note           string `json:"note,omitempty"`
+	VoidedAt       string `json:"voided_at,omitempty"`
+	CreatedAt      string `json:"created_at"`
+}
+
+// CreditEntry is a movement of a gift card or wallet balance, Amount is
+// negative for debits and Balance is the balance after the movement
+type CreditEntry struct {
+	ID        int64  `json:"id"`
+	OrderID   *int64 `json:"order_id,omitempty"`
+	Amount    string `json:"amount"`
+	Balance   string `json:"balance"`
+	Kind      string `json:"kind"`
+	Actor     string `json:"actor"`
+	Note      string `json:"note"`
+	CreatedAt string `json:"created_at"`
+}
+
+// GiftCardAudit response. LedgerBalance is the sum of the entries, it
+// matches the balance of the card when Consistent is set.
+type GiftCardAudit struct {
+	GiftCard
+	Entries       []CreditEntry `json:"entries"`
+	LedgerBalance string        `json:"ledger_balance"`
+	Consistent    bool          `json:"consistent"`
+}
+
+// Wallet response, the store credit of a user with its ledger
+type Wallet struct {
+	UserID        int64         `json:"user_id"`
+	Balance       string        `json:"balance"`
+	CurrencyCode  string        `json:"currency_code"`
+	Entries       []CreditEntry `json:"entries"`
+	LedgerBalance string        `json:"ledger_balance"`
+	Consistent    bool          `json:"consistent"`
+}
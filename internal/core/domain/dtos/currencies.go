@@ -0,0 +1,23 @@
+package dtos
+
+// ExchangeRate response, Rate is the value of one unit of the currency in
+// the base currency
+type ExchangeRate struct {
+	CurrencyCode string `json:"currency_code"`
+	Rate         string `json:"rate"`
+	Source       string `json:"source"`
+	UpdatedBy    string `json:"updated_by"`
+	UpdatedAt    string `json:"updated_at"`
+}
+
+// ExchangeRateUpdate request
+type ExchangeRateUpdate struct {
+	CurrencyCode string `json:"currency_code" validate:"required,len=3,alpha"`
+	Rate         string `json:"rate" validate:"required,numeric"`
+}
+
+// ExchangeRateImport response
+type ExchangeRateImport struct {
+	Imported int            `json:"imported"`
+	Rates    []ExchangeRate `json:"rates"`
+}
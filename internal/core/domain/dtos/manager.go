@@ -47,6 +47,11 @@ type UserUpdate struct {
 	Image       string `json:"image"`
 }
 
+// Notifications schema, the emails a user wants to receive
+type Notifications struct {
+	CartReminders bool `json:"cart_reminders"`
+}
+
 // OAuth2SaveUser schema
 type OAuth2SaveUser struct {
 	Email       string `json:"email" validate:"required"`
@@ -0,0 +1,106 @@
+package dtos
+
+import "encoding/json"
+
+// PaymentRequest request to pay an order online, Provider is the payment
+// method of the order when empty. The amount is what is left to pay on the
+// order.
+type PaymentRequest struct {
+	Provider  string `json:"provider"`
+	OrderType string `json:"order_type"`
+	OrderID   string `json:"order_id" validate:"required"`
+	OrderDesc string `json:"order_desc"`
+	BankCode  string `json:"bank_code"`
+	Language  string `json:"language"`
+	IPAddress string `json:"-"`
+}
+
+// PaymentResponse response, where the customer pays the order
+type PaymentResponse struct {
+	PaymentURL string `json:"payment_url"`
+	Message    string `json:"message"`
+	Success    bool   `json:"success"`
+	Provider   string `json:"provider"`
+}
+
+// PaymentStatus response, the status of the payment of an order at its
+// provider
+type PaymentStatus struct {
+	OrderCode     string `json:"order_code"`
+	Provider      string `json:"provider"`
+	Status        string `json:"status"`
+	TransactionNo string `json:"transaction_no"`
+	Amount        string `json:"amount"`
+	ResponseCode  string `json:"response_code"`
+	Message       string `json:"message"`
+}
+
+// PaymentIPN response, the answer VNPay expects from the IPN URL
+type PaymentIPN struct {
+	RspCode string `json:"RspCode"`
+	Message string `json:"Message"`
+}
+
+// PaymentResult response of a payment callback, Paid tells whether the order
+// is paid, RefundDue whether the payment reached an order given up and is to
+// be refunded, and RspCode is the IPN code of the callback
+type PaymentResult struct {
+	OrderCode string `json:"order_code"`
+	Paid      bool   `json:"paid"`
+	RefundDue bool   `json:"refund_due"`
+	RspCode   string `json:"rsp_code"`
+	Message   string `json:"message"`
+}
+
+// PaymentTransaction response, a step of the payment of an order
+type PaymentTransaction struct {
+	ID            int64           `json:"id"`
+	OrderCode     string          `json:"order_code"`
+	Provider      string          `json:"provider"`
+	Event         string          `json:"event"`
+	Status        string          `json:"status"`
+	Amount        string          `json:"amount"`
+	Currency      string          `json:"currency"`
+	TransactionNo string          `json:"transaction_no"`
+	BankCode      string          `json:"bank_code"`
+	ResponseCode  string          `json:"response_code"`
+	Message       string          `json:"message"`
+	Reference     string          `json:"reference"`
+	Payload       json.RawMessage `json:"payload" swaggertype:"object"`
+	CreatedAt     string          `json:"created_at"`
+}
+
+// PaymentRefundRequest request to pay back an order through the provider of
+// its payment, Amount in VND is the part of the payment not refunded yet
+// when empty
+type PaymentRefundRequest struct {
+	OrderCode string `json:"order_code" validate:"required"`
+	Amount    string `json:"amount"`
+	Reason    string `json:"reason"`
+}
+
+// PaymentRefund response, a refund of an order and its status at the
+// provider
+type PaymentRefund struct {
+	ID            int64  `json:"id"`
+	OrderCode     string `json:"order_code"`
+	Provider      string `json:"provider"`
+	Amount        string `json:"amount"`
+	Currency      string `json:"currency"`
+	Full          bool   `json:"full"`
+	Status        string `json:"status"`
+	TransactionNo string `json:"transaction_no"`
+	RefundNo      string `json:"refund_no"`
+	ResponseCode  string `json:"response_code"`
+	Message       string `json:"message"`
+	Attempts      int32  `json:"attempts"`
+	Reason        string `json:"reason"`
+	Actor         string `json:"actor"`
+	CreatedAt     string `json:"created_at"`
+	UpdatedAt     string `json:"updated_at"`
+}
+
+// PaymentRefundJob payload of the task paying back a refund
+type PaymentRefundJob struct {
+	ID int64 `json:"id"`
+}
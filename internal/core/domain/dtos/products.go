@@ -33,6 +33,9 @@ type ProductResponse struct {
 	Created     string       `json:"created"`
 	Category    string       `json:"category"`
 	Specs       ProductSpecs `json:"specs"`
+
+	// set when the price is converted to another currency
+	Currency string `json:"currency,omitempty"`
 }
 
 // UpdateProductInfo request, response
@@ -109,14 +112,19 @@ type Inventory struct {
 	Status       string   `json:"status"`
 	Image        []string `json:"image"`
 	Specs        Specs    `json:"specs"`
+
+	// pre-order items only
+	ExpectedShipDate string `json:"expected_ship_date,omitempty"`
+	DepositPercent   string `json:"deposit_percent,omitempty"`
 }
 
 // StockHeader response, request
 type StockHeader struct {
-	All     int `json:"all"`
-	Active  int `json:"active"`
-	Draft   int `json:"draft"`
-	Archive int `json:"archive"`
+	All      int `json:"all"`
+	Active   int `json:"active"`
+	Draft    int `json:"draft"`
+	Archive  int `json:"archive"`
+	Preorder int `json:"preorder"`
 }
 
 // InventoryItems response, request
@@ -134,7 +142,11 @@ type InvUpdate struct {
 	Price        string `json:"price" validate:"omitempty,number"`
 	Available    string `json:"available" validate:"omitempty,number"`
 	CurrencyCode string `json:"currency_code"`
-	Status       string `json:"status"`
+	Status       string `json:"status" validate:"omitempty,oneof=active draft archived preorder"`
+
+	// ExpectedShipDate (YYYY-MM-DD) and DepositPercent describe a pre-order item
+	ExpectedShipDate string `json:"expected_ship_date" validate:"omitempty,datetime=2006-01-02"`
+	DepositPercent   string `json:"deposit_percent" validate:"omitempty,numeric"`
 }
 
 type ProductSpecs struct {
@@ -154,6 +166,7 @@ type ProductDTO struct {
 	Category string       `json:"category"`
 	Rating   float64      `json:"rating"`
 	Specs    ProductSpecs `json:"specs"`
+	Currency string       `json:"currency,omitempty"`
 }
 
 //type Item struct {
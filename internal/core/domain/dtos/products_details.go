@@ -27,6 +27,9 @@ type ProductDetail struct {
 
 	Price string `json:"price"`
 
+	// Currency of the prices when they are converted to another currency
+	Currency string `json:"currency,omitempty"`
+
 	Rating float64 `json:"rating"`
 
 	// Image of product
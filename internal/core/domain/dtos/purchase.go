@@ -1,6 +1,8 @@
 package dtos
 
 import (
+	"time"
+
 	"github.com/swclabs/swipex/internal/core/domain/model"
 )
 
@@ -14,22 +16,45 @@ type Cart struct {
 	Color          string `json:"color"`
 	Code           string `json:"code"`
 	InventoryPrice string `json:"price"`
+	AddedPrice     string `json:"added_price"`
 	CurrencyCode   string `json:"currency_code"`
 	InventoryImage string `json:"image"`
 	InventorySpecs Specs  `json:"specs"`
 	CategoryName   string `json:"category"`
+	Available      int64  `json:"available"`
+	OutOfStock     bool   `json:"out_of_stock"`
+	Preorder       bool   `json:"preorder"`
+	Archived       bool   `json:"archived"`
+	Repriced       bool   `json:"repriced"`
 }
 
 // Carts schema
 type Carts struct {
-	UserID   int64  `json:"user_id"`
-	Products []Cart `json:"products"`
+	UserID      int64        `json:"user_id"`
+	Products    []Cart       `json:"products"`
+	Bundles     []CartBundle `json:"bundles"`
+	Subtotal    string       `json:"subtotal"`
+	Discount    string       `json:"discount"`
+	Total       string       `json:"total"`
+	CouponCode  string       `json:"coupon_code,omitempty"`
+	CouponError string       `json:"coupon_error,omitempty"`
+}
+
+// CartQuantity request to change the quantity of a cart line
+type CartQuantity struct {
+	Quantity int64 `json:"quantity" validate:"required,gt=0"`
+}
+
+// CartMerge request to fold a guest cart into the user cart, on top of the
+// guest cart kept under the session cookie
+type CartMerge struct {
+	Items []CartDTO `json:"items" validate:"dive"`
 }
 
 // CartDTO request, response
 type CartDTO struct {
 	InventoryID int64 `json:"inventory_id" validate:"required"`
-	Quantity    int64 `json:"quantity" validate:"required"`
+	Quantity    int64 `json:"quantity" validate:"required,gt=0"`
 }
 
 type CartInsertDTO struct {
@@ -51,14 +76,26 @@ type ProductOrderSchema struct {
 }
 
 type OrderInfo struct {
-	UUID          string            `json:"uuid"`
-	CreatedAt     string            `json:"time"`
-	PaymentMethod string            `json:"payment_method"`
-	User          OrderFormCustomer `json:"user"`
-	Delivery      OrderFormDelivery `json:"delivery"`
-	Address       OrderFormAddress  `json:"address"`
-	TotalAmount   string            `json:"total_amount"`
-	Items         []model.Order     `json:"items"`
+	UUID           string             `json:"uuid"`
+	CreatedAt      string             `json:"time"`
+	PaymentMethod  string             `json:"payment_method"`
+	User           OrderFormCustomer  `json:"user"`
+	Delivery       OrderFormDelivery  `json:"delivery"`
+	Address        OrderFormAddress   `json:"address"`
+	Subtotal       string             `json:"subtotal"`
+	Discount       string             `json:"discount_amount"`
+	TaxAmount      string             `json:"tax_amount"`
+	ShippingFee    string             `json:"shipping_fee"`
+	TotalAmount    string             `json:"total_amount"`
+	DepositAmount  string             `json:"deposit_amount"`
+	CreditAmount   string             `json:"credit_amount"`
+	PointsRedeemed int64              `json:"points_redeemed"`
+	PointsAmount   string             `json:"points_amount"`
+	Currency       string             `json:"currency"`
+	ExchangeRate   string             `json:"exchange_rate"`
+	Items          []model.Order      `json:"items"`
+	Shipments      []Shipment         `json:"shipments"`
+	Timeline       []OrderStatusEvent `json:"timeline"`
 }
 
 type Order struct {
@@ -67,7 +104,12 @@ type Order struct {
 	Customer      OrderFormCustomer  `json:"customer" validate:"required"`
 	Delivery      OrderFormDelivery  `json:"delivery" validate:"required"`
 	Address       OrderFormAddress   `json:"address" validate:"required"`
-	Product       []OrderFormProduct `json:"product" validate:"required"`
+	Product       []OrderFormProduct `json:"product" validate:"required_without=Bundles,dive"`
+	Bundles       []OrderFormBundle  `json:"bundles" validate:"required_without=Product,dive"`
+	GiftCards     []string           `json:"gift_cards" validate:"omitempty,dive,required"`
+	StoreCredit   string             `json:"store_credit" validate:"omitempty,numeric"`
+	Points        int64              `json:"points" validate:"gte=0"`
+	Currency      string             `json:"currency" validate:"omitempty,len=3,alpha"`
 }
 
 type OrderFormAddress struct {
@@ -79,7 +121,7 @@ type OrderFormAddress struct {
 
 type OrderFormProduct struct {
 	Code     string `json:"code" validate:"required"`
-	Quantity int64  `json:"quantity" validate:"required"`
+	Quantity int64  `json:"quantity" validate:"required,gt=0"`
 }
 
 type OrderFormDelivery struct {
@@ -101,14 +143,129 @@ type OrderForm struct {
 	Customer      OrderFormCustomer  `json:"customer" validate:"required"`
 	Delivery      OrderFormDelivery  `json:"delivery" validate:"required"`
 	Address       OrderFormAddress   `json:"address" validate:"required"`
-	Product       []OrderFormProduct `json:"product" validate:"required"`
+	Product       []OrderFormProduct `json:"product" validate:"required_without=Bundles,dive"`
+	Bundles       []OrderFormBundle  `json:"bundles" validate:"required_without=Product,dive"`
+	GiftCards     []string           `json:"gift_cards" validate:"omitempty,dive,required"`
+	StoreCredit   string             `json:"store_credit" validate:"omitempty,numeric"`
+	Points        int64              `json:"points" validate:"gte=0"`
+	Currency      string             `json:"currency" validate:"omitempty,len=3,alpha"`
 }
 
 type OrderStatus struct {
 	OrderCode string `json:"order_code" validate:"required"`
 	Status    string `json:"status" validate:"required"`
+	Reason    string `json:"reason"`
+}
+
+// CancelOrder request
+type CancelOrder struct {
+	Reason string `json:"reason"`
+}
+
+// OrderStatusEvent is one step of the order status timeline
+type OrderStatusEvent struct {
+	From   string `json:"from"`
+	To     string `json:"to"`
+	Actor  string `json:"actor"`
+	Reason string `json:"reason"`
+	Time   string `json:"time"`
 }
 
 type OrderResponse struct {
-	OrderCode string `json:"order_code"`
+	OrderCode     string `json:"order_code"`
+	TrackingToken string `json:"tracking_token,omitempty"`
+}
+
+// CartReminder payload of the task sending an abandoned cart email
+type CartReminder struct {
+	UserID int64 `json:"user_id"`
+}
+
+// CartReminderStats summary of the abandoned cart reminders sent
+type CartReminderStats struct {
+	Total      int64      `json:"total"`
+	LastDay    int64      `json:"last_24h"`
+	Items      int64      `json:"items"`
+	LastSentAt *time.Time `json:"last_sent_at"`
+}
+
+// InvoiceFile is an invoice rendered as HTML or PDF
+type InvoiceFile struct {
+	Number      string
+	FileName    string
+	ContentType string
+	Content     []byte
+	// Email of the customer, the only one allowed to download it
+	Email string
+}
+
+// OrderSearch filters the orders listed to admins. Dates are RFC 3339 times
+// or days (2006-01-02) in Vietnam time, to is inclusive for a day.
+type OrderSearch struct {
+	Status        string `query:"status"`
+	PaymentMethod string `query:"payment_method"`
+	From          string `query:"from"`
+	To            string `query:"to"`
+	Email         string `query:"email"`
+	Phone         string `query:"phone"`
+	MinTotal      string `query:"min_total" validate:"omitempty,numeric"`
+	MaxTotal      string `query:"max_total" validate:"omitempty,numeric"`
+	CouponCode    string `query:"coupon"`
+	Sort          string `query:"sort" validate:"omitempty,oneof=time -time total -total"`
+	Cursor        string `query:"cursor"`
+	Limit         int    `query:"limit" validate:"omitempty,gt=0,lte=100"`
+}
+
+// OrderSummary is an order as listed to admins
+type OrderSummary struct {
+	Code          string            `json:"code"`
+	Time          string            `json:"time"`
+	Status        string            `json:"status"`
+	PaymentMethod string            `json:"payment_method"`
+	Customer      OrderFormCustomer `json:"customer"`
+	CouponCode    string            `json:"coupon_code"`
+	Items         int64             `json:"items"`
+	Subtotal      string            `json:"subtotal"`
+	Discount      string            `json:"discount_amount"`
+	TaxAmount     string            `json:"tax_amount"`
+	ShippingFee   string            `json:"shipping_fee"`
+	TotalAmount   string            `json:"total_amount"`
+}
+
+// OrderPage is a page of orders, NextCursor is empty on the last page
+type OrderPage struct {
+	Orders     []OrderSummary `json:"orders"`
+	NextCursor string         `json:"next_cursor"`
+}
+
+// OrderExport filters the orders exported to a spreadsheet, dates are read
+// as in OrderSearch
+type OrderExport struct {
+	Format        string `json:"format" query:"format" validate:"omitempty,oneof=csv xlsx"`
+	Status        string `json:"status,omitempty" query:"status"`
+	PaymentMethod string `json:"payment_method,omitempty" query:"payment_method"`
+	From          string `json:"from,omitempty" query:"from"`
+	To            string `json:"to,omitempty" query:"to"`
+	Email         string `json:"email,omitempty" query:"email"`
+	Phone         string `json:"phone,omitempty" query:"phone"`
+	// Async runs the export on the worker instead of streaming the file
+	Async bool `json:"-" query:"async"`
+}
+
+// OrderExportJob is an export run by the worker, URL is set once it is done
+type OrderExportJob struct {
+	ID         string      `json:"id"`
+	Status     string      `json:"status"`
+	Filter     OrderExport `json:"filter"`
+	Rows       int64       `json:"rows"`
+	URL        string      `json:"url,omitempty"`
+	Error      string      `json:"error,omitempty"`
+	CreatedAt  time.Time   `json:"created_at"`
+	FinishedAt *time.Time  `json:"finished_at,omitempty"`
+}
+
+// StockReceipt records units of an inventory item received in the warehouse
+type StockReceipt struct {
+	InventoryID int64 `json:"inventory_id"`
+	Quantity    int64 `json:"quantity" validate:"required,gt=0"`
 }
@@ -0,0 +1,49 @@
+package dtos
+
+// ReturnItem is one order line the customer wants to send back
+type ReturnItem struct {
+	ItemID   int64 `json:"item_id" validate:"required"`
+	Quantity int64 `json:"quantity" validate:"required,gt=0"`
+}
+
+// ReturnRequest request
+type ReturnRequest struct {
+	OrderCode string       `json:"order_code" validate:"required"`
+	Reason    string       `json:"reason" validate:"required"`
+	Items     []ReturnItem `json:"items" validate:"required,min=1,dive"`
+}
+
+// ReturnDecision request. With StoreCredit the refund is paid at once
+// into the wallet of the customer instead of the original payment.
+type ReturnDecision struct {
+	Note        string `json:"note"`
+	SkipRestock bool   `json:"skip_restock"`
+	StoreCredit bool   `json:"store_credit"`
+}
+
+// ReturnItemInfo is one line of a return request
+type ReturnItemInfo struct {
+	ItemID      int64 `json:"item_id"`
+	InventoryID int64 `json:"inventory_id"`
+	Quantity    int64 `json:"quantity"`
+	Restocked   bool  `json:"restocked"`
+}
+
+// Return response
+type Return struct {
+	ID           int64            `json:"id"`
+	OrderCode    string           `json:"order_code"`
+	Status       string           `json:"status"`
+	Reason       string           `json:"reason"`
+	Note         string           `json:"note"`
+	Photos       []string         `json:"photos"`
+	Items        []ReturnItemInfo `json:"items"`
+	RefundAmount string           `json:"refund_amount"`
+	ReceivedAt   string           `json:"received_at"`
+	CreatedAt    string           `json:"created_at"`
+}
+
+// ReturnResponse response
+type ReturnResponse struct {
+	ReturnID int64 `json:"return_id"`
+}
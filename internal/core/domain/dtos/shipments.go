@@ -0,0 +1,49 @@
+package dtos
+
+// ShipmentForm request, the units of the lines of an order sent together.
+// The delivery method defaults to the one of the order.
+type ShipmentForm struct {
+	Items            []ShipmentFormItem `json:"items" validate:"required,min=1,dive"`
+	Method           string             `json:"method"`
+	Note             string             `json:"note"`
+	CarrierOrderCode string             `json:"carrier_order_code"`
+}
+
+// ShipmentFormItem request, ItemID is the ID of the order line
+type ShipmentFormItem struct {
+	ItemID   int64 `json:"item_id" validate:"required"`
+	Quantity int64 `json:"quantity" validate:"required,gt=0"`
+}
+
+// ShipmentUpdate request, the next status of a shipment or the code of its
+// carrier order
+type ShipmentUpdate struct {
+	Status           string `json:"status" validate:"omitempty,oneof=shipping delivered"`
+	CarrierOrderCode string `json:"carrier_order_code"`
+}
+
+// Shipment response, TrackingURL is empty until the carrier order is known
+type Shipment struct {
+	ID               int64          `json:"id"`
+	Status           string         `json:"status"`
+	CarrierOrderCode string         `json:"carrier_order_code"`
+	TrackingURL      string         `json:"tracking_url"`
+	Method           string         `json:"method"`
+	Note             string         `json:"note"`
+	CreatedAt        string         `json:"created_at"`
+	ShippedAt        string         `json:"shipped_at,omitempty"`
+	DeliveredAt      string         `json:"delivered_at,omitempty"`
+	Items            []ShipmentItem `json:"items"`
+}
+
+// ShipmentItem response
+type ShipmentItem struct {
+	ItemID   int64  `json:"item_id"`
+	Name     string `json:"name"`
+	Quantity int64  `json:"quantity"`
+}
+
+// ShipmentResponse response
+type ShipmentResponse struct {
+	ShipmentID int64 `json:"shipment_id"`
+}
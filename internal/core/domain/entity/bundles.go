@@ -0,0 +1,35 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Bundle table, inventory items sold together for one price
+type Bundle struct {
+	ID           int64           `json:"id" db:"id"`
+	Name         string          `json:"name" db:"name"`
+	Description  string          `json:"description" db:"description"`
+	Image        string          `json:"image" db:"image"`
+	Price        decimal.Decimal `json:"price" db:"price"`
+	CurrencyCode string          `json:"currency_code" db:"currency_code"`
+	Status       string          `json:"status" db:"status"`
+	CreatedAt    time.Time       `json:"created_at" db:"created_at"`
+}
+
+// BundleItem table, a component of a bundle
+type BundleItem struct {
+	BundleID    int64 `json:"bundle_id" db:"bundle_id"`
+	InventoryID int64 `json:"inventory_id" db:"inventory_id"`
+	Quantity    int64 `json:"quantity" db:"quantity"`
+}
+
+// CartBundle table, a bundle in the cart of a user
+type CartBundle struct {
+	ID        int64     `json:"id" db:"id"`
+	UserID    int64     `json:"user_id" db:"user_id"`
+	BundleID  int64     `json:"bundle_id" db:"bundle_id"`
+	Quantity  int64     `json:"quantity" db:"quantity"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
@@ -1,10 +1,27 @@
 // Package entity Cart entities
 package entity
 
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
 // Cart table
 type Cart struct {
-	ID          int64 `json:"id" db:"id"`
-	UserID      int64 `json:"user_id" db:"user_id"`
-	InventoryID int64 `json:"inventory_id" db:"inventory_id"`
-	Quantity    int64 `json:"quantity" db:"quantity"`
+	ID          int64           `json:"id" db:"id"`
+	UserID      int64           `json:"user_id" db:"user_id"`
+	InventoryID int64           `json:"inventory_id" db:"inventory_id"`
+	Quantity    int64           `json:"quantity" db:"quantity"`
+	Price       decimal.Decimal `json:"price" db:"price"`
+	UpdatedAt   time.Time       `json:"updated_at" db:"updated_at"`
+}
+
+// CartReminder table, a reminder email sent for an abandoned cart
+type CartReminder struct {
+	ID            int64     `json:"id" db:"id"`
+	UserID        int64     `json:"user_id" db:"user_id"`
+	Items         int64     `json:"items" db:"items"`
+	CartUpdatedAt time.Time `json:"cart_updated_at" db:"cart_updated_at"`
+	SentAt        time.Time `json:"sent_at" db:"sent_at"`
 }
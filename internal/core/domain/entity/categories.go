@@ -1,9 +1,12 @@
 // Package entity Categories entities
 package entity
 
+import "github.com/shopspring/decimal"
+
 // Category Table
 type Category struct {
-	ID          int64  `json:"id" db:"id"`
-	Name        string `json:"name" db:"name" validate:"required"`
-	Description string `json:"description" db:"description" validate:"required"`
+	ID          int64               `json:"id" db:"id"`
+	Name        string              `json:"name" db:"name" validate:"required"`
+	Description string              `json:"description" db:"description" validate:"required"`
+	VATRate     decimal.NullDecimal `json:"vat_rate" db:"vat_rate" swaggertype:"string"`
 }
@@ -0,0 +1,32 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// CODCollection table schema, the cash a carrier collects for a COD order
+type CODCollection struct {
+	OrderID        int64           `json:"order_id" db:"order_id"`
+	ExpectedAmount decimal.Decimal `json:"expected_amount" db:"expected_amount"`
+	RemittedAmount decimal.Decimal `json:"remitted_amount" db:"remitted_amount"`
+	Status         string          `json:"status" db:"status"`
+	ReconciledAt   *time.Time      `json:"reconciled_at" db:"reconciled_at"`
+	CreatedAt      time.Time       `json:"created_at" db:"created_at"`
+}
+
+// CODRemittance table schema, a line of a carrier remittance report
+type CODRemittance struct {
+	ID               int64           `json:"id" db:"id"`
+	Source           string          `json:"source" db:"source"`
+	Reference        string          `json:"reference" db:"reference"`
+	CarrierOrderCode string          `json:"carrier_order_code" db:"carrier_order_code"`
+	OrderCode        string          `json:"order_code" db:"order_code"`
+	OrderID          *int64          `json:"order_id" db:"order_id"`
+	Amount           decimal.Decimal `json:"amount" db:"amount"`
+	Result           string          `json:"result" db:"result"`
+	RemittedAt       *time.Time      `json:"remitted_at" db:"remitted_at"`
+	Actor            string          `json:"actor" db:"actor"`
+	CreatedAt        time.Time       `json:"created_at" db:"created_at"`
+}
@@ -1,6 +1,10 @@
 package entity
 
-import "time"
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
 
 // CREATE TABLE "coupons" (
 // 	"id" bigserial PRIMARY KEY,
@@ -12,16 +16,28 @@ import "time"
 // 	"description" varchar NOT NULL,
 // 	"expired_at" timestamptz NOT NULL
 //   );
+//
+// ALTER TABLE "coupons"
+//   ADD COLUMN "discount_type" varchar NOT NULL DEFAULT 'percent',
+//   ADD COLUMN "min_order_value" NUMERIC(19, 4) NOT NULL DEFAULT 0,
+//   ADD COLUMN "max_discount" NUMERIC(19, 4) NOT NULL DEFAULT 0,
+//   ADD COLUMN "category_ids" bigint[] NOT NULL DEFAULT '{}',
+//   ADD COLUMN "inventory_ids" bigint[] NOT NULL DEFAULT '{}';
 
 type Coupons struct {
-	ID          int64     `json:"id" db:"id"`
-	Code        string    `json:"code" db:"code"`
-	Used        int       `json:"used" db:"used"`
-	Status      string    `json:"status" db:"status"`
-	MaxUse      int       `json:"max_use" db:"max_use"`
-	Discount    int       `json:"discount" db:"discount"`
-	ExpiredAt   time.Time `json:"expired_at" db:"expired_at"`
-	Description string    `json:"description" db:"description"`
+	ID            int64           `json:"id" db:"id"`
+	Code          string          `json:"code" db:"code"`
+	Used          int             `json:"used" db:"used"`
+	Status        string          `json:"status" db:"status"`
+	MaxUse        int             `json:"max_use" db:"max_use"`
+	Discount      decimal.Decimal `json:"discount" db:"discount"`
+	ExpiredAt     time.Time       `json:"expired_at" db:"expired_at"`
+	Description   string          `json:"description" db:"description"`
+	DiscountType  string          `json:"discount_type" db:"discount_type"`
+	MinOrderValue decimal.Decimal `json:"min_order_value" db:"min_order_value"`
+	MaxDiscount   decimal.Decimal `json:"max_discount" db:"max_discount"`
+	CategoryIDs   []int64         `json:"category_ids" db:"category_ids"`
+	InventoryIDs  []int64         `json:"inventory_ids" db:"inventory_ids"`
 }
 
 type CouponsUsed struct {
@@ -0,0 +1,45 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// GiftCard table schema
+type GiftCard struct {
+	ID             int64           `json:"id" db:"id"`
+	Code           string          `json:"code" db:"code"`
+	InitialBalance decimal.Decimal `json:"initial_balance" db:"initial_balance"`
+	Balance        decimal.Decimal `json:"balance" db:"balance"`
+	CurrencyCode   string          `json:"currency_code" db:"currency_code"`
+	Status         string          `json:"status" db:"status"`
+	ExpiresAt      *time.Time      `json:"expires_at" db:"expires_at"`
+	OrderID        *int64          `json:"order_id" db:"order_id"`
+	Note           string          `json:"note" db:"note"`
+	VoidedAt       *time.Time      `json:"voided_at" db:"voided_at"`
+	CreatedAt      time.Time       `json:"created_at" db:"created_at"`
+}
+
+// Wallet table schema, the store credit of a user
+type Wallet struct {
+	UserID       int64           `json:"user_id" db:"user_id"`
+	Balance      decimal.Decimal `json:"balance" db:"balance"`
+	CurrencyCode string          `json:"currency_code" db:"currency_code"`
+	UpdatedAt    time.Time       `json:"updated_at" db:"updated_at"`
+}
+
+// CreditEntry table schema, a movement of a gift card or a wallet. Amount
+// is negative for debits.
+type CreditEntry struct {
+	ID         int64           `json:"id" db:"id"`
+	GiftCardID *int64          `json:"gift_card_id" db:"gift_card_id"`
+	UserID     *int64          `json:"user_id" db:"user_id"`
+	OrderID    *int64          `json:"order_id" db:"order_id"`
+	Amount     decimal.Decimal `json:"amount" db:"amount"`
+	Balance    decimal.Decimal `json:"balance" db:"balance"`
+	Kind       string          `json:"kind" db:"kind"`
+	Actor      string          `json:"actor" db:"actor"`
+	Note       string          `json:"note" db:"note"`
+	CreatedAt  time.Time       `json:"created_at" db:"created_at"`
+}
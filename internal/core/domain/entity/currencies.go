@@ -0,0 +1,17 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// ExchangeRate table schema, Rate is the value of one unit of the currency
+// in the base currency
+type ExchangeRate struct {
+	CurrencyCode string          `json:"currency_code" db:"currency_code"`
+	Rate         decimal.Decimal `json:"rate" db:"rate"`
+	Source       string          `json:"source" db:"source"`
+	UpdatedBy    string          `json:"updated_by" db:"updated_by"`
+	UpdatedAt    time.Time       `json:"updated_at" db:"updated_at"`
+}
@@ -1,6 +1,10 @@
 package entity
 
-import "github.com/shopspring/decimal"
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
 
 // Inventory table
 type Inventory struct {
@@ -14,4 +18,8 @@ type Inventory struct {
 	Image        string          `json:"image" db:"image"`
 	Specs        string          `json:"specs" db:"specs"`
 	Price        decimal.Decimal `json:"price" db:"price"`
+
+	// ExpectedShipDate and DepositPercent describe a pre-order item
+	ExpectedShipDate *time.Time      `json:"expected_ship_date" db:"expected_ship_date"`
+	DepositPercent   decimal.Decimal `json:"deposit_percent" db:"deposit_percent"`
 }
@@ -0,0 +1,13 @@
+package entity
+
+import "time"
+
+// Invoice table, the invoice issued for an order
+type Invoice struct {
+	ID       int64     `json:"id" db:"id"`
+	OrderID  int64     `json:"order_id" db:"order_id"`
+	Number   int64     `json:"number" db:"number"`
+	IssuedAt time.Time `json:"issued_at" db:"issued_at"`
+	HTMLURL  string    `json:"html_url" db:"html_url"`
+	PDFURL   string    `json:"pdf_url" db:"pdf_url"`
+}
@@ -0,0 +1,17 @@
+package entity
+
+import "time"
+
+// LoyaltyEntry table schema, a movement of the loyalty points of a user.
+// Points is negative for debits, Remaining and ExpiresAt are set on the
+// lots of earned or restored points.
+type LoyaltyEntry struct {
+	ID        int64      `json:"id" db:"id"`
+	UserID    int64      `json:"user_id" db:"user_id"`
+	OrderID   *int64     `json:"order_id" db:"order_id"`
+	Points    int64      `json:"points" db:"points"`
+	Remaining int64      `json:"remaining" db:"remaining"`
+	Kind      string     `json:"kind" db:"kind"`
+	ExpiresAt *time.Time `json:"expires_at" db:"expires_at"`
+	CreatedAt time.Time  `json:"created_at" db:"created_at"`
+}
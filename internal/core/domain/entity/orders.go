@@ -16,6 +16,21 @@ type Order struct {
 	Time          time.Time       `json:"time" db:"time"`
 	TotalAmount   decimal.Decimal `json:"total_amount" db:"total_amount"`
 	PaymentMethod string          `json:"payment_method" db:"payment_method"`
+
+	Subtotal       decimal.Decimal `json:"subtotal" db:"subtotal"`
+	DiscountAmount decimal.Decimal `json:"discount_amount" db:"discount_amount"`
+	TaxAmount      decimal.Decimal `json:"tax_amount" db:"tax_amount"`
+	ShippingFee    decimal.Decimal `json:"shipping_fee" db:"shipping_fee"`
+	DepositAmount  decimal.Decimal `json:"deposit_amount" db:"deposit_amount"`
+	CreditAmount   decimal.Decimal `json:"credit_amount" db:"credit_amount"`
+	PointsRedeemed int64           `json:"points_redeemed" db:"points_redeemed"`
+	PointsAmount   decimal.Decimal `json:"points_amount" db:"points_amount"`
+	PaidAt         *time.Time      `json:"paid_at" db:"paid_at"`
+	CurrencyCode   string          `json:"currency_code" db:"currency_code"`
+	ExchangeRate   decimal.Decimal `json:"exchange_rate" db:"exchange_rate"`
+	PaymentStatus  string          `json:"payment_status" db:"payment_status"`
+	PaymentDueAt   *time.Time      `json:"payment_due_at" db:"payment_due_at"`
+	DepositPaidAt  *time.Time      `json:"deposit_paid_at" db:"deposit_paid_at"`
 }
 
 // ProductInOrder table schema
@@ -26,4 +41,20 @@ type ProductInOrder struct {
 	Quantity     int64           `json:"quantity" db:"quantity"`
 	CurrencyCode string          `json:"currency_code" db:"currency_code"`
 	TotalAmount  decimal.Decimal `json:"total_amount" db:"total_amount"`
+	UnitPrice    decimal.Decimal `json:"unit_price" db:"unit_price"`
+	VATRate      decimal.Decimal `json:"vat_rate" db:"vat_rate"`
+	TaxAmount    decimal.Decimal `json:"tax_amount" db:"tax_amount"`
+	Backordered  int64           `json:"backordered" db:"backordered"`
+	BundleID     *int64          `json:"bundle_id" db:"bundle_id"`
+}
+
+// OrderStatusHistory table schema
+type OrderStatusHistory struct {
+	ID         int64     `json:"id" db:"id"`
+	OrderID    int64     `json:"order_id" db:"order_id"`
+	FromStatus string    `json:"from_status" db:"from_status"`
+	ToStatus   string    `json:"to_status" db:"to_status"`
+	Actor      string    `json:"actor" db:"actor"`
+	Reason     string    `json:"reason" db:"reason"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
 }
@@ -0,0 +1,28 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// PaymentTransaction table schema, a step of the payment of an order.
+// Payload is the request sent to the provider or the callback received,
+// in JSON. Reference is what the provider needs to query or refund the
+// payment.
+type PaymentTransaction struct {
+	ID            int64           `json:"id" db:"id"`
+	OrderUUID     string          `json:"order_uuid" db:"order_uuid"`
+	Provider      string          `json:"provider" db:"provider"`
+	Event         string          `json:"event" db:"event"`
+	Status        string          `json:"status" db:"status"`
+	Amount        decimal.Decimal `json:"amount" db:"amount"`
+	CurrencyCode  string          `json:"currency_code" db:"currency_code"`
+	TransactionNo string          `json:"transaction_no" db:"transaction_no"`
+	BankCode      string          `json:"bank_code" db:"bank_code"`
+	ResponseCode  string          `json:"response_code" db:"response_code"`
+	Message       string          `json:"message" db:"message"`
+	Payload       []byte          `json:"payload" db:"payload"`
+	Reference     string          `json:"reference" db:"reference"`
+	CreatedAt     time.Time       `json:"created_at" db:"created_at"`
+}
@@ -0,0 +1,52 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Return table schema
+type Return struct {
+	ID         int64      `json:"id" db:"id"`
+	OrderID    int64      `json:"order_id" db:"order_id"`
+	UserID     int64      `json:"user_id" db:"user_id"`
+	Status     string     `json:"status" db:"status"`
+	Reason     string     `json:"reason" db:"reason"`
+	Photos     string     `json:"photos" db:"photos"`
+	Note       string     `json:"note" db:"note"`
+	ReceivedAt *time.Time `json:"received_at" db:"received_at"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+}
+
+// ReturnItem table schema
+type ReturnItem struct {
+	ID               int64 `json:"id" db:"id"`
+	ReturnID         int64 `json:"return_id" db:"return_id"`
+	ProductInOrderID int64 `json:"product_in_order_id" db:"product_in_order_id"`
+	Quantity         int64 `json:"quantity" db:"quantity"`
+	Restocked        bool  `json:"restocked" db:"restocked"`
+}
+
+// Refund table schema. A refund paid back through the payment provider
+// names the provider and the transaction of the payment, RefundNo is the
+// reference of the refund at the provider.
+type Refund struct {
+	ID            int64           `json:"id" db:"id"`
+	OrderID       int64           `json:"order_id" db:"order_id"`
+	ReturnID      *int64          `json:"return_id" db:"return_id"`
+	Amount        decimal.Decimal `json:"amount" db:"amount"`
+	CurrencyCode  string          `json:"currency_code" db:"currency_code"`
+	Status        string          `json:"status" db:"status"`
+	Reason        string          `json:"reason" db:"reason"`
+	CreatedAt     time.Time       `json:"created_at" db:"created_at"`
+	Provider      string          `json:"provider" db:"provider"`
+	TransactionNo string          `json:"transaction_no" db:"transaction_no"`
+	Full          bool            `json:"full_refund" db:"full_refund"`
+	RefundNo      string          `json:"refund_no" db:"refund_no"`
+	ResponseCode  string          `json:"response_code" db:"response_code"`
+	Message       string          `json:"message" db:"message"`
+	Attempts      int32           `json:"attempts" db:"attempts"`
+	Actor         string          `json:"actor" db:"actor"`
+	UpdatedAt     time.Time       `json:"updated_at" db:"updated_at"`
+}
@@ -0,0 +1,23 @@
+package entity
+
+import "time"
+
+// Shipment table schema, a part of an order sent with its own delivery
+type Shipment struct {
+	ID               int64      `json:"id" db:"id"`
+	OrderID          int64      `json:"order_id" db:"order_id"`
+	DeliveryID       int64      `json:"delivery_id" db:"delivery_id"`
+	CarrierOrderCode *string    `json:"carrier_order_code" db:"carrier_order_code"`
+	Status           string     `json:"status" db:"status"`
+	Actor            string     `json:"actor" db:"actor"`
+	CreatedAt        time.Time  `json:"created_at" db:"created_at"`
+	ShippedAt        *time.Time `json:"shipped_at" db:"shipped_at"`
+	DeliveredAt      *time.Time `json:"delivered_at" db:"delivered_at"`
+}
+
+// ShipmentItem table schema, the units of an order line sent in a shipment
+type ShipmentItem struct {
+	ShipmentID int64 `json:"shipment_id" db:"shipment_id"`
+	ItemID     int64 `json:"item_id" db:"item_id"`
+	Quantity   int64 `json:"quantity" db:"quantity"`
+}
@@ -8,4 +8,9 @@ type User struct {
 	FirstName   string `json:"first_name" db:"first_name"`
 	LastName    string `json:"last_name" db:"last_name"`
 	Image       string `json:"image" db:"image"`
+
+	CartReminderOptOut bool `json:"cart_reminder_opt_out" db:"cart_reminder_opt_out"`
+
+	LoyaltyPoints  int64 `json:"loyalty_points" db:"loyalty_points"`
+	LifetimePoints int64 `json:"lifetime_points" db:"lifetime_points"`
 }
@@ -0,0 +1,24 @@
+package enum
+
+// CODStatus is an enumeration of the reconciliation states of the cash
+// collected on delivery.
+type CODStatus string
+
+const (
+	// CODPending is the status of a collection nothing was remitted for yet.
+	CODPending CODStatus = "pending"
+
+	// CODReconciled is the status of a collection remitted in full.
+	CODReconciled CODStatus = "reconciled"
+
+	// CODShort is the status of a collection remitted for less than expected.
+	CODShort CODStatus = "short"
+
+	// CODMismatch is the status of a collection remitted for more than expected.
+	CODMismatch CODStatus = "mismatch"
+)
+
+// String returns the string representation of the CODStatus.
+func (s CODStatus) String() string {
+	return string(s)
+}
@@ -0,0 +1,29 @@
+package enum
+
+import "fmt"
+
+// CouponDiscount is an enumeration of the ways a coupon takes money off an order.
+type CouponDiscount string
+
+const (
+	// CouponPercent takes a percentage of the eligible amount off.
+	CouponPercent CouponDiscount = "percent"
+
+	// CouponFixed takes a fixed amount off, at most the eligible amount.
+	CouponFixed CouponDiscount = "fixed"
+)
+
+// String returns the string representation of the CouponDiscount.
+func (d CouponDiscount) String() string {
+	return string(d)
+}
+
+// Load loads the coupon discount type.
+func (d *CouponDiscount) Load(discount string) error {
+	switch CouponDiscount(discount) {
+	case CouponPercent, CouponFixed:
+		*d = CouponDiscount(discount)
+		return nil
+	}
+	return fmt.Errorf("invalid coupon discount type: %s", discount)
+}
@@ -0,0 +1,36 @@
+package enum
+
+import "fmt"
+
+// InventoryStatus is an enumeration of the sale states of an inventory item.
+type InventoryStatus string
+
+const (
+	// InventoryActive is the status of an item sold from stock.
+	InventoryActive InventoryStatus = "active"
+
+	// InventoryDraft is the status of an item not yet published.
+	InventoryDraft InventoryStatus = "draft"
+
+	// InventoryArchived is the status of an item that is no longer sold.
+	InventoryArchived InventoryStatus = "archived"
+
+	// InventoryPreorder is the status of an item sold before its stock
+	// arrives, the units missing at checkout are backordered.
+	InventoryPreorder InventoryStatus = "preorder"
+)
+
+// String returns the string representation of the InventoryStatus.
+func (s InventoryStatus) String() string {
+	return string(s)
+}
+
+// Load loads the inventory status.
+func (s *InventoryStatus) Load(status string) error {
+	switch InventoryStatus(status) {
+	case InventoryActive, InventoryDraft, InventoryArchived, InventoryPreorder:
+		*s = InventoryStatus(status)
+		return nil
+	}
+	return fmt.Errorf("invalid inventory status: %s", status)
+}
@@ -0,0 +1,31 @@
+package enum
+
+// LoyaltyTier is an enumeration of the loyalty tiers of a customer.
+type LoyaltyTier string
+
+const (
+	// LoyaltyMember is the tier of a customer below the silver threshold.
+	LoyaltyMember LoyaltyTier = "member"
+
+	// LoyaltySilver is the tier of a customer past the silver threshold.
+	LoyaltySilver LoyaltyTier = "silver"
+
+	// LoyaltyGold is the tier of a customer past the gold threshold.
+	LoyaltyGold LoyaltyTier = "gold"
+)
+
+// String returns the string representation of the LoyaltyTier.
+func (t LoyaltyTier) String() string {
+	return string(t)
+}
+
+// LoyaltyTierOf returns the tier reached with the given lifetime points.
+func LoyaltyTierOf(points, silver, gold int64) LoyaltyTier {
+	switch {
+	case points >= gold:
+		return LoyaltyGold
+	case points >= silver:
+		return LoyaltySilver
+	}
+	return LoyaltyMember
+}
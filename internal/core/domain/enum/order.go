@@ -0,0 +1,94 @@
+package enum
+
+import (
+	"fmt"
+	"slices"
+)
+
+// OrderStatus is an enumeration of the order lifecycle states.
+type OrderStatus string
+
+const (
+	// OrderPending is the status of a newly created order.
+	OrderPending OrderStatus = "pending"
+
+	// OrderConfirmed is the status of an order accepted by the shop.
+	OrderConfirmed OrderStatus = "confirmed"
+
+	// OrderPacking is the status of an order being prepared in the warehouse.
+	OrderPacking OrderStatus = "packing"
+
+	// OrderPartiallyShipped is the status of an order with some of its
+	// shipments handed over to the carrier and units left to ship.
+	OrderPartiallyShipped OrderStatus = "partially_shipped"
+
+	// OrderShipping is the status of an order handed over to the carrier.
+	OrderShipping OrderStatus = "shipping"
+
+	// OrderDelivered is the status of an order received by the customer.
+	OrderDelivered OrderStatus = "delivered"
+
+	// OrderCancelled is the status of an order cancelled before shipping.
+	OrderCancelled OrderStatus = "cancelled"
+
+	// OrderExpired is the status of a pending order that was never confirmed.
+	OrderExpired OrderStatus = "expired"
+
+	// OrderReturned is the status of an order sent back by the customer.
+	OrderReturned OrderStatus = "returned"
+
+	// OrderRefunded is the status of an order whose payment was given back.
+	OrderRefunded OrderStatus = "refunded"
+)
+
+// orderTransitions lists the statuses reachable from each status.
+var orderTransitions = map[OrderStatus][]OrderStatus{
+	OrderPending:          {OrderConfirmed, OrderCancelled, OrderExpired},
+	OrderConfirmed:        {OrderPacking, OrderCancelled},
+	OrderPacking:          {OrderPartiallyShipped, OrderShipping, OrderCancelled},
+	OrderPartiallyShipped: {OrderShipping},
+	OrderShipping:         {OrderDelivered, OrderReturned},
+	OrderDelivered:        {OrderReturned, OrderRefunded},
+	OrderCancelled:        {OrderRefunded},
+	OrderReturned:         {OrderRefunded},
+	OrderExpired:          {},
+	OrderRefunded:         {},
+}
+
+// backorderStatuses are the statuses of the orders that may still wait for
+// backordered units: an order ships its last units when it enters shipping.
+var backorderStatuses = []OrderStatus{OrderPending, OrderConfirmed, OrderPacking, OrderPartiallyShipped}
+
+// BackorderStatuses returns the statuses of the orders that may still wait
+// for backordered units.
+func BackorderStatuses() []string {
+	statuses := make([]string, 0, len(backorderStatuses))
+	for _, status := range backorderStatuses {
+		statuses = append(statuses, status.String())
+	}
+	return statuses
+}
+
+// String returns the string representation of the OrderStatus.
+func (s OrderStatus) String() string {
+	return string(s)
+}
+
+// Load loads the order status.
+func (s *OrderStatus) Load(status string) error {
+	if _, ok := orderTransitions[OrderStatus(status)]; !ok {
+		return fmt.Errorf("invalid order status: %s", status)
+	}
+	*s = OrderStatus(status)
+	return nil
+}
+
+// CanTransitionTo reports whether an order may move from s to next.
+func (s OrderStatus) CanTransitionTo(next OrderStatus) bool {
+	return slices.Contains(orderTransitions[s], next)
+}
+
+// ReleasesStock reports whether entering s gives the reserved stock back.
+func (s OrderStatus) ReleasesStock() bool {
+	return s == OrderCancelled || s == OrderExpired
+}
@@ -0,0 +1,52 @@
+package enum
+
+// PaymentEvent is an enumeration of the steps of a payment recorded in the
+// payment transactions ledger.
+type PaymentEvent string
+
+const (
+	// PaymentCreate is the event of a payment URL built for an order.
+	PaymentCreate PaymentEvent = "create"
+
+	// PaymentReturn is the event of a customer redirected back by the provider.
+	PaymentReturn PaymentEvent = "return"
+
+	// PaymentIPN is the event of a server to server notification of the provider.
+	PaymentIPN PaymentEvent = "ipn"
+
+	// PaymentRefund is the event of a refund asked to the provider.
+	PaymentRefund PaymentEvent = "refund"
+)
+
+// String returns the string representation of the PaymentEvent.
+func (e PaymentEvent) String() string {
+	return string(e)
+}
+
+// PaymentStatus is an enumeration of the outcomes of the steps of a payment.
+type PaymentStatus string
+
+const (
+	// PaymentPending is the status of a payment waiting for the customer.
+	PaymentPending PaymentStatus = "pending"
+
+	// PaymentSucceeded is the status of a callback that paid its order.
+	PaymentSucceeded PaymentStatus = "succeeded"
+
+	// PaymentFailed is the status of a payment the provider did not complete.
+	PaymentFailed PaymentStatus = "failed"
+
+	// PaymentRejected is the status of a callback rejected because of its
+	// checksum, its amount, its order or because it was replayed.
+	PaymentRejected PaymentStatus = "rejected"
+
+	// PaymentRefundDue is the status of a callback that paid an order
+	// cancelled or expired before the payment arrived, the amount paid is to
+	// be refunded.
+	PaymentRefundDue PaymentStatus = "refund_due"
+)
+
+// String returns the string representation of the PaymentStatus.
+func (s PaymentStatus) String() string {
+	return string(s)
+}
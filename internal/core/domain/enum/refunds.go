@@ -0,0 +1,24 @@
+package enum
+
+// RefundStatus is an enumeration of the refund payout states.
+type RefundStatus string
+
+const (
+	// RefundPending is the status of a refund that has not been paid out yet.
+	RefundPending RefundStatus = "pending"
+
+	// RefundInFlight is the status of a refund asked to the payment provider,
+	// until its answer is recorded.
+	RefundInFlight RefundStatus = "in_flight"
+
+	// RefundSucceeded is the status of a refund the customer has received.
+	RefundSucceeded RefundStatus = "succeeded"
+
+	// RefundFailed is the status of a refund rejected by the payment provider.
+	RefundFailed RefundStatus = "failed"
+)
+
+// String returns the string representation of the RefundStatus.
+func (s RefundStatus) String() string {
+	return string(s)
+}
@@ -0,0 +1,32 @@
+package enum
+
+import "fmt"
+
+// ReturnStatus is an enumeration of the return request decisions.
+type ReturnStatus string
+
+const (
+	// ReturnRequested is the status of a return waiting for review.
+	ReturnRequested ReturnStatus = "requested"
+
+	// ReturnApproved is the status of a return accepted by the shop.
+	ReturnApproved ReturnStatus = "approved"
+
+	// ReturnRejected is the status of a return declined by the shop.
+	ReturnRejected ReturnStatus = "rejected"
+)
+
+// String returns the string representation of the ReturnStatus.
+func (s ReturnStatus) String() string {
+	return string(s)
+}
+
+// Load loads the return status.
+func (s *ReturnStatus) Load(status string) error {
+	switch ReturnStatus(status) {
+	case ReturnRequested, ReturnApproved, ReturnRejected:
+		*s = ReturnStatus(status)
+		return nil
+	}
+	return fmt.Errorf("invalid return status: %s", status)
+}
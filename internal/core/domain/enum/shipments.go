@@ -0,0 +1,51 @@
+package enum
+
+import (
+	"fmt"
+	"slices"
+)
+
+// ShipmentStatus is an enumeration of the states of a shipment of an order.
+type ShipmentStatus string
+
+const (
+	// ShipmentPending is the status of a shipment not handed to the carrier yet.
+	ShipmentPending ShipmentStatus = "pending"
+
+	// ShipmentShipping is the status of a shipment handed to the carrier.
+	ShipmentShipping ShipmentStatus = "shipping"
+
+	// ShipmentDelivered is the status of a shipment received by the customer.
+	ShipmentDelivered ShipmentStatus = "delivered"
+)
+
+// shipmentTransitions lists the statuses reachable from each status.
+var shipmentTransitions = map[ShipmentStatus][]ShipmentStatus{
+	ShipmentPending:   {ShipmentShipping},
+	ShipmentShipping:  {ShipmentDelivered},
+	ShipmentDelivered: {},
+}
+
+// String returns the string representation of the ShipmentStatus.
+func (s ShipmentStatus) String() string {
+	return string(s)
+}
+
+// Load loads the shipment status.
+func (s *ShipmentStatus) Load(status string) error {
+	if _, ok := shipmentTransitions[ShipmentStatus(status)]; !ok {
+		return fmt.Errorf("invalid shipment status: %s", status)
+	}
+	*s = ShipmentStatus(status)
+	return nil
+}
+
+// CanTransitionTo reports whether a shipment may move from s to next.
+func (s ShipmentStatus) CanTransitionTo(next ShipmentStatus) bool {
+	return slices.Contains(shipmentTransitions[s], next)
+}
+
+// Shipped reports whether the shipment left the warehouse.
+func (s ShipmentStatus) Shipped() bool {
+	return s == ShipmentShipping || s == ShipmentDelivered
+}
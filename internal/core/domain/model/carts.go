@@ -1,5 +1,7 @@
 package model
 
+import "time"
+
 type Carts struct {
 	Name           string `json:"name" db:"name"`
 	CartID         int64  `json:"cart_id" db:"cart_id"`
@@ -12,4 +14,26 @@ type Carts struct {
 	InventoryImage string `json:"inventory_image" db:"inventory_image"`
 	InventorySpecs string `json:"inventory_specs" db:"inventory_specs"`
 	CategoryName   string `json:"category_name" db:"category_name"`
+	CategoryID     int64  `json:"category_id" db:"category_id"`
+	AddedPrice     string `json:"added_price" db:"added_price"`
+	Available      int64  `json:"available" db:"available"`
+	Status         string `json:"status" db:"inventory_status"`
+}
+
+// AbandonedCart is the cart of a user left untouched, with its owner
+type AbandonedCart struct {
+	UserID    int64     `json:"user_id" db:"user_id"`
+	Email     string    `json:"email" db:"email"`
+	FirstName string    `json:"first_name" db:"first_name"`
+	LastName  string    `json:"last_name" db:"last_name"`
+	Items     int64     `json:"items" db:"items"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// CartReminderStats summary of the abandoned cart reminders sent
+type CartReminderStats struct {
+	Total      int64      `json:"total" db:"total"`
+	Recent     int64      `json:"recent" db:"recent"`
+	Items      int64      `json:"items" db:"items"`
+	LastSentAt *time.Time `json:"last_sent_at" db:"last_sent_at"`
 }
@@ -0,0 +1,47 @@
+package model
+
+import (
+	"github.com/shopspring/decimal"
+)
+
+// zeroDecimalCurrencies are the currencies without minor units
+var zeroDecimalCurrencies = map[string]bool{
+	"VND": true,
+	"JPY": true,
+	"KRW": true,
+}
+
+// ExchangeRates maps the currency codes to the value of one unit in the
+// base currency, the base currency maps to 1
+type ExchangeRates map[string]decimal.Decimal
+
+// Has reports whether amounts can be converted from and to currency.
+func (r ExchangeRates) Has(currency string) bool {
+	_, ok := r[currency]
+	return ok
+}
+
+// Convert converts amount from a currency to another, rounded to the minor
+// unit of the target currency. It reports false for unknown currencies.
+func (r ExchangeRates) Convert(amount decimal.Decimal, from, to string) (decimal.Decimal, bool) {
+	if from == to {
+		return amount, true
+	}
+	fromRate, ok := r[from]
+	if !ok {
+		return decimal.Zero, false
+	}
+	toRate, ok := r[to]
+	if !ok {
+		return decimal.Zero, false
+	}
+	return RoundCurrency(amount.Mul(fromRate).Div(toRate), to), true
+}
+
+// RoundCurrency rounds amount to the minor unit of currency.
+func RoundCurrency(amount decimal.Decimal, currency string) decimal.Decimal {
+	if zeroDecimalCurrencies[currency] {
+		return amount.Round(0)
+	}
+	return amount.Round(2)
+}
@@ -1,16 +1,111 @@
 package model
 
 import (
+	"time"
+
 	"github.com/shopspring/decimal"
 )
 
 type Order struct {
+	ID           int64           `json:"id" db:"id"`
 	CategoryID   int64           `json:"category_id" db:"category_id"`
 	Quantity     int             `json:"quantity" db:"quantity"`
+	Backordered  int             `json:"backordered" db:"backordered"`
+	BundleID     *int64          `json:"bundle_id,omitempty" db:"bundle_id"`
 	CurrencyCode string          `json:"currency_code" db:"currency_code"`
 	Color        string          `json:"color" db:"color"`
 	Image        string          `json:"image" db:"image"`
 	Name         string          `json:"name" db:"name"`
 	TotalAmount  decimal.Decimal `json:"total_amount" db:"total_amount"`
+	UnitPrice    decimal.Decimal `json:"unit_price" db:"unit_price"`
+	VATRate      decimal.Decimal `json:"vat_rate" db:"vat_rate"`
+	TaxAmount    decimal.Decimal `json:"tax_amount" db:"tax_amount"`
 	ItemSpecs    string          `json:"item_specs" db:"item_specs"`
 }
+
+// OrderFilter selects the orders listed to admins. Zero fields are not
+// filtered on. Sort is one of "time", "total", prefixed with "-" for a
+// descending order, and the After fields are the sort key of the last order
+// of the previous page.
+type OrderFilter struct {
+	Status        string
+	PaymentMethod string
+	From          time.Time
+	To            time.Time
+	Email         string
+	Phone         string
+	MinTotal      decimal.NullDecimal
+	MaxTotal      decimal.NullDecimal
+	CouponCode    string
+	Sort          string
+	AfterID       int64
+	AfterTime     time.Time
+	AfterTotal    decimal.Decimal
+	Limit         int
+}
+
+// OrderSummary is an order with its customer, as listed to admins
+type OrderSummary struct {
+	ID             int64           `json:"id" db:"id"`
+	UUID           string          `json:"uuid" db:"uuid"`
+	Time           time.Time       `json:"time" db:"time"`
+	Status         string          `json:"status" db:"status"`
+	PaymentMethod  string          `json:"payment_method" db:"payment_method"`
+	Subtotal       decimal.Decimal `json:"subtotal" db:"subtotal"`
+	DiscountAmount decimal.Decimal `json:"discount_amount" db:"discount_amount"`
+	TaxAmount      decimal.Decimal `json:"tax_amount" db:"tax_amount"`
+	ShippingFee    decimal.Decimal `json:"shipping_fee" db:"shipping_fee"`
+	TotalAmount    decimal.Decimal `json:"total_amount" db:"total_amount"`
+	UserID         int64           `json:"user_id" db:"user_id"`
+	Email          string          `json:"email" db:"email"`
+	FirstName      string          `json:"first_name" db:"first_name"`
+	LastName       string          `json:"last_name" db:"last_name"`
+	PhoneNumber    string          `json:"phone_number" db:"phone_number"`
+	CouponCode     string          `json:"coupon_code" db:"coupon_code"`
+	Items          int64           `json:"items" db:"items"`
+}
+
+// OrderExportRow is a line item of an order with its order and customer,
+// as exported to spreadsheets
+type OrderExportRow struct {
+	UUID           string          `json:"uuid" db:"uuid"`
+	Time           time.Time       `json:"time" db:"time"`
+	Status         string          `json:"status" db:"status"`
+	PaymentMethod  string          `json:"payment_method" db:"payment_method"`
+	Email          string          `json:"email" db:"email"`
+	FirstName      string          `json:"first_name" db:"first_name"`
+	LastName       string          `json:"last_name" db:"last_name"`
+	PhoneNumber    string          `json:"phone_number" db:"phone_number"`
+	CouponCode     string          `json:"coupon_code" db:"coupon_code"`
+	Subtotal       decimal.Decimal `json:"subtotal" db:"subtotal"`
+	DiscountAmount decimal.Decimal `json:"discount_amount" db:"discount_amount"`
+	TaxAmount      decimal.Decimal `json:"tax_amount" db:"tax_amount"`
+	ShippingFee    decimal.Decimal `json:"shipping_fee" db:"shipping_fee"`
+	TotalAmount    decimal.Decimal `json:"total_amount" db:"total_amount"`
+	ProductName    string          `json:"product_name" db:"product_name"`
+	Color          string          `json:"color" db:"color"`
+	Quantity       int64           `json:"quantity" db:"quantity"`
+	UnitPrice      decimal.Decimal `json:"unit_price" db:"unit_price"`
+	VATRate        decimal.Decimal `json:"vat_rate" db:"vat_rate"`
+	LineTaxAmount  decimal.Decimal `json:"line_tax_amount" db:"line_tax_amount"`
+	LineAmount     decimal.Decimal `json:"line_amount" db:"line_amount"`
+}
+
+// Backorder is a line of a pending order waiting for stock
+type Backorder struct {
+	ID          int64  `json:"id" db:"id"`
+	OrderID     int64  `json:"order_id" db:"order_id"`
+	OrderCode   string `json:"order_code" db:"order_code"`
+	Backordered int64  `json:"backordered" db:"backordered"`
+}
+
+// CODOrder is a COD order whose collection is not reconciled yet, Since is
+// when it was delivered, or placed for orders never delivered
+type CODOrder struct {
+	OrderCode      string          `json:"order_code" db:"order_code"`
+	OrderStatus    string          `json:"order_status" db:"order_status"`
+	ExpectedAmount decimal.Decimal `json:"expected_amount" db:"expected_amount"`
+	RemittedAmount decimal.Decimal `json:"remitted_amount" db:"remitted_amount"`
+	Status         string          `json:"status" db:"status"`
+	Since          time.Time       `json:"since" db:"since"`
+}
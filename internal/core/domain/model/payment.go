@@ -0,0 +1,92 @@
+package model
+
+import (
+	"net/url"
+	"time"
+
+	"github.com/swclabs/swipex/internal/core/domain/enum"
+
+	"github.com/shopspring/decimal"
+)
+
+// PaymentOrder is the payment of an order asked to a payment provider,
+// Amount is in VND
+type PaymentOrder struct {
+	OrderCode   string
+	Amount      int64
+	Description string
+	OrderType   string
+	BankCode    string
+	Language    string
+	IPAddress   string
+}
+
+// PaymentCreated is where the customer pays an order. Reference is what the
+// provider needs later to query or refund the payment.
+type PaymentCreated struct {
+	URL       string
+	Reference string
+	Message   string
+}
+
+// PaymentCallbackRequest is a callback of a payment provider, from the
+// customer redirected back in Query or from the provider server in Body
+type PaymentCallbackRequest struct {
+	Query url.Values
+	Body  []byte
+}
+
+// PaymentCallback is a callback whose signature was verified, Amount is in
+// VND
+type PaymentCallback struct {
+	OrderCode     string
+	Amount        decimal.Decimal
+	Success       bool
+	PaidAt        time.Time
+	TransactionNo string
+	BankCode      string
+	ResponseCode  string
+	Message       string
+}
+
+// PaymentRef identifies a payment made with a provider, Reference is the one
+// returned when the payment was created
+type PaymentRef struct {
+	OrderCode     string
+	Reference     string
+	TransactionNo string
+	Amount        decimal.Decimal
+	IPAddress     string
+}
+
+// PaymentState is the status of a payment at its provider
+type PaymentState struct {
+	Status        enum.PaymentStatus
+	TransactionNo string
+	Amount        decimal.Decimal
+	ResponseCode  string
+	Message       string
+}
+
+// PaymentRefund asks a provider to pay back Amount of a payment, RefundID
+// is unique to the refund and Full tells whether the whole payment is paid
+// back. The ids the refund is asked with at the provider are derived from
+// RefundID and CreatedAt, so that asking again names the same refund.
+type PaymentRefund struct {
+	Payment   PaymentRef
+	RefundID  string
+	Amount    decimal.Decimal
+	Full      bool
+	Reason    string
+	Actor     string
+	CreatedAt time.Time
+}
+
+// PaymentRefunded is the answer of a provider to a refund, RefundNo is its
+// reference of the refund
+type PaymentRefunded struct {
+	Status       enum.RefundStatus
+	RefundNo     string
+	ResponseCode string
+	Message      string
+}
@@ -11,4 +11,10 @@ type Users struct {
 	Image       string `json:"image" validate:"required" db:"image"`
 	Username    string `json:"username" validate:"required" db:"username"`
 	Role        string `json:"role" validate:"required" db:"role"`
+
+	CartReminderOptOut bool `json:"cart_reminder_opt_out" db:"cart_reminder_opt_out"`
+
+	LoyaltyPoints  int64  `json:"loyalty_points" db:"loyalty_points"`
+	LifetimePoints int64  `json:"lifetime_points" db:"lifetime_points"`
+	LoyaltyTier    string `json:"loyalty_tier" db:"-"`
 }
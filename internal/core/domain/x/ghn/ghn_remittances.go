@@ -0,0 +1,24 @@
+package ghn
+
+// RemittanceQuery asks for the COD remittances transferred in a period,
+// as unix timestamps
+type RemittanceQuery struct {
+	FromTime int64 `json:"from_time"`
+	ToTime   int64 `json:"to_time"`
+}
+
+// RemittanceDTO is the list of COD remittances of the shop
+type RemittanceDTO struct {
+	Code    int          `json:"code"`
+	Message string       `json:"message"`
+	Data    []Remittance `json:"data"`
+}
+
+// Remittance is an order whose collected cash was transferred to the shop
+type Remittance struct {
+	RemittanceCode  string `json:"remittance_code"`
+	OrderCode       string `json:"order_code"`
+	ClientOrderCode string `json:"client_order_code"`
+	CODAmount       int    `json:"cod_amount"`
+	CODTransferDate string `json:"cod_transfer_date"`
+}
@@ -0,0 +1,136 @@
+// Package momo contains the requests and responses of the MoMo payment API
+package momo
+
+// Account is the partner account of the MoMo payment API
+type Account struct {
+	Endpoint    string
+	PartnerCode string
+	AccessKey   string
+	SecretKey   string
+	RedirectURL string
+	IPNURL      string
+}
+
+// CreateRequest asks for the payment URL of an order
+type CreateRequest struct {
+	PartnerCode string `json:"partnerCode"`
+	RequestID   string `json:"requestId"`
+	Amount      int64  `json:"amount"`
+	OrderID     string `json:"orderId"`
+	OrderInfo   string `json:"orderInfo"`
+	RedirectURL string `json:"redirectUrl"`
+	IpnURL      string `json:"ipnUrl"`
+	RequestType string `json:"requestType"`
+	ExtraData   string `json:"extraData"`
+	Lang        string `json:"lang"`
+	Signature   string `json:"signature"`
+}
+
+// CreateResponse is the payment URL of an order
+type CreateResponse struct {
+	PartnerCode  string `json:"partnerCode"`
+	RequestID    string `json:"requestId"`
+	OrderID      string `json:"orderId"`
+	Amount       int64  `json:"amount"`
+	ResponseTime int64  `json:"responseTime"`
+	Message      string `json:"message"`
+	ResultCode   int    `json:"resultCode"`
+	PayURL       string `json:"payUrl"`
+}
+
+// Callback is the result of a payment, posted to the IPN URL or added to
+// the redirect URL
+type Callback struct {
+	PartnerCode  string `json:"partnerCode"`
+	OrderID      string `json:"orderId"`
+	RequestID    string `json:"requestId"`
+	Amount       int64  `json:"amount"`
+	OrderInfo    string `json:"orderInfo"`
+	OrderType    string `json:"orderType"`
+	TransID      int64  `json:"transId"`
+	ResultCode   int    `json:"resultCode"`
+	Message      string `json:"message"`
+	PayType      string `json:"payType"`
+	ResponseTime int64  `json:"responseTime"`
+	ExtraData    string `json:"extraData"`
+	Signature    string `json:"signature"`
+}
+
+// QueryRequest asks for the status of a payment
+type QueryRequest struct {
+	PartnerCode string `json:"partnerCode"`
+	RequestID   string `json:"requestId"`
+	OrderID     string `json:"orderId"`
+	Lang        string `json:"lang"`
+	Signature   string `json:"signature"`
+}
+
+// QueryResponse is the status of a payment
+type QueryResponse struct {
+	PartnerCode  string `json:"partnerCode"`
+	RequestID    string `json:"requestId"`
+	OrderID      string `json:"orderId"`
+	Amount       int64  `json:"amount"`
+	TransID      int64  `json:"transId"`
+	ResultCode   int    `json:"resultCode"`
+	Message      string `json:"message"`
+	PayType      string `json:"payType"`
+	ResponseTime int64  `json:"responseTime"`
+}
+
+// RefundRequest asks to pay back Amount of a payment, OrderID is unique to
+// the refund
+type RefundRequest struct {
+	PartnerCode string `json:"partnerCode"`
+	OrderID     string `json:"orderId"`
+	RequestID   string `json:"requestId"`
+	Amount      int64  `json:"amount"`
+	TransID     int64  `json:"transId"`
+	Lang        string `json:"lang"`
+	Description string `json:"description"`
+	Signature   string `json:"signature"`
+}
+
+// RefundResponse is the result of a refund, TransID is the MoMo transaction
+// of the refund
+type RefundResponse struct {
+	PartnerCode  string `json:"partnerCode"`
+	OrderID      string `json:"orderId"`
+	RequestID    string `json:"requestId"`
+	Amount       int64  `json:"amount"`
+	TransID      int64  `json:"transId"`
+	ResultCode   int    `json:"resultCode"`
+	Message      string `json:"message"`
+	ResponseTime int64  `json:"responseTime"`
+}
+
+// RefundQueryRequest asks for the refunds of a payment, OrderID is the id
+// of the payment
+type RefundQueryRequest struct {
+	PartnerCode string `json:"partnerCode"`
+	RequestID   string `json:"requestId"`
+	OrderID     string `json:"orderId"`
+	Lang        string `json:"lang"`
+	Signature   string `json:"signature"`
+}
+
+// RefundQueryResponse lists the refunds of a payment
+type RefundQueryResponse struct {
+	PartnerCode  string        `json:"partnerCode"`
+	OrderID      string        `json:"orderId"`
+	RequestID    string        `json:"requestId"`
+	ResultCode   int           `json:"resultCode"`
+	Message      string        `json:"message"`
+	ResponseTime int64         `json:"responseTime"`
+	RefundTrans  []RefundTrans `json:"refundTrans"`
+}
+
+// RefundTrans is a refund of a payment, OrderID is the id of the refund and
+// TransID its MoMo transaction
+type RefundTrans struct {
+	OrderID     string `json:"orderId"`
+	Amount      int64  `json:"amount"`
+	ResultCode  int    `json:"resultCode"`
+	TransID     int64  `json:"transId"`
+	CreatedTime int64  `json:"createdTime"`
+}
@@ -0,0 +1,130 @@
+// Package zalopay contains the requests and responses of the ZaloPay
+// payment API
+package zalopay
+
+// Account is the merchant application of the ZaloPay payment API, Key1
+// signs the requests and Key2 the callbacks
+type Account struct {
+	Endpoint    string
+	AppID       int64
+	Key1        string
+	Key2        string
+	RedirectURL string
+	CallbackURL string
+}
+
+// CreateRequest asks for the payment URL of an order, AppTransID is unique
+// to the payment and starts with its date as yymmdd
+type CreateRequest struct {
+	AppID       int64  `json:"app_id"`
+	AppUser     string `json:"app_user"`
+	AppTransID  string `json:"app_trans_id"`
+	AppTime     int64  `json:"app_time"`
+	Amount      int64  `json:"amount"`
+	Item        string `json:"item"`
+	Description string `json:"description"`
+	EmbedData   string `json:"embed_data"`
+	BankCode    string `json:"bank_code"`
+	CallbackURL string `json:"callback_url"`
+	Mac         string `json:"mac"`
+}
+
+// CreateResponse is the payment URL of an order, ReturnCode is 1 when the
+// order was created
+type CreateResponse struct {
+	ReturnCode       int    `json:"return_code"`
+	ReturnMessage    string `json:"return_message"`
+	SubReturnCode    int    `json:"sub_return_code"`
+	SubReturnMessage string `json:"sub_return_message"`
+	OrderURL         string `json:"order_url"`
+	ZpTransToken     string `json:"zp_trans_token"`
+}
+
+// Callback is posted to the callback URL once a payment succeeded, Data is
+// a CallbackData in JSON signed by Mac
+type Callback struct {
+	Data string `json:"data"`
+	Mac  string `json:"mac"`
+	Type int    `json:"type"`
+}
+
+// CallbackData is the payment of a callback
+type CallbackData struct {
+	AppID          int64  `json:"app_id"`
+	AppTransID     string `json:"app_trans_id"`
+	AppTime        int64  `json:"app_time"`
+	AppUser        string `json:"app_user"`
+	Amount         int64  `json:"amount"`
+	EmbedData      string `json:"embed_data"`
+	Item           string `json:"item"`
+	ZpTransID      int64  `json:"zp_trans_id"`
+	ServerTime     int64  `json:"server_time"`
+	Channel        int    `json:"channel"`
+	MerchantUserID string `json:"merchant_user_id"`
+	UserFeeAmount  int64  `json:"user_fee_amount"`
+	DiscountAmount int64  `json:"discount_amount"`
+}
+
+// CallbackAck is the answer ZaloPay expects from the callback URL
+type CallbackAck struct {
+	ReturnCode    int    `json:"return_code"`
+	ReturnMessage string `json:"return_message"`
+}
+
+// QueryRequest asks for the status of a payment
+type QueryRequest struct {
+	AppID      int64  `json:"app_id"`
+	AppTransID string `json:"app_trans_id"`
+	Mac        string `json:"mac"`
+}
+
+// QueryResponse is the status of a payment, ReturnCode is 1 when it
+// succeeded, 2 when it failed and 3 while it is processed
+type QueryResponse struct {
+	ReturnCode       int    `json:"return_code"`
+	ReturnMessage    string `json:"return_message"`
+	SubReturnCode    int    `json:"sub_return_code"`
+	SubReturnMessage string `json:"sub_return_message"`
+	IsProcessing     bool   `json:"is_processing"`
+	Amount           int64  `json:"amount"`
+	ZpTransID        int64  `json:"zp_trans_id"`
+}
+
+// RefundRequest asks to pay back Amount of a payment, MRefundID is unique to
+// the refund and formatted as yymmdd_appid_id
+type RefundRequest struct {
+	AppID       int64  `json:"app_id"`
+	MRefundID   string `json:"m_refund_id"`
+	ZpTransID   string `json:"zp_trans_id"`
+	Amount      int64  `json:"amount"`
+	Timestamp   int64  `json:"timestamp"`
+	Description string `json:"description"`
+	Mac         string `json:"mac"`
+}
+
+// RefundResponse is the result of a refund, ReturnCode is 1 when it
+// succeeded, 2 when it failed and 3 while it is processed
+type RefundResponse struct {
+	ReturnCode       int    `json:"return_code"`
+	ReturnMessage    string `json:"return_message"`
+	SubReturnCode    int    `json:"sub_return_code"`
+	SubReturnMessage string `json:"sub_return_message"`
+	RefundID         int64  `json:"refund_id"`
+}
+
+// RefundQueryRequest asks for the status of a refund
+type RefundQueryRequest struct {
+	AppID     int64  `json:"app_id"`
+	MRefundID string `json:"m_refund_id"`
+	Timestamp int64  `json:"timestamp"`
+	Mac       string `json:"mac"`
+}
+
+// RefundQueryResponse is the status of a refund, ReturnCode is 1 when it
+// succeeded, 2 when it failed and 3 while it is processed
+type RefundQueryResponse struct {
+	ReturnCode       int    `json:"return_code"`
+	ReturnMessage    string `json:"return_message"`
+	SubReturnCode    int    `json:"sub_return_code"`
+	SubReturnMessage string `json:"sub_return_message"`
+}
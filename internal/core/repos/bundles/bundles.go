@@ -0,0 +1,113 @@
+// Package bundles implements bundles repos
+package bundles
+
+import (
+	"context"
+
+	"github.com/swclabs/swipex/app"
+	"github.com/swclabs/swipex/internal/core/domain/entity"
+	"github.com/swclabs/swipex/pkg/infra/db"
+)
+
+var _ = app.Repos(New)
+
+// New creates a new Bundles object
+func New(conn db.IDatabase) IBundles {
+	return &Bundles{db: conn}
+}
+
+var _ IBundles = (*Bundles)(nil)
+
+// Bundles represents the repos for bundles
+type Bundles struct {
+	db db.IDatabase
+}
+
+// Insert implements IBundles.
+func (b *Bundles) Insert(ctx context.Context, bundle entity.Bundle) (int64, error) {
+	return b.db.SafeWriteReturn(ctx, insert,
+		bundle.Name, bundle.Description, bundle.Image,
+		bundle.Price.String(), bundle.CurrencyCode, bundle.Status,
+	)
+}
+
+// Update implements IBundles.
+func (b *Bundles) Update(ctx context.Context, bundle entity.Bundle) error {
+	_, err := b.db.SafeWriteReturn(ctx, update, bundle.ID,
+		bundle.Name, bundle.Description, bundle.Image,
+		bundle.Price.String(), bundle.CurrencyCode, bundle.Status,
+	)
+	return err
+}
+
+// Delete implements IBundles.
+func (b *Bundles) Delete(ctx context.Context, bundleID int64) error {
+	_, err := b.db.SafeWriteReturn(ctx, deleteByID, bundleID)
+	return err
+}
+
+// GetByID implements IBundles.
+func (b *Bundles) GetByID(ctx context.Context, bundleID int64) (*entity.Bundle, error) {
+	rows, err := b.db.Query(ctx, selectByID, bundleID)
+	if err != nil {
+		return nil, err
+	}
+	bundle, err := db.CollectRow[entity.Bundle](rows)
+	if err != nil {
+		return nil, err
+	}
+	return &bundle, nil
+}
+
+// GetList implements IBundles.
+func (b *Bundles) GetList(ctx context.Context, status string, limit, offset int) ([]entity.Bundle, error) {
+	rows, err := b.db.Query(ctx, selectList, status, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	return db.CollectRows[entity.Bundle](rows)
+}
+
+// GetItems implements IBundles.
+func (b *Bundles) GetItems(ctx context.Context, bundleID int64) ([]entity.BundleItem, error) {
+	rows, err := b.db.Query(ctx, selectItems, bundleID)
+	if err != nil {
+		return nil, err
+	}
+	return db.CollectRows[entity.BundleItem](rows)
+}
+
+// InsertItem implements IBundles.
+func (b *Bundles) InsertItem(ctx context.Context, item entity.BundleItem) error {
+	return b.db.SafeWrite(ctx, insertItem, item.BundleID, item.InventoryID, item.Quantity)
+}
+
+// DeleteItems implements IBundles.
+func (b *Bundles) DeleteItems(ctx context.Context, bundleID int64) error {
+	return b.db.SafeWrite(ctx, deleteItems, bundleID)
+}
+
+// AddToCart implements IBundles.
+func (b *Bundles) AddToCart(ctx context.Context, cart entity.CartBundle) error {
+	return b.db.SafeWrite(ctx, addToCart, cart.UserID, cart.BundleID, cart.Quantity)
+}
+
+// UpdateCartQuantity implements IBundles.
+func (b *Bundles) UpdateCartQuantity(ctx context.Context, userID, bundleID int64, quantity int64) error {
+	_, err := b.db.SafeWriteReturn(ctx, updateCartQuantity, userID, bundleID, quantity)
+	return err
+}
+
+// RemoveFromCart implements IBundles.
+func (b *Bundles) RemoveFromCart(ctx context.Context, userID, bundleID int64) error {
+	return b.db.SafeWrite(ctx, removeFromCart, userID, bundleID)
+}
+
+// GetCart implements IBundles.
+func (b *Bundles) GetCart(ctx context.Context, userID int64) ([]entity.CartBundle, error) {
+	rows, err := b.db.Query(ctx, selectCart, userID)
+	if err != nil {
+		return nil, err
+	}
+	return db.CollectRows[entity.CartBundle](rows)
+}
@@ -0,0 +1,49 @@
+package bundles
+
+import (
+	"context"
+
+	"github.com/swclabs/swipex/internal/core/domain/entity"
+)
+
+// IBundles interface for bundles repos
+type IBundles interface {
+	// Insert creates a bundle without its items
+	Insert(ctx context.Context, bundle entity.Bundle) (int64, error)
+
+	// Update replaces the fields of a bundle, pgx.ErrNoRows if there is none
+	Update(ctx context.Context, bundle entity.Bundle) error
+
+	// Delete removes a bundle with its items, pgx.ErrNoRows if there is none
+	Delete(ctx context.Context, bundleID int64) error
+
+	// GetByID returns a bundle
+	GetByID(ctx context.Context, bundleID int64) (*entity.Bundle, error)
+
+	// GetList lists the bundles with the given status, all of them when
+	// status is empty, newest first
+	GetList(ctx context.Context, status string, limit, offset int) ([]entity.Bundle, error)
+
+	// GetItems returns the components of a bundle
+	GetItems(ctx context.Context, bundleID int64) ([]entity.BundleItem, error)
+
+	// InsertItem adds a component to a bundle
+	InsertItem(ctx context.Context, item entity.BundleItem) error
+
+	// DeleteItems removes the components of a bundle
+	DeleteItems(ctx context.Context, bundleID int64) error
+
+	// AddToCart puts a bundle in the cart of a user, adding to the quantity
+	// already there
+	AddToCart(ctx context.Context, cart entity.CartBundle) error
+
+	// UpdateCartQuantity sets the quantity of a bundle in a cart,
+	// pgx.ErrNoRows if it is not there
+	UpdateCartQuantity(ctx context.Context, userID, bundleID int64, quantity int64) error
+
+	// RemoveFromCart takes a bundle out of the cart of a user
+	RemoveFromCart(ctx context.Context, userID, bundleID int64) error
+
+	// GetCart returns the bundles in the cart of a user
+	GetCart(ctx context.Context, userID int64) ([]entity.CartBundle, error)
+}
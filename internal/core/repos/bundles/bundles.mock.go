@@ -0,0 +1,80 @@
+package bundles
+
+import (
+	"context"
+
+	"github.com/swclabs/swipex/internal/core/domain/entity"
+
+	"github.com/stretchr/testify/mock"
+)
+
+var _ IBundles = (*Mock)(nil)
+
+// Mock represents a mock for IBundles.
+type Mock struct {
+	mock.Mock
+}
+
+// Insert implements IBundles.
+func (b *Mock) Insert(_ context.Context, _ entity.Bundle) (int64, error) {
+	panic("unimplemented")
+}
+
+// Update implements IBundles.
+func (b *Mock) Update(_ context.Context, _ entity.Bundle) error {
+	panic("unimplemented")
+}
+
+// Delete implements IBundles.
+func (b *Mock) Delete(_ context.Context, _ int64) error {
+	panic("unimplemented")
+}
+
+// GetByID implements IBundles.
+func (b *Mock) GetByID(ctx context.Context, bundleID int64) (*entity.Bundle, error) {
+	args := b.Called(ctx, bundleID)
+	bundle, _ := args.Get(0).(*entity.Bundle)
+	return bundle, args.Error(1)
+}
+
+// GetList implements IBundles.
+func (b *Mock) GetList(_ context.Context, _ string, _, _ int) ([]entity.Bundle, error) {
+	panic("unimplemented")
+}
+
+// GetItems implements IBundles.
+func (b *Mock) GetItems(ctx context.Context, bundleID int64) ([]entity.BundleItem, error) {
+	args := b.Called(ctx, bundleID)
+	items, _ := args.Get(0).([]entity.BundleItem)
+	return items, args.Error(1)
+}
+
+// InsertItem implements IBundles.
+func (b *Mock) InsertItem(_ context.Context, _ entity.BundleItem) error {
+	panic("unimplemented")
+}
+
+// DeleteItems implements IBundles.
+func (b *Mock) DeleteItems(_ context.Context, _ int64) error {
+	panic("unimplemented")
+}
+
+// AddToCart implements IBundles.
+func (b *Mock) AddToCart(_ context.Context, _ entity.CartBundle) error {
+	panic("unimplemented")
+}
+
+// UpdateCartQuantity implements IBundles.
+func (b *Mock) UpdateCartQuantity(_ context.Context, _, _ int64, _ int64) error {
+	panic("unimplemented")
+}
+
+// RemoveFromCart implements IBundles.
+func (b *Mock) RemoveFromCart(_ context.Context, _, _ int64) error {
+	panic("unimplemented")
+}
+
+// GetCart implements IBundles.
+func (b *Mock) GetCart(_ context.Context, _ int64) ([]entity.CartBundle, error) {
+	panic("unimplemented")
+}
@@ -0,0 +1,72 @@
+package bundles
+
+const (
+	insert = `
+		INSERT INTO bundles (name, description, image, price, currency_code, status)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id;
+	`
+
+	update = `
+		UPDATE bundles
+		SET name = $2, description = $3, image = $4, price = $5, currency_code = $6, status = $7
+		WHERE id = $1
+		RETURNING id;
+	`
+
+	deleteByID = `
+		DELETE FROM bundles
+		WHERE id = $1
+		RETURNING id;
+	`
+
+	selectByID = `
+		SELECT * FROM bundles WHERE id = $1;
+	`
+
+	selectList = `
+		SELECT * FROM bundles
+		WHERE ($1 = '' OR status = $1)
+		ORDER BY id DESC
+		LIMIT $2 OFFSET $3;
+	`
+
+	selectItems = `
+		SELECT * FROM bundle_items
+		WHERE bundle_id = $1
+		ORDER BY inventory_id ASC;
+	`
+
+	insertItem = `
+		INSERT INTO bundle_items (bundle_id, inventory_id, quantity)
+		VALUES ($1, $2, $3);
+	`
+
+	deleteItems = `
+		DELETE FROM bundle_items WHERE bundle_id = $1;
+	`
+
+	addToCart = `
+		INSERT INTO cart_bundles (user_id, bundle_id, quantity)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id, bundle_id)
+		DO UPDATE SET quantity = cart_bundles.quantity + EXCLUDED.quantity, updated_at = now() at time zone 'utc';
+	`
+
+	updateCartQuantity = `
+		UPDATE cart_bundles
+		SET quantity = $3, updated_at = now() at time zone 'utc'
+		WHERE user_id = $1 AND bundle_id = $2
+		RETURNING id;
+	`
+
+	removeFromCart = `
+		DELETE FROM cart_bundles WHERE user_id = $1 AND bundle_id = $2;
+	`
+
+	selectCart = `
+		SELECT * FROM cart_bundles
+		WHERE user_id = $1
+		ORDER BY id ASC;
+	`
+)
@@ -2,6 +2,7 @@ package carts
 
 import (
 	"context"
+	"time"
 
 	"github.com/swclabs/swipex/internal/core/domain/entity"
 	"github.com/swclabs/swipex/internal/core/domain/model"
@@ -46,3 +47,23 @@ func (c *_cache) Insert(ctx context.Context, cart entity.Cart) error {
 func (c *_cache) RemoveByID(ctx context.Context, ID int64) error {
 	return c.cart.RemoveByID(ctx, ID)
 }
+
+// GetByItemID implements ICarts.
+func (c *_cache) GetByItemID(ctx context.Context, userID int64, itemID int64) (*entity.Cart, error) {
+	return c.cart.GetByItemID(ctx, userID, itemID)
+}
+
+// UpdateQuantity implements ICarts.
+func (c *_cache) UpdateQuantity(ctx context.Context, userID int64, itemID int64, quantity int64) error {
+	return c.cart.UpdateQuantity(ctx, userID, itemID, quantity)
+}
+
+// Merge implements ICarts.
+func (c *_cache) Merge(ctx context.Context, cart entity.Cart) error {
+	return c.cart.Merge(ctx, cart)
+}
+
+// GetAbandoned implements ICarts.
+func (c *_cache) GetAbandoned(ctx context.Context, idleSince, remindedSince time.Time, limit int) ([]model.AbandonedCart, error) {
+	return c.cart.GetAbandoned(ctx, idleSince, remindedSince, limit)
+}
@@ -3,6 +3,7 @@ package carts
 
 import (
 	"context"
+	"time"
 
 	"github.com/swclabs/swipex/app"
 	"github.com/swclabs/swipex/internal/core/domain/entity"
@@ -65,11 +66,46 @@ func (c *Carts) GetCartByUserID(ctx context.Context, userID int64, limit int) ([
 // Insert implements domain.ICartRepository.
 func (c *Carts) Insert(ctx context.Context, cart entity.Cart) error {
 	return c.db.SafeWrite(ctx, insertItemToCart,
-		cart.UserID, cart.InventoryID, cart.Quantity,
+		cart.UserID, cart.InventoryID, cart.Quantity, cart.Price.String(),
 	)
 }
 
+// Merge implements ICarts.
+func (c *Carts) Merge(ctx context.Context, cart entity.Cart) error {
+	return c.db.SafeWrite(ctx, mergeItemToCart,
+		cart.UserID, cart.InventoryID, cart.Quantity, cart.Price.String(),
+	)
+}
+
+// GetByItemID implements ICarts.
+func (c *Carts) GetByItemID(ctx context.Context, userID int64, itemID int64) (*entity.Cart, error) {
+	rows, err := c.db.Query(ctx, selectByItemID, userID, itemID)
+	if err != nil {
+		return nil, err
+	}
+	cart, err := db.CollectRow[entity.Cart](rows)
+	if err != nil {
+		return nil, err
+	}
+	return &cart, nil
+}
+
+// UpdateQuantity implements ICarts.
+func (c *Carts) UpdateQuantity(ctx context.Context, userID int64, itemID int64, quantity int64) error {
+	_, err := c.db.SafeWriteReturn(ctx, updateQuantity, userID, itemID, quantity)
+	return err
+}
+
 // RemoveByID implements domain.ICartRepository.
 func (c *Carts) RemoveByID(ctx context.Context, ID int64) error {
 	return c.db.SafeWrite(ctx, deleteItem, ID)
 }
+
+// GetAbandoned implements ICarts.
+func (c *Carts) GetAbandoned(ctx context.Context, idleSince, remindedSince time.Time, limit int) ([]model.AbandonedCart, error) {
+	rows, err := c.db.Query(ctx, selectAbandoned, idleSince, remindedSince, limit)
+	if err != nil {
+		return nil, err
+	}
+	return db.CollectRows[model.AbandonedCart](rows)
+}
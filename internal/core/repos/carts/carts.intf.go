@@ -2,6 +2,7 @@ package carts
 
 import (
 	"context"
+	"time"
 
 	"github.com/swclabs/swipex/internal/core/domain/entity"
 	"github.com/swclabs/swipex/internal/core/domain/model"
@@ -21,4 +22,18 @@ type ICarts interface {
 	RemoveByID(ctx context.Context, ID int64) error
 
 	RemoveByItemID(ctx context.Context, userID, itemID int64) error
+
+	// GetByItemID returns the cart line of a user for an inventory item
+	GetByItemID(ctx context.Context, userID, itemID int64) (*entity.Cart, error)
+
+	// UpdateQuantity sets the quantity of a cart line, pgx.ErrNoRows if there is none
+	UpdateQuantity(ctx context.Context, userID, itemID int64, quantity int64) error
+
+	// Merge adds a line to the cart, keeping the larger quantity if the item is already there
+	Merge(ctx context.Context, cart entity.Cart) error
+
+	// GetAbandoned returns the carts untouched since idleSince of the users
+	// who did not opt out, skipping carts reminded since remindedSince or not
+	// changed since their last reminder
+	GetAbandoned(ctx context.Context, idleSince, remindedSince time.Time, limit int) ([]model.AbandonedCart, error)
 }
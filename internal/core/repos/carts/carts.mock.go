@@ -3,6 +3,7 @@ package carts
 
 import (
 	"context"
+	"time"
 
 	"github.com/swclabs/swipex/internal/core/domain/entity"
 	"github.com/swclabs/swipex/internal/core/domain/model"
@@ -46,3 +47,24 @@ func (c *Mock) Insert(_ context.Context, _ entity.Cart) error {
 func (c *Mock) RemoveByID(_ context.Context, _ int64) error {
 	panic("unimplemented")
 }
+
+// GetByItemID implements ICarts.
+func (c *Mock) GetByItemID(_ context.Context, _ int64, _ int64) (*entity.Cart, error) {
+	panic("unimplemented")
+}
+
+// UpdateQuantity implements ICarts.
+func (c *Mock) UpdateQuantity(_ context.Context, _ int64, _ int64, _ int64) error {
+	panic("unimplemented")
+}
+
+// Merge implements ICarts.
+func (c *Mock) Merge(_ context.Context, _ entity.Cart) error {
+	panic("unimplemented")
+}
+
+// GetAbandoned implements ICarts.
+func (c *Mock) GetAbandoned(ctx context.Context, from, to time.Time, limit int) ([]model.AbandonedCart, error) {
+	args := c.Called(ctx, from, to, limit)
+	return args.Get(0).([]model.AbandonedCart), args.Error(1)
+}
@@ -2,10 +2,52 @@ package carts
 
 const (
 	insertItemToCart = `
-		INSERT INTO carts (user_id, inventory_id, quantity)
-		VALUES ($1, $2, $3)
+		INSERT INTO carts (user_id, inventory_id, quantity, price)
+		VALUES ($1, $2, $3, $4)
 		ON CONFLICT (inventory_id, user_id)
-		DO UPDATE SET quantity = carts.quantity + EXCLUDED.quantity;
+		DO UPDATE SET quantity = carts.quantity + EXCLUDED.quantity, price = EXCLUDED.price, updated_at = now() at time zone 'utc';
+	`
+
+	mergeItemToCart = `
+		INSERT INTO carts (user_id, inventory_id, quantity, price)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (inventory_id, user_id)
+		DO UPDATE SET quantity = GREATEST(carts.quantity, EXCLUDED.quantity), updated_at = now() at time zone 'utc';
+	`
+
+	selectAbandoned = `
+		SELECT
+			carts.user_id,
+			users.email,
+			users.first_name,
+			users.last_name,
+			COUNT(carts.id)::bigint AS items,
+			MAX(carts.updated_at) AS updated_at
+		FROM carts
+		JOIN users ON users.id = carts.user_id
+		WHERE users.cart_reminder_opt_out = false
+		GROUP BY carts.user_id, users.email, users.first_name, users.last_name
+		HAVING MAX(carts.updated_at) < $1
+			AND NOT EXISTS (
+				SELECT 1 FROM cart_reminders
+				WHERE cart_reminders.user_id = carts.user_id
+					AND (cart_reminders.sent_at > $2
+						OR cart_reminders.cart_updated_at >= MAX(carts.updated_at))
+			)
+		ORDER BY updated_at
+		LIMIT $3;
+	`
+
+	selectByItemID = `
+		SELECT * FROM carts
+		WHERE user_id = $1 AND inventory_id = $2;
+	`
+
+	updateQuantity = `
+		UPDATE carts
+		SET quantity = $3, updated_at = now() at time zone 'utc'
+		WHERE user_id = $1 AND inventory_id = $2
+		RETURNING id;
 	`
 
 	selectByUserID = `
@@ -31,11 +73,15 @@ const (
 			quantity,
 			product_id,
 			inventory_price,
+			added_price,
+			available,
+			inventory_status,
 			currency_code,
 			color,
 			inventory_specs,
 			products.name AS name,
 			image AS inventory_image,
+			categories.id AS category_id,
 			categories.name AS category_name
 		FROM
 		(
@@ -46,7 +92,10 @@ const (
 				product_id,
 				inventory_id,
 				currency_code,
-				price AS inventory_price,
+				added_price,
+				available,
+				inventories.status AS inventory_status,
+				inventories.price AS inventory_price,
 				image AS inventory_image,
 				inventories.specs AS inventory_specs
 			FROM
@@ -54,7 +103,8 @@ const (
 				SELECT
 					id AS cart_id,
 					inventory_id,
-					quantity
+					quantity,
+					price AS added_price
 				FROM
 					carts
 				WHERE
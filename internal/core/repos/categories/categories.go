@@ -44,7 +44,7 @@ func (category *Categories) GetByID(ctx context.Context, ID int64) (*entity.Cate
 // Insert implements ICategoriesRepository.
 func (category *Categories) Insert(ctx context.Context, ctg entity.Category) error {
 	return category.db.SafeWrite(
-		ctx, insertIntoCategory, ctg.Name, ctg.Description)
+		ctx, insertIntoCategory, ctg.Name, ctg.Description, ctg.VATRate)
 }
 
 // GetLimit implements ICategoriesRepository.
@@ -73,6 +73,7 @@ func (category *Categories) Update(ctx context.Context, ctg entity.Category) err
 			ctg.ID,
 			ctg.Name,
 			ctg.Description,
+			ctg.VATRate,
 		),
 	)
 }
@@ -2,8 +2,8 @@ package categories
 
 const (
 	insertIntoCategory = `
-		INSERT INTO categories (name, description) 
-		VALUES ($1, $2)
+		INSERT INTO categories (name, description, vat_rate)
+		VALUES ($1, $2, $3)
 	`
 
 	selectCategoryLimit string = `
@@ -24,7 +24,7 @@ const (
 	`
 
 	updateCategories = `
-		UPDATE suppliers
+		UPDATE categories
 		SET name = CASE
 						WHEN $2 <> '' THEN $2
 						ELSE name
@@ -32,7 +32,8 @@ const (
 			description = CASE
 						WHEN $3 <> '' THEN $3
 						ELSE description
-					END
+					END,
+			vat_rate = COALESCE($4::numeric, vat_rate)
 		WHERE id = $1;
 	`
 )
@@ -0,0 +1,71 @@
+// Package cod implements cash on delivery repos
+package cod
+
+import (
+	"context"
+	"time"
+
+	"github.com/swclabs/swipex/app"
+	"github.com/swclabs/swipex/internal/core/domain/entity"
+	"github.com/swclabs/swipex/internal/core/domain/model"
+	"github.com/swclabs/swipex/pkg/infra/db"
+
+	"github.com/shopspring/decimal"
+)
+
+var _ = app.Repos(New)
+
+// New creates a new COD object
+func New(conn db.IDatabase) ICOD {
+	return &COD{db: conn}
+}
+
+var _ ICOD = (*COD)(nil)
+
+// COD represents the repos for COD collections and remittances
+type COD struct {
+	db db.IDatabase
+}
+
+// InsertCollection implements ICOD.
+func (c *COD) InsertCollection(ctx context.Context, collection entity.CODCollection) error {
+	return c.db.SafeWrite(ctx, insertCollection,
+		collection.OrderID, collection.ExpectedAmount.String(),
+	)
+}
+
+// GetCollectionForUpdate implements ICOD.
+func (c *COD) GetCollectionForUpdate(ctx context.Context, orderID int64) (*entity.CODCollection, error) {
+	rows, err := c.db.Query(ctx, selectCollectionForUpdate, orderID)
+	if err != nil {
+		return nil, err
+	}
+	collection, err := db.CollectRow[entity.CODCollection](rows)
+	if err != nil {
+		return nil, err
+	}
+	return &collection, nil
+}
+
+// UpdateCollection implements ICOD.
+func (c *COD) UpdateCollection(ctx context.Context, orderID int64, remitted decimal.Decimal, status string) error {
+	return c.db.SafeWrite(ctx, updateCollection, orderID, remitted.String(), status)
+}
+
+// InsertRemittance implements ICOD.
+func (c *COD) InsertRemittance(ctx context.Context, remittance entity.CODRemittance) (int64, error) {
+	return c.db.SafeWriteReturn(ctx, insertRemittance,
+		remittance.Source, remittance.Reference, remittance.CarrierOrderCode, remittance.OrderCode,
+		remittance.OrderID, remittance.Amount.String(), remittance.Result, remittance.RemittedAt,
+		remittance.Actor,
+	)
+}
+
+// GetUnreconciled implements ICOD.
+func (c *COD) GetUnreconciled(ctx context.Context, before time.Time, limit, offset int) ([]model.CODOrder, error) {
+	rows, err := c.db.Query(ctx, selectUnreconciled, before.UTC(), limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	return db.CollectRows[model.CODOrder](rows)
+}
@@ -0,0 +1,32 @@
+package cod
+
+import (
+	"context"
+	"time"
+
+	"github.com/swclabs/swipex/internal/core/domain/entity"
+	"github.com/swclabs/swipex/internal/core/domain/model"
+
+	"github.com/shopspring/decimal"
+)
+
+// ICOD interface for the cash collected on delivery and the carrier
+// remittances it is reconciled against
+type ICOD interface {
+	// InsertCollection records the cash to collect for an order
+	InsertCollection(ctx context.Context, collection entity.CODCollection) error
+
+	// GetCollectionForUpdate returns the collection of an order and locks its row
+	GetCollectionForUpdate(ctx context.Context, orderID int64) (*entity.CODCollection, error)
+
+	// UpdateCollection sets the amount remitted for an order and its status
+	UpdateCollection(ctx context.Context, orderID int64, remitted decimal.Decimal, status string) error
+
+	// InsertRemittance records a line of a remittance report, it returns
+	// pgx.ErrNoRows when the line of the report was already imported
+	InsertRemittance(ctx context.Context, remittance entity.CODRemittance) (int64, error)
+
+	// GetUnreconciled lists the collections not reconciled since before
+	// the given time, oldest first
+	GetUnreconciled(ctx context.Context, before time.Time, limit, offset int) ([]model.CODOrder, error)
+}
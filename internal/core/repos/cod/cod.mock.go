@@ -0,0 +1,47 @@
+package cod
+
+import (
+	"context"
+	"time"
+
+	"github.com/swclabs/swipex/internal/core/domain/entity"
+	"github.com/swclabs/swipex/internal/core/domain/model"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/mock"
+)
+
+var _ ICOD = (*Mock)(nil)
+
+// Mock represents a mock for ICOD.
+type Mock struct {
+	mock.Mock
+}
+
+// InsertCollection implements ICOD.
+func (c *Mock) InsertCollection(ctx context.Context, collection entity.CODCollection) error {
+	return c.Called(ctx, collection).Error(0)
+}
+
+// GetCollectionForUpdate implements ICOD.
+func (c *Mock) GetCollectionForUpdate(ctx context.Context, orderID int64) (*entity.CODCollection, error) {
+	args := c.Called(ctx, orderID)
+	collection, _ := args.Get(0).(*entity.CODCollection)
+	return collection, args.Error(1)
+}
+
+// UpdateCollection implements ICOD.
+func (c *Mock) UpdateCollection(ctx context.Context, orderID int64, remitted decimal.Decimal, status string) error {
+	return c.Called(ctx, orderID, remitted, status).Error(0)
+}
+
+// InsertRemittance implements ICOD.
+func (c *Mock) InsertRemittance(ctx context.Context, remittance entity.CODRemittance) (int64, error) {
+	args := c.Called(ctx, remittance)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// GetUnreconciled implements ICOD.
+func (c *Mock) GetUnreconciled(_ context.Context, _ time.Time, _, _ int) ([]model.CODOrder, error) {
+	panic("unimplemented")
+}
@@ -0,0 +1,47 @@
+package cod
+
+const (
+	insertCollection = `
+		INSERT INTO cod_collections (order_id, expected_amount)
+		VALUES ($1, $2)
+		ON CONFLICT (order_id) DO NOTHING;
+	`
+
+	selectCollectionForUpdate = `
+		SELECT * FROM cod_collections WHERE order_id = $1 FOR UPDATE;
+	`
+
+	updateCollection = `
+		UPDATE cod_collections
+		SET remitted_amount = $2, status = $3,
+			reconciled_at = CASE WHEN $3 = 'reconciled' THEN now() at time zone 'utc' END
+		WHERE order_id = $1;
+	`
+
+	insertRemittance = `
+		INSERT INTO cod_remittances (source, reference, carrier_order_code, order_code,
+			order_id, amount, result, remitted_at, actor)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (reference, carrier_order_code) DO NOTHING
+		RETURNING id;
+	`
+
+	// a pending collection is only due once its order was delivered, the
+	// shortfalls and mismatches are reported whatever the order status
+	selectUnreconciled = `
+		SELECT orders.uuid AS order_code, orders.status AS order_status,
+			cod_collections.expected_amount, cod_collections.remitted_amount,
+			cod_collections.status, COALESCE(delivered.at, orders.time) AS since
+		FROM cod_collections
+		JOIN orders ON orders.id = cod_collections.order_id
+		LEFT JOIN LATERAL (
+			SELECT max(created_at) AS at FROM order_status_history
+			WHERE order_id = orders.id AND to_status = 'delivered'
+		) delivered ON true
+		WHERE cod_collections.status <> 'reconciled'
+			AND (orders.status = 'delivered' OR cod_collections.status <> 'pending')
+			AND COALESCE(delivered.at, orders.time) <= $1
+		ORDER BY since ASC, orders.id ASC
+		LIMIT $2 OFFSET $3;
+	`
+)
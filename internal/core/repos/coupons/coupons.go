@@ -18,6 +18,60 @@ type Coupon struct {
 	db db.IDatabase
 }
 
+// GetUsedByOrderID implements ICoupons.
+func (c *Coupon) GetUsedByOrderID(ctx context.Context, orderID int64) (*entity.CouponsUsed, error) {
+	rows, err := c.db.Query(ctx, getUsedByOrderID, orderID)
+	if err != nil {
+		return nil, err
+	}
+	used, err := db.CollectRow[entity.CouponsUsed](rows)
+	if err != nil {
+		return nil, err
+	}
+	return &used, nil
+}
+
+// DeleteUsed implements ICoupons.
+func (c *Coupon) DeleteUsed(ctx context.Context, ID int64) error {
+	return c.db.SafeWrite(ctx, deleteUsed, ID)
+}
+
+// DecreaseUsed implements ICoupons.
+func (c *Coupon) DecreaseUsed(ctx context.Context, code string) error {
+	return c.db.SafeWrite(ctx, decreaseUsed, code)
+}
+
+// GetByCodeForUpdate implements ICoupons.
+func (c *Coupon) GetByCodeForUpdate(ctx context.Context, code string) (*entity.Coupons, error) {
+	rows, err := c.db.Query(ctx, getByCodeForUpdate, code)
+	if err != nil {
+		return nil, err
+	}
+	coupon, err := db.CollectRow[entity.Coupons](rows)
+	if err != nil {
+		return nil, err
+	}
+	return &coupon, nil
+}
+
+// GetUsedByUser implements ICoupons.
+func (c *Coupon) GetUsedByUser(ctx context.Context, userID int64, code string) (*entity.CouponsUsed, error) {
+	rows, err := c.db.Query(ctx, getUsedByUser, userID, code)
+	if err != nil {
+		return nil, err
+	}
+	used, err := db.CollectRow[entity.CouponsUsed](rows)
+	if err != nil {
+		return nil, err
+	}
+	return &used, nil
+}
+
+// IncreaseUsed implements ICoupons.
+func (c *Coupon) IncreaseUsed(ctx context.Context, code string) error {
+	return c.db.SafeWrite(ctx, increaseUsed, code)
+}
+
 // Delete implements ICoupons.
 func (c *Coupon) Delete(ctx context.Context, code string) error {
 	return c.db.SafeWrite(ctx, delete, code)
@@ -64,12 +118,14 @@ func (c *Coupon) GetAll(ctx context.Context) ([]entity.Coupons, error) {
 
 // Create implements ICoupons.
 func (c *Coupon) Create(ctx context.Context, coupon entity.Coupons) error {
-	return c.db.SafeWrite(ctx, insert, coupon.Code, coupon.Discount,
-		coupon.Status, coupon.Used, coupon.MaxUse, coupon.Description, coupon.ExpiredAt)
+	return c.db.SafeWrite(ctx, insert, coupon.Code, coupon.Discount.String(),
+		coupon.Status, coupon.Used, coupon.MaxUse, coupon.Description, coupon.ExpiredAt,
+		coupon.DiscountType, coupon.MinOrderValue.String(), coupon.MaxDiscount.String(),
+		coupon.CategoryIDs, coupon.InventoryIDs)
 }
 
 // Use implements ICoupons.
 func (c *Coupon) Use(ctx context.Context, couponInfo entity.CouponsUsed) error {
-	return c.db.SafeWrite(ctx, useCoupons, couponInfo.CouponCode, couponInfo.UserID, couponInfo.CouponCode,
+	return c.db.SafeWrite(ctx, useCoupons, couponInfo.UserID, couponInfo.CouponCode,
 		couponInfo.OrderID, couponInfo.UsedAt)
 }
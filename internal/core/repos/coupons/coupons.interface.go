@@ -10,7 +10,13 @@ type ICoupons interface {
 	Create(ctx context.Context, coupon entity.Coupons) error
 	GetAll(ctx context.Context) ([]entity.Coupons, error)
 	GetByCode(ctx context.Context, code string) (*entity.Coupons, error)
+	GetByCodeForUpdate(ctx context.Context, code string) (*entity.Coupons, error)
+	GetUsedByUser(ctx context.Context, userID int64, code string) (*entity.CouponsUsed, error)
+	IncreaseUsed(ctx context.Context, code string) error
 	GetByUser(ctx context.Context, userID int64) ([]entity.CouponsUsed, error)
 	Use(ctx context.Context, couponInfo entity.CouponsUsed) error
 	Delete(ctx context.Context, code string) error
+	GetUsedByOrderID(ctx context.Context, orderID int64) (*entity.CouponsUsed, error)
+	DeleteUsed(ctx context.Context, ID int64) error
+	DecreaseUsed(ctx context.Context, code string) error
 }
@@ -0,0 +1,84 @@
+package coupons
+
+import (
+	"context"
+
+	"github.com/swclabs/swipex/internal/core/domain/entity"
+
+	"github.com/stretchr/testify/mock"
+)
+
+var _ ICoupons = (*Mock)(nil)
+
+// Mock represents a mock for ICoupons.
+type Mock struct {
+	mock.Mock
+}
+
+// Create implements ICoupons.
+func (c *Mock) Create(_ context.Context, _ entity.Coupons) error {
+	panic("unimplemented")
+}
+
+// GetAll implements ICoupons.
+func (c *Mock) GetAll(_ context.Context) ([]entity.Coupons, error) {
+	panic("unimplemented")
+}
+
+// GetByCode implements ICoupons.
+func (c *Mock) GetByCode(ctx context.Context, code string) (*entity.Coupons, error) {
+	args := c.Called(ctx, code)
+	result, _ := args.Get(0).(*entity.Coupons)
+	return result, args.Error(1)
+}
+
+// GetByCodeForUpdate implements ICoupons.
+func (c *Mock) GetByCodeForUpdate(ctx context.Context, code string) (*entity.Coupons, error) {
+	args := c.Called(ctx, code)
+	result, _ := args.Get(0).(*entity.Coupons)
+	return result, args.Error(1)
+}
+
+// GetUsedByUser implements ICoupons.
+func (c *Mock) GetUsedByUser(ctx context.Context, userID int64, code string) (*entity.CouponsUsed, error) {
+	args := c.Called(ctx, userID, code)
+	result, _ := args.Get(0).(*entity.CouponsUsed)
+	return result, args.Error(1)
+}
+
+// IncreaseUsed implements ICoupons.
+func (c *Mock) IncreaseUsed(ctx context.Context, code string) error {
+	return c.Called(ctx, code).Error(0)
+}
+
+// GetByUser implements ICoupons.
+func (c *Mock) GetByUser(_ context.Context, _ int64) ([]entity.CouponsUsed, error) {
+	panic("unimplemented")
+}
+
+// Use implements ICoupons.
+func (c *Mock) Use(ctx context.Context, couponInfo entity.CouponsUsed) error {
+	return c.Called(ctx, couponInfo).Error(0)
+}
+
+// Delete implements ICoupons.
+func (c *Mock) Delete(_ context.Context, _ string) error {
+	panic("unimplemented")
+}
+
+// GetUsedByOrderID implements ICoupons.
+func (c *Mock) GetUsedByOrderID(ctx context.Context, orderID int64) (*entity.CouponsUsed, error) {
+	args := c.Called(ctx, orderID)
+	result, _ := args.Get(0).(*entity.CouponsUsed)
+	return result, args.Error(1)
+}
+
+// DeleteUsed implements ICoupons.
+func (c *Mock) DeleteUsed(ctx context.Context, ID int64) error {
+	return c.Called(ctx, ID).Error(0)
+}
+
+// DecreaseUsed implements ICoupons.
+func (c *Mock) DecreaseUsed(ctx context.Context, code string) error {
+	return c.Called(ctx, code).Error(0)
+}
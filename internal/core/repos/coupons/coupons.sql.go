@@ -21,8 +21,9 @@ package coupons
 
 const (
 	insert = `
-		INSERT INTO coupons (code, discount, status, used, max_use, description, expired_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7);
+		INSERT INTO coupons (code, discount, status, used, max_use, description, expired_at,
+			discount_type, min_order_value, max_discount, category_ids, inventory_ids)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12);
 	`
 
 	useCoupons = `
@@ -42,7 +43,35 @@ const (
 		SELECT * FROM coupons WHERE code = $1;
 	`
 
+	getByCodeForUpdate = `
+		SELECT * FROM coupons WHERE code = $1 FOR UPDATE;
+	`
+
+	getUsedByUser = `
+		SELECT * FROM coupons_used WHERE user_id = $1 AND coupon_code = $2;
+	`
+
+	increaseUsed = `
+		UPDATE coupons
+		SET used = used + 1
+		WHERE code = $1;
+	`
+
 	delete = `
 		DELETE FROM coupons WHERE code = $1;
 	`
+
+	getUsedByOrderID = `
+		SELECT * FROM coupons_used WHERE order_id = $1;
+	`
+
+	deleteUsed = `
+		DELETE FROM coupons_used WHERE id = $1;
+	`
+
+	decreaseUsed = `
+		UPDATE coupons
+		SET used = used - 1
+		WHERE code = $1 AND used > 0;
+	`
 )
@@ -0,0 +1,141 @@
+// Package credits implements gift card and store credit repos
+package credits
+
+import (
+	"context"
+
+	"github.com/swclabs/swipex/app"
+	"github.com/swclabs/swipex/internal/core/domain/entity"
+	"github.com/swclabs/swipex/pkg/infra/db"
+
+	"github.com/shopspring/decimal"
+)
+
+var _ = app.Repos(New)
+
+// New creates a new Credits object
+func New(conn db.IDatabase) ICredits {
+	return &Credits{db: conn}
+}
+
+var _ ICredits = (*Credits)(nil)
+
+// Credits represents the repos for gift cards and wallets
+type Credits struct {
+	db db.IDatabase
+}
+
+// InsertGiftCard implements ICredits.
+func (c *Credits) InsertGiftCard(ctx context.Context, card entity.GiftCard) (int64, error) {
+	return c.db.SafeWriteReturn(ctx, insertGiftCard,
+		card.Code, card.InitialBalance.String(), card.Balance.String(), card.CurrencyCode,
+		card.Status, card.ExpiresAt, card.OrderID, card.Note,
+	)
+}
+
+// GetGiftCard implements ICredits.
+func (c *Credits) GetGiftCard(ctx context.Context, code string) (*entity.GiftCard, error) {
+	return c.giftCard(ctx, selectGiftCard, code)
+}
+
+// GetGiftCardForUpdate implements ICredits.
+func (c *Credits) GetGiftCardForUpdate(ctx context.Context, code string) (*entity.GiftCard, error) {
+	return c.giftCard(ctx, selectGiftCardForUpdate, code)
+}
+
+// GetGiftCardByIDForUpdate implements ICredits.
+func (c *Credits) GetGiftCardByIDForUpdate(ctx context.Context, id int64) (*entity.GiftCard, error) {
+	return c.giftCard(ctx, selectGiftCardByIDForUpdate, id)
+}
+
+func (c *Credits) giftCard(ctx context.Context, sql string, arg any) (*entity.GiftCard, error) {
+	rows, err := c.db.Query(ctx, sql, arg)
+	if err != nil {
+		return nil, err
+	}
+	card, err := db.CollectRow[entity.GiftCard](rows)
+	if err != nil {
+		return nil, err
+	}
+	return &card, nil
+}
+
+// GetGiftCards implements ICredits.
+func (c *Credits) GetGiftCards(ctx context.Context, status string, limit, offset int) ([]entity.GiftCard, error) {
+	rows, err := c.db.Query(ctx, selectGiftCards, status, limit, offset)
+	if err != nil {
+		return nil, err
+	}
+	return db.CollectRows[entity.GiftCard](rows)
+}
+
+// SetGiftCardBalance implements ICredits.
+func (c *Credits) SetGiftCardBalance(ctx context.Context, id int64, balance decimal.Decimal) error {
+	return c.db.SafeWrite(ctx, setGiftCardBalance, id, balance.String())
+}
+
+// VoidGiftCard implements ICredits.
+func (c *Credits) VoidGiftCard(ctx context.Context, id int64) error {
+	return c.db.SafeWrite(ctx, voidGiftCard, id)
+}
+
+// GetWallet implements ICredits.
+func (c *Credits) GetWallet(ctx context.Context, userID int64) (*entity.Wallet, error) {
+	return c.wallet(ctx, selectWallet, userID)
+}
+
+// GetWalletForUpdate implements ICredits.
+func (c *Credits) GetWalletForUpdate(ctx context.Context, userID int64) (*entity.Wallet, error) {
+	if err := c.db.SafeWrite(ctx, insertWallet, userID); err != nil {
+		return nil, err
+	}
+	return c.wallet(ctx, selectWalletForUpdate, userID)
+}
+
+func (c *Credits) wallet(ctx context.Context, sql string, userID int64) (*entity.Wallet, error) {
+	rows, err := c.db.Query(ctx, sql, userID)
+	if err != nil {
+		return nil, err
+	}
+	wallet, err := db.CollectRow[entity.Wallet](rows)
+	if err != nil {
+		return nil, err
+	}
+	return &wallet, nil
+}
+
+// SetWalletBalance implements ICredits.
+func (c *Credits) SetWalletBalance(ctx context.Context, userID int64, balance decimal.Decimal) error {
+	return c.db.SafeWrite(ctx, setWalletBalance, userID, balance.String())
+}
+
+// InsertEntry implements ICredits.
+func (c *Credits) InsertEntry(ctx context.Context, entry entity.CreditEntry) (int64, error) {
+	return c.db.SafeWriteReturn(ctx, insertEntry,
+		entry.GiftCardID, entry.UserID, entry.OrderID, entry.Amount.String(),
+		entry.Balance.String(), entry.Kind, entry.Actor, entry.Note,
+	)
+}
+
+// GetGiftCardEntries implements ICredits.
+func (c *Credits) GetGiftCardEntries(ctx context.Context, giftCardID int64) ([]entity.CreditEntry, error) {
+	return c.entries(ctx, selectGiftCardEntries, giftCardID)
+}
+
+// GetWalletEntries implements ICredits.
+func (c *Credits) GetWalletEntries(ctx context.Context, userID int64) ([]entity.CreditEntry, error) {
+	return c.entries(ctx, selectWalletEntries, userID)
+}
+
+// GetOrderEntries implements ICredits.
+func (c *Credits) GetOrderEntries(ctx context.Context, orderID int64) ([]entity.CreditEntry, error) {
+	return c.entries(ctx, selectOrderEntries, orderID)
+}
+
+func (c *Credits) entries(ctx context.Context, sql string, id int64) ([]entity.CreditEntry, error) {
+	rows, err := c.db.Query(ctx, sql, id)
+	if err != nil {
+		return nil, err
+	}
+	return db.CollectRows[entity.CreditEntry](rows)
+}
@@ -0,0 +1,58 @@
+package credits
+
+import (
+	"context"
+
+	"github.com/swclabs/swipex/internal/core/domain/entity"
+
+	"github.com/shopspring/decimal"
+)
+
+// ICredits interface for gift cards, wallets and their ledger. Balances
+// are only changed together with a ledger entry, on rows locked by the
+// ForUpdate getters.
+type ICredits interface {
+	// InsertGiftCard creates a gift card
+	InsertGiftCard(ctx context.Context, card entity.GiftCard) (int64, error)
+
+	// GetGiftCard returns a gift card by code
+	GetGiftCard(ctx context.Context, code string) (*entity.GiftCard, error)
+
+	// GetGiftCardForUpdate returns a gift card by code and locks its row
+	GetGiftCardForUpdate(ctx context.Context, code string) (*entity.GiftCard, error)
+
+	// GetGiftCardByIDForUpdate returns a gift card by ID and locks its row
+	GetGiftCardByIDForUpdate(ctx context.Context, id int64) (*entity.GiftCard, error)
+
+	// GetGiftCards lists the gift cards with the given status, all of them
+	// when status is empty, newest first
+	GetGiftCards(ctx context.Context, status string, limit, offset int) ([]entity.GiftCard, error)
+
+	// SetGiftCardBalance sets the balance of a gift card
+	SetGiftCardBalance(ctx context.Context, id int64, balance decimal.Decimal) error
+
+	// VoidGiftCard marks a gift card voided and empties it
+	VoidGiftCard(ctx context.Context, id int64) error
+
+	// GetWallet returns the wallet of a user, pgx.ErrNoRows if it has none
+	GetWallet(ctx context.Context, userID int64) (*entity.Wallet, error)
+
+	// GetWalletForUpdate returns the wallet of a user and locks its row, the
+	// wallet is created empty if the user has none
+	GetWalletForUpdate(ctx context.Context, userID int64) (*entity.Wallet, error)
+
+	// SetWalletBalance sets the balance of a wallet
+	SetWalletBalance(ctx context.Context, userID int64, balance decimal.Decimal) error
+
+	// InsertEntry appends an entry to the ledger
+	InsertEntry(ctx context.Context, entry entity.CreditEntry) (int64, error)
+
+	// GetGiftCardEntries returns the ledger of a gift card, oldest first
+	GetGiftCardEntries(ctx context.Context, giftCardID int64) ([]entity.CreditEntry, error)
+
+	// GetWalletEntries returns the ledger of a wallet, oldest first
+	GetWalletEntries(ctx context.Context, userID int64) ([]entity.CreditEntry, error)
+
+	// GetOrderEntries returns the ledger entries of an order, oldest first
+	GetOrderEntries(ctx context.Context, orderID int64) ([]entity.CreditEntry, error)
+}
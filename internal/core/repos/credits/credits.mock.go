@@ -0,0 +1,96 @@
+package credits
+
+import (
+	"context"
+
+	"github.com/swclabs/swipex/internal/core/domain/entity"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/mock"
+)
+
+var _ ICredits = (*Mock)(nil)
+
+// Mock represents a mock for ICredits.
+type Mock struct {
+	mock.Mock
+}
+
+// InsertGiftCard implements ICredits.
+func (c *Mock) InsertGiftCard(_ context.Context, _ entity.GiftCard) (int64, error) {
+	panic("unimplemented")
+}
+
+// GetGiftCard implements ICredits.
+func (c *Mock) GetGiftCard(_ context.Context, _ string) (*entity.GiftCard, error) {
+	panic("unimplemented")
+}
+
+// GetGiftCardForUpdate implements ICredits.
+func (c *Mock) GetGiftCardForUpdate(ctx context.Context, code string) (*entity.GiftCard, error) {
+	args := c.Called(ctx, code)
+	result, _ := args.Get(0).(*entity.GiftCard)
+	return result, args.Error(1)
+}
+
+// GetGiftCardByIDForUpdate implements ICredits.
+func (c *Mock) GetGiftCardByIDForUpdate(ctx context.Context, id int64) (*entity.GiftCard, error) {
+	args := c.Called(ctx, id)
+	result, _ := args.Get(0).(*entity.GiftCard)
+	return result, args.Error(1)
+}
+
+// GetGiftCards implements ICredits.
+func (c *Mock) GetGiftCards(_ context.Context, _ string, _ int, _ int) ([]entity.GiftCard, error) {
+	panic("unimplemented")
+}
+
+// SetGiftCardBalance implements ICredits.
+func (c *Mock) SetGiftCardBalance(ctx context.Context, id int64, balance decimal.Decimal) error {
+	return c.Called(ctx, id, balance).Error(0)
+}
+
+// VoidGiftCard implements ICredits.
+func (c *Mock) VoidGiftCard(_ context.Context, _ int64) error {
+	panic("unimplemented")
+}
+
+// GetWallet implements ICredits.
+func (c *Mock) GetWallet(_ context.Context, _ int64) (*entity.Wallet, error) {
+	panic("unimplemented")
+}
+
+// GetWalletForUpdate implements ICredits.
+func (c *Mock) GetWalletForUpdate(ctx context.Context, userID int64) (*entity.Wallet, error) {
+	args := c.Called(ctx, userID)
+	result, _ := args.Get(0).(*entity.Wallet)
+	return result, args.Error(1)
+}
+
+// SetWalletBalance implements ICredits.
+func (c *Mock) SetWalletBalance(ctx context.Context, userID int64, balance decimal.Decimal) error {
+	return c.Called(ctx, userID, balance).Error(0)
+}
+
+// InsertEntry implements ICredits.
+func (c *Mock) InsertEntry(ctx context.Context, entry entity.CreditEntry) (int64, error) {
+	args := c.Called(ctx, entry)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// GetGiftCardEntries implements ICredits.
+func (c *Mock) GetGiftCardEntries(_ context.Context, _ int64) ([]entity.CreditEntry, error) {
+	panic("unimplemented")
+}
+
+// GetWalletEntries implements ICredits.
+func (c *Mock) GetWalletEntries(_ context.Context, _ int64) ([]entity.CreditEntry, error) {
+	panic("unimplemented")
+}
+
+// GetOrderEntries implements ICredits.
+func (c *Mock) GetOrderEntries(ctx context.Context, orderID int64) ([]entity.CreditEntry, error) {
+	args := c.Called(ctx, orderID)
+	result, _ := args.Get(0).([]entity.CreditEntry)
+	return result, args.Error(1)
+}
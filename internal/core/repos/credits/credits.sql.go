@@ -0,0 +1,75 @@
+package credits
+
+const (
+	insertGiftCard = `
+		INSERT INTO gift_cards (code, initial_balance, balance, currency_code, status, expires_at, order_id, note)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id;
+	`
+
+	selectGiftCard = `
+		SELECT * FROM gift_cards WHERE code = $1;
+	`
+
+	selectGiftCardForUpdate = `
+		SELECT * FROM gift_cards WHERE code = $1 FOR UPDATE;
+	`
+
+	selectGiftCardByIDForUpdate = `
+		SELECT * FROM gift_cards WHERE id = $1 FOR UPDATE;
+	`
+
+	selectGiftCards = `
+		SELECT * FROM gift_cards
+		WHERE ($1 = '' OR status = $1)
+		ORDER BY id DESC
+		LIMIT $2 OFFSET $3;
+	`
+
+	setGiftCardBalance = `
+		UPDATE gift_cards SET balance = $2 WHERE id = $1;
+	`
+
+	voidGiftCard = `
+		UPDATE gift_cards
+		SET status = 'voided', balance = 0, voided_at = now() at time zone 'utc'
+		WHERE id = $1;
+	`
+
+	selectWallet = `
+		SELECT * FROM wallets WHERE user_id = $1;
+	`
+
+	insertWallet = `
+		INSERT INTO wallets (user_id) VALUES ($1)
+		ON CONFLICT (user_id) DO NOTHING;
+	`
+
+	selectWalletForUpdate = `
+		SELECT * FROM wallets WHERE user_id = $1 FOR UPDATE;
+	`
+
+	setWalletBalance = `
+		UPDATE wallets
+		SET balance = $2, updated_at = now() at time zone 'utc'
+		WHERE user_id = $1;
+	`
+
+	insertEntry = `
+		INSERT INTO credit_ledger (gift_card_id, user_id, order_id, amount, balance, kind, actor, note)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id;
+	`
+
+	selectGiftCardEntries = `
+		SELECT * FROM credit_ledger WHERE gift_card_id = $1 ORDER BY id ASC;
+	`
+
+	selectWalletEntries = `
+		SELECT * FROM credit_ledger WHERE user_id = $1 ORDER BY id ASC;
+	`
+
+	selectOrderEntries = `
+		SELECT * FROM credit_ledger WHERE order_id = $1 ORDER BY id ASC;
+	`
+)
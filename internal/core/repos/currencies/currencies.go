@@ -0,0 +1,63 @@
+// Package currencies implements exchange rate repos
+package currencies
+
+import (
+	"context"
+
+	"github.com/swclabs/swipex/app"
+	"github.com/swclabs/swipex/internal/config"
+	"github.com/swclabs/swipex/internal/core/domain/entity"
+	"github.com/swclabs/swipex/internal/core/domain/model"
+	"github.com/swclabs/swipex/pkg/infra/db"
+
+	"github.com/shopspring/decimal"
+)
+
+var _ = app.Repos(New)
+
+// New creates a new Currencies object
+func New(conn db.IDatabase) ICurrencies {
+	return &Currencies{db: conn}
+}
+
+var _ ICurrencies = (*Currencies)(nil)
+
+// Currencies represents the repos for exchange rates
+type Currencies struct {
+	db db.IDatabase
+}
+
+// GetRates implements ICurrencies.
+func (c *Currencies) GetRates(ctx context.Context) (model.ExchangeRates, error) {
+	rates, err := c.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := make(model.ExchangeRates, len(rates)+1)
+	for _, rate := range rates {
+		result[rate.CurrencyCode] = rate.Rate
+	}
+	result[config.BaseCurrency] = decimal.NewFromInt(1)
+	return result, nil
+}
+
+// GetAll implements ICurrencies.
+func (c *Currencies) GetAll(ctx context.Context) ([]entity.ExchangeRate, error) {
+	rows, err := c.db.Query(ctx, selectRates)
+	if err != nil {
+		return nil, err
+	}
+	return db.CollectRows[entity.ExchangeRate](rows)
+}
+
+// Upsert implements ICurrencies.
+func (c *Currencies) Upsert(ctx context.Context, rate entity.ExchangeRate) error {
+	return c.db.SafeWrite(ctx, upsertRate,
+		rate.CurrencyCode, rate.Rate.String(), rate.Source, rate.UpdatedBy,
+	)
+}
+
+// Delete implements ICurrencies.
+func (c *Currencies) Delete(ctx context.Context, currencyCode string) error {
+	return c.db.SafeWrite(ctx, deleteRate, currencyCode)
+}
@@ -0,0 +1,23 @@
+package currencies
+
+import (
+	"context"
+
+	"github.com/swclabs/swipex/internal/core/domain/entity"
+	"github.com/swclabs/swipex/internal/core/domain/model"
+)
+
+// ICurrencies interface for the exchange rates
+type ICurrencies interface {
+	// GetRates returns the rates of all currencies, with the base currency
+	GetRates(ctx context.Context) (model.ExchangeRates, error)
+
+	// GetAll lists the exchange rates by currency code
+	GetAll(ctx context.Context) ([]entity.ExchangeRate, error)
+
+	// Upsert creates or replaces the rate of a currency
+	Upsert(ctx context.Context, rate entity.ExchangeRate) error
+
+	// Delete deletes the rate of a currency
+	Delete(ctx context.Context, currencyCode string) error
+}
@@ -0,0 +1,39 @@
+package currencies
+
+import (
+	"context"
+
+	"github.com/swclabs/swipex/internal/core/domain/entity"
+	"github.com/swclabs/swipex/internal/core/domain/model"
+
+	"github.com/stretchr/testify/mock"
+)
+
+var _ ICurrencies = (*Mock)(nil)
+
+// Mock represents a mock for ICurrencies.
+type Mock struct {
+	mock.Mock
+}
+
+// GetRates implements ICurrencies.
+func (c *Mock) GetRates(ctx context.Context) (model.ExchangeRates, error) {
+	args := c.Called(ctx)
+	rates, _ := args.Get(0).(model.ExchangeRates)
+	return rates, args.Error(1)
+}
+
+// GetAll implements ICurrencies.
+func (c *Mock) GetAll(_ context.Context) ([]entity.ExchangeRate, error) {
+	panic("unimplemented")
+}
+
+// Upsert implements ICurrencies.
+func (c *Mock) Upsert(_ context.Context, _ entity.ExchangeRate) error {
+	panic("unimplemented")
+}
+
+// Delete implements ICurrencies.
+func (c *Mock) Delete(_ context.Context, _ string) error {
+	panic("unimplemented")
+}
@@ -0,0 +1,19 @@
+package currencies
+
+const (
+	selectRates = `
+		SELECT * FROM exchange_rates ORDER BY currency_code;
+	`
+
+	upsertRate = `
+		INSERT INTO exchange_rates (currency_code, rate, source, updated_by)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (currency_code) DO UPDATE
+		SET rate = EXCLUDED.rate, source = EXCLUDED.source, updated_by = EXCLUDED.updated_by,
+			updated_at = now() at time zone 'utc';
+	`
+
+	deleteRate = `
+		DELETE FROM exchange_rates WHERE currency_code = $1;
+	`
+)
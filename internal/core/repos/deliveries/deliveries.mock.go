@@ -0,0 +1,34 @@
+package deliveries
+
+import (
+	"context"
+
+	"github.com/swclabs/swipex/internal/core/domain/entity"
+
+	"github.com/stretchr/testify/mock"
+)
+
+var _ IDeliveries = (*Mock)(nil)
+
+// Mock represents a mock for IDeliveries.
+type Mock struct {
+	mock.Mock
+}
+
+// Create implements IDeliveries.
+func (d *Mock) Create(ctx context.Context, delivery entity.Delivery) (int64, error) {
+	args := d.Called(ctx, delivery)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// GetByID implements IDeliveries.
+func (d *Mock) GetByID(ctx context.Context, ID int64) (*entity.Delivery, error) {
+	args := d.Called(ctx, ID)
+	delivery, _ := args.Get(0).(*entity.Delivery)
+	return delivery, args.Error(1)
+}
+
+// GetByUserID implements IDeliveries.
+func (d *Mock) GetByUserID(_ context.Context, _ int64) ([]entity.Delivery, error) {
+	panic("unimplemented")
+}
@@ -24,9 +24,14 @@ func useCache(cache cache.ICache, repo IInventories) IInventories {
 	}
 }
 
+// _Cache caches the inventories. The cache of a transaction, whose
+// afterCommit is set, reads through the transaction and only removes the
+// entries it changed once the transaction is committed: a concurrent read
+// would cache again what the transaction has not committed yet.
 type _Cache struct {
-	cache     cache.ICache
-	inventory IInventories
+	cache       cache.ICache
+	inventory   IInventories
+	afterCommit func(func(ctx context.Context) error)
 }
 
 // UploadColorImage implements IInventories.
@@ -34,8 +39,7 @@ func (c *_Cache) UploadColorImage(ctx context.Context, ID int, url string) error
 	if err := c.inventory.UploadColorImage(ctx, ID, url); err != nil {
 		return err
 	}
-	key := crypto.HashOf(fmt.Sprintf(keyGetByID, ID))
-	return cache.Delete(ctx, c.cache, key)
+	return c.invalidate(ctx, crypto.HashOf(fmt.Sprintf(keyGetByID, ID)))
 }
 
 // GetByColor implements IInventories.
@@ -53,8 +57,7 @@ func (c *_Cache) Update(ctx context.Context, inventory entity.Inventory) error {
 	if err := c.inventory.Update(ctx, inventory); err != nil {
 		return err
 	}
-	key := crypto.HashOf(fmt.Sprintf(keyGetByID, inventory.ID))
-	return cache.Delete(ctx, c.cache, key)
+	return c.invalidate(ctx, crypto.HashOf(fmt.Sprintf(keyGetByID, inventory.ID)))
 }
 
 // UploadImage implements IInventoryRepository.
@@ -62,8 +65,7 @@ func (c *_Cache) UploadImage(ctx context.Context, ID int, url string) error {
 	if err := c.inventory.UploadImage(ctx, ID, url); err != nil {
 		return err
 	}
-	key := crypto.HashOf(fmt.Sprintf(keyGetByID, ID))
-	return cache.Delete(ctx, c.cache, key)
+	return c.invalidate(ctx, crypto.HashOf(fmt.Sprintf(keyGetByID, ID)))
 }
 
 // DeleteByID implements IInventoryRepository.
@@ -71,8 +73,7 @@ func (c *_Cache) DeleteByID(ctx context.Context, inventoryID int64) error {
 	if err := c.inventory.DeleteByID(ctx, inventoryID); err != nil {
 		return err
 	}
-	key := crypto.HashOf(fmt.Sprintf(keyGetByID, inventoryID))
-	return cache.Delete(ctx, c.cache, key)
+	return c.invalidate(ctx, crypto.HashOf(fmt.Sprintf(keyGetByID, inventoryID)))
 }
 
 // InsertProduct implements IInventoryRepository.
@@ -81,12 +82,63 @@ func (c *_Cache) InsertProduct(ctx context.Context, product entity.Inventory) (i
 	if err != nil {
 		return -1, err
 	}
-	key := crypto.HashOf(fmt.Sprintf(keyGetByProductID, product.ProductID))
-	return ID, cache.Delete(ctx, c.cache, key)
+	return ID, c.invalidate(ctx, crypto.HashOf(fmt.Sprintf(keyGetByProductID, product.ProductID)))
+}
+
+// GetByIDForUpdate implements IInventories.
+func (c *_Cache) GetByIDForUpdate(ctx context.Context, inventoryID int64) (*entity.Inventory, error) {
+	return c.inventory.GetByIDForUpdate(ctx, inventoryID)
+}
+
+// Reserve implements IInventories.
+func (c *_Cache) Reserve(ctx context.Context, inventoryID int64, quantity int64) (int64, error) {
+	productID, err := c.inventory.Reserve(ctx, inventoryID, quantity)
+	if err != nil {
+		return -1, err
+	}
+	return productID, c.invalidateStock(ctx, inventoryID, productID)
+}
+
+// Release implements IInventories.
+func (c *_Cache) Release(ctx context.Context, inventoryID int64, quantity int64) (int64, error) {
+	productID, err := c.inventory.Release(ctx, inventoryID, quantity)
+	if err != nil {
+		return -1, err
+	}
+	return productID, c.invalidateStock(ctx, inventoryID, productID)
+}
+
+// invalidateStock removes every cached entry holding the available quantity of an inventory.
+func (c *_Cache) invalidateStock(ctx context.Context, inventoryID, productID int64) error {
+	return c.invalidate(ctx,
+		crypto.HashOf(fmt.Sprintf(keyGetByID, inventoryID)),
+		crypto.HashOf(fmt.Sprintf(keyGetByProductID, productID)),
+	)
+}
+
+// invalidate removes cached entries, once the transaction is committed for
+// the cache of a transaction.
+func (c *_Cache) invalidate(ctx context.Context, keys ...string) error {
+	remove := func(ctx context.Context) error {
+		for _, key := range keys {
+			if err := cache.Delete(ctx, c.cache, key); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if c.afterCommit != nil {
+		c.afterCommit(remove)
+		return nil
+	}
+	return remove(ctx)
 }
 
 // GetLimit implements IInventoryRepository.
 func (c *_Cache) GetLimit(ctx context.Context, limit int, offset int) ([]entity.Inventory, error) {
+	if c.afterCommit != nil {
+		return c.inventory.GetLimit(ctx, limit, offset)
+	}
 	key := crypto.HashOf(fmt.Sprintf("IInventoryRepository:GetLimit:%d:%d", limit, offset))
 	result, err := cache.GetSlice[entity.Inventory](ctx, c.cache, key)
 	if err != nil {
@@ -103,6 +155,9 @@ func (c *_Cache) GetLimit(ctx context.Context, limit int, offset int) ([]entity.
 
 // GetByProductID implements IInventoryRepository.
 func (c *_Cache) GetByProductID(ctx context.Context, ID int64) ([]entity.Inventory, error) {
+	if c.afterCommit != nil {
+		return c.inventory.GetByProductID(ctx, ID)
+	}
 	key := crypto.HashOf(fmt.Sprintf(keyGetByProductID, ID))
 	result, err := cache.GetSlice[entity.Inventory](ctx, c.cache, key)
 	if err != nil {
@@ -119,6 +174,9 @@ func (c *_Cache) GetByProductID(ctx context.Context, ID int64) ([]entity.Invento
 
 // GetByID implements IInventoryRepository.
 func (c *_Cache) GetByID(ctx context.Context, inventoryID int64) (*entity.Inventory, error) {
+	if c.afterCommit != nil {
+		return c.inventory.GetByID(ctx, inventoryID)
+	}
 	key := crypto.HashOf(fmt.Sprintf(keyGetByID, inventoryID))
 	result, err := cache.Get[entity.Inventory](ctx, c.cache, key)
 	if err != nil {
@@ -26,6 +26,13 @@ func Init(conn db.IDatabase, cache cache.ICache) IInventories {
 	return useCache(cache, &Inventory{db: conn})
 }
 
+// InitTx initializes the Inventory object of a transaction with database
+// and redis connection, the cache is updated through afterCommit once the
+// transaction is committed.
+func InitTx(conn db.ITransaction, cache cache.ICache, afterCommit func(func(ctx context.Context) error)) IInventories {
+	return &_Cache{inventory: &Inventory{db: conn}, cache: cache, afterCommit: afterCommit}
+}
+
 // Inventory represents the Inventory object.
 type Inventory struct {
 	db db.IDatabase
@@ -74,6 +81,8 @@ func (w *Inventory) Update(ctx context.Context, inventory entity.Inventory) erro
 		inventory.Image,
 		inventory.Color,
 		inventory.ColorImg,
+		inventory.ExpectedShipDate,
+		inventory.DepositPercent.String(),
 	)
 }
 
@@ -125,6 +134,29 @@ func (w *Inventory) GetByID(ctx context.Context, inventoryID int64) (*entity.Inv
 	return &inventory, nil
 }
 
+// GetByIDForUpdate implements IInventories.
+func (w *Inventory) GetByIDForUpdate(ctx context.Context, inventoryID int64) (*entity.Inventory, error) {
+	rows, err := w.db.Query(ctx, getByIDForUpdate, inventoryID)
+	if err != nil {
+		return nil, err
+	}
+	inventory, err := db.CollectRow[entity.Inventory](rows)
+	if err != nil {
+		return nil, err
+	}
+	return &inventory, nil
+}
+
+// Reserve implements IInventories.
+func (w *Inventory) Reserve(ctx context.Context, inventoryID int64, quantity int64) (int64, error) {
+	return w.db.SafeWriteReturn(ctx, reserve, inventoryID, quantity)
+}
+
+// Release implements IInventories.
+func (w *Inventory) Release(ctx context.Context, inventoryID int64, quantity int64) (int64, error) {
+	return w.db.SafeWriteReturn(ctx, release, inventoryID, quantity)
+}
+
 // InsertProduct implements IInventoryRepository.
 func (w *Inventory) InsertProduct(ctx context.Context, product entity.Inventory) (int64, error) {
 	return w.db.SafeWriteReturn(ctx, insertIntoInventory,
@@ -15,6 +15,17 @@ type IInventories interface {
 	// GetByID gets an inventory by its ID.
 	GetByID(ctx context.Context, inventoryID int64) (*entity.Inventory, error)
 
+	// GetByIDForUpdate gets an inventory by its ID and locks the row
+	// until the surrounding transaction ends.
+	GetByIDForUpdate(ctx context.Context, inventoryID int64) (*entity.Inventory, error)
+
+	// Reserve decrements the available quantity of an inventory.
+	// Returns pgx.ErrNoRows if the inventory does not have enough stock.
+	Reserve(ctx context.Context, inventoryID int64, quantity int64) (productID int64, err error)
+
+	// Release increments the available quantity of an inventory.
+	Release(ctx context.Context, inventoryID int64, quantity int64) (productID int64, err error)
+
 	// GetByProductID gets inventories by product ID.
 	GetByProductID(ctx context.Context, productID int64) ([]entity.Inventory, error)
 
@@ -29,7 +40,8 @@ type IInventories interface {
 
 	UploadColorImage(ctx context.Context, ID int, url string) error
 
-	// Update updates an inventory.
+	// Update updates an inventory. Empty strings, -1 and a nil
+	// ExpectedShipDate keep the current values.
 	Update(ctx context.Context, inventory entity.Inventory) error
 
 	GetColor(ctx context.Context, productID int64) ([]model.ColorItem, error)
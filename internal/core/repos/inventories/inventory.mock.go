@@ -65,6 +65,24 @@ func (w *Mock) GetByID(ctx context.Context, ID int64) (*entity.Inventory, error)
 	return args.Get(0).(*entity.Inventory), args.Error(1)
 }
 
+// GetByIDForUpdate implements IInventories.
+func (w *Mock) GetByIDForUpdate(ctx context.Context, ID int64) (*entity.Inventory, error) {
+	args := w.Called(ctx, ID)
+	return args.Get(0).(*entity.Inventory), args.Error(1)
+}
+
+// Reserve implements IInventories.
+func (w *Mock) Reserve(ctx context.Context, ID int64, quantity int64) (int64, error) {
+	args := w.Called(ctx, ID, quantity)
+	return int64(args.Int(0)), args.Error(1)
+}
+
+// Release implements IInventories.
+func (w *Mock) Release(ctx context.Context, ID int64, quantity int64) (int64, error) {
+	args := w.Called(ctx, ID, quantity)
+	return int64(args.Int(0)), args.Error(1)
+}
+
 // InsertProduct implements IInventoryRepository.
 func (w *Mock) InsertProduct(ctx context.Context, product entity.Inventory) (int64, error) {
 	args := w.Called(ctx, product)
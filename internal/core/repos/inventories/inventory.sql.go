@@ -11,6 +11,24 @@ const (
 		SELECT * FROM inventories WHERE id = $1;
 	`
 
+	getByIDForUpdate string = `
+		SELECT * FROM inventories WHERE id = $1 FOR UPDATE;
+	`
+
+	reserve = `
+		UPDATE inventories
+		SET available = available - $2
+		WHERE id = $1 AND available >= $2
+		RETURNING product_id;
+	`
+
+	release = `
+		UPDATE inventories
+		SET available = available + $2
+		WHERE id = $1
+		RETURNING product_id;
+	`
+
 	getByProductID = `
 		SELECT * FROM inventories WHERE product_id = $1;
 	`
@@ -73,7 +91,12 @@ const (
 			color_img = CASE
 							WHEN $9 <> '' THEN $9
 							ELSE color_img
-						END
+						END,
+			expected_ship_date = COALESCE($10::date, expected_ship_date),
+			deposit_percent = CASE
+								WHEN $11 <> '-1' THEN CAST($11 AS NUMERIC)
+								ELSE deposit_percent
+							END
 		WHERE id = $1;
 	`
 
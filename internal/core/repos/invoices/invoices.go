@@ -0,0 +1,56 @@
+// Package invoices implements invoices repos
+package invoices
+
+import (
+	"context"
+
+	"github.com/swclabs/swipex/app"
+	"github.com/swclabs/swipex/internal/core/domain/entity"
+	"github.com/swclabs/swipex/pkg/infra/db"
+)
+
+var _ = app.Repos(New)
+
+// New creates a new Invoices object
+func New(conn db.IDatabase) IInvoices {
+	return &Invoices{db: conn}
+}
+
+var _ IInvoices = (*Invoices)(nil)
+
+// Invoices represents the repos for invoices
+type Invoices struct {
+	db db.IDatabase
+}
+
+// NextNumber implements IInvoices.
+func (i *Invoices) NextNumber(ctx context.Context) (int64, error) {
+	rows, err := i.db.Query(ctx, nextNumber)
+	if err != nil {
+		return 0, err
+	}
+	return db.CollectValue[int64](rows)
+}
+
+// Insert implements IInvoices.
+func (i *Invoices) Insert(ctx context.Context, invoice entity.Invoice) (int64, error) {
+	return i.db.SafeWriteReturn(ctx, insert, invoice.OrderID, invoice.Number)
+}
+
+// GetByOrderID implements IInvoices.
+func (i *Invoices) GetByOrderID(ctx context.Context, orderID int64) (*entity.Invoice, error) {
+	rows, err := i.db.Query(ctx, selectByOrderID, orderID)
+	if err != nil {
+		return nil, err
+	}
+	invoice, err := db.CollectRow[entity.Invoice](rows)
+	if err != nil {
+		return nil, err
+	}
+	return &invoice, nil
+}
+
+// UpdateURLs implements IInvoices.
+func (i *Invoices) UpdateURLs(ctx context.Context, id int64, htmlURL, pdfURL string) error {
+	return i.db.SafeWrite(ctx, updateURLs, id, htmlURL, pdfURL)
+}
@@ -0,0 +1,23 @@
+package invoices
+
+import (
+	"context"
+
+	"github.com/swclabs/swipex/internal/core/domain/entity"
+)
+
+// IInvoices interface for invoices repos
+type IInvoices interface {
+	// NextNumber takes the next invoice number. The counter stays locked
+	// until the end of the transaction, so it must run inside one.
+	NextNumber(ctx context.Context) (int64, error)
+
+	// Insert records the invoice of an order
+	Insert(ctx context.Context, invoice entity.Invoice) (int64, error)
+
+	// GetByOrderID returns the invoice of an order
+	GetByOrderID(ctx context.Context, orderID int64) (*entity.Invoice, error)
+
+	// UpdateURLs stores where the rendered invoice files are kept
+	UpdateURLs(ctx context.Context, id int64, htmlURL, pdfURL string) error
+}
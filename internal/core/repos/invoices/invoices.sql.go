@@ -0,0 +1,28 @@
+package invoices
+
+const (
+	nextNumber = `
+		UPDATE invoice_counter
+		SET last_number = last_number + 1
+		WHERE id = 1
+		RETURNING last_number;
+	`
+
+	insert = `
+		INSERT INTO invoices (order_id, number)
+		VALUES ($1, $2)
+		RETURNING id;
+	`
+
+	selectByOrderID = `
+		SELECT *
+		FROM invoices
+		WHERE order_id = $1;
+	`
+
+	updateURLs = `
+		UPDATE invoices
+		SET html_url = $2, pdf_url = $3
+		WHERE id = $1;
+	`
+)
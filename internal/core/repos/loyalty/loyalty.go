@@ -0,0 +1,87 @@
+// Package loyalty implements loyalty points repos
+package loyalty
+
+import (
+	"context"
+	"time"
+
+	"github.com/swclabs/swipex/app"
+	"github.com/swclabs/swipex/internal/core/domain/entity"
+	"github.com/swclabs/swipex/pkg/infra/db"
+)
+
+var _ = app.Repos(New)
+
+// New creates a new Loyalty object
+func New(conn db.IDatabase) ILoyalty {
+	return &Loyalty{db: conn}
+}
+
+var _ ILoyalty = (*Loyalty)(nil)
+
+// Loyalty represents the repos for loyalty points
+type Loyalty struct {
+	db db.IDatabase
+}
+
+// GetBalanceForUpdate implements ILoyalty.
+func (l *Loyalty) GetBalanceForUpdate(ctx context.Context, userID int64) (int64, error) {
+	rows, err := l.db.Query(ctx, selectBalanceForUpdate, userID)
+	if err != nil {
+		return 0, err
+	}
+	return db.CollectValue[int64](rows)
+}
+
+// AddPoints implements ILoyalty.
+func (l *Loyalty) AddPoints(ctx context.Context, userID int64, points int64, lifetime int64) error {
+	return l.db.SafeWrite(ctx, addPoints, userID, points, lifetime)
+}
+
+// InsertEntry implements ILoyalty.
+func (l *Loyalty) InsertEntry(ctx context.Context, entry entity.LoyaltyEntry) (int64, error) {
+	return l.db.SafeWriteReturn(ctx, insertEntry,
+		entry.UserID, entry.OrderID, entry.Points, entry.Remaining, entry.Kind, entry.ExpiresAt,
+	)
+}
+
+// GetOrderEntries implements ILoyalty.
+func (l *Loyalty) GetOrderEntries(ctx context.Context, orderID int64) ([]entity.LoyaltyEntry, error) {
+	rows, err := l.db.Query(ctx, selectOrderEntries, orderID)
+	if err != nil {
+		return nil, err
+	}
+	return db.CollectRows[entity.LoyaltyEntry](rows)
+}
+
+// GetLots implements ILoyalty.
+func (l *Loyalty) GetLots(ctx context.Context, userID int64) ([]entity.LoyaltyEntry, error) {
+	rows, err := l.db.Query(ctx, selectLots, userID)
+	if err != nil {
+		return nil, err
+	}
+	return db.CollectRows[entity.LoyaltyEntry](rows)
+}
+
+// SetRemaining implements ILoyalty.
+func (l *Loyalty) SetRemaining(ctx context.Context, entryID int64, remaining int64) error {
+	return l.db.SafeWrite(ctx, setRemaining, entryID, remaining)
+}
+
+// GetExpiredUsers implements ILoyalty.
+func (l *Loyalty) GetExpiredUsers(ctx context.Context, now time.Time, limit int) ([]int64, error) {
+	rows, err := l.db.Query(ctx, selectExpiredUsers, now, limit)
+	if err != nil {
+		return nil, err
+	}
+	return db.CollectValues[int64](rows)
+}
+
+// GetExpiredLots implements ILoyalty.
+func (l *Loyalty) GetExpiredLots(ctx context.Context, userID int64, now time.Time) ([]entity.LoyaltyEntry, error) {
+	rows, err := l.db.Query(ctx, selectExpiredLots, userID, now)
+	if err != nil {
+		return nil, err
+	}
+	return db.CollectRows[entity.LoyaltyEntry](rows)
+}
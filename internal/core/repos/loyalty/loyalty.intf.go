@@ -0,0 +1,39 @@
+package loyalty
+
+import (
+	"context"
+	"time"
+
+	"github.com/swclabs/swipex/internal/core/domain/entity"
+)
+
+// ILoyalty interface for loyalty points repos. The balance of a user only
+// changes together with a ledger entry, on the row locked by GetBalanceForUpdate.
+type ILoyalty interface {
+	// GetBalanceForUpdate returns the points balance of a user and locks
+	// the user row
+	GetBalanceForUpdate(ctx context.Context, userID int64) (int64, error)
+
+	// AddPoints adds points to the balance of a user and lifetime to its
+	// lifetime points, both may be negative
+	AddPoints(ctx context.Context, userID int64, points int64, lifetime int64) error
+
+	// InsertEntry appends an entry to the ledger
+	InsertEntry(ctx context.Context, entry entity.LoyaltyEntry) (int64, error)
+
+	// GetOrderEntries returns the ledger entries of an order, oldest first
+	GetOrderEntries(ctx context.Context, orderID int64) ([]entity.LoyaltyEntry, error)
+
+	// GetLots returns the lots of a user with points left, the first to
+	// expire first, and locks them
+	GetLots(ctx context.Context, userID int64) ([]entity.LoyaltyEntry, error)
+
+	// SetRemaining sets the points left in a lot
+	SetRemaining(ctx context.Context, entryID int64, remaining int64) error
+
+	// GetExpiredUsers returns the users holding lots expired at now
+	GetExpiredUsers(ctx context.Context, now time.Time, limit int) ([]int64, error)
+
+	// GetExpiredLots returns the lots of a user expired at now and locks them
+	GetExpiredLots(ctx context.Context, userID int64, now time.Time) ([]entity.LoyaltyEntry, error)
+}
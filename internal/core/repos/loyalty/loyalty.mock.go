@@ -0,0 +1,67 @@
+package loyalty
+
+import (
+	"context"
+	"time"
+
+	"github.com/swclabs/swipex/internal/core/domain/entity"
+
+	"github.com/stretchr/testify/mock"
+)
+
+var _ ILoyalty = (*Mock)(nil)
+
+// Mock represents a mock for ILoyalty.
+type Mock struct {
+	mock.Mock
+}
+
+// GetBalanceForUpdate implements ILoyalty.
+func (l *Mock) GetBalanceForUpdate(ctx context.Context, userID int64) (int64, error) {
+	args := l.Called(ctx, userID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// AddPoints implements ILoyalty.
+func (l *Mock) AddPoints(ctx context.Context, userID int64, points int64, lifetime int64) error {
+	return l.Called(ctx, userID, points, lifetime).Error(0)
+}
+
+// InsertEntry implements ILoyalty.
+func (l *Mock) InsertEntry(ctx context.Context, entry entity.LoyaltyEntry) (int64, error) {
+	args := l.Called(ctx, entry)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// GetOrderEntries implements ILoyalty.
+func (l *Mock) GetOrderEntries(ctx context.Context, orderID int64) ([]entity.LoyaltyEntry, error) {
+	args := l.Called(ctx, orderID)
+	entries, _ := args.Get(0).([]entity.LoyaltyEntry)
+	return entries, args.Error(1)
+}
+
+// GetLots implements ILoyalty.
+func (l *Mock) GetLots(ctx context.Context, userID int64) ([]entity.LoyaltyEntry, error) {
+	args := l.Called(ctx, userID)
+	lots, _ := args.Get(0).([]entity.LoyaltyEntry)
+	return lots, args.Error(1)
+}
+
+// SetRemaining implements ILoyalty.
+func (l *Mock) SetRemaining(ctx context.Context, entryID int64, remaining int64) error {
+	return l.Called(ctx, entryID, remaining).Error(0)
+}
+
+// GetExpiredUsers implements ILoyalty.
+func (l *Mock) GetExpiredUsers(ctx context.Context, now time.Time, limit int) ([]int64, error) {
+	args := l.Called(ctx, now, limit)
+	users, _ := args.Get(0).([]int64)
+	return users, args.Error(1)
+}
+
+// GetExpiredLots implements ILoyalty.
+func (l *Mock) GetExpiredLots(ctx context.Context, userID int64, now time.Time) ([]entity.LoyaltyEntry, error) {
+	args := l.Called(ctx, userID, now)
+	lots, _ := args.Get(0).([]entity.LoyaltyEntry)
+	return lots, args.Error(1)
+}
@@ -0,0 +1,48 @@
+package loyalty
+
+const (
+	selectBalanceForUpdate = `
+		SELECT loyalty_points FROM users WHERE id = $1 FOR UPDATE;
+	`
+
+	addPoints = `
+		UPDATE users
+		SET loyalty_points = loyalty_points + $2,
+			lifetime_points = GREATEST(lifetime_points + $3, 0)
+		WHERE id = $1;
+	`
+
+	insertEntry = `
+		INSERT INTO loyalty_ledger (user_id, order_id, points, remaining, kind, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id;
+	`
+
+	selectOrderEntries = `
+		SELECT * FROM loyalty_ledger WHERE order_id = $1 ORDER BY id ASC;
+	`
+
+	selectLots = `
+		SELECT * FROM loyalty_ledger
+		WHERE user_id = $1 AND remaining > 0
+		ORDER BY expires_at ASC, id ASC
+		FOR UPDATE;
+	`
+
+	setRemaining = `
+		UPDATE loyalty_ledger SET remaining = $2 WHERE id = $1;
+	`
+
+	selectExpiredUsers = `
+		SELECT DISTINCT user_id FROM loyalty_ledger
+		WHERE remaining > 0 AND expires_at <= $1
+		LIMIT $2;
+	`
+
+	selectExpiredLots = `
+		SELECT * FROM loyalty_ledger
+		WHERE user_id = $1 AND remaining > 0 AND expires_at <= $2
+		ORDER BY id ASC
+		FOR UPDATE;
+	`
+)
@@ -4,6 +4,7 @@ package orders
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/swclabs/swipex/internal/core/domain/entity"
 	"github.com/swclabs/swipex/internal/core/domain/model"
@@ -28,8 +29,54 @@ func (c *_Cache) UpdateStatus(ctx context.Context, orderCode string, status stri
 	return c.orders.UpdateStatus(ctx, orderCode, status)
 }
 
-func (c *_Cache) GetLimit(ctx context.Context, limit int) ([]entity.Order, error) {
-	return c.orders.GetLimit(ctx, limit)
+// InsertStatusHistory implements IOrders.
+func (c *_Cache) InsertStatusHistory(ctx context.Context, history entity.OrderStatusHistory) error {
+	return c.orders.InsertStatusHistory(ctx, history)
+}
+
+// GetStatusHistory implements IOrders.
+func (c *_Cache) GetStatusHistory(ctx context.Context, orderID int64) ([]entity.OrderStatusHistory, error) {
+	return c.orders.GetStatusHistory(ctx, orderID)
+}
+
+// GetBackorders implements IOrders.
+func (c *_Cache) GetBackorders(ctx context.Context, inventoryID int64) ([]model.Backorder, error) {
+	return c.orders.GetBackorders(ctx, inventoryID)
+}
+
+// AllocateBackorder implements IOrders.
+func (c *_Cache) AllocateBackorder(ctx context.Context, itemID int64, quantity int64) error {
+	return c.orders.AllocateBackorder(ctx, itemID, quantity)
+}
+
+// SetPaid implements IOrders.
+func (c *_Cache) SetPaid(ctx context.Context, orderID int64, paidAt time.Time) error {
+	return c.orders.SetPaid(ctx, orderID, paidAt)
+}
+
+// SetDepositPaid implements IOrders.
+func (c *_Cache) SetDepositPaid(ctx context.Context, orderID int64, paidAt time.Time) error {
+	return c.orders.SetDepositPaid(ctx, orderID, paidAt)
+}
+
+// SetPaymentFailed implements IOrders.
+func (c *_Cache) SetPaymentFailed(ctx context.Context, orderID int64) error {
+	return c.orders.SetPaymentFailed(ctx, orderID)
+}
+
+// GetUnpaidPending implements IOrders.
+func (c *_Cache) GetUnpaidPending(ctx context.Context, before time.Time, limit int) ([]string, error) {
+	return c.orders.GetUnpaidPending(ctx, before, limit)
+}
+
+// Search implements IOrders.
+func (c *_Cache) Search(ctx context.Context, filter model.OrderFilter) ([]model.OrderSummary, error) {
+	return c.orders.Search(ctx, filter)
+}
+
+// Export implements IOrders.
+func (c *_Cache) Export(ctx context.Context, filter model.OrderFilter, fn func(model.OrderExportRow) error) error {
+	return c.orders.Export(ctx, filter, fn)
 }
 
 // GetItemByCode implements IOrders.
@@ -37,11 +84,21 @@ func (c *_Cache) GetItemByCode(ctx context.Context, orderCode string) ([]model.O
 	return c.orders.GetItemByCode(ctx, orderCode)
 }
 
+// GetByID implements IOrders.
+func (c *_Cache) GetByID(ctx context.Context, ID int64) (*entity.Order, error) {
+	return c.orders.GetByID(ctx, ID)
+}
+
 // GetByUUID implements IOrders.
 func (c *_Cache) GetByUUID(ctx context.Context, orderCode string) (*entity.Order, error) {
 	return c.orders.GetByUUID(ctx, orderCode)
 }
 
+// GetByUUIDForUpdate implements IOrders.
+func (c *_Cache) GetByUUIDForUpdate(ctx context.Context, orderCode string) (*entity.Order, error) {
+	return c.orders.GetByUUIDForUpdate(ctx, orderCode)
+}
+
 // Create implements IOrdersRepository.
 func (c *_Cache) Create(ctx context.Context, order entity.Order) (int64, error) {
 	return c.orders.Create(ctx, order)
@@ -3,9 +3,12 @@ package orders
 import (
 	"context"
 	"fmt"
+	"strings"
+	"time"
 
 	"github.com/swclabs/swipex/app"
 	"github.com/swclabs/swipex/internal/core/domain/entity"
+	"github.com/swclabs/swipex/internal/core/domain/enum"
 	"github.com/swclabs/swipex/internal/core/domain/model"
 	"github.com/swclabs/swipex/pkg/infra/cache"
 	"github.com/swclabs/swipex/pkg/infra/db"
@@ -37,16 +40,85 @@ func (orders *Orders) UpdateStatus(ctx context.Context, orderCode string, status
 	return orders.db.SafeWrite(ctx, updateStatus, status, orderCode)
 }
 
-func (orders *Orders) GetLimit(ctx context.Context, limit int) ([]entity.Order, error) {
-	rows, err := orders.db.Query(ctx, getLimit, limit)
+// InsertStatusHistory implements IOrders.
+func (orders *Orders) InsertStatusHistory(ctx context.Context, history entity.OrderStatusHistory) error {
+	return orders.db.SafeWrite(ctx, insertStatusHistory,
+		history.OrderID, history.FromStatus, history.ToStatus, history.Actor, history.Reason,
+	)
+}
+
+// GetStatusHistory implements IOrders.
+func (orders *Orders) GetStatusHistory(ctx context.Context, orderID int64) ([]entity.OrderStatusHistory, error) {
+	rows, err := orders.db.Query(ctx, getStatusHistory, orderID)
 	if err != nil {
 		return nil, err
 	}
-	ords, err := db.CollectRows[entity.Order](rows)
+	return db.CollectRows[entity.OrderStatusHistory](rows)
+}
+
+// GetBackorders implements IOrders.
+func (orders *Orders) GetBackorders(ctx context.Context, inventoryID int64) ([]model.Backorder, error) {
+	rows, err := orders.db.Query(ctx, getBackorders, inventoryID, enum.BackorderStatuses())
 	if err != nil {
 		return nil, err
 	}
-	return ords, nil
+	return db.CollectRows[model.Backorder](rows)
+}
+
+// AllocateBackorder implements IOrders.
+func (orders *Orders) AllocateBackorder(ctx context.Context, itemID int64, quantity int64) error {
+	return orders.db.SafeWrite(ctx, allocateBackorder, itemID, quantity)
+}
+
+// SetPaid implements IOrders.
+func (orders *Orders) SetPaid(ctx context.Context, orderID int64, paidAt time.Time) error {
+	return orders.db.SafeWrite(ctx, setPaid, orderID, paidAt)
+}
+
+// SetDepositPaid implements IOrders.
+func (orders *Orders) SetDepositPaid(ctx context.Context, orderID int64, paidAt time.Time) error {
+	return orders.db.SafeWrite(ctx, setDepositPaid, orderID, paidAt)
+}
+
+// SetPaymentFailed implements IOrders.
+func (orders *Orders) SetPaymentFailed(ctx context.Context, orderID int64) error {
+	return orders.db.SafeWrite(ctx, setPaymentFailed, orderID)
+}
+
+// GetUnpaidPending implements IOrders.
+func (orders *Orders) GetUnpaidPending(ctx context.Context, before time.Time, limit int) ([]string, error) {
+	rows, err := orders.db.Query(ctx, getUnpaidPending, enum.OrderPending.String(), before, limit)
+	if err != nil {
+		return nil, err
+	}
+	return db.CollectValues[string](rows)
+}
+
+// Search implements IOrders.
+func (orders *Orders) Search(ctx context.Context, filter model.OrderFilter) ([]model.OrderSummary, error) {
+	var (
+		query  string
+		after  any
+		cursor any
+	)
+	switch filter.Sort {
+	case "time":
+		query, after = searchByTimeAsc, filter.AfterTime
+	case "total":
+		query, after = searchByTotalAsc, filter.AfterTotal
+	case "-total":
+		query, after = searchByTotalDesc, filter.AfterTotal
+	default:
+		query, after = searchByTimeDesc, filter.AfterTime
+	}
+	if filter.AfterID != 0 {
+		cursor = filter.AfterID
+	}
+	rows, err := orders.db.Query(ctx, query, append(filterArgs(filter), cursor, after, filter.Limit)...)
+	if err != nil {
+		return nil, err
+	}
+	return db.CollectRows[model.OrderSummary](rows)
 }
 
 // GetItemByCode implements IOrders.
@@ -63,6 +135,19 @@ func (orders *Orders) GetItemByCode(ctx context.Context, orderCode string) ([]mo
 	return order, nil
 }
 
+// GetByID implements IOrders.
+func (orders *Orders) GetByID(ctx context.Context, ID int64) (*entity.Order, error) {
+	rows, err := orders.db.Query(ctx, getByID, ID)
+	if err != nil {
+		return nil, err
+	}
+	order, err := db.CollectRow[entity.Order](rows)
+	if err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
 // GetByUUID implements IOrders.
 func (orders *Orders) GetByUUID(ctx context.Context, uuid string) (*entity.Order, error) {
 	rows, err := orders.db.Query(ctx, getByUUID, uuid)
@@ -76,18 +161,37 @@ func (orders *Orders) GetByUUID(ctx context.Context, uuid string) (*entity.Order
 	return &order, nil
 }
 
+// GetByUUIDForUpdate implements IOrders.
+func (orders *Orders) GetByUUIDForUpdate(ctx context.Context, uuid string) (*entity.Order, error) {
+	rows, err := orders.db.Query(ctx, getByUUIDForUpdate, uuid)
+	if err != nil {
+		return nil, err
+	}
+	order, err := db.CollectRow[entity.Order](rows)
+	if err != nil {
+		return nil, err
+	}
+	return &order, nil
+}
+
 // InsertProduct implements IOrdersRepository.
 func (orders *Orders) InsertProduct(ctx context.Context, product entity.ProductInOrder) error {
 	return orders.db.SafeWrite(ctx, insertProductToOrder,
-		product.OrderID, product.InventoryID, product.Quantity, "VND",
-		product.TotalAmount.String(),
+		product.OrderID, product.InventoryID, product.Quantity, product.CurrencyCode,
+		product.TotalAmount.String(), product.UnitPrice.String(),
+		product.VATRate.String(), product.TaxAmount.String(), product.Backordered,
+		product.BundleID,
 	)
 }
 
 // Create implements IOrdersRepository.
 func (orders *Orders) Create(ctx context.Context, order entity.Order) (int64, error) {
 	return orders.db.SafeWriteReturn(ctx, insertOrder,
-		order.UUID, order.UserID, "active", order.TotalAmount.String(), order.DeliveryID, order.PaymentMethod,
+		order.UUID, order.UserID, order.Status, order.TotalAmount.String(), order.DeliveryID, order.PaymentMethod,
+		order.Subtotal.String(), order.DiscountAmount.String(), order.TaxAmount.String(), order.ShippingFee.String(),
+		order.DepositAmount.String(), order.CreditAmount.String(),
+		order.PointsRedeemed, order.PointsAmount.String(),
+		order.CurrencyCode, order.ExchangeRate.String(), order.PaymentDueAt, order.DepositPaidAt,
 	)
 }
 
@@ -116,3 +220,52 @@ func (orders *Orders) GetProductByOrderID(ctx context.Context, orderID int64) ([
 	}
 	return _products, nil
 }
+
+// Export implements IOrders.
+func (orders *Orders) Export(ctx context.Context, filter model.OrderFilter, fn func(model.OrderExportRow) error) error {
+	rows, err := orders.db.Query(ctx, exportOrders, filterArgs(filter)...)
+	if err != nil {
+		return err
+	}
+	return db.EachRow(rows, fn)
+}
+
+// filterArgs returns the arguments of orderFilters
+func filterArgs(filter model.OrderFilter) []any {
+	return []any{
+		nullString(filter.Status),
+		nullString(filter.PaymentMethod),
+		nullTime(filter.From),
+		nullTime(filter.To),
+		nullString(likePattern(filter.Email)),
+		nullString(likePattern(filter.Phone)),
+		filter.MinTotal,
+		filter.MaxTotal,
+		nullString(filter.CouponCode),
+	}
+}
+
+// nullString passes an empty filter as NULL
+func nullString(s string) any {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+// nullTime passes a zero time filter as NULL, other times as UTC, which
+// orders.time is stored in
+func nullTime(t time.Time) any {
+	if t.IsZero() {
+		return nil
+	}
+	return t.UTC()
+}
+
+// likePattern matches the values containing s
+func likePattern(s string) string {
+	if s == "" {
+		return ""
+	}
+	return "%" + strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s) + "%"
+}
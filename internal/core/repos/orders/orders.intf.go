@@ -2,6 +2,7 @@ package orders
 
 import (
 	"context"
+	"time"
 
 	"github.com/swclabs/swipex/internal/core/domain/entity"
 	"github.com/swclabs/swipex/internal/core/domain/model"
@@ -11,10 +12,43 @@ import (
 type IOrders interface {
 	Create(ctx context.Context, order entity.Order) (int64, error)
 	GetByUserID(ctx context.Context, userID int64, limit int) ([]entity.Order, error)
+	GetByID(ctx context.Context, ID int64) (*entity.Order, error)
 	GetByUUID(ctx context.Context, orderCode string) (*entity.Order, error)
+	GetByUUIDForUpdate(ctx context.Context, orderCode string) (*entity.Order, error)
 	GetItemByCode(ctx context.Context, orderCode string) ([]model.Order, error)
 	InsertProduct(ctx context.Context, product entity.ProductInOrder) error
 	GetProductByOrderID(ctx context.Context, orderID int64) ([]entity.ProductInOrder, error)
-	GetLimit(ctx context.Context, limit int) ([]entity.Order, error)
+	// Search lists the orders matching filter with their customer in a
+	// single query, Limit orders at most
+	Search(ctx context.Context, filter model.OrderFilter) ([]model.OrderSummary, error)
+
+	// Export calls fn for each line item of the orders matching filter, in
+	// order placement, reading them from the database as fn consumes them
+	Export(ctx context.Context, filter model.OrderFilter, fn func(model.OrderExportRow) error) error
 	UpdateStatus(ctx context.Context, orderCode string, status string) error
+	InsertStatusHistory(ctx context.Context, history entity.OrderStatusHistory) error
+	GetStatusHistory(ctx context.Context, orderID int64) ([]entity.OrderStatusHistory, error)
+
+	// GetBackorders locks the backordered lines of the orders still waiting
+	// for an inventory, oldest orders first: pending orders and the orders
+	// confirmed to ship their units in stock first
+	GetBackorders(ctx context.Context, inventoryID int64) ([]model.Backorder, error)
+
+	// AllocateBackorder takes quantity units off the backordered quantity
+	// of an order line
+	AllocateBackorder(ctx context.Context, itemID int64, quantity int64) error
+
+	// SetPaid records when an order was paid, an order is only paid once
+	SetPaid(ctx context.Context, orderID int64, paidAt time.Time) error
+
+	// SetDepositPaid records when the deposit of a pre-order was paid, the
+	// order no longer expires
+	SetDepositPaid(ctx context.Context, orderID int64, paidAt time.Time) error
+
+	// SetPaymentFailed records that the last payment of an unpaid order failed
+	SetPaymentFailed(ctx context.Context, orderID int64) error
+
+	// GetUnpaidPending returns the codes of the pending orders whose online
+	// payment was due before and never came, the most overdue first
+	GetUnpaidPending(ctx context.Context, before time.Time, limit int) ([]string, error)
 }
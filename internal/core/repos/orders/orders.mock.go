@@ -1 +1,129 @@
 package orders
+
+import (
+	"context"
+	"time"
+
+	"github.com/swclabs/swipex/internal/core/domain/entity"
+	"github.com/swclabs/swipex/internal/core/domain/model"
+
+	"github.com/stretchr/testify/mock"
+)
+
+var _ IOrders = (*Mock)(nil)
+
+// Mock represents a mock for IOrders.
+type Mock struct {
+	mock.Mock
+}
+
+// NewOrdersMock creates a new mock for IOrders.
+func NewOrdersMock() *Mock {
+	return &Mock{}
+}
+
+// Create implements IOrders.
+func (o *Mock) Create(ctx context.Context, order entity.Order) (int64, error) {
+	args := o.Called(ctx, order)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// GetByUserID implements IOrders.
+func (o *Mock) GetByUserID(_ context.Context, _ int64, _ int) ([]entity.Order, error) {
+	panic("unimplemented")
+}
+
+// GetByID implements IOrders.
+func (o *Mock) GetByID(ctx context.Context, ID int64) (*entity.Order, error) {
+	args := o.Called(ctx, ID)
+	order, _ := args.Get(0).(*entity.Order)
+	return order, args.Error(1)
+}
+
+// GetByUUID implements IOrders.
+func (o *Mock) GetByUUID(ctx context.Context, orderCode string) (*entity.Order, error) {
+	args := o.Called(ctx, orderCode)
+	order, _ := args.Get(0).(*entity.Order)
+	return order, args.Error(1)
+}
+
+// GetByUUIDForUpdate implements IOrders.
+func (o *Mock) GetByUUIDForUpdate(ctx context.Context, orderCode string) (*entity.Order, error) {
+	args := o.Called(ctx, orderCode)
+	order, _ := args.Get(0).(*entity.Order)
+	return order, args.Error(1)
+}
+
+// GetItemByCode implements IOrders.
+func (o *Mock) GetItemByCode(_ context.Context, _ string) ([]model.Order, error) {
+	panic("unimplemented")
+}
+
+// InsertProduct implements IOrders.
+func (o *Mock) InsertProduct(ctx context.Context, product entity.ProductInOrder) error {
+	return o.Called(ctx, product).Error(0)
+}
+
+// GetProductByOrderID implements IOrders.
+func (o *Mock) GetProductByOrderID(ctx context.Context, orderID int64) ([]entity.ProductInOrder, error) {
+	args := o.Called(ctx, orderID)
+	items, _ := args.Get(0).([]entity.ProductInOrder)
+	return items, args.Error(1)
+}
+
+// Search implements IOrders.
+func (o *Mock) Search(_ context.Context, _ model.OrderFilter) ([]model.OrderSummary, error) {
+	panic("unimplemented")
+}
+
+// Export implements IOrders.
+func (o *Mock) Export(_ context.Context, _ model.OrderFilter, _ func(model.OrderExportRow) error) error {
+	panic("unimplemented")
+}
+
+// UpdateStatus implements IOrders.
+func (o *Mock) UpdateStatus(ctx context.Context, orderCode string, status string) error {
+	return o.Called(ctx, orderCode, status).Error(0)
+}
+
+// InsertStatusHistory implements IOrders.
+func (o *Mock) InsertStatusHistory(ctx context.Context, history entity.OrderStatusHistory) error {
+	return o.Called(ctx, history).Error(0)
+}
+
+// GetStatusHistory implements IOrders.
+func (o *Mock) GetStatusHistory(_ context.Context, _ int64) ([]entity.OrderStatusHistory, error) {
+	panic("unimplemented")
+}
+
+// GetBackorders implements IOrders.
+func (o *Mock) GetBackorders(_ context.Context, _ int64) ([]model.Backorder, error) {
+	panic("unimplemented")
+}
+
+// AllocateBackorder implements IOrders.
+func (o *Mock) AllocateBackorder(_ context.Context, _ int64, _ int64) error {
+	panic("unimplemented")
+}
+
+// SetPaid implements IOrders.
+func (o *Mock) SetPaid(ctx context.Context, orderID int64, paidAt time.Time) error {
+	return o.Called(ctx, orderID, paidAt).Error(0)
+}
+
+// SetDepositPaid implements IOrders.
+func (o *Mock) SetDepositPaid(ctx context.Context, orderID int64, paidAt time.Time) error {
+	return o.Called(ctx, orderID, paidAt).Error(0)
+}
+
+// SetPaymentFailed implements IOrders.
+func (o *Mock) SetPaymentFailed(ctx context.Context, orderID int64) error {
+	return o.Called(ctx, orderID).Error(0)
+}
+
+// GetUnpaidPending implements IOrders.
+func (o *Mock) GetUnpaidPending(ctx context.Context, before time.Time, limit int) ([]string, error) {
+	args := o.Called(ctx, before, limit)
+	codes, _ := args.Get(0).([]string)
+	return codes, args.Error(1)
+}
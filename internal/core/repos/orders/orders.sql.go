@@ -2,18 +2,92 @@ package orders
 
 const (
 	insertOrder = `
-		INSERT INTO orders (uuid, user_id, status, total_amount, delivery_id, payment_method)
-		VALUES ($1, $2, $3, $4, $5, $6)
+		INSERT INTO orders (uuid, user_id, status, total_amount, delivery_id, payment_method,
+			subtotal, discount_amount, tax_amount, shipping_fee, deposit_amount, credit_amount,
+			points_redeemed, points_amount, currency_code, exchange_rate, payment_due_at, deposit_paid_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)
 		RETURNING id;
 	`
 
-	getLimit = `
-		SELECT * FROM orders LIMIT $1;
+	// orderFilters filters on $1 to $9, NULL for the filters not set. It
+	// needs orders joined with users and coupons_used.
+	orderFilters = `
+		WHERE ($1::varchar IS NULL OR orders.status = $1)
+			AND ($2::varchar IS NULL OR orders.payment_method = $2)
+			AND ($3::timestamp IS NULL OR orders.time >= $3)
+			AND ($4::timestamp IS NULL OR orders.time < $4)
+			AND ($5::varchar IS NULL OR users.email ILIKE $5)
+			AND ($6::varchar IS NULL OR users.phone_number LIKE $6)
+			AND ($7::numeric IS NULL OR orders.total_amount >= $7)
+			AND ($8::numeric IS NULL OR orders.total_amount <= $8)
+			AND ($9::varchar IS NULL OR coupons_used.coupon_code = $9)
+	`
+
+	// the sort variants of searchOrders page with the key ($11, $10) of
+	// the last order seen, $10 is NULL on the first page
+	searchOrders = `
+		SELECT orders.id, orders.uuid, orders.time, orders.status, orders.payment_method,
+			orders.subtotal, orders.discount_amount, orders.tax_amount, orders.shipping_fee,
+			orders.total_amount, orders.user_id, users.email, users.first_name, users.last_name,
+			users.phone_number, COALESCE(coupons_used.coupon_code, '') AS coupon_code,
+			COALESCE(items.quantity, 0)::bigint AS items
+		FROM orders
+		JOIN users ON users.id = orders.user_id
+		LEFT JOIN coupons_used ON coupons_used.order_id = orders.id
+		LEFT JOIN LATERAL (
+			SELECT SUM(product_in_order.quantity) AS quantity
+			FROM product_in_order
+			WHERE product_in_order.order_id = orders.id
+		) AS items ON true
+	` + orderFilters
+
+	exportOrders = `
+		SELECT orders.uuid, orders.time, orders.status, orders.payment_method,
+			users.email, users.first_name, users.last_name, users.phone_number,
+			COALESCE(coupons_used.coupon_code, '') AS coupon_code,
+			orders.subtotal, orders.discount_amount, orders.tax_amount, orders.shipping_fee,
+			orders.total_amount, products.name AS product_name, inventories.color,
+			product_in_order.quantity, product_in_order.unit_price, product_in_order.vat_rate,
+			product_in_order.tax_amount AS line_tax_amount,
+			product_in_order.total_amount AS line_amount
+		FROM orders
+		JOIN users ON users.id = orders.user_id
+		LEFT JOIN coupons_used ON coupons_used.order_id = orders.id
+		JOIN product_in_order ON product_in_order.order_id = orders.id
+		JOIN inventories ON inventories.id = product_in_order.inventory_id
+		JOIN products ON products.id = inventories.product_id
+	` + orderFilters + `
+		ORDER BY orders.time ASC, orders.id ASC, product_in_order.id ASC;
+	`
+
+	searchByTimeDesc = searchOrders + `
+			AND ($10::bigint IS NULL OR (orders.time, orders.id) < ($11::timestamp, $10))
+		ORDER BY orders.time DESC, orders.id DESC
+		LIMIT $12;
+	`
+
+	searchByTimeAsc = searchOrders + `
+			AND ($10::bigint IS NULL OR (orders.time, orders.id) > ($11::timestamp, $10))
+		ORDER BY orders.time ASC, orders.id ASC
+		LIMIT $12;
+	`
+
+	searchByTotalDesc = searchOrders + `
+			AND ($10::bigint IS NULL OR (orders.total_amount, orders.id) < ($11::numeric, $10))
+		ORDER BY orders.total_amount DESC, orders.id DESC
+		LIMIT $12;
+	`
+
+	searchByTotalAsc = searchOrders + `
+			AND ($10::bigint IS NULL OR (orders.total_amount, orders.id) > ($11::numeric, $10))
+		ORDER BY orders.total_amount ASC, orders.id ASC
+		LIMIT $12;
 	`
 
 	insertProductToOrder = `
-		INSERT INTO product_in_order (order_id, inventory_id, quantity, currency_code, total_amount)
-		VALUES ($1, $2, $3, $4, $5);
+		INSERT INTO product_in_order (order_id, inventory_id, quantity, currency_code, total_amount,
+			unit_price, vat_rate, tax_amount, backordered, bundle_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10);
 	`
 
 	getOrder = `
@@ -28,14 +102,22 @@ const (
 		SELECT * FROM product_in_order WHERE order_id = $1 ORDER BY id ASC;
 	`
 
+	getByID = `
+		SELECT * FROM orders WHERE id = $1;
+	`
+
 	getByUUID = `
 		SELECT * FROM orders WHERE uuid = $1;
 	`
 
+	getByUUIDForUpdate = `
+		SELECT * FROM orders WHERE uuid = $1 FOR UPDATE;
+	`
+
 	getByOrderCode = `
-		SELECT total_amount, quantity, currency_code, color, products.image, name, category_id, item_specs FROM (
-			SELECT uuid, time, user_id, total_amount, quantity, inventories.currency_code, color, image as inventory_image, product_id, specs as item_specs FROM (
-				SELECT product_in_order.order_id, uuid, time, user_id, delivery_id, product_in_order.total_amount, status, inventory_id, quantity, currency_code FROM (
+		SELECT item_id as id, total_amount, unit_price, vat_rate, tax_amount, quantity, backordered, bundle_id, currency_code, color, products.image, name, category_id, item_specs FROM (
+			SELECT item_id, uuid, time, user_id, total_amount, unit_price, vat_rate, tax_amount, quantity, backordered, bundle_id, line_currency as currency_code, color, image as inventory_image, product_id, specs as item_specs FROM (
+				SELECT product_in_order.id as item_id, product_in_order.order_id, uuid, time, user_id, delivery_id, product_in_order.total_amount, product_in_order.unit_price, product_in_order.vat_rate, product_in_order.tax_amount, status, inventory_id, quantity, backordered, product_in_order.bundle_id, product_in_order.currency_code as line_currency FROM (
 					SELECT id as o_id, uuid, time, user_id, delivery_id, total_amount, status 
 					FROM orders where orders.uuid = $1
 				) JOIN product_in_order ON product_in_order.order_id = o_id
@@ -48,4 +130,57 @@ const (
 		SET status = $1
 		WHERE uuid = $2;
 	`
+
+	insertStatusHistory = `
+		INSERT INTO order_status_history (order_id, from_status, to_status, actor, reason)
+		VALUES ($1, $2, $3, $4, $5);
+	`
+
+	getStatusHistory = `
+		SELECT * FROM order_status_history
+		WHERE order_id = $1
+		ORDER BY created_at ASC, id ASC;
+	`
+
+	// backorders are served in order placement, the lines are locked until
+	// their allocation commits
+	getBackorders = `
+		SELECT product_in_order.id, product_in_order.order_id, orders.uuid AS order_code,
+			product_in_order.backordered
+		FROM product_in_order
+		JOIN orders ON orders.id = product_in_order.order_id
+		WHERE product_in_order.inventory_id = $1
+			AND product_in_order.backordered > 0
+			AND orders.status = ANY($2)
+		ORDER BY orders.time ASC, orders.id ASC, product_in_order.id ASC
+		FOR UPDATE OF product_in_order;
+	`
+
+	allocateBackorder = `
+		UPDATE product_in_order
+		SET backordered = backordered - $2
+		WHERE id = $1 AND backordered >= $2;
+	`
+
+	setPaid = `
+		UPDATE orders SET paid_at = $2, payment_status = 'paid'
+		WHERE id = $1 AND paid_at IS NULL;
+	`
+
+	setDepositPaid = `
+		UPDATE orders SET deposit_paid_at = $2, payment_due_at = NULL
+		WHERE id = $1 AND deposit_paid_at IS NULL;
+	`
+
+	setPaymentFailed = `
+		UPDATE orders SET payment_status = 'failed'
+		WHERE id = $1 AND paid_at IS NULL;
+	`
+
+	getUnpaidPending = `
+		SELECT uuid FROM orders
+		WHERE status = $1 AND paid_at IS NULL AND deposit_paid_at IS NULL AND payment_due_at < $2
+		ORDER BY payment_due_at ASC, id ASC
+		LIMIT $3;
+	`
 )
@@ -0,0 +1,47 @@
+// Package payments implements payment transaction repos
+package payments
+
+import (
+	"context"
+
+	"github.com/swclabs/swipex/app"
+	"github.com/swclabs/swipex/internal/core/domain/entity"
+	"github.com/swclabs/swipex/pkg/infra/db"
+)
+
+var _ = app.Repos(New)
+
+// New creates a new Payments object
+func New(conn db.IDatabase) IPayments {
+	return &Payments{db: conn}
+}
+
+var _ IPayments = (*Payments)(nil)
+
+// Payments represents the repos for the payment transactions ledger
+type Payments struct {
+	db db.IDatabase
+}
+
+// Insert implements IPayments.
+func (p *Payments) Insert(ctx context.Context, transaction entity.PaymentTransaction) (int64, error) {
+	payload := string(transaction.Payload)
+	if payload == "" {
+		payload = "{}"
+	}
+	return p.db.SafeWriteReturn(ctx, insert,
+		transaction.OrderUUID, transaction.Provider, transaction.Event, transaction.Status,
+		transaction.Amount.String(), transaction.CurrencyCode, transaction.TransactionNo,
+		transaction.BankCode, transaction.ResponseCode, transaction.Message, payload,
+		transaction.Reference,
+	)
+}
+
+// GetByOrderUUID implements IPayments.
+func (p *Payments) GetByOrderUUID(ctx context.Context, orderCode string) ([]entity.PaymentTransaction, error) {
+	rows, err := p.db.Query(ctx, getByOrderUUID, orderCode)
+	if err != nil {
+		return nil, err
+	}
+	return db.CollectRows[entity.PaymentTransaction](rows)
+}
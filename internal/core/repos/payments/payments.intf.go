@@ -0,0 +1,16 @@
+package payments
+
+import (
+	"context"
+
+	"github.com/swclabs/swipex/internal/core/domain/entity"
+)
+
+// IPayments interface for the payment transactions ledger
+type IPayments interface {
+	// Insert appends a step of a payment to the ledger
+	Insert(ctx context.Context, transaction entity.PaymentTransaction) (int64, error)
+
+	// GetByOrderUUID lists the payment transactions of an order, oldest first
+	GetByOrderUUID(ctx context.Context, orderCode string) ([]entity.PaymentTransaction, error)
+}
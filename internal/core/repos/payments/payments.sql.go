@@ -0,0 +1,14 @@
+package payments
+
+const (
+	insert = `
+		INSERT INTO payment_transactions (order_uuid, provider, event, status, amount,
+			currency_code, transaction_no, bank_code, response_code, message, payload, reference)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11::jsonb, $12)
+		RETURNING id;
+	`
+
+	getByOrderUUID = `
+		SELECT * FROM payment_transactions WHERE order_uuid = $1 ORDER BY id ASC;
+	`
+)
@@ -0,0 +1,89 @@
+// Package refunds implements refund repos
+package refunds
+
+import (
+	"context"
+	"time"
+
+	"github.com/swclabs/swipex/app"
+	"github.com/swclabs/swipex/internal/core/domain/entity"
+	"github.com/swclabs/swipex/pkg/infra/db"
+)
+
+var _ = app.Repos(New)
+
+// New creates a new Refunds object
+func New(conn db.IDatabase) IRefunds {
+	return &Refunds{db: conn}
+}
+
+var _ IRefunds = (*Refunds)(nil)
+
+// Refunds represents the repos for refunds
+type Refunds struct {
+	db db.IDatabase
+}
+
+// Create implements IRefunds.
+func (r *Refunds) Create(ctx context.Context, refund entity.Refund) (int64, error) {
+	return r.db.SafeWriteReturn(ctx, insert,
+		refund.OrderID, refund.ReturnID, refund.Amount.String(),
+		refund.CurrencyCode, refund.Status, refund.Reason,
+		refund.Provider, refund.TransactionNo, refund.Full, refund.Actor,
+	)
+}
+
+// GetByOrderID implements IRefunds.
+func (r *Refunds) GetByOrderID(ctx context.Context, orderID int64) ([]entity.Refund, error) {
+	rows, err := r.db.Query(ctx, getByOrderID, orderID)
+	if err != nil {
+		return nil, err
+	}
+	return db.CollectRows[entity.Refund](rows)
+}
+
+// GetByReturnID implements IRefunds.
+func (r *Refunds) GetByReturnID(ctx context.Context, returnID int64) (*entity.Refund, error) {
+	rows, err := r.db.Query(ctx, getByReturnID, returnID)
+	if err != nil {
+		return nil, err
+	}
+	refund, err := db.CollectRow[entity.Refund](rows)
+	if err != nil {
+		return nil, err
+	}
+	return &refund, nil
+}
+
+// GetByID implements IRefunds.
+func (r *Refunds) GetByID(ctx context.Context, ID int64) (*entity.Refund, error) {
+	rows, err := r.db.Query(ctx, getByID, ID)
+	if err != nil {
+		return nil, err
+	}
+	refund, err := db.CollectRow[entity.Refund](rows)
+	if err != nil {
+		return nil, err
+	}
+	return &refund, nil
+}
+
+// Claim implements IRefunds.
+func (r *Refunds) Claim(ctx context.Context, ID int64, lease time.Duration) (*entity.Refund, error) {
+	rows, err := r.db.Query(ctx, claim, ID, lease.Seconds())
+	if err != nil {
+		return nil, err
+	}
+	refund, err := db.CollectRow[entity.Refund](rows)
+	if err != nil {
+		return nil, err
+	}
+	return &refund, nil
+}
+
+// UpdateResult implements IRefunds.
+func (r *Refunds) UpdateResult(ctx context.Context, refund entity.Refund) error {
+	return r.db.SafeWrite(ctx, updateResult,
+		refund.ID, refund.Status, refund.RefundNo, refund.ResponseCode, refund.Message,
+	)
+}
@@ -0,0 +1,27 @@
+package refunds
+
+import (
+	"context"
+	"time"
+
+	"github.com/swclabs/swipex/internal/core/domain/entity"
+)
+
+// IRefunds interface for refunds repos
+type IRefunds interface {
+	Create(ctx context.Context, refund entity.Refund) (int64, error)
+	GetByOrderID(ctx context.Context, orderID int64) ([]entity.Refund, error)
+	GetByReturnID(ctx context.Context, returnID int64) (*entity.Refund, error)
+
+	GetByID(ctx context.Context, ID int64) (*entity.Refund, error)
+
+	// Claim marks a refund in flight to its provider and counts the attempt:
+	// a pending refund of a provider, or a refund in flight for longer than
+	// lease, whose worker was lost. Returns pgx.ErrNoRows when the refund
+	// cannot be claimed.
+	Claim(ctx context.Context, ID int64, lease time.Duration) (*entity.Refund, error)
+
+	// UpdateResult records the answer of the provider to a refund in flight:
+	// its status, refund number, response code and message
+	UpdateResult(ctx context.Context, refund entity.Refund) error
+}
@@ -0,0 +1,58 @@
+package refunds
+
+import (
+	"context"
+	"time"
+
+	"github.com/swclabs/swipex/internal/core/domain/entity"
+
+	"github.com/stretchr/testify/mock"
+)
+
+var _ IRefunds = (*Mock)(nil)
+
+// Mock represents a mock for IRefunds.
+type Mock struct {
+	mock.Mock
+}
+
+// NewRefundsMock creates a new mock for IRefunds.
+func NewRefundsMock() *Mock {
+	return &Mock{}
+}
+
+// Create implements IRefunds.
+func (r *Mock) Create(ctx context.Context, refund entity.Refund) (int64, error) {
+	args := r.Called(ctx, refund)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// GetByOrderID implements IRefunds.
+func (r *Mock) GetByOrderID(_ context.Context, _ int64) ([]entity.Refund, error) {
+	panic("unimplemented")
+}
+
+// GetByReturnID implements IRefunds.
+func (r *Mock) GetByReturnID(_ context.Context, _ int64) (*entity.Refund, error) {
+	panic("unimplemented")
+}
+
+// GetByID implements IRefunds.
+func (r *Mock) GetByID(ctx context.Context, ID int64) (*entity.Refund, error) {
+	args := r.Called(ctx, ID)
+	refund, _ := args.Get(0).(*entity.Refund)
+	return refund, args.Error(1)
+}
+
+// Claim implements IRefunds.
+func (r *Mock) Claim(ctx context.Context, ID int64, lease time.Duration) (*entity.Refund, error) {
+	args := r.Called(ctx, ID, lease)
+	refund, _ := args.Get(0).(*entity.Refund)
+	return refund, args.Error(1)
+}
+
+// UpdateResult implements IRefunds.
+func (r *Mock) UpdateResult(ctx context.Context, refund entity.Refund) error {
+	args := r.Called(ctx, refund)
+	return args.Error(0)
+}
@@ -0,0 +1,38 @@
+package refunds
+
+const (
+	insert = `
+		INSERT INTO refunds (order_id, return_id, amount, currency_code, status, reason,
+			provider, transaction_no, full_refund, actor)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING id;
+	`
+
+	getByOrderID = `
+		SELECT * FROM refunds WHERE order_id = $1 ORDER BY id ASC;
+	`
+
+	getByReturnID = `
+		SELECT * FROM refunds WHERE return_id = $1;
+	`
+
+	getByID = `
+		SELECT * FROM refunds WHERE id = $1;
+	`
+
+	claim = `
+		UPDATE refunds
+		SET status = 'in_flight', attempts = attempts + 1, updated_at = (now() at time zone 'utc')
+		WHERE id = $1 AND provider <> ''
+			AND (status = 'pending'
+				OR (status = 'in_flight' AND updated_at < (now() at time zone 'utc') - make_interval(secs => $2)))
+		RETURNING *;
+	`
+
+	updateResult = `
+		UPDATE refunds
+		SET status = $2, refund_no = $3, response_code = $4, message = $5,
+			updated_at = (now() at time zone 'utc')
+		WHERE id = $1 AND status = 'in_flight';
+	`
+)
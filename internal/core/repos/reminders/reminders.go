@@ -0,0 +1,51 @@
+// Package reminders implements cart reminder repos
+package reminders
+
+import (
+	"context"
+	"time"
+
+	"github.com/swclabs/swipex/app"
+	"github.com/swclabs/swipex/internal/core/domain/entity"
+	"github.com/swclabs/swipex/internal/core/domain/model"
+	"github.com/swclabs/swipex/pkg/infra/db"
+)
+
+var _ = app.Repos(New)
+
+// New creates a new Reminders object
+func New(conn db.IDatabase) IReminders {
+	return &Reminders{db: conn}
+}
+
+var _ IReminders = (*Reminders)(nil)
+
+// Reminders represents the repos for cart reminders
+type Reminders struct {
+	db db.IDatabase
+}
+
+// Create implements IReminders.
+func (r *Reminders) Create(ctx context.Context, reminder entity.CartReminder) (int64, error) {
+	return r.db.SafeWriteReturn(ctx, insert,
+		reminder.UserID, reminder.Items, reminder.CartUpdatedAt,
+	)
+}
+
+// Delete implements IReminders.
+func (r *Reminders) Delete(ctx context.Context, ID int64) error {
+	return r.db.SafeWrite(ctx, deleteByID, ID)
+}
+
+// Stats implements IReminders.
+func (r *Reminders) Stats(ctx context.Context, since time.Time) (*model.CartReminderStats, error) {
+	rows, err := r.db.Query(ctx, selectStats, since)
+	if err != nil {
+		return nil, err
+	}
+	stats, err := db.CollectRow[model.CartReminderStats](rows)
+	if err != nil {
+		return nil, err
+	}
+	return &stats, nil
+}
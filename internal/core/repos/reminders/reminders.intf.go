@@ -0,0 +1,21 @@
+package reminders
+
+import (
+	"context"
+	"time"
+
+	"github.com/swclabs/swipex/internal/core/domain/entity"
+	"github.com/swclabs/swipex/internal/core/domain/model"
+)
+
+// IReminders interface for cart reminders repos
+type IReminders interface {
+	// Create records a reminder sent for an abandoned cart
+	Create(ctx context.Context, reminder entity.CartReminder) (int64, error)
+
+	// Delete removes a reminder whose email could not be queued
+	Delete(ctx context.Context, ID int64) error
+
+	// Stats summarizes the reminders sent, Recent counts those sent after since
+	Stats(ctx context.Context, since time.Time) (*model.CartReminderStats, error)
+}
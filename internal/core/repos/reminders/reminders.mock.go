@@ -0,0 +1,40 @@
+package reminders
+
+import (
+	"context"
+	"time"
+
+	"github.com/swclabs/swipex/internal/core/domain/entity"
+	"github.com/swclabs/swipex/internal/core/domain/model"
+
+	"github.com/stretchr/testify/mock"
+)
+
+var _ IReminders = (*Mock)(nil)
+
+// Mock represents a mock for IReminders.
+type Mock struct {
+	mock.Mock
+}
+
+// NewRemindersMock creates a new mock for IReminders.
+func NewRemindersMock() *Mock {
+	return &Mock{}
+}
+
+// Create implements IReminders.
+func (r *Mock) Create(ctx context.Context, reminder entity.CartReminder) (int64, error) {
+	args := r.Called(ctx, reminder)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// Delete implements IReminders.
+func (r *Mock) Delete(ctx context.Context, ID int64) error {
+	args := r.Called(ctx, ID)
+	return args.Error(0)
+}
+
+// Stats implements IReminders.
+func (r *Mock) Stats(_ context.Context, _ time.Time) (*model.CartReminderStats, error) {
+	panic("unimplemented")
+}
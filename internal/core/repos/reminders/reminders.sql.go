@@ -0,0 +1,22 @@
+package reminders
+
+const (
+	insert = `
+		INSERT INTO cart_reminders (user_id, items, cart_updated_at)
+		VALUES ($1, $2, $3)
+		RETURNING id;
+	`
+
+	deleteByID = `
+		DELETE FROM cart_reminders WHERE id = $1;
+	`
+
+	selectStats = `
+		SELECT
+			COUNT(*)::bigint AS total,
+			COUNT(*) FILTER (WHERE sent_at > $1)::bigint AS recent,
+			COALESCE(SUM(items), 0)::bigint AS items,
+			MAX(sent_at) AS last_sent_at
+		FROM cart_reminders;
+	`
+)
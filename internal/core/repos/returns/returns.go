@@ -0,0 +1,113 @@
+// Package returns implements return request repos
+package returns
+
+import (
+	"context"
+
+	"github.com/swclabs/swipex/app"
+	"github.com/swclabs/swipex/internal/core/domain/entity"
+	"github.com/swclabs/swipex/pkg/infra/db"
+)
+
+var _ = app.Repos(New)
+
+// New creates a new Returns object
+func New(conn db.IDatabase) IReturns {
+	return &Returns{db: conn}
+}
+
+var _ IReturns = (*Returns)(nil)
+
+// Returns represents the repos for returns
+type Returns struct {
+	db db.IDatabase
+}
+
+// Create implements IReturns.
+func (r *Returns) Create(ctx context.Context, ret entity.Return) (int64, error) {
+	return r.db.SafeWriteReturn(ctx, insert,
+		ret.OrderID, ret.UserID, ret.Status, ret.Reason, ret.Photos,
+	)
+}
+
+// InsertItem implements IReturns.
+func (r *Returns) InsertItem(ctx context.Context, item entity.ReturnItem) error {
+	return r.db.SafeWrite(ctx, insertItem, item.ReturnID, item.ProductInOrderID, item.Quantity)
+}
+
+// GetByID implements IReturns.
+func (r *Returns) GetByID(ctx context.Context, ID int64) (*entity.Return, error) {
+	rows, err := r.db.Query(ctx, getByID, ID)
+	if err != nil {
+		return nil, err
+	}
+	ret, err := db.CollectRow[entity.Return](rows)
+	if err != nil {
+		return nil, err
+	}
+	return &ret, nil
+}
+
+// GetByIDForUpdate implements IReturns.
+func (r *Returns) GetByIDForUpdate(ctx context.Context, ID int64) (*entity.Return, error) {
+	rows, err := r.db.Query(ctx, getByIDForUpdate, ID)
+	if err != nil {
+		return nil, err
+	}
+	ret, err := db.CollectRow[entity.Return](rows)
+	if err != nil {
+		return nil, err
+	}
+	return &ret, nil
+}
+
+// GetByUserID implements IReturns.
+func (r *Returns) GetByUserID(ctx context.Context, userID int64, limit int) ([]entity.Return, error) {
+	rows, err := r.db.Query(ctx, getByUserID, userID, limit)
+	if err != nil {
+		return nil, err
+	}
+	return db.CollectRows[entity.Return](rows)
+}
+
+// GetLimit implements IReturns.
+func (r *Returns) GetLimit(ctx context.Context, status string, limit int) ([]entity.Return, error) {
+	rows, err := r.db.Query(ctx, getLimit, status, limit)
+	if err != nil {
+		return nil, err
+	}
+	return db.CollectRows[entity.Return](rows)
+}
+
+// GetItems implements IReturns.
+func (r *Returns) GetItems(ctx context.Context, returnID int64) ([]entity.ReturnItem, error) {
+	rows, err := r.db.Query(ctx, getItems, returnID)
+	if err != nil {
+		return nil, err
+	}
+	return db.CollectRows[entity.ReturnItem](rows)
+}
+
+// GetReturnedQuantity implements IReturns.
+func (r *Returns) GetReturnedQuantity(ctx context.Context, productInOrderID int64, statuses []string) (int64, error) {
+	rows, err := r.db.Query(ctx, getReturnedQuantity, productInOrderID, statuses)
+	if err != nil {
+		return 0, err
+	}
+	return db.CollectValue[int64](rows)
+}
+
+// UpdateStatus implements IReturns.
+func (r *Returns) UpdateStatus(ctx context.Context, ID int64, status string, note string) error {
+	return r.db.SafeWrite(ctx, updateStatus, ID, status, note)
+}
+
+// MarkReceived implements IReturns.
+func (r *Returns) MarkReceived(ctx context.Context, ID int64) error {
+	return r.db.SafeWrite(ctx, markReceived, ID)
+}
+
+// MarkItemRestocked implements IReturns.
+func (r *Returns) MarkItemRestocked(ctx context.Context, itemID int64) error {
+	return r.db.SafeWrite(ctx, markItemRestocked, itemID)
+}
@@ -0,0 +1,22 @@
+package returns
+
+import (
+	"context"
+
+	"github.com/swclabs/swipex/internal/core/domain/entity"
+)
+
+// IReturns interface for returns repos
+type IReturns interface {
+	Create(ctx context.Context, ret entity.Return) (int64, error)
+	InsertItem(ctx context.Context, item entity.ReturnItem) error
+	GetByID(ctx context.Context, ID int64) (*entity.Return, error)
+	GetByIDForUpdate(ctx context.Context, ID int64) (*entity.Return, error)
+	GetByUserID(ctx context.Context, userID int64, limit int) ([]entity.Return, error)
+	GetLimit(ctx context.Context, status string, limit int) ([]entity.Return, error)
+	GetItems(ctx context.Context, returnID int64) ([]entity.ReturnItem, error)
+	GetReturnedQuantity(ctx context.Context, productInOrderID int64, statuses []string) (int64, error)
+	UpdateStatus(ctx context.Context, ID int64, status string, note string) error
+	MarkReceived(ctx context.Context, ID int64) error
+	MarkItemRestocked(ctx context.Context, itemID int64) error
+}
@@ -0,0 +1,77 @@
+package returns
+
+import (
+	"context"
+
+	"github.com/swclabs/swipex/internal/core/domain/entity"
+
+	"github.com/stretchr/testify/mock"
+)
+
+var _ IReturns = (*Mock)(nil)
+
+// Mock represents a mock for IReturns.
+type Mock struct {
+	mock.Mock
+}
+
+// Create implements IReturns.
+func (r *Mock) Create(ctx context.Context, ret entity.Return) (int64, error) {
+	args := r.Called(ctx, ret)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// InsertItem implements IReturns.
+func (r *Mock) InsertItem(ctx context.Context, item entity.ReturnItem) error {
+	return r.Called(ctx, item).Error(0)
+}
+
+// GetByID implements IReturns.
+func (r *Mock) GetByID(_ context.Context, _ int64) (*entity.Return, error) {
+	panic("unimplemented")
+}
+
+// GetByIDForUpdate implements IReturns.
+func (r *Mock) GetByIDForUpdate(ctx context.Context, ID int64) (*entity.Return, error) {
+	args := r.Called(ctx, ID)
+	ret, _ := args.Get(0).(*entity.Return)
+	return ret, args.Error(1)
+}
+
+// GetByUserID implements IReturns.
+func (r *Mock) GetByUserID(_ context.Context, _ int64, _ int) ([]entity.Return, error) {
+	panic("unimplemented")
+}
+
+// GetLimit implements IReturns.
+func (r *Mock) GetLimit(_ context.Context, _ string, _ int) ([]entity.Return, error) {
+	panic("unimplemented")
+}
+
+// GetItems implements IReturns.
+func (r *Mock) GetItems(ctx context.Context, returnID int64) ([]entity.ReturnItem, error) {
+	args := r.Called(ctx, returnID)
+	items, _ := args.Get(0).([]entity.ReturnItem)
+	return items, args.Error(1)
+}
+
+// GetReturnedQuantity implements IReturns.
+func (r *Mock) GetReturnedQuantity(ctx context.Context, productInOrderID int64, statuses []string) (int64, error) {
+	args := r.Called(ctx, productInOrderID, statuses)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// UpdateStatus implements IReturns.
+func (r *Mock) UpdateStatus(ctx context.Context, ID int64, status string, note string) error {
+	return r.Called(ctx, ID, status, note).Error(0)
+}
+
+// MarkReceived implements IReturns.
+func (r *Mock) MarkReceived(ctx context.Context, ID int64) error {
+	return r.Called(ctx, ID).Error(0)
+}
+
+// MarkItemRestocked implements IReturns.
+func (r *Mock) MarkItemRestocked(ctx context.Context, itemID int64) error {
+	return r.Called(ctx, itemID).Error(0)
+}
@@ -0,0 +1,64 @@
+package returns
+
+const (
+	insert = `
+		INSERT INTO returns (order_id, user_id, status, reason, photos)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id;
+	`
+
+	insertItem = `
+		INSERT INTO return_items (return_id, product_in_order_id, quantity)
+		VALUES ($1, $2, $3);
+	`
+
+	getByID = `
+		SELECT * FROM returns WHERE id = $1;
+	`
+
+	getByIDForUpdate = `
+		SELECT * FROM returns WHERE id = $1 FOR UPDATE;
+	`
+
+	getByUserID = `
+		SELECT * FROM returns
+		WHERE user_id = $1
+		ORDER BY id DESC
+		LIMIT $2;
+	`
+
+	getLimit = `
+		SELECT * FROM returns
+		WHERE status = $1 OR $1 = ''
+		ORDER BY id DESC
+		LIMIT $2;
+	`
+
+	getItems = `
+		SELECT * FROM return_items WHERE return_id = $1 ORDER BY id ASC;
+	`
+
+	getReturnedQuantity = `
+		SELECT COALESCE(SUM(return_items.quantity), 0)::bigint
+		FROM return_items JOIN returns ON returns.id = return_items.return_id
+		WHERE return_items.product_in_order_id = $1 AND returns.status = ANY($2);
+	`
+
+	updateStatus = `
+		UPDATE returns
+		SET status = $2, note = $3
+		WHERE id = $1;
+	`
+
+	markReceived = `
+		UPDATE returns
+		SET received_at = (now() at time zone 'utc')
+		WHERE id = $1;
+	`
+
+	markItemRestocked = `
+		UPDATE return_items
+		SET restocked = true
+		WHERE id = $1;
+	`
+)
@@ -0,0 +1,78 @@
+// Package shipments implements the repos of the shipments of the orders
+package shipments
+
+import (
+	"context"
+	"time"
+
+	"github.com/swclabs/swipex/app"
+	"github.com/swclabs/swipex/internal/core/domain/entity"
+	"github.com/swclabs/swipex/pkg/infra/db"
+)
+
+var _ = app.Repos(New)
+
+// New creates a new Shipments object
+func New(conn db.IDatabase) IShipments {
+	return &Shipments{db: conn}
+}
+
+var _ IShipments = (*Shipments)(nil)
+
+// Shipments represents the repos for the shipments
+type Shipments struct {
+	db db.IDatabase
+}
+
+// Create implements IShipments.
+func (s *Shipments) Create(ctx context.Context, shipment entity.Shipment) (int64, error) {
+	return s.db.SafeWriteReturn(ctx, insertShipment,
+		shipment.OrderID, shipment.DeliveryID, shipment.CarrierOrderCode, shipment.Status, shipment.Actor,
+	)
+}
+
+// InsertItem implements IShipments.
+func (s *Shipments) InsertItem(ctx context.Context, item entity.ShipmentItem) error {
+	return s.db.SafeWrite(ctx, insertItem, item.ShipmentID, item.ItemID, item.Quantity)
+}
+
+// GetByIDForUpdate implements IShipments.
+func (s *Shipments) GetByIDForUpdate(ctx context.Context, ID int64) (*entity.Shipment, error) {
+	rows, err := s.db.Query(ctx, selectForUpdate, ID)
+	if err != nil {
+		return nil, err
+	}
+	shipment, err := db.CollectRow[entity.Shipment](rows)
+	if err != nil {
+		return nil, err
+	}
+	return &shipment, nil
+}
+
+// GetByOrderID implements IShipments.
+func (s *Shipments) GetByOrderID(ctx context.Context, orderID int64) ([]entity.Shipment, error) {
+	rows, err := s.db.Query(ctx, selectByOrderID, orderID)
+	if err != nil {
+		return nil, err
+	}
+	return db.CollectRows[entity.Shipment](rows)
+}
+
+// GetItemsByOrderID implements IShipments.
+func (s *Shipments) GetItemsByOrderID(ctx context.Context, orderID int64) ([]entity.ShipmentItem, error) {
+	rows, err := s.db.Query(ctx, selectItemsByOrderID, orderID)
+	if err != nil {
+		return nil, err
+	}
+	return db.CollectRows[entity.ShipmentItem](rows)
+}
+
+// UpdateStatus implements IShipments.
+func (s *Shipments) UpdateStatus(ctx context.Context, ID int64, status string, at time.Time) error {
+	return s.db.SafeWrite(ctx, updateStatus, ID, status, at)
+}
+
+// SetCarrierOrderCode implements IShipments.
+func (s *Shipments) SetCarrierOrderCode(ctx context.Context, ID int64, code string) error {
+	return s.db.SafeWrite(ctx, updateCarrierOrderCode, ID, code)
+}
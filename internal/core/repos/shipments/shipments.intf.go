@@ -0,0 +1,32 @@
+package shipments
+
+import (
+	"context"
+	"time"
+
+	"github.com/swclabs/swipex/internal/core/domain/entity"
+)
+
+// IShipments interface for the shipments of the orders
+type IShipments interface {
+	// Create creates a shipment and returns its ID
+	Create(ctx context.Context, shipment entity.Shipment) (int64, error)
+
+	// InsertItem adds the units of an order line to a shipment
+	InsertItem(ctx context.Context, item entity.ShipmentItem) error
+
+	// GetByIDForUpdate returns a shipment and locks it
+	GetByIDForUpdate(ctx context.Context, ID int64) (*entity.Shipment, error)
+
+	// GetByOrderID lists the shipments of an order, the first created first
+	GetByOrderID(ctx context.Context, orderID int64) ([]entity.Shipment, error)
+
+	// GetItemsByOrderID lists the items of all shipments of an order
+	GetItemsByOrderID(ctx context.Context, orderID int64) ([]entity.ShipmentItem, error)
+
+	// UpdateStatus moves a shipment to status at the given time
+	UpdateStatus(ctx context.Context, ID int64, status string, at time.Time) error
+
+	// SetCarrierOrderCode sets the code of the carrier order of a shipment
+	SetCarrierOrderCode(ctx context.Context, ID int64, code string) error
+}
@@ -0,0 +1,59 @@
+package shipments
+
+import (
+	"context"
+	"time"
+
+	"github.com/swclabs/swipex/internal/core/domain/entity"
+
+	"github.com/stretchr/testify/mock"
+)
+
+var _ IShipments = (*Mock)(nil)
+
+// Mock represents a mock for IShipments.
+type Mock struct {
+	mock.Mock
+}
+
+// Create implements IShipments.
+func (s *Mock) Create(ctx context.Context, shipment entity.Shipment) (int64, error) {
+	args := s.Called(ctx, shipment)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+// InsertItem implements IShipments.
+func (s *Mock) InsertItem(ctx context.Context, item entity.ShipmentItem) error {
+	return s.Called(ctx, item).Error(0)
+}
+
+// GetByIDForUpdate implements IShipments.
+func (s *Mock) GetByIDForUpdate(ctx context.Context, ID int64) (*entity.Shipment, error) {
+	args := s.Called(ctx, ID)
+	shipment, _ := args.Get(0).(*entity.Shipment)
+	return shipment, args.Error(1)
+}
+
+// GetByOrderID implements IShipments.
+func (s *Mock) GetByOrderID(ctx context.Context, orderID int64) ([]entity.Shipment, error) {
+	args := s.Called(ctx, orderID)
+	shipments, _ := args.Get(0).([]entity.Shipment)
+	return shipments, args.Error(1)
+}
+
+// GetItemsByOrderID implements IShipments.
+func (s *Mock) GetItemsByOrderID(ctx context.Context, orderID int64) ([]entity.ShipmentItem, error) {
+	args := s.Called(ctx, orderID)
+	items, _ := args.Get(0).([]entity.ShipmentItem)
+	return items, args.Error(1)
+}
+
+// UpdateStatus implements IShipments.
+func (s *Mock) UpdateStatus(ctx context.Context, ID int64, status string, at time.Time) error {
+	return s.Called(ctx, ID, status, at).Error(0)
+}
+
+// SetCarrierOrderCode implements IShipments.
+func (s *Mock) SetCarrierOrderCode(ctx context.Context, ID int64, code string) error {
+	return s.Called(ctx, ID, code).Error(0)
+}
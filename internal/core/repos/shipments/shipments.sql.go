@@ -0,0 +1,42 @@
+package shipments
+
+const (
+	insertShipment = `
+		INSERT INTO shipments (order_id, delivery_id, carrier_order_code, status, actor)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id;
+	`
+
+	insertItem = `
+		INSERT INTO shipment_items (shipment_id, item_id, quantity)
+		VALUES ($1, $2, $3);
+	`
+
+	selectForUpdate = `
+		SELECT * FROM shipments WHERE id = $1 FOR UPDATE;
+	`
+
+	selectByOrderID = `
+		SELECT * FROM shipments WHERE order_id = $1 ORDER BY id;
+	`
+
+	selectItemsByOrderID = `
+		SELECT shipment_items.*
+		FROM shipment_items JOIN shipments ON shipments.id = shipment_items.shipment_id
+		WHERE shipments.order_id = $1
+		ORDER BY shipment_items.shipment_id, shipment_items.item_id;
+	`
+
+	// the time of the status is kept in its own column
+	updateStatus = `
+		UPDATE shipments
+		SET status = $2,
+			shipped_at = CASE WHEN $2 = 'shipping' THEN $3 ELSE shipped_at END,
+			delivered_at = CASE WHEN $2 = 'delivered' THEN $3 ELSE delivered_at END
+		WHERE id = $1;
+	`
+
+	updateCarrierOrderCode = `
+		UPDATE shipments SET carrier_order_code = $2 WHERE id = $1;
+	`
+)
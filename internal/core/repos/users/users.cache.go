@@ -56,3 +56,8 @@ func (c *_cache) Save(ctx context.Context, user entity.User) error {
 func (c *_cache) GetByID(ctx context.Context, id int64) (*entity.User, error) {
 	return c.user.GetByID(ctx, id)
 }
+
+// SetCartReminderOptOut implements IUserRepository.
+func (c *_cache) SetCartReminderOptOut(ctx context.Context, email string, optOut bool) error {
+	return c.user.SetCartReminderOptOut(ctx, email, optOut)
+}
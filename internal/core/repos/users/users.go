@@ -110,3 +110,8 @@ func (usr *Users) GetByPhone(ctx context.Context, nPhone string) (*entity.User,
 	}
 	return &user, nil
 }
+
+// SetCartReminderOptOut implements IUserRepository.
+func (usr *Users) SetCartReminderOptOut(ctx context.Context, email string, optOut bool) error {
+	return usr.db.SafeWrite(ctx, updateCartReminderOptOut, email, optOut)
+}
@@ -31,4 +31,7 @@ type IUsers interface {
 
 	// OAuth2SaveInfo saves Users information from OAuth2 login.
 	OAuth2SaveInfo(ctx context.Context, user entity.User) error
+
+	// SetCartReminderOptOut turns the abandoned cart emails of a user off or on.
+	SetCartReminderOptOut(ctx context.Context, email string, optOut bool) error
 }
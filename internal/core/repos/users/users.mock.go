@@ -61,3 +61,9 @@ func (u *Mock) Save(ctx context.Context, user entity.User) error {
 func (u *Mock) GetByID(_ context.Context, _ int64) (*entity.User, error) {
 	panic("unimplemented")
 }
+
+// SetCartReminderOptOut implements IUserRepository.
+func (u *Mock) SetCartReminderOptOut(ctx context.Context, email string, optOut bool) error {
+	args := u.Called(ctx, email, optOut)
+	return args.Error(0)
+}
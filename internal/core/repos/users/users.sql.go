@@ -8,7 +8,8 @@ const (
 	`
 
 	selectUserInfo string = `
-		SELECT users.id, users.email, phone_number, first_name, last_name, image, username, role
+		SELECT users.id, users.email, phone_number, first_name, last_name, image, username, role,
+			cart_reminder_opt_out, loyalty_points, lifetime_points
 		FROM users 
 		JOIN accounts ON users.email = accounts.email
 		WHERE users.email = $1;
@@ -44,6 +45,11 @@ const (
 				last_name = EXCLUDED.last_name,
 				image = EXCLUDED.image;
 	`
+	updateCartReminderOptOut string = `
+		UPDATE users
+		SET cart_reminder_opt_out = $2
+		WHERE email = $1;
+	`
 	selectByEmail string = `
 		SELECT *
 		FROM users
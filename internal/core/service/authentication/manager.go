@@ -16,8 +16,10 @@ import (
 	"mime/multipart"
 
 	"github.com/swclabs/swipex/app"
+	"github.com/swclabs/swipex/internal/config"
 	"github.com/swclabs/swipex/internal/core/domain/dtos"
 	"github.com/swclabs/swipex/internal/core/domain/entity"
+	"github.com/swclabs/swipex/internal/core/domain/enum"
 	"github.com/swclabs/swipex/internal/core/domain/model"
 	"github.com/swclabs/swipex/internal/core/repos/accounts"
 	"github.com/swclabs/swipex/internal/core/repos/addresses"
@@ -136,7 +138,13 @@ func (auth *Authentication) Login(ctx context.Context, req dtos.LoginRequest) (s
 // UserInfo return user information from Database
 func (auth *Authentication) UserInfo(ctx context.Context, email string) (*model.Users, error) {
 	// get user information
-	return auth.User.Info(ctx, email)
+	user, err := auth.User.Info(ctx, email)
+	if err != nil {
+		return nil, err
+	}
+	user.LoyaltyTier = enum.LoyaltyTierOf(user.LifetimePoints,
+		config.LoyaltySilverPoints, config.LoyaltyGoldPoints).String()
+	return user, nil
 }
 
 // UpdateUserInfo update user information to database
@@ -150,6 +158,11 @@ func (auth *Authentication) UpdateUserInfo(ctx context.Context, req dtos.UserUpd
 	})
 }
 
+// UpdateNotifications update the email notification settings of a user
+func (auth *Authentication) UpdateNotifications(ctx context.Context, email string, req dtos.Notifications) error {
+	return auth.User.SetCartReminderOptOut(ctx, email, !req.CartReminders)
+}
+
 // UploadAvatar upload image to blob storage and save img url to database
 func (auth *Authentication) UploadAvatar(email string, fileHeader *multipart.FileHeader) error {
 	file, err := fileHeader.Open()
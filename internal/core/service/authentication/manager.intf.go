@@ -41,6 +41,12 @@ type IAuthentication interface {
 	// Returns an error if any issues occur during the update process.
 	UpdateUserInfo(ctx context.Context, req dtos.UserUpdate) error
 
+	// UpdateNotifications updates the email notification settings of a user.
+	// ctx is the context to manage the request's lifecycle.
+	// email is the email address of the user.
+	// Returns an error if any issues occur during the update process.
+	UpdateNotifications(ctx context.Context, email string, req dtos.Notifications) error
+
 	// UploadAvatar uploads a user's avatar.
 	// email is the email address of the user.
 	// fileHeader contains the file header of the avatar to be uploaded.
@@ -81,6 +81,11 @@ func (t *Task) UserInfo(ctx context.Context, email string) (*model.Users, error)
 	return t.service.UserInfo(ctx, email)
 }
 
+// UpdateNotifications update the email notification settings of a user
+func (t *Task) UpdateNotifications(ctx context.Context, email string, req dtos.Notifications) error {
+	return t.service.UpdateNotifications(ctx, email, req)
+}
+
 // UploadAvatar upload avatar to database
 func (t *Task) UploadAvatar(email string, fileHeader *multipart.FileHeader) error {
 	return t.service.UploadAvatar(email, fileHeader)
@@ -3,19 +3,26 @@ package classify
 
 import (
 	"context"
+	"errors"
 	"log"
 
 	"github.com/swclabs/swipex/app"
 
+	"github.com/swclabs/swipex/internal/config"
 	"github.com/swclabs/swipex/internal/core/domain/dtos"
 	"github.com/swclabs/swipex/internal/core/domain/entity"
 	"github.com/swclabs/swipex/internal/core/repos/categories"
 	"github.com/swclabs/swipex/internal/core/repos/suppliers"
 	"github.com/swclabs/swipex/pkg/infra/db"
+
+	"github.com/shopspring/decimal"
 )
 
 var _ = app.Service(New)
 
+// ErrInvalidVATRate is returned when a category VAT rate is not a percentage.
+var ErrInvalidVATRate = errors.New("vat_rate must be between 0 and 100")
+
 // New creates a new Classify object
 func New(
 	category categories.ICategories,
@@ -35,6 +42,12 @@ type Classify struct {
 
 // CreateCategory implements IClassify.
 func (c *Classify) CreateCategory(ctx context.Context, ctg entity.Category) error {
+	if !ctg.VATRate.Valid {
+		ctg.VATRate = decimal.NewNullDecimal(config.VATRate)
+	}
+	if !validVATRate(ctg.VATRate) {
+		return ErrInvalidVATRate
+	}
 	return c.Category.Insert(ctx, ctg)
 }
 
@@ -79,11 +92,20 @@ func (c *Classify) DelCategoryByID(ctx context.Context, categoryID int64) error
 // UpdateCategoryInfo implements IProductService.
 func (c *Classify) UpdateCategoryInfo(ctx context.Context, category dtos.UpdateCategories) error {
 
+	if !validVATRate(category.VATRate) {
+		return ErrInvalidVATRate
+	}
 	_category := entity.Category{
 		ID:          category.ID,
 		Name:        category.Name,
 		Description: category.Description,
+		VATRate:     category.VATRate,
 	}
 
 	return c.Category.Update(ctx, _category)
 }
+
+// validVATRate reports whether an optional VAT rate is a percentage.
+func validVATRate(rate decimal.NullDecimal) bool {
+	return !rate.Valid || (!rate.Decimal.IsNegative() && rate.Decimal.LessThanOrEqual(decimal.NewFromInt(100)))
+}
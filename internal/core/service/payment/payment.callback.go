@@ -0,0 +1,225 @@
+package payment
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/swclabs/swipex/internal/config"
+	"github.com/swclabs/swipex/internal/core/domain/dtos"
+	"github.com/swclabs/swipex/internal/core/domain/entity"
+	"github.com/swclabs/swipex/internal/core/domain/enum"
+	"github.com/swclabs/swipex/internal/core/domain/model"
+	"github.com/swclabs/swipex/internal/core/repos/orders"
+	"github.com/swclabs/swipex/internal/core/repos/payments"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+)
+
+// codes of the results of the callbacks, the answers VNPay expects from
+// the IPN URL
+const (
+	RspConfirmed        = "00"
+	RspOrderNotFound    = "01"
+	RspAlreadyConfirmed = "02"
+	RspInvalidAmount    = "04"
+	RspInvalidChecksum  = "97"
+	RspUnknownError     = "99"
+)
+
+// PaymentReturn checks the callback of a provider, from the return URL or
+// the IPN URL, and records its result on the order. A callback for an order
+// already paid or for another amount is rejected, a payment of an order
+// cancelled or expired is recorded to be refunded. The error is only returned
+// when the callback could not be processed. Every callback is recorded in
+// the payment transactions ledger.
+func (p *Payment) PaymentReturn(ctx context.Context,
+	event enum.PaymentEvent, providerName string, req model.PaymentCallbackRequest) (*dtos.PaymentResult, error) {
+	provider, err := p.Provider(providerName)
+	if err != nil {
+		return nil, err
+	}
+	callback, err := provider.VerifyCallback(ctx, req)
+	switch {
+	case errors.Is(err, ErrInvalidSignature):
+		result := &dtos.PaymentResult{
+			OrderCode: callback.OrderCode,
+			RspCode:   RspInvalidChecksum,
+			Message:   "Invalid Checksum",
+		}
+		return result, p.recordCallback(ctx, p.Ledger, event, provider.Name(), req, callback, result)
+	case errors.Is(err, ErrInvalidCallback):
+		if callback == nil {
+			callback = &model.PaymentCallback{}
+		}
+		result := &dtos.PaymentResult{
+			OrderCode: callback.OrderCode,
+			RspCode:   RspUnknownError,
+			Message:   err.Error(),
+		}
+		return result, p.recordCallback(ctx, p.Ledger, event, provider.Name(), req, callback, result)
+	case err != nil:
+		return nil, err
+	}
+
+	tx, err := p.begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result, err := p.recordPayment(ctx, tx.Order, tx.Ledger, callback)
+	if err == nil {
+		err = p.recordCallback(ctx, tx.Ledger, event, provider.Name(), req, callback, result)
+	}
+	if err != nil {
+		if errTx := tx.Rollback(ctx); errTx != nil {
+			log.Fatal(errTx)
+		}
+		return nil, err
+	}
+	return result, tx.Commit(ctx)
+}
+
+// recordPayment marks the order of a verified callback paid or failed, or
+// the deposit of a pre-order paid.
+func (p *Payment) recordPayment(ctx context.Context, orderRepo orders.IOrders,
+	ledger payments.IPayments, callback *model.PaymentCallback) (*dtos.PaymentResult, error) {
+	result := &dtos.PaymentResult{OrderCode: callback.OrderCode}
+	order, err := orderRepo.GetByUUIDForUpdate(ctx, callback.OrderCode)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			result.RspCode, result.Message = RspOrderNotFound, "Order not found"
+			return result, nil
+		}
+		return nil, err
+	}
+	// a replayed callback finds the order paid, or the payment it reports
+	// already recorded when it paid the deposit of a pre-order
+	replayed, err := recorded(ctx, ledger, order.UUID, callback.TransactionNo)
+	if err != nil {
+		return nil, err
+	}
+	if order.PaidAt != nil || replayed {
+		result.Paid = true
+		result.RspCode, result.Message = RspAlreadyConfirmed, "Order already confirmed"
+		return result, nil
+	}
+	// the order was given up before the payment arrived: it stays unpaid and
+	// the provider is told the callback was handled, what was paid is
+	// refunded
+	if order.Status == enum.OrderCancelled.String() || order.Status == enum.OrderExpired.String() {
+		result.RspCode, result.Message = RspAlreadyConfirmed, fmt.Sprintf("Order %s", order.Status)
+		if callback.Success {
+			result.RefundDue = true
+			result.Message += ", payment to refund"
+		}
+		return result, nil
+	}
+	if due := AmountDue(order); !callback.Amount.Equal(due) {
+		result.RspCode = RspInvalidAmount
+		result.Message = fmt.Sprintf("Invalid amount: %s paid, %s due", callback.Amount, due)
+		return result, nil
+	}
+
+	switch {
+	case callback.Success && order.DepositAmount.IsPositive() && order.DepositPaidAt == nil:
+		if err := orderRepo.SetDepositPaid(ctx, order.ID, callback.PaidAt.UTC()); err != nil {
+			return nil, err
+		}
+		result.Paid = true
+	case callback.Success:
+		if err := orderRepo.SetPaid(ctx, order.ID, callback.PaidAt.UTC()); err != nil {
+			return nil, err
+		}
+		result.Paid = true
+	default:
+		if err := orderRepo.SetPaymentFailed(ctx, order.ID); err != nil {
+			return nil, err
+		}
+	}
+	result.RspCode, result.Message = RspConfirmed, "Confirm Success"
+	return result, nil
+}
+
+// recorded reports whether the ledger holds a succeeded payment of the
+// order with the transaction number of the provider.
+func recorded(ctx context.Context, ledger payments.IPayments, orderCode string, transactionNo string) (bool, error) {
+	if transactionNo == "" || transactionNo == "0" {
+		return false, nil
+	}
+	transactions, err := ledger.GetByOrderUUID(ctx, orderCode)
+	if err != nil {
+		return false, err
+	}
+	for _, transaction := range transactions {
+		if transaction.TransactionNo == transactionNo &&
+			transaction.Status == enum.PaymentSucceeded.String() &&
+			transaction.Event != enum.PaymentRefund.String() {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// recordCallback appends a callback and its result to the ledger, the
+// callback is stored as received.
+func (p *Payment) recordCallback(ctx context.Context, ledger payments.IPayments, event enum.PaymentEvent,
+	provider string, req model.PaymentCallbackRequest, callback *model.PaymentCallback, result *dtos.PaymentResult) error {
+	payload := req.Body
+	if len(payload) == 0 || !json.Valid(payload) {
+		params := make(map[string]string, len(req.Query))
+		for key := range req.Query {
+			params[key] = req.Query.Get(key)
+		}
+		var err error
+		if payload, err = json.Marshal(params); err != nil {
+			return err
+		}
+	}
+	status := enum.PaymentRejected
+	switch {
+	case result.RefundDue:
+		status = enum.PaymentRefundDue
+	case result.RspCode == RspConfirmed:
+		status = enum.PaymentFailed
+		if result.Paid {
+			status = enum.PaymentSucceeded
+		}
+	}
+	_, err := ledger.Insert(ctx, entity.PaymentTransaction{
+		OrderUUID:     result.OrderCode,
+		Provider:      provider,
+		Event:         event.String(),
+		Status:        status.String(),
+		Amount:        callback.Amount,
+		CurrencyCode:  paymentCurrency,
+		TransactionNo: callback.TransactionNo,
+		BankCode:      callback.BankCode,
+		ResponseCode:  callback.ResponseCode,
+		Message:       result.Message,
+		Payload:       payload,
+	})
+	return err
+}
+
+// AmountDue returns the amount of an order left to pay online in the base
+// currency, rounded to what the provider is charged: the total of the order
+// less the store credit and points settled when it was placed. A pre-order
+// pays its deposit first, which the store credit and points paid for, then
+// the balance.
+func AmountDue(order *entity.Order) decimal.Decimal {
+	due := order.TotalAmount.Sub(order.CreditAmount).Sub(order.PointsAmount)
+	if order.DepositAmount.IsPositive() {
+		if order.DepositPaidAt == nil {
+			due = order.DepositAmount.Sub(order.CreditAmount).Sub(order.PointsAmount)
+		} else {
+			due = order.TotalAmount.Sub(order.DepositAmount)
+		}
+	}
+	if order.CurrencyCode != "" && order.CurrencyCode != config.BaseCurrency && order.ExchangeRate.IsPositive() {
+		due = due.Mul(order.ExchangeRate)
+	}
+	return model.RoundCurrency(due, paymentCurrency)
+}
@@ -0,0 +1,40 @@
+package payment
+
+import "errors"
+
+// ErrUnknownProvider is returned for a payment provider that does not exist.
+var ErrUnknownProvider = errors.New("unknown payment provider")
+
+// ErrInvalidSignature is returned for a callback its provider did not sign.
+var ErrInvalidSignature = errors.New("invalid signature")
+
+// ErrInvalidCallback is wrapped when a callback cannot be read.
+var ErrInvalidCallback = errors.New("invalid callback")
+
+// ErrProviderRejected is wrapped when a provider rejects a request.
+var ErrProviderRejected = errors.New("payment provider rejected the request")
+
+// ErrOrderNotFound is returned when the order of a payment does not exist.
+var ErrOrderNotFound = errors.New("order not found")
+
+// ErrPaymentNotAllowed is wrapped when an order cannot be paid: it was
+// cancelled, expired, already paid or has nothing left to pay online.
+var ErrPaymentNotAllowed = errors.New("payment not allowed")
+
+// ErrPaymentNotFound is returned for an order no payment was created for.
+var ErrPaymentNotFound = errors.New("payment not found")
+
+// ErrRefundNotFound is returned for a refund that does not exist.
+var ErrRefundNotFound = errors.New("refund not found")
+
+// ErrRefundInFlight is returned when a refund is being asked to its
+// provider by another worker.
+var ErrRefundInFlight = errors.New("refund in flight")
+
+// ErrRefundProcessing is wrapped when the provider of a refund has not
+// finished paying it back.
+var ErrRefundProcessing = errors.New("refund processing")
+
+// ErrRefundNotAllowed is returned when an order cannot be refunded through
+// its payment provider, or not for the amount asked.
+var ErrRefundNotAllowed = errors.New("refund not allowed")
@@ -6,25 +6,99 @@ import (
 
 	"github.com/swclabs/swipex/app"
 	"github.com/swclabs/swipex/internal/config"
+	"github.com/swclabs/swipex/internal/core/domain/x/momo"
+	"github.com/swclabs/swipex/internal/core/domain/x/zalopay"
+	"github.com/swclabs/swipex/internal/core/repos/orders"
+	"github.com/swclabs/swipex/internal/core/repos/payments"
+	"github.com/swclabs/swipex/internal/core/repos/refunds"
 	"github.com/swclabs/swipex/pkg/gen/payment"
+	"github.com/swclabs/swipex/pkg/lib/vnpay"
+	"github.com/swclabs/swipex/pkg/lib/worker"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
 )
 
 var _ = app.Service(New)
 
-func New() *Payment {
-	conn, err := grpc.NewClient(config.PaymentService, grpc.WithTransportCredentials(insecure.NewCredentials()))
-	if err != nil {
-		log.Fatalf("failed to connect to payment service: %v", err)
+// New creates the payment service with the providers of the payment
+// methods, VNPay payments are signed by the backend set in config.
+func New(ledger payments.IPayments, order orders.IOrders, refund refunds.IRefunds) *Payment {
+	var client payment.VNPayClient
+	if config.PaymentBackend == "native" {
+		client = Native(merchant())
+	} else {
+		conn, err := grpc.NewClient(config.PaymentService, grpc.WithTransportCredentials(insecure.NewCredentials()))
+		if err != nil {
+			log.Fatalf("failed to connect to payment service: %v", err)
+		}
+		client = payment.NewVNPayClient(conn)
 	}
 	return &Payment{
-		payment.NewVNPayClient(conn),
+		client: client,
+		providers: indexProviders(
+			NewVNPay(client, merchant()),
+			NewMoMo(momo.Account{
+				Endpoint:    config.MoMoEndpoint,
+				PartnerCode: config.MoMoPartnerCode,
+				AccessKey:   config.MoMoAccessKey,
+				SecretKey:   config.MoMoSecretKey,
+				RedirectURL: config.MoMoRedirectURL,
+				IPNURL:      config.MoMoIPNURL,
+			}),
+			NewZaloPay(zalopay.Account{
+				Endpoint:    config.ZaloPayEndpoint,
+				AppID:       config.ZaloPayAppID,
+				Key1:        config.ZaloPayKey1,
+				Key2:        config.ZaloPayKey2,
+				RedirectURL: config.ZaloPayRedirectURL,
+				CallbackURL: config.ZaloPayCallbackURL,
+			}),
+		),
+		Ledger: ledger,
+		Order:  order,
+		Refund: refund,
+		Worker: worker.NewClient(config.RedisHost, config.RedisPort, config.RedisPassword),
 	}
 }
 
+// indexProviders indexes payment providers by the payment method of their
+// orders.
+func indexProviders(providers ...PaymentProvider) map[string]PaymentProvider {
+	byName := make(map[string]PaymentProvider, len(providers))
+	for _, provider := range providers {
+		byName[provider.Name()] = provider
+	}
+	return byName
+}
+
+// merchant returns the VNPay merchant account of the config.
+func merchant() vnpay.Merchant {
+	return vnpay.Merchant{
+		TmnCode:    config.VNPayTmnCode,
+		HashSecret: config.VNPayHashSecret,
+		PaymentURL: config.VNPayPaymentURL,
+		ReturnURL:  config.VNPayReturnURL,
+		APIURL:     config.VNPayAPIURL,
+	}
+}
+
+// Payment is the service of the online payments of the orders.
 type Payment struct {
-	client payment.VNPayClient
+	client    payment.VNPayClient
+	providers map[string]PaymentProvider
+	Ledger    payments.IPayments
+	Order     orders.IOrders
+	Refund    refunds.IRefunds
+	Worker    worker.IWorkerClient
+
+	// Begin starts the transactions of the service, a transaction of the
+	// database when nil
+	Begin func(ctx context.Context) (*Tx, error)
+}
+
+// NewWithProviders creates the payment service with the given providers.
+func NewWithProviders(ledger payments.IPayments, order orders.IOrders, providers ...PaymentProvider) *Payment {
+	return &Payment{providers: indexProviders(providers...), Ledger: ledger, Order: order}
 }
 
 // CheckStatus implements payment.VNPayClient.
@@ -41,3 +115,37 @@ func (p *Payment) ProcessPayment(ctx context.Context, in *payment.PaymentRequest
 func (p *Payment) ProcessPaymentReturn(ctx context.Context, in *payment.PaymentReturnRequest, opts ...grpc.CallOption) (*payment.PaymentReturnResponse, error) {
 	return p.client.ProcessPaymentReturn(ctx, in, opts...)
 }
+
+// Native returns a client of the VNPay payment service signing the payments
+// in process for merchant.
+func Native(merchant vnpay.Merchant) payment.VNPayClient {
+	return &server{vnpay.New(merchant)}
+}
+
+// server calls a payment.VNPayServer in process, the call options are
+// ignored.
+type server struct {
+	server payment.VNPayServer
+}
+
+var _ payment.VNPayClient = (*server)(nil)
+
+// CheckStatus implements payment.VNPayClient.
+func (s *server) CheckStatus(ctx context.Context, in *payment.StatusRequest, _ ...grpc.CallOption) (*payment.StatusResponse, error) {
+	return s.server.CheckStatus(ctx, in)
+}
+
+// ProcessPayment implements payment.VNPayClient.
+func (s *server) ProcessPayment(ctx context.Context, in *payment.PaymentRequest, _ ...grpc.CallOption) (*payment.PaymentResponse, error) {
+	return s.server.ProcessPayment(ctx, in)
+}
+
+// ProcessPaymentReturn implements payment.VNPayClient.
+func (s *server) ProcessPaymentReturn(ctx context.Context, in *payment.PaymentReturnRequest, _ ...grpc.CallOption) (*payment.PaymentReturnResponse, error) {
+	return s.server.ProcessPaymentReturn(ctx, in)
+}
+
+// Refund implements payment.VNPayClient.
+func (s *server) Refund(ctx context.Context, in *payment.RefundRequest, _ ...grpc.CallOption) (*payment.RefundResponse, error) {
+	return s.server.Refund(ctx, in)
+}
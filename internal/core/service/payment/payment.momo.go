@@ -0,0 +1,300 @@
+package payment
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/swclabs/swipex/internal/core/domain/enum"
+	"github.com/swclabs/swipex/internal/core/domain/model"
+	"github.com/swclabs/swipex/internal/core/domain/x/momo"
+
+	"github.com/shopspring/decimal"
+)
+
+// result codes of the MoMo payment API
+const (
+	momoSuccess = 0
+)
+
+// momoPending are the result codes of the payments and refunds MoMo has not
+// completed yet
+var momoPending = map[int]bool{1000: true, 7000: true, 7002: true, 9000: true}
+
+// MoMo pays the orders with the MoMo wallet.
+type MoMo struct {
+	account momo.Account
+	now     func() time.Time
+}
+
+var _ PaymentProvider = (*MoMo)(nil)
+
+// NewMoMo creates the MoMo provider
+func NewMoMo(account momo.Account) *MoMo {
+	return &MoMo{account: account, now: time.Now}
+}
+
+// Name implements PaymentProvider.
+func (m *MoMo) Name() string {
+	return ProviderMoMo
+}
+
+// CreatePayment implements PaymentProvider.
+func (m *MoMo) CreatePayment(ctx context.Context, order model.PaymentOrder) (*model.PaymentCreated, error) {
+	// the order id of MoMo is unique to each payment of the order
+	req := momo.CreateRequest{
+		PartnerCode: m.account.PartnerCode,
+		RequestID:   m.requestID(order.OrderCode),
+		Amount:      order.Amount,
+		OrderID:     m.requestID(order.OrderCode),
+		OrderInfo:   order.Description,
+		RedirectURL: m.account.RedirectURL,
+		IpnURL:      m.account.IPNURL,
+		RequestType: "captureWallet",
+		Lang:        momoLang(order.Language),
+	}
+	req.Signature = m.sign(
+		"accessKey", m.account.AccessKey,
+		"amount", strconv.FormatInt(req.Amount, 10),
+		"extraData", req.ExtraData,
+		"ipnUrl", req.IpnURL,
+		"orderId", req.OrderID,
+		"orderInfo", req.OrderInfo,
+		"partnerCode", req.PartnerCode,
+		"redirectUrl", req.RedirectURL,
+		"requestId", req.RequestID,
+		"requestType", req.RequestType,
+	)
+	var resp momo.CreateResponse
+	if err := postJSON(ctx, m.account.Endpoint+"/v2/gateway/api/create", req, &resp); err != nil {
+		return nil, err
+	}
+	if resp.ResultCode != momoSuccess {
+		return nil, fmt.Errorf("%w: %d %s", ErrProviderRejected, resp.ResultCode, resp.Message)
+	}
+	return &model.PaymentCreated{URL: resp.PayURL, Reference: req.OrderID, Message: resp.Message}, nil
+}
+
+// VerifyCallback implements PaymentProvider. The IPN is posted in JSON, the
+// redirect adds the same fields to the query.
+func (m *MoMo) VerifyCallback(_ context.Context, req model.PaymentCallbackRequest) (*model.PaymentCallback, error) {
+	var callback momo.Callback
+	if len(req.Body) > 0 {
+		if err := json.Unmarshal(req.Body, &callback); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidCallback, err)
+		}
+	} else if err := momoCallback(req.Query, &callback); err != nil {
+		return nil, err
+	}
+	result := &model.PaymentCallback{
+		OrderCode:     orderCodeOf(callback.OrderID),
+		Amount:        decimal.NewFromInt(callback.Amount),
+		Success:       callback.ResultCode == momoSuccess,
+		PaidAt:        time.UnixMilli(callback.ResponseTime),
+		TransactionNo: strconv.FormatInt(callback.TransID, 10),
+		BankCode:      callback.PayType,
+		ResponseCode:  strconv.Itoa(callback.ResultCode),
+		Message:       callback.Message,
+	}
+	signature := m.sign(
+		"accessKey", m.account.AccessKey,
+		"amount", strconv.FormatInt(callback.Amount, 10),
+		"extraData", callback.ExtraData,
+		"message", callback.Message,
+		"orderId", callback.OrderID,
+		"orderInfo", callback.OrderInfo,
+		"orderType", callback.OrderType,
+		"partnerCode", callback.PartnerCode,
+		"payType", callback.PayType,
+		"requestId", callback.RequestID,
+		"responseTime", strconv.FormatInt(callback.ResponseTime, 10),
+		"resultCode", strconv.Itoa(callback.ResultCode),
+		"transId", strconv.FormatInt(callback.TransID, 10),
+	)
+	if !strings.EqualFold(signature, callback.Signature) {
+		return result, ErrInvalidSignature
+	}
+	return result, nil
+}
+
+// QueryStatus implements PaymentProvider.
+func (m *MoMo) QueryStatus(ctx context.Context, ref model.PaymentRef) (*model.PaymentState, error) {
+	req := momo.QueryRequest{
+		PartnerCode: m.account.PartnerCode,
+		RequestID:   m.requestID(ref.OrderCode),
+		OrderID:     ref.Reference,
+		Lang:        "vi",
+	}
+	req.Signature = m.sign(
+		"accessKey", m.account.AccessKey,
+		"orderId", req.OrderID,
+		"partnerCode", req.PartnerCode,
+		"requestId", req.RequestID,
+	)
+	var resp momo.QueryResponse
+	if err := postJSON(ctx, m.account.Endpoint+"/v2/gateway/api/query", req, &resp); err != nil {
+		return nil, err
+	}
+	state := &model.PaymentState{
+		Status:        enum.PaymentFailed,
+		TransactionNo: strconv.FormatInt(resp.TransID, 10),
+		Amount:        decimal.NewFromInt(resp.Amount),
+		ResponseCode:  strconv.Itoa(resp.ResultCode),
+		Message:       resp.Message,
+	}
+	switch {
+	case resp.ResultCode == momoSuccess:
+		state.Status = enum.PaymentSucceeded
+	case momoPending[resp.ResultCode]:
+		state.Status = enum.PaymentPending
+	}
+	return state, nil
+}
+
+// Refund implements PaymentProvider.
+func (m *MoMo) Refund(ctx context.Context, refund model.PaymentRefund) (*model.PaymentRefunded, error) {
+	transID, err := strconv.ParseInt(refund.Payment.TransactionNo, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("momo: the transaction %q of order %s is not a number",
+			refund.Payment.TransactionNo, refund.Payment.OrderCode)
+	}
+	req := momo.RefundRequest{
+		PartnerCode: m.account.PartnerCode,
+		OrderID:     refund.RefundID,
+		RequestID:   refund.RefundID,
+		Amount:      refund.Amount.IntPart(),
+		TransID:     transID,
+		Lang:        "vi",
+		Description: refundInfo(refund),
+	}
+	req.Signature = m.sign(
+		"accessKey", m.account.AccessKey,
+		"amount", strconv.FormatInt(req.Amount, 10),
+		"description", req.Description,
+		"orderId", req.OrderID,
+		"partnerCode", req.PartnerCode,
+		"requestId", req.RequestID,
+		"transId", strconv.FormatInt(req.TransID, 10),
+	)
+	var resp momo.RefundResponse
+	if err := postJSON(ctx, m.account.Endpoint+"/v2/gateway/api/refund", req, &resp); err != nil {
+		return nil, err
+	}
+	refunded := &model.PaymentRefunded{
+		Status:       enum.RefundFailed,
+		RefundNo:     strconv.FormatInt(resp.TransID, 10),
+		ResponseCode: strconv.Itoa(resp.ResultCode),
+		Message:      resp.Message,
+	}
+	switch {
+	case resp.ResultCode == momoSuccess:
+		refunded.Status = enum.RefundSucceeded
+	case momoPending[resp.ResultCode]:
+		refunded.Status = enum.RefundPending
+	}
+	return refunded, nil
+}
+
+// QueryRefund implements PaymentProvider. The refunds of the payment are
+// listed, the refund is the one whose id is its RefundID.
+func (m *MoMo) QueryRefund(ctx context.Context, refund model.PaymentRefund) (*model.PaymentRefunded, error) {
+	req := momo.RefundQueryRequest{
+		PartnerCode: m.account.PartnerCode,
+		RequestID:   m.requestID(refund.Payment.OrderCode),
+		OrderID:     refund.Payment.Reference,
+		Lang:        "vi",
+	}
+	req.Signature = m.sign(
+		"accessKey", m.account.AccessKey,
+		"orderId", req.OrderID,
+		"partnerCode", req.PartnerCode,
+		"requestId", req.RequestID,
+	)
+	var resp momo.RefundQueryResponse
+	if err := postJSON(ctx, m.account.Endpoint+"/v2/gateway/api/refund/query", req, &resp); err != nil {
+		return nil, err
+	}
+	if resp.ResultCode != momoSuccess {
+		return nil, fmt.Errorf("%w: %d %s", ErrProviderRejected, resp.ResultCode, resp.Message)
+	}
+	for _, trans := range resp.RefundTrans {
+		if trans.OrderID != refund.RefundID {
+			continue
+		}
+		refunded := &model.PaymentRefunded{
+			Status:       enum.RefundFailed,
+			RefundNo:     strconv.FormatInt(trans.TransID, 10),
+			ResponseCode: strconv.Itoa(trans.ResultCode),
+			Message:      resp.Message,
+		}
+		switch {
+		case trans.ResultCode == momoSuccess:
+			refunded.Status = enum.RefundSucceeded
+		case momoPending[trans.ResultCode]:
+			refunded.Status = enum.RefundPending
+		}
+		return refunded, nil
+	}
+	return nil, nil
+}
+
+// sign returns the signature of the fields given as name and value pairs,
+// in the order MoMo signs them.
+func (m *MoMo) sign(fields ...string) string {
+	pairs := make([]string, 0, len(fields)/2)
+	for i := 0; i+1 < len(fields); i += 2 {
+		pairs = append(pairs, fields[i]+"="+fields[i+1])
+	}
+	return hmacSHA256(m.account.SecretKey, strings.Join(pairs, "&"))
+}
+
+// requestID returns an id unique to a request about an order.
+func (m *MoMo) requestID(orderCode string) string {
+	return fmt.Sprintf("%s_%d", orderCode, m.now().UnixNano())
+}
+
+// momoCallback reads the callback MoMo adds to the redirect URL.
+func momoCallback(query url.Values, callback *momo.Callback) error {
+	var err error
+	callback.PartnerCode = query.Get("partnerCode")
+	callback.OrderID = query.Get("orderId")
+	callback.RequestID = query.Get("requestId")
+	callback.OrderInfo = query.Get("orderInfo")
+	callback.OrderType = query.Get("orderType")
+	callback.Message = query.Get("message")
+	callback.PayType = query.Get("payType")
+	callback.ExtraData = query.Get("extraData")
+	callback.Signature = query.Get("signature")
+	if callback.Amount, err = strconv.ParseInt(query.Get("amount"), 10, 64); err != nil {
+		return fmt.Errorf("%w: amount is not a number", ErrInvalidCallback)
+	}
+	if callback.TransID, err = strconv.ParseInt(query.Get("transId"), 10, 64); err != nil {
+		return fmt.Errorf("%w: transId is not a number", ErrInvalidCallback)
+	}
+	if callback.ResultCode, err = strconv.Atoi(query.Get("resultCode")); err != nil {
+		return fmt.Errorf("%w: resultCode is not a number", ErrInvalidCallback)
+	}
+	if callback.ResponseTime, err = strconv.ParseInt(query.Get("responseTime"), 10, 64); err != nil {
+		return fmt.Errorf("%w: responseTime is not a number", ErrInvalidCallback)
+	}
+	return nil
+}
+
+// momoLang returns the language of the MoMo payment page.
+func momoLang(language string) string {
+	if language == "en" {
+		return "en"
+	}
+	return "vi"
+}
+
+// orderCodeOf returns the order of a payment id made of the order code and
+// a suffix after an underscore.
+func orderCodeOf(paymentID string) string {
+	orderCode, _, _ := strings.Cut(paymentID, "_")
+	return orderCode
+}
@@ -0,0 +1,92 @@
+package payment
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/swclabs/swipex/internal/core/domain/model"
+)
+
+// PaymentProvider is a payment gateway the orders are paid with, its name
+// is the payment method of the orders.
+type PaymentProvider interface {
+	// Name returns the payment method of the orders paid with the provider.
+	Name() string
+
+	// CreatePayment returns where the customer pays an order.
+	// Returns an error wrapping ErrProviderRejected when the provider
+	// refuses the payment.
+	CreatePayment(ctx context.Context, order model.PaymentOrder) (*model.PaymentCreated, error)
+
+	// VerifyCallback checks the signature of a callback and reads it.
+	// Returns ErrInvalidSignature, with the callback as read, for a callback
+	// the provider did not sign, and an error wrapping ErrInvalidCallback
+	// for a callback that cannot be read.
+	VerifyCallback(ctx context.Context, req model.PaymentCallbackRequest) (*model.PaymentCallback, error)
+
+	// QueryStatus asks the provider for the status of a payment.
+	QueryStatus(ctx context.Context, ref model.PaymentRef) (*model.PaymentState, error)
+
+	// Refund asks the provider to pay back a payment in full or in part,
+	// a refund the provider declines is returned with the failed status.
+	Refund(ctx context.Context, refund model.PaymentRefund) (*model.PaymentRefunded, error)
+
+	// QueryRefund asks the provider for the status of a refund asked before,
+	// returns nil when the provider has no record of it.
+	QueryRefund(ctx context.Context, refund model.PaymentRefund) (*model.PaymentRefunded, error)
+}
+
+// names of the payment providers, the payment methods of their orders
+const (
+	ProviderVNPay   = "vnpay"
+	ProviderMoMo    = "momo"
+	ProviderZaloPay = "zalopay"
+)
+
+var httpClient = &http.Client{Timeout: 30 * time.Second}
+
+// Provider returns the provider of a payment method.
+func (p *Payment) Provider(name string) (PaymentProvider, error) {
+	provider, ok := p.providers[strings.ToLower(strings.TrimSpace(name))]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownProvider, name)
+	}
+	return provider, nil
+}
+
+// postJSON posts body to url and decodes the answer in out.
+func postJSON(ctx context.Context, url string, body any, out any) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s answered %s", url, resp.Status)
+	}
+	return json.NewDecoder(resp.Body).Decode(out)
+}
+
+// hmacSHA256 returns the HMAC-SHA256 of data in hex.
+func hmacSHA256(key string, data string) string {
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write([]byte(data))
+	return hex.EncodeToString(mac.Sum(nil))
+}
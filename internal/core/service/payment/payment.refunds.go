@@ -0,0 +1,266 @@
+package payment
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/swclabs/swipex/internal/core/domain/dtos"
+	"github.com/swclabs/swipex/internal/core/domain/entity"
+	"github.com/swclabs/swipex/internal/core/domain/enum"
+	"github.com/swclabs/swipex/internal/core/domain/model"
+	"github.com/swclabs/swipex/internal/core/repos/orders"
+	"github.com/swclabs/swipex/internal/core/repos/refunds"
+	"github.com/swclabs/swipex/internal/core/tasks"
+	"github.com/swclabs/swipex/internal/workers/queue"
+	"github.com/swclabs/swipex/pkg/infra/db"
+	"github.com/swclabs/swipex/pkg/lib/worker"
+	"github.com/swclabs/swipex/pkg/utils"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+)
+
+// CreateRefund records a refund of a paid order, pending until the worker
+// pays it back through the provider of the payment. The refund is full when
+// it pays back the whole payment at once.
+func (p *Payment) CreateRefund(ctx context.Context, actor string, req dtos.PaymentRefundRequest) (*dtos.PaymentRefund, error) {
+	tx, err := db.NewTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	refund, err := p.createRefund(ctx, orders.New(tx), refunds.New(tx), actor, req)
+	if err != nil {
+		if errTx := tx.Rollback(ctx); errTx != nil {
+			log.Fatal(errTx)
+		}
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	if err := p.queueRefund(ctx, refund.ID); err != nil {
+		return nil, err
+	}
+	return refundOf(req.OrderCode, *refund), nil
+}
+
+func (p *Payment) createRefund(ctx context.Context, orderRepo orders.IOrders,
+	refundRepo refunds.IRefunds, actor string, req dtos.PaymentRefundRequest) (*entity.Refund, error) {
+	order, err := orderRepo.GetByUUIDForUpdate(ctx, req.OrderCode)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrOrderNotFound
+		}
+		return nil, err
+	}
+	if order.PaidAt == nil && order.DepositPaidAt == nil {
+		return nil, fmt.Errorf("%w: order %s is not paid", ErrRefundNotAllowed, order.UUID)
+	}
+	provider, ref, err := p.paymentRef(ctx, order.UUID)
+	if err != nil {
+		return nil, err
+	}
+	if ref.TransactionNo == "" {
+		return nil, fmt.Errorf("%w: order %s was not paid through %s", ErrRefundNotAllowed, order.UUID, provider.Name())
+	}
+
+	// every refund of the order not declined is taken from the payment,
+	// the refunds of returns to store credit included
+	previous, err := refundRepo.GetByOrderID(ctx, order.ID)
+	if err != nil {
+		return nil, err
+	}
+	refunded := decimal.Zero
+	for _, refund := range previous {
+		if refund.Status != enum.RefundFailed.String() {
+			refunded = refunded.Add(paymentAmount(order, refund))
+		}
+	}
+	left := ref.Amount.Sub(refunded)
+	amount := left
+	if value := strings.TrimSpace(req.Amount); value != "" {
+		if amount, err = decimal.NewFromString(value); err != nil || !amount.IsPositive() || !amount.IsInteger() {
+			return nil, fmt.Errorf("%w: the amount must be a positive number of VND", ErrRefundNotAllowed)
+		}
+	}
+	if !amount.IsPositive() || amount.GreaterThan(left) {
+		return nil, fmt.Errorf("%w: %s VND of order %s is left to refund", ErrRefundNotAllowed, left, order.UUID)
+	}
+
+	refund := entity.Refund{
+		OrderID:       order.ID,
+		Amount:        amount,
+		CurrencyCode:  paymentCurrency,
+		Status:        enum.RefundPending.String(),
+		Reason:        req.Reason,
+		Provider:      provider.Name(),
+		TransactionNo: ref.TransactionNo,
+		Full:          refunded.IsZero() && amount.Equal(ref.Amount),
+		Actor:         actor,
+	}
+	if refund.ID, err = refundRepo.Create(ctx, refund); err != nil {
+		return nil, err
+	}
+	return &refund, nil
+}
+
+// refundLease is how long a refund stays in flight before another worker
+// may claim it, its worker is then taken as lost
+const refundLease = 2 * time.Minute
+
+// ProcessRefund pays back a pending refund through the provider of its
+// payment, run by the worker. The refund is claimed in flight before the
+// provider is asked, so that a task run twice pays back once, and a refund
+// asked before is queried at the provider before it is asked again. An
+// error of the provider, or a refund the provider is still processing,
+// leaves the refund in flight and is returned for the worker to retry.
+func (p *Payment) ProcessRefund(ctx context.Context, refundID int64) error {
+	refund, err := p.Refund.Claim(ctx, refundID, refundLease)
+	if err != nil {
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return err
+		}
+		// the refund is answered or another worker is asking for it
+		refund, err = p.Refund.GetByID(ctx, refundID)
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+			return ErrRefundNotFound
+		case err != nil:
+			return err
+		case refund.Status == enum.RefundInFlight.String():
+			return fmt.Errorf("%w: refund %d", ErrRefundInFlight, refundID)
+		}
+		return nil
+	}
+	order, err := p.Order.GetByID(ctx, refund.OrderID)
+	if err != nil {
+		return err
+	}
+	provider, ref, err := p.paymentRef(ctx, order.UUID)
+	if err != nil {
+		return err
+	}
+	ref.TransactionNo = refund.TransactionNo
+	req := model.PaymentRefund{
+		Payment:   *ref,
+		RefundID:  strconv.FormatInt(refund.ID, 10),
+		Amount:    refund.Amount,
+		Full:      refund.Full,
+		Reason:    refund.Reason,
+		Actor:     refund.Actor,
+		CreatedAt: refund.CreatedAt,
+	}
+
+	// an attempt before may have reached the provider
+	var result *model.PaymentRefunded
+	if refund.Attempts > 1 {
+		result, err = provider.QueryRefund(ctx, req)
+	}
+	if err == nil && result == nil {
+		result, err = provider.Refund(ctx, req)
+	}
+	if err != nil {
+		refund.Message = err.Error()
+		if errResult := p.Refund.UpdateResult(ctx, *refund); errResult != nil {
+			return errResult
+		}
+		return err
+	}
+
+	refund.RefundNo, refund.ResponseCode, refund.Message = result.RefundNo, result.ResponseCode, result.Message
+	if result.Status == enum.RefundPending {
+		if err := p.Refund.UpdateResult(ctx, *refund); err != nil {
+			return err
+		}
+		return fmt.Errorf("%w: refund %d", ErrRefundProcessing, refund.ID)
+	}
+	payload, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+	status := enum.PaymentFailed
+	if result.Status == enum.RefundSucceeded {
+		status = enum.PaymentSucceeded
+	}
+	// the ledger is written first: a refund left in flight is queried again
+	if _, err := p.Ledger.Insert(ctx, entity.PaymentTransaction{
+		OrderUUID:     order.UUID,
+		Provider:      provider.Name(),
+		Event:         enum.PaymentRefund.String(),
+		Status:        status.String(),
+		Amount:        refund.Amount,
+		CurrencyCode:  refund.CurrencyCode,
+		TransactionNo: result.RefundNo,
+		ResponseCode:  result.ResponseCode,
+		Message:       result.Message,
+		Payload:       payload,
+		Reference:     strconv.FormatInt(refund.ID, 10),
+	}); err != nil {
+		return err
+	}
+	refund.Status = result.Status.String()
+	return p.Refund.UpdateResult(ctx, *refund)
+}
+
+// GetRefunds returns the refunds of an order, oldest first.
+func (p *Payment) GetRefunds(ctx context.Context, orderCode string) ([]dtos.PaymentRefund, error) {
+	order, err := p.Order.GetByUUID(ctx, orderCode)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrOrderNotFound
+		}
+		return nil, err
+	}
+	all, err := p.Refund.GetByOrderID(ctx, order.ID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]dtos.PaymentRefund, 0, len(all))
+	for _, refund := range all {
+		result = append(result, *refundOf(order.UUID, refund))
+	}
+	return result, nil
+}
+
+// queueRefund queues the task paying back a refund.
+func (p *Payment) queueRefund(ctx context.Context, refundID int64) error {
+	return p.Worker.Exec(ctx, queue.OrderQueue,
+		worker.NewTask(tasks.PaymentRefund, dtos.PaymentRefundJob{ID: refundID}))
+}
+
+// paymentAmount returns the amount of a refund in the currency the order
+// was paid in, the refunds of returns are in the currency of the order.
+func paymentAmount(order *entity.Order, refund entity.Refund) decimal.Decimal {
+	if refund.CurrencyCode == "" || refund.CurrencyCode == paymentCurrency || !order.ExchangeRate.IsPositive() {
+		return refund.Amount
+	}
+	return model.RoundCurrency(refund.Amount.Mul(order.ExchangeRate), paymentCurrency)
+}
+
+// refundOf returns the response of a refund of an order
+func refundOf(orderCode string, refund entity.Refund) *dtos.PaymentRefund {
+	return &dtos.PaymentRefund{
+		ID:            refund.ID,
+		OrderCode:     orderCode,
+		Provider:      refund.Provider,
+		Amount:        refund.Amount.String(),
+		Currency:      refund.CurrencyCode,
+		Full:          refund.Full,
+		Status:        refund.Status,
+		TransactionNo: refund.TransactionNo,
+		RefundNo:      refund.RefundNo,
+		ResponseCode:  refund.ResponseCode,
+		Message:       refund.Message,
+		Attempts:      refund.Attempts,
+		Reason:        refund.Reason,
+		Actor:         refund.Actor,
+		CreatedAt:     utils.HanoiTimezone(refund.CreatedAt),
+		UpdatedAt:     utils.HanoiTimezone(refund.UpdatedAt),
+	}
+}
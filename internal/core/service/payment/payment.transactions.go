@@ -0,0 +1,176 @@
+package payment
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/swclabs/swipex/internal/core/domain/dtos"
+	"github.com/swclabs/swipex/internal/core/domain/entity"
+	"github.com/swclabs/swipex/internal/core/domain/enum"
+	"github.com/swclabs/swipex/internal/core/domain/model"
+	"github.com/swclabs/swipex/pkg/utils"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// paymentCurrency is the currency the providers are paid in
+const paymentCurrency = "VND"
+
+// CreatePayment asks the provider of an order where the customer pays it
+// and records the payment in the ledger, pending until the provider calls
+// back. The amount is what is left to pay online on the order, the balance
+// of a pre-order once its deposit is paid, and the provider is the payment
+// method of the order when the request names none. An order cancelled,
+// expired or already paid cannot be paid.
+func (p *Payment) CreatePayment(ctx context.Context, req dtos.PaymentRequest) (*dtos.PaymentResponse, error) {
+	order, err := p.Order.GetByUUID(ctx, req.OrderID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrOrderNotFound
+		}
+		return nil, err
+	}
+	switch {
+	case order.PaidAt != nil:
+		return nil, fmt.Errorf("%w: order %s is already paid", ErrPaymentNotAllowed, order.UUID)
+	case order.Status == enum.OrderCancelled.String() || order.Status == enum.OrderExpired.String():
+		return nil, fmt.Errorf("%w: order %s is %s", ErrPaymentNotAllowed, order.UUID, order.Status)
+	}
+	due := AmountDue(order)
+	if !due.IsPositive() {
+		return nil, fmt.Errorf("%w: order %s has nothing left to pay", ErrPaymentNotAllowed, order.UUID)
+	}
+	name := req.Provider
+	if name == "" {
+		name = order.PaymentMethod
+	}
+	provider, err := p.Provider(name)
+	if err != nil {
+		return nil, err
+	}
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	transaction := entity.PaymentTransaction{
+		OrderUUID:    req.OrderID,
+		Provider:     provider.Name(),
+		Event:        enum.PaymentCreate.String(),
+		Status:       enum.PaymentPending.String(),
+		Amount:       due,
+		CurrencyCode: paymentCurrency,
+		BankCode:     req.BankCode,
+		Payload:      payload,
+	}
+	created, err := provider.CreatePayment(ctx, model.PaymentOrder{
+		OrderCode:   req.OrderID,
+		Amount:      due.IntPart(),
+		Description: req.OrderDesc,
+		OrderType:   req.OrderType,
+		BankCode:    req.BankCode,
+		Language:    req.Language,
+		IPAddress:   req.IPAddress,
+	})
+	if err != nil {
+		transaction.Status, transaction.Message = enum.PaymentFailed.String(), err.Error()
+		if _, errLedger := p.Ledger.Insert(ctx, transaction); errLedger != nil {
+			return nil, errLedger
+		}
+		return nil, err
+	}
+	transaction.Message, transaction.Reference = created.Message, created.Reference
+	if _, err := p.Ledger.Insert(ctx, transaction); err != nil {
+		return nil, err
+	}
+	return &dtos.PaymentResponse{
+		PaymentURL: created.URL,
+		Message:    created.Message,
+		Success:    true,
+		Provider:   provider.Name(),
+	}, nil
+}
+
+// QueryPayment asks the provider of the last payment of an order for its
+// status.
+func (p *Payment) QueryPayment(ctx context.Context, orderCode string) (*dtos.PaymentStatus, error) {
+	provider, ref, err := p.paymentRef(ctx, orderCode)
+	if err != nil {
+		return nil, err
+	}
+	state, err := provider.QueryStatus(ctx, *ref)
+	if err != nil {
+		return nil, err
+	}
+	return &dtos.PaymentStatus{
+		OrderCode:     orderCode,
+		Provider:      provider.Name(),
+		Status:        state.Status.String(),
+		TransactionNo: state.TransactionNo,
+		Amount:        state.Amount.String(),
+		ResponseCode:  state.ResponseCode,
+		Message:       state.Message,
+	}, nil
+}
+
+// paymentRef returns the provider and the reference of the last payment of
+// an order from the ledger, with the transaction of the callback that paid
+// it if any. The refunds recorded in the ledger are not payments.
+func (p *Payment) paymentRef(ctx context.Context, orderCode string) (PaymentProvider, *model.PaymentRef, error) {
+	transactions, err := p.Ledger.GetByOrderUUID(ctx, orderCode)
+	if err != nil {
+		return nil, nil, err
+	}
+	var created, paid *entity.PaymentTransaction
+	for i := range transactions {
+		transaction := &transactions[i]
+		switch {
+		case transaction.Event == enum.PaymentCreate.String() && transaction.Status == enum.PaymentPending.String():
+			created = transaction
+		case transaction.Status == enum.PaymentSucceeded.String() && transaction.Event != enum.PaymentRefund.String():
+			paid = transaction
+		}
+	}
+	if created == nil {
+		return nil, nil, fmt.Errorf("%w: order %s", ErrPaymentNotFound, orderCode)
+	}
+	provider, err := p.Provider(created.Provider)
+	if err != nil {
+		return nil, nil, err
+	}
+	ref := &model.PaymentRef{OrderCode: orderCode, Reference: created.Reference, Amount: created.Amount}
+	if paid != nil && paid.Provider == created.Provider {
+		ref.TransactionNo, ref.Amount = paid.TransactionNo, paid.Amount
+	}
+	return provider, ref, nil
+}
+
+// GetTransactions returns the ledger of the payments of an order, oldest
+// first.
+func (p *Payment) GetTransactions(ctx context.Context, orderCode string) ([]dtos.PaymentTransaction, error) {
+	transactions, err := p.Ledger.GetByOrderUUID(ctx, orderCode)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]dtos.PaymentTransaction, 0, len(transactions))
+	for _, transaction := range transactions {
+		result = append(result, dtos.PaymentTransaction{
+			ID:            transaction.ID,
+			OrderCode:     transaction.OrderUUID,
+			Provider:      transaction.Provider,
+			Event:         transaction.Event,
+			Status:        transaction.Status,
+			Amount:        transaction.Amount.String(),
+			Currency:      transaction.CurrencyCode,
+			TransactionNo: transaction.TransactionNo,
+			BankCode:      transaction.BankCode,
+			ResponseCode:  transaction.ResponseCode,
+			Message:       transaction.Message,
+			Reference:     transaction.Reference,
+			Payload:       transaction.Payload,
+			CreatedAt:     utils.HanoiTimezone(transaction.CreatedAt),
+		})
+	}
+	return result, nil
+}
@@ -0,0 +1,32 @@
+package payment
+
+import (
+	"context"
+
+	"github.com/swclabs/swipex/internal/core/repos/orders"
+	"github.com/swclabs/swipex/internal/core/repos/payments"
+	"github.com/swclabs/swipex/pkg/infra/db"
+)
+
+// Tx is a transaction of the service with the repositories bound to it.
+type Tx struct {
+	db.ITransaction
+	Order  orders.IOrders
+	Ledger payments.IPayments
+}
+
+// begin starts a transaction of the service, through Begin when it is set.
+func (p *Payment) begin(ctx context.Context) (*Tx, error) {
+	if p.Begin != nil {
+		return p.Begin(ctx)
+	}
+	conn, err := db.NewTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &Tx{
+		ITransaction: conn,
+		Order:        orders.New(conn),
+		Ledger:       payments.New(conn),
+	}, nil
+}
@@ -0,0 +1,282 @@
+package payment
+
+import (
+	"context"
+	"crypto/md5"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/swclabs/swipex/internal/core/domain/enum"
+	"github.com/swclabs/swipex/internal/core/domain/model"
+	"github.com/swclabs/swipex/pkg/gen/payment"
+	"github.com/swclabs/swipex/pkg/lib/vnpay"
+
+	"github.com/shopspring/decimal"
+)
+
+// resultSuccess is the result of a callback whose checksum is valid
+const resultSuccess = "success"
+
+// vnpSuccess is the response code and transaction status of a payment
+// VNPay accepted
+const vnpSuccess = "00"
+
+// vnpDuplicate is the response code of a refund whose request id VNPay has
+// already received
+const vnpDuplicate = "94"
+
+// vnpRefundProcessing are the transaction statuses of a refund VNPay has
+// not paid back yet
+var vnpRefundProcessing = map[string]bool{"05": true, "06": true}
+
+// VNPay pays the orders with VNPay. The payments and refunds are signed by
+// the VNPay payment service, the merchant API queries them.
+type VNPay struct {
+	client   payment.VNPayClient
+	merchant vnpay.Merchant
+}
+
+var _ PaymentProvider = (*VNPay)(nil)
+
+// NewVNPay creates the VNPay provider
+func NewVNPay(client payment.VNPayClient, merchant vnpay.Merchant) *VNPay {
+	return &VNPay{client: client, merchant: merchant}
+}
+
+// Name implements PaymentProvider.
+func (v *VNPay) Name() string {
+	return ProviderVNPay
+}
+
+// CreatePayment implements PaymentProvider.
+func (v *VNPay) CreatePayment(ctx context.Context, order model.PaymentOrder) (*model.PaymentCreated, error) {
+	resp, err := v.client.ProcessPayment(ctx, &payment.PaymentRequest{
+		OrderType: order.OrderType,
+		OrderId:   order.OrderCode,
+		Amount:    order.Amount,
+		OrderDesc: order.Description,
+		BankCode:  order.BankCode,
+		Language:  order.Language,
+		IpAddress: order.IPAddress,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !resp.Success {
+		return nil, fmt.Errorf("%w: %s", ErrProviderRejected, resp.Message)
+	}
+	payURL, err := url.Parse(resp.PaymentUrl)
+	if err != nil {
+		return nil, err
+	}
+	// the merchant API asks for the date the payment was created
+	return &model.PaymentCreated{
+		URL:       resp.PaymentUrl,
+		Reference: payURL.Query().Get("vnp_CreateDate"),
+		Message:   resp.Message,
+	}, nil
+}
+
+// VerifyCallback implements PaymentProvider.
+func (v *VNPay) VerifyCallback(ctx context.Context, req model.PaymentCallbackRequest) (*model.PaymentCallback, error) {
+	query := req.Query
+	callback := &model.PaymentCallback{
+		OrderCode:     query.Get("vnp_TxnRef"),
+		TransactionNo: query.Get("vnp_TransactionNo"),
+		BankCode:      query.Get("vnp_BankCode"),
+		ResponseCode:  query.Get("vnp_ResponseCode"),
+	}
+	// vnp_Amount is the amount in VND times 100
+	if amount, err := decimal.NewFromString(query.Get("vnp_Amount")); err == nil {
+		callback.Amount = amount.Shift(-2)
+	}
+	returnReq, err := returnRequest(query)
+	if err != nil {
+		return callback, err
+	}
+	resp, err := v.client.ProcessPaymentReturn(ctx, returnReq)
+	if err != nil {
+		return nil, err
+	}
+	if resp.GetResult() != resultSuccess {
+		return callback, ErrInvalidSignature
+	}
+	callback.Success = returnReq.Vnp_ResponseCode == vnpSuccess && returnReq.Vnp_TransactionStatus == vnpSuccess
+	callback.Message = resp.GetMessage()
+	callback.PaidAt, err = time.ParseInLocation(vnpay.CreateDate, returnReq.Vnp_PayDate, vnpay.Location)
+	if err != nil {
+		callback.PaidAt = time.Now()
+	}
+	return callback, nil
+}
+
+// QueryStatus implements PaymentProvider.
+func (v *VNPay) QueryStatus(ctx context.Context, ref model.PaymentRef) (*model.PaymentState, error) {
+	resp, err := v.merchant.QueryDR(ctx, vnpay.QueryRequest{
+		TxnRef:          ref.OrderCode,
+		TransactionNo:   ref.TransactionNo,
+		TransactionDate: ref.Reference,
+		OrderInfo:       "Query payment of order " + ref.OrderCode,
+		IPAddress:       ipAddress(ref.IPAddress),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if resp.ResponseCode != vnpSuccess {
+		return nil, fmt.Errorf("%w: %s %s", ErrProviderRejected, resp.ResponseCode, resp.Message)
+	}
+	state := &model.PaymentState{
+		Status:        enum.PaymentFailed,
+		TransactionNo: resp.TransactionNo,
+		ResponseCode:  resp.TransactionStatus,
+		Message:       resp.Message,
+	}
+	if amount, err := decimal.NewFromString(resp.Amount); err == nil {
+		state.Amount = amount.Shift(-2)
+	}
+	switch resp.TransactionStatus {
+	case vnpSuccess:
+		state.Status = enum.PaymentSucceeded
+	case "01":
+		state.Status = enum.PaymentPending
+	}
+	return state, nil
+}
+
+// Refund implements PaymentProvider. The refund goes through the VNPay
+// payment service, which signs it for the merchant API.
+func (v *VNPay) Refund(ctx context.Context, refund model.PaymentRefund) (*model.PaymentRefunded, error) {
+	resp, err := v.client.Refund(ctx, &payment.RefundRequest{
+		RequestId:       refundRequestID(refund),
+		OrderId:         refund.Payment.OrderCode,
+		TransactionNo:   refund.Payment.TransactionNo,
+		TransactionDate: refund.Payment.Reference,
+		Amount:          refund.Amount.IntPart(),
+		Full:            refund.Full,
+		OrderInfo:       refundInfo(refund),
+		CreateBy:        refund.Actor,
+		IpAddress:       ipAddress(refund.Payment.IPAddress),
+	})
+	if err != nil {
+		return nil, err
+	}
+	// the refund was asked before, its status is queried on the next attempt
+	if resp.ResponseCode == vnpDuplicate {
+		return nil, fmt.Errorf("vnpay: refund %s of order %s already asked: %s",
+			refund.RefundID, refund.Payment.OrderCode, resp.Message)
+	}
+	refunded := &model.PaymentRefunded{
+		Status:       enum.RefundFailed,
+		RefundNo:     resp.TransactionNo,
+		ResponseCode: resp.ResponseCode,
+		Message:      resp.Message,
+	}
+	if resp.Success {
+		refunded.Status = enum.RefundSucceeded
+	}
+	return refunded, nil
+}
+
+// QueryRefund implements PaymentProvider. The merchant API answers with
+// the last transaction of the payment, the refund has no record unless it
+// is a refund of its amount.
+func (v *VNPay) QueryRefund(ctx context.Context, refund model.PaymentRefund) (*model.PaymentRefunded, error) {
+	resp, err := v.merchant.QueryDR(ctx, vnpay.QueryRequest{
+		TxnRef:          refund.Payment.OrderCode,
+		TransactionNo:   refund.Payment.TransactionNo,
+		TransactionDate: refund.Payment.Reference,
+		OrderInfo:       "Query refund of order " + refund.Payment.OrderCode,
+		IPAddress:       ipAddress(refund.Payment.IPAddress),
+	})
+	if err != nil {
+		return nil, err
+	}
+	if resp.ResponseCode != vnpSuccess {
+		return nil, fmt.Errorf("%w: %s %s", ErrProviderRejected, resp.ResponseCode, resp.Message)
+	}
+	if resp.TransactionType != vnpay.RefundFull && resp.TransactionType != vnpay.RefundPartial {
+		return nil, nil
+	}
+	// vnp_Amount is the amount in VND times 100
+	if amount, err := decimal.NewFromString(resp.Amount); err != nil || !amount.Shift(-2).Equal(refund.Amount) {
+		return nil, nil
+	}
+	refunded := &model.PaymentRefunded{
+		Status:       enum.RefundFailed,
+		RefundNo:     resp.TransactionNo,
+		ResponseCode: resp.TransactionStatus,
+		Message:      resp.Message,
+	}
+	switch {
+	case resp.TransactionStatus == vnpSuccess:
+		refunded.Status = enum.RefundSucceeded
+	case vnpRefundProcessing[resp.TransactionStatus]:
+		refunded.Status = enum.RefundPending
+	}
+	return refunded, nil
+}
+
+// returnRequest reads the query parameters of a VNPay callback.
+func returnRequest(query url.Values) (*payment.PaymentReturnRequest, error) {
+	req := &payment.PaymentReturnRequest{
+		Vnp_TmnCode:           query.Get("vnp_TmnCode"),
+		Vnp_BankCode:          query.Get("vnp_BankCode"),
+		Vnp_BankTranNo:        query.Get("vnp_BankTranNo"),
+		Vnp_CardType:          query.Get("vnp_CardType"),
+		Vnp_PayDate:           query.Get("vnp_PayDate"),
+		Vnp_OrderInfo:         query.Get("vnp_OrderInfo"),
+		Vnp_ResponseCode:      query.Get("vnp_ResponseCode"),
+		Vnp_TransactionStatus: query.Get("vnp_TransactionStatus"),
+		Vnp_TxnRef:            query.Get("vnp_TxnRef"),
+		Vnp_SecureHashType:    query.Get("vnp_SecureHashType"),
+		Vnp_SecureHash:        query.Get("vnp_SecureHash"),
+		Params:                map[string]string{},
+	}
+	// VNPay signs the parameters as sent, empty and zero ones included
+	for key := range query {
+		if strings.HasPrefix(key, "vnp_") {
+			req.Params[key] = query.Get(key)
+		}
+	}
+	if req.Vnp_TxnRef == "" || req.Vnp_SecureHash == "" {
+		return nil, fmt.Errorf("%w: missing vnp_TxnRef or vnp_SecureHash", ErrInvalidCallback)
+	}
+	var err error
+	if req.Vnp_Amount, err = strconv.ParseUint(query.Get("vnp_Amount"), 10, 64); err != nil {
+		return nil, fmt.Errorf("%w: vnp_Amount is not a number", ErrInvalidCallback)
+	}
+	if number := query.Get("vnp_TransactionNo"); number != "" {
+		if req.Vnp_TransactionNo, err = strconv.ParseUint(number, 10, 64); err != nil {
+			return nil, fmt.Errorf("%w: vnp_TransactionNo is not a number", ErrInvalidCallback)
+		}
+	}
+	return req, nil
+}
+
+// ipAddress returns the address the requests to the providers are made
+// from, the server when no customer or admin made them.
+func ipAddress(address string) string {
+	if address == "" {
+		return "127.0.0.1"
+	}
+	return address
+}
+
+// refundInfo describes a refund to the providers.
+func refundInfo(refund model.PaymentRefund) string {
+	if refund.Reason != "" {
+		return refund.Reason
+	}
+	return "Refund of order " + refund.Payment.OrderCode
+}
+
+// refundRequestID returns the vnp_RequestId of a refund, the same each
+// time the refund is asked so that VNPay pays it back once.
+func refundRequestID(refund model.PaymentRefund) string {
+	sum := md5.Sum([]byte(refund.Payment.OrderCode + ":" + refund.RefundID))
+	return hex.EncodeToString(sum[:])
+}
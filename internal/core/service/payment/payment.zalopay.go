@@ -0,0 +1,245 @@
+package payment
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/swclabs/swipex/internal/core/domain/enum"
+	"github.com/swclabs/swipex/internal/core/domain/model"
+	"github.com/swclabs/swipex/internal/core/domain/x/zalopay"
+	"github.com/swclabs/swipex/pkg/lib/vnpay"
+
+	"github.com/shopspring/decimal"
+)
+
+// return codes of the ZaloPay payment API
+const (
+	zaloSuccess    = 1
+	zaloProcessing = 3
+)
+
+// zaloDate is the date the ids of the ZaloPay payments and refunds start with
+const zaloDate = "060102"
+
+// ZaloPay pays the orders with the ZaloPay wallet.
+type ZaloPay struct {
+	account zalopay.Account
+	now     func() time.Time
+}
+
+var _ PaymentProvider = (*ZaloPay)(nil)
+
+// NewZaloPay creates the ZaloPay provider
+func NewZaloPay(account zalopay.Account) *ZaloPay {
+	return &ZaloPay{account: account, now: time.Now}
+}
+
+// Name implements PaymentProvider.
+func (z *ZaloPay) Name() string {
+	return ProviderZaloPay
+}
+
+// CreatePayment implements PaymentProvider.
+func (z *ZaloPay) CreatePayment(ctx context.Context, order model.PaymentOrder) (*model.PaymentCreated, error) {
+	now := z.now().In(vnpay.Location)
+	embed, err := json.Marshal(map[string]string{"redirecturl": z.account.RedirectURL})
+	if err != nil {
+		return nil, err
+	}
+	// app_trans_id is unique to each payment of the order
+	req := zalopay.CreateRequest{
+		AppID:       z.account.AppID,
+		AppUser:     "swipex",
+		AppTransID:  fmt.Sprintf("%s_%s_%d", now.Format(zaloDate), order.OrderCode, now.Unix()),
+		AppTime:     now.UnixMilli(),
+		Amount:      order.Amount,
+		Item:        "[]",
+		Description: order.Description,
+		EmbedData:   string(embed),
+		BankCode:    order.BankCode,
+		CallbackURL: z.account.CallbackURL,
+	}
+	req.Mac = hmacSHA256(z.account.Key1, pipe(
+		strconv.FormatInt(req.AppID, 10), req.AppTransID, req.AppUser,
+		strconv.FormatInt(req.Amount, 10), strconv.FormatInt(req.AppTime, 10),
+		req.EmbedData, req.Item,
+	))
+	var resp zalopay.CreateResponse
+	if err := postJSON(ctx, z.account.Endpoint+"/v2/create", req, &resp); err != nil {
+		return nil, err
+	}
+	if resp.ReturnCode != zaloSuccess {
+		return nil, fmt.Errorf("%w: %d %s", ErrProviderRejected, resp.SubReturnCode, resp.SubReturnMessage)
+	}
+	return &model.PaymentCreated{URL: resp.OrderURL, Reference: req.AppTransID, Message: resp.ReturnMessage}, nil
+}
+
+// VerifyCallback implements PaymentProvider. ZaloPay posts the callback of
+// the payments that succeeded, the redirect adds the status of every
+// payment to the query.
+func (z *ZaloPay) VerifyCallback(_ context.Context, req model.PaymentCallbackRequest) (*model.PaymentCallback, error) {
+	if len(req.Body) == 0 {
+		return z.verifyRedirect(req.Query)
+	}
+	var callback zalopay.Callback
+	if err := json.Unmarshal(req.Body, &callback); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCallback, err)
+	}
+	var data zalopay.CallbackData
+	if err := json.Unmarshal([]byte(callback.Data), &data); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCallback, err)
+	}
+	result := &model.PaymentCallback{
+		OrderCode:     zaloOrderCode(data.AppTransID),
+		Amount:        decimal.NewFromInt(data.Amount),
+		Success:       true,
+		PaidAt:        time.UnixMilli(data.ServerTime),
+		TransactionNo: strconv.FormatInt(data.ZpTransID, 10),
+		BankCode:      strconv.Itoa(data.Channel),
+		ResponseCode:  strconv.Itoa(zaloSuccess),
+	}
+	if !strings.EqualFold(hmacSHA256(z.account.Key2, callback.Data), callback.Mac) {
+		return result, ErrInvalidSignature
+	}
+	return result, nil
+}
+
+// verifyRedirect checks the status ZaloPay adds to the redirect URL.
+func (z *ZaloPay) verifyRedirect(query url.Values) (*model.PaymentCallback, error) {
+	amount, err := strconv.ParseInt(query.Get("amount"), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("%w: amount is not a number", ErrInvalidCallback)
+	}
+	result := &model.PaymentCallback{
+		OrderCode:    zaloOrderCode(query.Get("apptransid")),
+		Amount:       decimal.NewFromInt(amount),
+		Success:      query.Get("status") == strconv.Itoa(zaloSuccess),
+		PaidAt:       z.now(),
+		BankCode:     query.Get("bankcode"),
+		ResponseCode: query.Get("status"),
+	}
+	checksum := hmacSHA256(z.account.Key2, pipe(
+		query.Get("appid"), query.Get("apptransid"), query.Get("pmcid"), query.Get("bankcode"),
+		query.Get("amount"), query.Get("discountamount"), query.Get("status"),
+	))
+	if !strings.EqualFold(checksum, query.Get("checksum")) {
+		return result, ErrInvalidSignature
+	}
+	return result, nil
+}
+
+// QueryStatus implements PaymentProvider.
+func (z *ZaloPay) QueryStatus(ctx context.Context, ref model.PaymentRef) (*model.PaymentState, error) {
+	req := zalopay.QueryRequest{AppID: z.account.AppID, AppTransID: ref.Reference}
+	req.Mac = hmacSHA256(z.account.Key1, pipe(strconv.FormatInt(req.AppID, 10), req.AppTransID, z.account.Key1))
+	var resp zalopay.QueryResponse
+	if err := postJSON(ctx, z.account.Endpoint+"/v2/query", req, &resp); err != nil {
+		return nil, err
+	}
+	state := &model.PaymentState{
+		Status:        enum.PaymentFailed,
+		TransactionNo: strconv.FormatInt(resp.ZpTransID, 10),
+		Amount:        decimal.NewFromInt(resp.Amount),
+		ResponseCode:  strconv.Itoa(resp.ReturnCode),
+		Message:       resp.ReturnMessage,
+	}
+	switch {
+	case resp.ReturnCode == zaloSuccess:
+		state.Status = enum.PaymentSucceeded
+	case resp.ReturnCode == zaloProcessing || resp.IsProcessing:
+		state.Status = enum.PaymentPending
+	}
+	return state, nil
+}
+
+// Refund implements PaymentProvider.
+func (z *ZaloPay) Refund(ctx context.Context, refund model.PaymentRefund) (*model.PaymentRefunded, error) {
+	now := z.now().In(vnpay.Location)
+	req := zalopay.RefundRequest{
+		AppID:       z.account.AppID,
+		MRefundID:   z.refundID(refund),
+		ZpTransID:   refund.Payment.TransactionNo,
+		Amount:      refund.Amount.IntPart(),
+		Timestamp:   now.UnixMilli(),
+		Description: refundInfo(refund),
+	}
+	req.Mac = hmacSHA256(z.account.Key1, pipe(
+		strconv.FormatInt(req.AppID, 10), req.ZpTransID, strconv.FormatInt(req.Amount, 10),
+		req.Description, strconv.FormatInt(req.Timestamp, 10),
+	))
+	var resp zalopay.RefundResponse
+	if err := postJSON(ctx, z.account.Endpoint+"/v2/refund", req, &resp); err != nil {
+		return nil, err
+	}
+	refunded := &model.PaymentRefunded{
+		Status:       enum.RefundFailed,
+		RefundNo:     strconv.FormatInt(resp.RefundID, 10),
+		ResponseCode: strconv.Itoa(resp.ReturnCode),
+		Message:      resp.ReturnMessage,
+	}
+	switch resp.ReturnCode {
+	case zaloSuccess:
+		refunded.Status = enum.RefundSucceeded
+	case zaloProcessing:
+		refunded.Status = enum.RefundPending
+	}
+	return refunded, nil
+}
+
+// QueryRefund implements PaymentProvider. ZaloPay answers a refund it has
+// no record of with a failure, which is taken as not found so that the
+// refund is asked again under the same id.
+func (z *ZaloPay) QueryRefund(ctx context.Context, refund model.PaymentRefund) (*model.PaymentRefunded, error) {
+	req := zalopay.RefundQueryRequest{
+		AppID:     z.account.AppID,
+		MRefundID: z.refundID(refund),
+		Timestamp: z.now().UnixMilli(),
+	}
+	req.Mac = hmacSHA256(z.account.Key1, pipe(
+		strconv.FormatInt(req.AppID, 10), req.MRefundID, strconv.FormatInt(req.Timestamp, 10),
+	))
+	var resp zalopay.RefundQueryResponse
+	if err := postJSON(ctx, z.account.Endpoint+"/v2/query_refund", req, &resp); err != nil {
+		return nil, err
+	}
+	refunded := &model.PaymentRefunded{
+		ResponseCode: strconv.Itoa(resp.ReturnCode),
+		Message:      resp.ReturnMessage,
+	}
+	switch resp.ReturnCode {
+	case zaloSuccess:
+		refunded.Status = enum.RefundSucceeded
+	case zaloProcessing:
+		refunded.Status = enum.RefundPending
+	default:
+		return nil, nil
+	}
+	return refunded, nil
+}
+
+// refundID returns the m_refund_id of a refund, dated the day the refund
+// was created so that it stays the same when the refund is asked again.
+func (z *ZaloPay) refundID(refund model.PaymentRefund) string {
+	created := refund.CreatedAt
+	if created.IsZero() {
+		created = z.now()
+	}
+	return fmt.Sprintf("%s_%d_%s", created.In(vnpay.Location).Format(zaloDate), z.account.AppID, refund.RefundID)
+}
+
+// zaloOrderCode returns the order of an app_trans_id, made of its date, the
+// order code and a suffix.
+func zaloOrderCode(appTransID string) string {
+	_, rest, _ := strings.Cut(appTransID, "_")
+	return orderCodeOf(rest)
+}
+
+// pipe joins the fields ZaloPay signs.
+func pipe(fields ...string) string {
+	return strings.Join(fields, "|")
+}
@@ -0,0 +1,225 @@
+package products
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+
+	"github.com/swclabs/swipex/internal/config"
+	"github.com/swclabs/swipex/internal/core/domain/dtos"
+	"github.com/swclabs/swipex/internal/core/domain/entity"
+	"github.com/swclabs/swipex/internal/core/domain/model"
+	"github.com/swclabs/swipex/internal/core/repos/currencies"
+	"github.com/swclabs/swipex/pkg/infra/db"
+	"github.com/swclabs/swipex/pkg/utils"
+
+	"github.com/shopspring/decimal"
+)
+
+// sources of the exchange rates
+const (
+	rateAdmin = "admin"
+	rateFile  = "file"
+)
+
+var (
+	// ErrUnknownCurrency is returned when prices are asked in a currency
+	// without an exchange rate
+	ErrUnknownCurrency = errors.New("unknown currency")
+
+	// ErrInvalidExchangeRate is returned when an exchange rate or a file of
+	// exchange rates cannot be used
+	ErrInvalidExchangeRate = errors.New("invalid exchange rate")
+)
+
+// GetExchangeRates implements IProducts.
+func (p *Products) GetExchangeRates(ctx context.Context) ([]dtos.ExchangeRate, error) {
+	rates, err := p.Currency.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]dtos.ExchangeRate, 0, len(rates))
+	for _, rate := range rates {
+		result = append(result, exchangeRateOf(rate))
+	}
+	return result, nil
+}
+
+// SetExchangeRate implements IProducts.
+func (p *Products) SetExchangeRate(ctx context.Context, actor string, req dtos.ExchangeRateUpdate) error {
+	rate, err := exchangeRateEntity(req.CurrencyCode, req.Rate)
+	if err != nil {
+		return err
+	}
+	rate.Source, rate.UpdatedBy = rateAdmin, actor
+	return p.Currency.Upsert(ctx, *rate)
+}
+
+// DeleteExchangeRate implements IProducts.
+func (p *Products) DeleteExchangeRate(ctx context.Context, currency string) error {
+	return p.Currency.Delete(ctx, strings.ToUpper(currency))
+}
+
+// ImportExchangeRates implements IProducts.
+func (p *Products) ImportExchangeRates(ctx context.Context, actor string, file io.Reader) (*dtos.ExchangeRateImport, error) {
+	rates, err := parseExchangeRates(file)
+	if err != nil {
+		return nil, err
+	}
+	tx, err := db.NewTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	currencyRepo := currencies.New(tx)
+	result := &dtos.ExchangeRateImport{Rates: make([]dtos.ExchangeRate, 0, len(rates))}
+	for _, rate := range rates {
+		rate.Source, rate.UpdatedBy = rateFile, actor
+		if err := currencyRepo.Upsert(ctx, rate); err != nil {
+			if errTx := tx.Rollback(ctx); errTx != nil {
+				log.Fatal(errTx)
+			}
+			return nil, err
+		}
+		result.Imported++
+		result.Rates = append(result.Rates, dtos.ExchangeRate{
+			CurrencyCode: rate.CurrencyCode,
+			Rate:         rate.Rate.String(),
+			Source:       rate.Source,
+			UpdatedBy:    rate.UpdatedBy,
+		})
+	}
+	return result, tx.Commit(ctx)
+}
+
+// catalogRates returns the exchange rates to show the catalog in currency,
+// nil when the prices are shown as stored.
+func (p *Products) catalogRates(ctx context.Context, currency string) (model.ExchangeRates, error) {
+	if currency == "" {
+		return nil, nil
+	}
+	rates, err := p.Currency.GetRates(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !rates.Has(currency) {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownCurrency, currency)
+	}
+	return rates, nil
+}
+
+// fromPrice returns the lowest price of the items of a product in
+// currency, false when the product has no item.
+func (p *Products) fromPrice(
+	ctx context.Context, rates model.ExchangeRates, productID int64, currency string) (string, bool, error) {
+	items, err := p.Inventory.GetByProductID(ctx, productID)
+	if err != nil {
+		return "", false, err
+	}
+	var lowest *decimal.Decimal
+	for _, item := range items {
+		price, err := convertPrice(rates, item.Price, item.CurrencyCode, currency)
+		if err != nil {
+			return "", false, err
+		}
+		if lowest == nil || price.LessThan(*lowest) {
+			lowest = &price
+		}
+	}
+	if lowest == nil {
+		return "", false, nil
+	}
+	return lowest.String(), true, nil
+}
+
+// convertPrice converts a price of the catalog to currency, prices without
+// a currency are in the base currency.
+func convertPrice(rates model.ExchangeRates, price decimal.Decimal, from, to string) (decimal.Decimal, error) {
+	if from == "" {
+		from = config.BaseCurrency
+	}
+	converted, ok := rates.Convert(price, from, to)
+	if !ok {
+		return decimal.Zero, fmt.Errorf("%w: no exchange rate from %s to %s", ErrUnknownCurrency, from, to)
+	}
+	return converted, nil
+}
+
+// exchangeRateEntity checks the rate of a currency, the base currency has
+// no rate of its own.
+func exchangeRateEntity(currency string, value string) (*entity.ExchangeRate, error) {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	if len(currency) != 3 {
+		return nil, fmt.Errorf("%w: %q is not a currency code", ErrInvalidExchangeRate, currency)
+	}
+	if currency == config.BaseCurrency {
+		return nil, fmt.Errorf("%w: %s is the base currency", ErrInvalidExchangeRate, currency)
+	}
+	rate, err := decimal.NewFromString(strings.TrimSpace(value))
+	if err != nil || !rate.IsPositive() {
+		return nil, fmt.Errorf("%w: the rate of %s must be a positive number", ErrInvalidExchangeRate, currency)
+	}
+	return &entity.ExchangeRate{CurrencyCode: currency, Rate: rate}, nil
+}
+
+// parseExchangeRates reads a file of exchange rates in CSV, the header
+// names the columns currency_code and rate.
+func parseExchangeRates(file io.Reader) ([]entity.ExchangeRate, error) {
+	reader := csv.NewReader(file)
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: cannot read the header: %v", ErrInvalidExchangeRate, err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+	for _, name := range []string{"currency_code", "rate"} {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("%w: missing column %q", ErrInvalidExchangeRate, name)
+		}
+	}
+
+	var (
+		rates []entity.ExchangeRate
+		seen  = map[string]int{}
+	)
+	for row := 2; ; row++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidExchangeRate, err)
+		}
+		rate, err := exchangeRateEntity(record[columns["currency_code"]], record[columns["rate"]])
+		if err != nil {
+			return nil, fmt.Errorf("row %d: %w", row, err)
+		}
+		if first, ok := seen[rate.CurrencyCode]; ok {
+			return nil, fmt.Errorf("%w: row %d repeats %s of row %d",
+				ErrInvalidExchangeRate, row, rate.CurrencyCode, first)
+		}
+		seen[rate.CurrencyCode] = row
+		rates = append(rates, *rate)
+	}
+	if len(rates) == 0 {
+		return nil, fmt.Errorf("%w: the file has no rates", ErrInvalidExchangeRate)
+	}
+	return rates, nil
+}
+
+// exchangeRateOf returns the response of an exchange rate
+func exchangeRateOf(rate entity.ExchangeRate) dtos.ExchangeRate {
+	return dtos.ExchangeRate{
+		CurrencyCode: rate.CurrencyCode,
+		Rate:         rate.Rate.String(),
+		Source:       rate.Source,
+		UpdatedBy:    rate.UpdatedBy,
+		UpdatedAt:    utils.HanoiTimezone(rate.UpdatedAt),
+	}
+}
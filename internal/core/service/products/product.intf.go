@@ -3,6 +3,7 @@ package products
 
 import (
 	"context"
+	"io"
 	"mime/multipart"
 
 	"github.com/swclabs/swipex/internal/core/domain/dtos"
@@ -16,9 +17,10 @@ type IProducts interface {
 	// GetProducts retrieves a list of products with a specified limit.
 	// ctx is the context to manage the request's lifecycle.
 	// limit is the maximum number of products to retrieve.
+	// currency is the currency to show the prices in, empty for the stored prices.
 	// Returns a slice of ProductResponse objects and an error if any issues occur during the retrieval process.
-	GetProducts(ctx context.Context, limit int) ([]dtos.ProductResponse, error)
-	GetProductInfo(ctx context.Context, productID int64) (*dtos.ProductResponse, error)
+	GetProducts(ctx context.Context, limit int, currency string) ([]dtos.ProductResponse, error)
+	GetProductInfo(ctx context.Context, productID int64, currency string) (*dtos.ProductResponse, error)
 
 	// UploadProductImage uploads images for a product.
 	// ctx is the context to manage the request's lifecycle.
@@ -67,14 +69,16 @@ type IProducts interface {
 	// Search retrieves a list of products based on a search keyword.
 	// ctx is the context to manage the request's lifecycle.
 	// keyword is the search keyword.
+	// currency is the currency to show the prices in, empty for the stored prices.
 	// Returns a slice of ProductResponse objects and an error if any issues occur during the retrieval process.
-	Search(ctx context.Context, keyword string) ([]dtos.ProductResponse, error)
+	Search(ctx context.Context, keyword string, currency string) ([]dtos.ProductResponse, error)
 
 	// SearchDetails retrieves a list of products based on a search keyword.
 	// ctx is the context to manage the request's lifecycle.
 	// keyword is the search keyword.
+	// currency is the currency to show the prices in, empty for the stored prices.
 	// Returns a slice of ProductDetail objects and an error if any issues occur during the retrieval process.
-	SearchDetails(ctx context.Context, userID int64, keyword string) ([]dtos.ProductDetail, error)
+	SearchDetails(ctx context.Context, userID int64, keyword string, currency string) ([]dtos.ProductDetail, error)
 
 	// GetInvItems retrieves a list of all stock.
 	// ctx is the context to manage the request's lifecycle.
@@ -110,20 +114,23 @@ type IProducts interface {
 	// Detail retrieves the details of a product.
 	// ctx is the context to manage the request's lifecycle.
 	// productID is the ID of the product to retrieve details for.
+	// currency is the currency to show the prices in, empty for the stored prices.
 	// Returns a pointer to the Detail object and an error if any issues occur during the retrieval
-	Detail(ctx context.Context, userID int64, productID int64) (*dtos.ProductDetail, error)
+	Detail(ctx context.Context, userID int64, productID int64, currency string) (*dtos.ProductDetail, error)
 
 	// GetItem retrieves an inventory by its ID.
 	// ctx is the context to manage the request's lifecycle.
 	// inventoryID is the ID of the inventory to retrieve.
+	// currency is the currency to show the price in, empty for the stored price.
 	// Returns a pointer to the Inventory object and an error if any issues occur during the retrieval process.
-	GetItem(ctx context.Context, inventoryID int64) (*dtos.Inventory, error)
+	GetItem(ctx context.Context, inventoryID int64, currency string) (*dtos.Inventory, error)
 
 	// ProductType retrieves the data of a product.
 	// ctx is the context to manage the request's lifecycle.
 	// types is the category of the product.
+	// currency is the currency to show the prices in, empty for the stored prices.
 	// Returns a slice of ProductView objects and an error if any issues occur during the retrieval process.
-	ProductType(ctx context.Context, types enum.Category, offset int) ([]dtos.ProductDTO, error)
+	ProductType(ctx context.Context, types enum.Category, offset int, currency string) ([]dtos.ProductDTO, error)
 
 	// Rating updates the rating of a product.
 	// ctx is the context to manage the request's lifecycle.
@@ -132,6 +139,32 @@ type IProducts interface {
 	// Returns an error if any issues occur during the update process.
 	Rating(ctx context.Context, userID, productID int64, rating int) error
 
+	// GetExchangeRates lists the exchange rates of the currencies prices can be shown in.
+	// ctx is the context to manage the request's lifecycle.
+	// Returns a slice of ExchangeRate objects and an error if any issues occur during the retrieval process.
+	GetExchangeRates(ctx context.Context) ([]dtos.ExchangeRate, error)
+
+	// SetExchangeRate creates or replaces the exchange rate of a currency.
+	// ctx is the context to manage the request's lifecycle.
+	// actor is the admin who sets the rate.
+	// rate contains the currency code and its value in the base currency.
+	// Returns an error if the rate is invalid or any issues occur during the update process.
+	SetExchangeRate(ctx context.Context, actor string, rate dtos.ExchangeRateUpdate) error
+
+	// DeleteExchangeRate deletes the exchange rate of a currency.
+	// ctx is the context to manage the request's lifecycle.
+	// currency is the currency code.
+	// Returns an error if any issues occur during the deletion process.
+	DeleteExchangeRate(ctx context.Context, currency string) error
+
+	// ImportExchangeRates sets the exchange rates of a CSV file with the columns
+	// currency_code and rate, all of them or none.
+	// ctx is the context to manage the request's lifecycle.
+	// actor is the admin who imports the file.
+	// file is the content of the CSV file.
+	// Returns the rates imported and an error if the file is invalid or any issues occur during the import.
+	ImportExchangeRates(ctx context.Context, actor string, file io.Reader) (*dtos.ExchangeRateImport, error)
+
 	AddBookmark(ctx context.Context, userID, inventoryID int64) error
 	RemoveBookmark(ctx context.Context, userID, inventoryID int64) error
 	GetBookmarks(ctx context.Context, userID int64) ([]dtos.Bookmark, error)
@@ -8,18 +8,26 @@ import (
 	"net/http"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/swclabs/swipex/internal/core/domain/dtos"
 	"github.com/swclabs/swipex/internal/core/domain/entity"
 	"github.com/swclabs/swipex/internal/core/domain/enum"
+	"github.com/swclabs/swipex/internal/core/domain/model"
 	swcerr "github.com/swclabs/swipex/pkg/lib/errors"
 	"github.com/swclabs/swipex/pkg/utils"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
 )
 
 // GetProductInfo implements IProducts.
-func (p *Products) GetProductInfo(ctx context.Context, productID int64) (*dtos.ProductResponse, error) {
+func (p *Products) GetProductInfo(ctx context.Context, productID int64, currency string) (*dtos.ProductResponse, error) {
+	rates, err := p.catalogRates(ctx, currency)
+	if err != nil {
+		return nil, err
+	}
+
 	product, err := p.Products.GetByID(ctx, productID)
 	if err != nil {
 		return nil, err
@@ -45,11 +53,19 @@ func (p *Products) GetProductInfo(ctx context.Context, productID int64) (*dtos.P
 		resp.Image = strings.Split(product.Image, ",")[0]
 	}
 
+	if err := p.convertResponse(ctx, rates, &resp, currency); err != nil {
+		return nil, err
+	}
+
 	return &resp, nil
 }
 
 // SearchDetails implements IProducts.
-func (p *Products) SearchDetails(ctx context.Context, userID int64, keyword string) ([]dtos.ProductDetail, error) {
+func (p *Products) SearchDetails(ctx context.Context, userID int64, keyword string, currency string) ([]dtos.ProductDetail, error) {
+	if _, err := p.catalogRates(ctx, currency); err != nil {
+		return nil, err
+	}
+
 	products, err := p.Products.Search(ctx, keyword)
 	if err != nil {
 		return nil, swcerr.Service("keyword error", err)
@@ -58,7 +74,7 @@ func (p *Products) SearchDetails(ctx context.Context, userID int64, keyword stri
 	var details []dtos.ProductDetail
 	for _, product := range products {
 
-		detail, err := p.Detail(ctx, userID, product.ID)
+		detail, err := p.Detail(ctx, userID, product.ID, currency)
 		if err != nil {
 			return nil, err
 		}
@@ -70,30 +86,45 @@ func (p *Products) SearchDetails(ctx context.Context, userID int64, keyword stri
 }
 
 // ProductType implements IProductService.
-func (p *Products) ProductType(ctx context.Context, types enum.Category, offset int) ([]dtos.ProductDTO, error) {
+func (p *Products) ProductType(ctx context.Context, types enum.Category, offset int, currency string) ([]dtos.ProductDTO, error) {
+	rates, err := p.catalogRates(ctx, currency)
+	if err != nil {
+		return nil, err
+	}
+
 	products, err := p.Products.GetByCategory(ctx, types, offset)
 	if err != nil {
 		return nil, err
 	}
 
 	var productView []dtos.ProductDTO
-	for _, p := range products {
+	for _, product := range products {
 
 		_view := dtos.ProductDTO{
-			ID:       p.ID,
-			Price:    p.Price,
-			Desc:     p.Description,
-			Name:     p.Name,
-			Image:    p.Image,
-			Rating:   p.Rating,
-			Category: p.CategoryName,
+			ID:       product.ID,
+			Price:    product.Price,
+			Desc:     product.Description,
+			Name:     product.Name,
+			Image:    product.Image,
+			Rating:   product.Rating,
+			Category: product.CategoryName,
 		}
 
 		var specs dtos.ProductSpecs
-		if err := json.Unmarshal([]byte(p.Specs), &specs); err != nil {
+		if err := json.Unmarshal([]byte(product.Specs), &specs); err != nil {
 			return nil, fmt.Errorf("[code %d] %v", http.StatusBadRequest, err)
 		}
 
+		if rates != nil {
+			price, ok, err := p.fromPrice(ctx, rates, product.ID, currency)
+			if err != nil {
+				return nil, err
+			}
+			if ok {
+				_view.Price, _view.Currency = price, currency
+			}
+		}
+
 		_view.Specs = specs
 		productView = append(productView, _view)
 	}
@@ -102,7 +133,12 @@ func (p *Products) ProductType(ctx context.Context, types enum.Category, offset
 }
 
 // GetItem implements IProductService.
-func (p *Products) GetItem(ctx context.Context, inventoryID int64) (*dtos.Inventory, error) {
+func (p *Products) GetItem(ctx context.Context, inventoryID int64, currency string) (*dtos.Inventory, error) {
+	rates, err := p.catalogRates(ctx, currency)
+	if err != nil {
+		return nil, err
+	}
+
 	item, err := p.Inventory.GetByID(ctx, inventoryID)
 	if err != nil {
 		return nil, err
@@ -136,18 +172,33 @@ func (p *Products) GetItem(ctx context.Context, inventoryID int64) (*dtos.Invent
 			ItemCode:     category.Name + "#" + strconv.Itoa(int(item.ID)),
 		}
 	)
+	preorderInfo(&result, item)
+
+	if rates != nil {
+		price, err := convertPrice(rates, item.Price, item.CurrencyCode, currency)
+		if err != nil {
+			return nil, err
+		}
+		result.Price, result.CurrencyCode = price.String(), currency
+	}
 
 	return &result, nil
 
 }
 
 // Detail implements IProductService.
-func (p *Products) Detail(ctx context.Context, userID int64, productID int64) (*dtos.ProductDetail, error) {
+func (p *Products) Detail(ctx context.Context, userID int64, productID int64, currency string) (*dtos.ProductDetail, error) {
 	var (
 		productSpecs dtos.ProductSpecs
 		details      dtos.ProductDetail
+		lowest       *decimal.Decimal
 	)
 
+	rates, err := p.catalogRates(ctx, currency)
+	if err != nil {
+		return nil, err
+	}
+
 	colors, err := p.Inventory.GetColor(ctx, productID)
 	if err != nil {
 		return nil, err
@@ -204,12 +255,25 @@ func (p *Products) Detail(ctx context.Context, userID int64, productID int64) (*
 			}
 
 			spec.Price = item.Price.String()
+			if rates != nil {
+				price, err := convertPrice(rates, item.Price, item.CurrencyCode, currency)
+				if err != nil {
+					return nil, err
+				}
+				if lowest == nil || price.LessThan(*lowest) {
+					lowest = &price
+				}
+				spec.Price = price.String()
+			}
 			spec.InventoryID = item.ID
 			detailsColor.Specs = append(detailsColor.Specs, spec)
 		}
 
 		details.Color = append(details.Color, detailsColor)
 	}
+	if lowest != nil {
+		details.Price, details.Currency = lowest.String(), currency
+	}
 	return &details, nil
 }
 
@@ -240,6 +304,8 @@ func (p *Products) GetInvItems(ctx context.Context, page int, limit int) (*dtos.
 			invItems.Header.Draft++
 		case "archived":
 			invItems.Header.Archive++
+		case "preorder":
+			invItems.Header.Preorder++
 		}
 
 		invItems.Header.All++
@@ -266,6 +332,7 @@ func (p *Products) GetInvItems(ctx context.Context, page int, limit int) (*dtos.
 		}
 
 		_item.Specs = specs
+		preorderInfo(&_item, &item)
 		invItems.Stock = append(invItems.Stock, _item)
 	}
 
@@ -281,7 +348,12 @@ func (p *Products) GetItems(ctx context.Context, productID int64) ([]entity.Inve
 }
 
 // Search implements IProductService.
-func (p *Products) Search(ctx context.Context, keyword string) ([]dtos.ProductResponse, error) {
+func (p *Products) Search(ctx context.Context, keyword string, currency string) ([]dtos.ProductResponse, error) {
+	rates, err := p.catalogRates(ctx, currency)
+	if err != nil {
+		return nil, err
+	}
+
 	_products, err := p.Products.Search(ctx, keyword)
 	if err != nil {
 		return nil, swcerr.Service("keyword error", err)
@@ -310,13 +382,22 @@ func (p *Products) Search(ctx context.Context, keyword string) ([]dtos.ProductRe
 			resp.Image = strings.Split(product.Image, ",")[0]
 		}
 
+		if err := p.convertResponse(ctx, rates, &resp, currency); err != nil {
+			return nil, err
+		}
+
 		productSchema = append(productSchema, resp)
 	}
 	return productSchema, nil
 }
 
 // GetProducts implements IProductService.
-func (p *Products) GetProducts(ctx context.Context, limit int) ([]dtos.ProductResponse, error) {
+func (p *Products) GetProducts(ctx context.Context, limit int, currency string) ([]dtos.ProductResponse, error) {
+	rates, err := p.catalogRates(ctx, currency)
+	if err != nil {
+		return nil, err
+	}
+
 	products, err := p.Products.GetLimit(ctx, limit, 1)
 	if err != nil {
 		return nil, err
@@ -356,7 +437,38 @@ func (p *Products) GetProducts(ctx context.Context, limit int) ([]dtos.ProductRe
 		}
 
 		product.Category = category.Name
+		if err := p.convertResponse(ctx, rates, &product, currency); err != nil {
+			return nil, err
+		}
 		productResponse = append(productResponse, product)
 	}
 	return productResponse, nil
 }
+
+// convertResponse shows the price of a product as the lowest price of its
+// items in currency, the stored price is kept without rates.
+func (p *Products) convertResponse(
+	ctx context.Context, rates model.ExchangeRates, resp *dtos.ProductResponse, currency string) error {
+	if rates == nil {
+		return nil
+	}
+	price, ok, err := p.fromPrice(ctx, rates, resp.ID, currency)
+	if err != nil {
+		return err
+	}
+	if ok {
+		resp.Price, resp.Currency = price, currency
+	}
+	return nil
+}
+
+// preorderInfo fills the pre-order terms of an inventory item
+func preorderInfo(dst *dtos.Inventory, item *entity.Inventory) {
+	if item.Status != enum.InventoryPreorder.String() {
+		return
+	}
+	if item.ExpectedShipDate != nil {
+		dst.ExpectedShipDate = item.ExpectedShipDate.Format(time.DateOnly)
+	}
+	dst.DepositPercent = item.DepositPercent.String()
+}
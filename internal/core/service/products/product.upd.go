@@ -7,6 +7,7 @@ import (
 	"mime/multipart"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/swclabs/swipex/internal/core/domain/dtos"
 	"github.com/swclabs/swipex/internal/core/domain/entity"
@@ -107,13 +108,36 @@ func (p *Products) UpdateItem(ctx context.Context, inventory dtos.InvUpdate) err
 		avai = -1
 	}
 	invID, _ := strconv.ParseInt(inventory.ID, 10, 64)
+	if inventory.Status != "" {
+		var status enum.InventoryStatus
+		if err := status.Load(inventory.Status); err != nil {
+			return fmt.Errorf("[code %d] %w", http.StatusBadRequest, err)
+		}
+	}
+	var shipDate *time.Time
+	if inventory.ExpectedShipDate != "" {
+		date, err := time.Parse(time.DateOnly, inventory.ExpectedShipDate)
+		if err != nil {
+			return fmt.Errorf("[code %d] invalid expected ship date: %s", http.StatusBadRequest, inventory.ExpectedShipDate)
+		}
+		shipDate = &date
+	}
+	deposit := decimal.NewFromInt(-1)
+	if inventory.DepositPercent != "" {
+		deposit, err = decimal.NewFromString(inventory.DepositPercent)
+		if err != nil || deposit.IsNegative() || deposit.GreaterThan(decimal.NewFromInt(100)) {
+			return fmt.Errorf("[code %d] deposit percent must be between 0 and 100", http.StatusBadRequest)
+		}
+	}
 	return p.Inventory.Update(ctx, entity.Inventory{
-		Price:        price,
-		ID:           invID,
-		Available:    avai,
-		ProductID:    int64(pid),
-		Status:       inventory.Status,
-		CurrencyCode: inventory.CurrencyCode,
+		Price:            price,
+		ID:               invID,
+		Available:        avai,
+		ProductID:        int64(pid),
+		Status:           inventory.Status,
+		CurrencyCode:     inventory.CurrencyCode,
+		ExpectedShipDate: shipDate,
+		DepositPercent:   deposit,
 	})
 }
 
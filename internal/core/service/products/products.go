@@ -13,6 +13,7 @@ import (
 	"github.com/swclabs/swipex/internal/core/domain/entity"
 	"github.com/swclabs/swipex/internal/core/domain/enum"
 	"github.com/swclabs/swipex/internal/core/repos/categories"
+	"github.com/swclabs/swipex/internal/core/repos/currencies"
 	"github.com/swclabs/swipex/internal/core/repos/favorite"
 	"github.com/swclabs/swipex/internal/core/repos/inventories"
 	"github.com/swclabs/swipex/internal/core/repos/products"
@@ -35,6 +36,7 @@ func New(
 	category categories.ICategories,
 	star stars.IStar,
 	favorite favorite.IFavorite,
+	currency currencies.ICurrencies,
 ) IProducts {
 	return &Products{
 		Blob:      blob,
@@ -43,6 +45,7 @@ func New(
 		Category:  category,
 		Star:      star,
 		Favorite:  favorite,
+		Currency:  currency,
 	}
 }
 
@@ -54,6 +57,7 @@ type Products struct {
 	Category  categories.ICategories
 	Star      stars.IStar
 	Favorite  favorite.IFavorite
+	Currency  currencies.ICurrencies
 }
 
 // AddBookmark implements IProducts.
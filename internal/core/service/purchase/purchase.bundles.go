@@ -0,0 +1,504 @@
+package purchase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/swclabs/swipex/internal/core/domain/dtos"
+	"github.com/swclabs/swipex/internal/core/domain/entity"
+	"github.com/swclabs/swipex/internal/core/domain/enum"
+	"github.com/swclabs/swipex/internal/core/repos/bundles"
+	"github.com/swclabs/swipex/pkg/infra/db"
+	"github.com/swclabs/swipex/pkg/utils"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+)
+
+// statuses of a bundle
+const (
+	BundleActive   = "active"
+	BundleArchived = "archived"
+)
+
+// bundleComponent is an item of a bundle with its inventory
+type bundleComponent struct {
+	Item      entity.BundleItem
+	Inventory *entity.Inventory
+}
+
+// bundleLine is a bundle bought in an order
+type bundleLine struct {
+	Bundle     *entity.Bundle
+	Components []bundleComponent
+	Quantity   int64
+}
+
+// CreateBundle implements IPurchase.
+func (p *Purchase) CreateBundle(ctx context.Context, req dtos.CreateBundle) (int64, error) {
+	bundle, items, err := p.bundleEntity(ctx, req)
+	if err != nil {
+		return -1, err
+	}
+	tx, err := db.NewTx(ctx)
+	if err != nil {
+		return -1, err
+	}
+	bundleRepo := bundles.New(tx)
+	id, err := bundleRepo.Insert(ctx, *bundle)
+	if err != nil {
+		if errTx := tx.Rollback(ctx); errTx != nil {
+			log.Fatal(errTx)
+		}
+		return -1, err
+	}
+	if err := insertBundleItems(ctx, bundleRepo, id, items); err != nil {
+		if errTx := tx.Rollback(ctx); errTx != nil {
+			log.Fatal(errTx)
+		}
+		return -1, err
+	}
+	return id, tx.Commit(ctx)
+}
+
+// UpdateBundle implements IPurchase.
+func (p *Purchase) UpdateBundle(ctx context.Context, bundleID int64, req dtos.CreateBundle) error {
+	bundle, items, err := p.bundleEntity(ctx, req)
+	if err != nil {
+		return err
+	}
+	bundle.ID = bundleID
+	tx, err := db.NewTx(ctx)
+	if err != nil {
+		return err
+	}
+	bundleRepo := bundles.New(tx)
+	if err := bundleRepo.Update(ctx, *bundle); err != nil {
+		if errTx := tx.Rollback(ctx); errTx != nil {
+			log.Fatal(errTx)
+		}
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrBundleNotFound
+		}
+		return err
+	}
+	if err := bundleRepo.DeleteItems(ctx, bundleID); err != nil {
+		if errTx := tx.Rollback(ctx); errTx != nil {
+			log.Fatal(errTx)
+		}
+		return err
+	}
+	if err := insertBundleItems(ctx, bundleRepo, bundleID, items); err != nil {
+		if errTx := tx.Rollback(ctx); errTx != nil {
+			log.Fatal(errTx)
+		}
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// DeleteBundle implements IPurchase.
+func (p *Purchase) DeleteBundle(ctx context.Context, bundleID int64) error {
+	if err := p.Bundle.Delete(ctx, bundleID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrBundleNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// GetBundle implements IPurchase.
+func (p *Purchase) GetBundle(ctx context.Context, bundleID int64) (*dtos.Bundle, error) {
+	bundle, err := p.Bundle.GetByID(ctx, bundleID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrBundleNotFound
+		}
+		return nil, err
+	}
+	return p.bundleDTO(ctx, bundle)
+}
+
+// GetBundles implements IPurchase.
+func (p *Purchase) GetBundles(ctx context.Context, status string, limit, page int) ([]dtos.Bundle, error) {
+	if page < 1 {
+		page = 1
+	}
+	list, err := p.Bundle.GetList(ctx, status, limit, (page-1)*limit)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]dtos.Bundle, 0, len(list))
+	for i := range list {
+		bundle, err := p.bundleDTO(ctx, &list[i])
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, *bundle)
+	}
+	return result, nil
+}
+
+// AddBundleToCart implements IPurchase.
+func (p *Purchase) AddBundleToCart(ctx context.Context, userID int64, req dtos.CartBundleDTO) error {
+	line, err := p.loadBundle(ctx, p.Bundle, p.Inventory.GetByID, req.BundleID, req.Quantity)
+	if err != nil {
+		return err
+	}
+	if err := checkBundleStock(line); err != nil {
+		return err
+	}
+	return p.Bundle.AddToCart(ctx, entity.CartBundle{
+		UserID:   userID,
+		BundleID: req.BundleID,
+		Quantity: req.Quantity,
+	})
+}
+
+// UpdateCartBundle implements IPurchase.
+func (p *Purchase) UpdateCartBundle(ctx context.Context, userID int64, bundleID int64, quantity int64) error {
+	line, err := p.loadBundle(ctx, p.Bundle, p.Inventory.GetByID, bundleID, quantity)
+	if err != nil {
+		return err
+	}
+	if err := checkBundleStock(line); err != nil {
+		return err
+	}
+	if err := p.Bundle.UpdateCartQuantity(ctx, userID, bundleID, quantity); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrCartItemNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// DeleteBundleFromCart implements IPurchase.
+func (p *Purchase) DeleteBundleFromCart(ctx context.Context, userID int64, bundleID int64) error {
+	return p.Bundle.RemoveFromCart(ctx, userID, bundleID)
+}
+
+// cartBundles builds the bundle lines of a cart and returns the amount of
+// those that can be checked out.
+func (p *Purchase) cartBundles(ctx context.Context, userID int64) ([]dtos.CartBundle, decimal.Decimal, error) {
+	var (
+		amount = decimal.Zero
+		result = []dtos.CartBundle{}
+	)
+	if userID == 0 {
+		return result, amount, nil
+	}
+	carts, err := p.Bundle.GetCart(ctx, userID)
+	if err != nil {
+		return nil, amount, err
+	}
+	for _, cart := range carts {
+		bundle, err := p.Bundle.GetByID(ctx, cart.BundleID)
+		if err != nil {
+			return nil, amount, err
+		}
+		components, err := p.bundleComponents(ctx, p.Bundle, p.Inventory.GetByID, bundle.ID)
+		if err != nil {
+			return nil, amount, err
+		}
+		line := &bundleLine{Bundle: bundle, Components: components, Quantity: cart.Quantity}
+		items, err := p.bundleItems(ctx, components)
+		if err != nil {
+			return nil, amount, err
+		}
+		dto := dtos.CartBundle{
+			BundleID:     bundle.ID,
+			Name:         bundle.Name,
+			Image:        bundle.Image,
+			Price:        bundle.Price.String(),
+			CurrencyCode: bundle.CurrencyCode,
+			Quantity:     cart.Quantity,
+			OutOfStock:   checkBundleStock(line) != nil,
+			Preorder:     hasPreorder(components),
+			Archived:     bundle.Status == BundleArchived,
+			Items:        items,
+		}
+		result = append(result, dto)
+		if dto.OutOfStock || dto.Archived {
+			continue
+		}
+		amount = amount.Add(bundle.Price.Mul(decimal.NewFromInt(cart.Quantity)))
+	}
+	return result, amount, nil
+}
+
+// orderBundles loads the bundles of an order with their components, it
+// runs inside the order transaction before reserveStock locks the stock.
+func (p *Purchase) orderBundles(
+	ctx context.Context,
+	bundleRepo bundles.IBundles,
+	lookup func(ctx context.Context, inventoryID int64) (*entity.Inventory, error),
+	order dtos.OrderForm,
+) ([]bundleLine, error) {
+	lines := make([]bundleLine, 0, len(order.Bundles))
+	for _, item := range order.Bundles {
+		line, err := p.loadBundle(ctx, bundleRepo, lookup, item.BundleID, item.Quantity)
+		if err != nil {
+			return nil, err
+		}
+		lines = append(lines, *line)
+	}
+	return lines, nil
+}
+
+// loadBundle returns quantity bundles with their components, only active
+// bundles can be bought.
+func (p *Purchase) loadBundle(
+	ctx context.Context,
+	bundleRepo bundles.IBundles,
+	lookup func(ctx context.Context, inventoryID int64) (*entity.Inventory, error),
+	bundleID int64,
+	quantity int64,
+) (*bundleLine, error) {
+	bundle, err := bundleRepo.GetByID(ctx, bundleID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrBundleNotFound
+		}
+		return nil, err
+	}
+	if bundle.Status != BundleActive {
+		return nil, ErrBundleArchived
+	}
+	components, err := p.bundleComponents(ctx, bundleRepo, lookup, bundleID)
+	if err != nil {
+		return nil, err
+	}
+	return &bundleLine{Bundle: bundle, Components: components, Quantity: quantity}, nil
+}
+
+func (p *Purchase) bundleComponents(
+	ctx context.Context,
+	bundleRepo bundles.IBundles,
+	lookup func(ctx context.Context, inventoryID int64) (*entity.Inventory, error),
+	bundleID int64,
+) ([]bundleComponent, error) {
+	items, err := bundleRepo.GetItems(ctx, bundleID)
+	if err != nil {
+		return nil, err
+	}
+	components := make([]bundleComponent, 0, len(items))
+	for _, item := range items {
+		inv, err := lookup(ctx, item.InventoryID)
+		if err != nil {
+			return nil, err
+		}
+		components = append(components, bundleComponent{Item: item, Inventory: inv})
+	}
+	return components, nil
+}
+
+// checkBundleStock returns an error if a component of the bundles of a
+// line is archived or short of stock.
+func checkBundleStock(line *bundleLine) error {
+	for _, component := range line.Components {
+		if err := checkCartStock(component.Inventory, component.Item.Quantity*line.Quantity); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func hasPreorder(components []bundleComponent) bool {
+	for _, component := range components {
+		if component.Inventory.Status == enum.InventoryPreorder.String() {
+			return true
+		}
+	}
+	return false
+}
+
+// priceBundle spreads the price of the bundles of a line over their
+// components in proportion to the list price of each, the last component
+// takes the rounding difference so that the lines add up to the bundle
+// price. The lines are in the currency of the order.
+func (p *Purchase) priceBundle(ctx context.Context, line bundleLine, currency orderCurrency) ([]orderLine, error) {
+	total, err := currency.price(line.Bundle.Price.Mul(decimal.NewFromInt(line.Quantity)), line.Bundle.CurrencyCode)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		weights = make([]decimal.Decimal, len(line.Components))
+		sum     = decimal.Zero
+	)
+	for i, component := range line.Components {
+		weight, err := currency.base(
+			component.Inventory.Price.Mul(decimal.NewFromInt(component.Item.Quantity)), component.Inventory.CurrencyCode)
+		if err != nil {
+			return nil, err
+		}
+		weights[i] = weight
+		sum = sum.Add(weights[i])
+	}
+	// free components still share the price by quantity
+	if !sum.IsPositive() {
+		sum = decimal.Zero
+		for i, component := range line.Components {
+			weights[i] = decimal.NewFromInt(component.Item.Quantity)
+			sum = sum.Add(weights[i])
+		}
+	}
+
+	lines := make([]orderLine, 0, len(line.Components))
+	allocated := decimal.Zero
+	for i, component := range line.Components {
+		product, err := p.Product.GetByID(ctx, component.Inventory.ProductID)
+		if err != nil {
+			return nil, err
+		}
+		category, err := p.Category.GetByID(ctx, product.CategoryID)
+		if err != nil {
+			return nil, err
+		}
+		amount := total.Sub(allocated)
+		if i < len(line.Components)-1 {
+			amount = total.Mul(weights[i]).Div(sum).Round(2)
+		}
+		allocated = allocated.Add(amount)
+
+		quantity := component.Item.Quantity * line.Quantity
+		orderLine := orderLine{
+			InventoryID: component.Item.InventoryID,
+			BundleID:    line.Bundle.ID,
+			CategoryID:  product.CategoryID,
+			Quantity:    quantity,
+			UnitPrice:   amount.DivRound(decimal.NewFromInt(quantity), 4),
+			VATRate:     category.VATRate.Decimal,
+			Amount:      amount,
+		}
+		if component.Inventory.Status == enum.InventoryPreorder.String() {
+			orderLine.DepositPercent = component.Inventory.DepositPercent
+		}
+		lines = append(lines, orderLine)
+	}
+	return lines, nil
+}
+
+// bundleEntity checks a bundle request: its components must be distinct
+// inventory items still on sale.
+func (p *Purchase) bundleEntity(ctx context.Context, req dtos.CreateBundle) (*entity.Bundle, []entity.BundleItem, error) {
+	price, err := decimal.NewFromString(req.Price)
+	if err != nil || price.IsNegative() {
+		return nil, nil, fmt.Errorf("%w: price must be a positive number", ErrInvalidBundle)
+	}
+	bundle := &entity.Bundle{
+		Name:         req.Name,
+		Description:  req.Description,
+		Image:        req.Image,
+		Price:        price,
+		CurrencyCode: req.CurrencyCode,
+		Status:       req.Status,
+	}
+	if bundle.CurrencyCode == "" {
+		bundle.CurrencyCode = "VND"
+	}
+	if bundle.Status == "" {
+		bundle.Status = BundleActive
+	}
+
+	items := make([]entity.BundleItem, 0, len(req.Items))
+	seen := map[int64]bool{}
+	for _, item := range req.Items {
+		if seen[item.InventoryID] {
+			return nil, nil, fmt.Errorf("%w: inventory %d is listed twice", ErrInvalidBundle, item.InventoryID)
+		}
+		seen[item.InventoryID] = true
+		inv, err := p.Inventory.GetByID(ctx, item.InventoryID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, nil, fmt.Errorf("%w: inventory %d does not exist", ErrInvalidBundle, item.InventoryID)
+			}
+			return nil, nil, err
+		}
+		if inv.Status == enum.InventoryArchived.String() {
+			return nil, nil, fmt.Errorf("%w: inventory %d is archived", ErrInvalidBundle, item.InventoryID)
+		}
+		items = append(items, entity.BundleItem{
+			InventoryID: item.InventoryID,
+			Quantity:    item.Quantity,
+		})
+	}
+	return bundle, items, nil
+}
+
+func insertBundleItems(ctx context.Context, bundleRepo bundles.IBundles, bundleID int64, items []entity.BundleItem) error {
+	for _, item := range items {
+		item.BundleID = bundleID
+		if err := bundleRepo.InsertItem(ctx, item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// bundleDTO describes a bundle with its components and their list price.
+func (p *Purchase) bundleDTO(ctx context.Context, bundle *entity.Bundle) (*dtos.Bundle, error) {
+	components, err := p.bundleComponents(ctx, p.Bundle, p.Inventory.GetByID, bundle.ID)
+	if err != nil {
+		return nil, err
+	}
+	items, err := p.bundleItems(ctx, components)
+	if err != nil {
+		return nil, err
+	}
+	listPrice := decimal.Zero
+	for _, component := range components {
+		listPrice = listPrice.Add(component.Inventory.Price.Mul(decimal.NewFromInt(component.Item.Quantity)))
+	}
+	return &dtos.Bundle{
+		ID:           bundle.ID,
+		Name:         bundle.Name,
+		Description:  bundle.Description,
+		Image:        bundle.Image,
+		Price:        bundle.Price.String(),
+		ListPrice:    listPrice.String(),
+		CurrencyCode: bundle.CurrencyCode,
+		Status:       bundle.Status,
+		OutOfStock:   checkBundleStock(&bundleLine{Bundle: bundle, Components: components, Quantity: 1}) != nil,
+		Preorder:     hasPreorder(components),
+		Items:        items,
+		CreatedAt:    utils.HanoiTimezone(bundle.CreatedAt),
+	}, nil
+}
+
+func (p *Purchase) bundleItems(ctx context.Context, components []bundleComponent) ([]dtos.BundleItem, error) {
+	items := make([]dtos.BundleItem, 0, len(components))
+	for _, component := range components {
+		product, err := p.Product.GetByID(ctx, component.Inventory.ProductID)
+		if err != nil {
+			return nil, err
+		}
+		category, err := p.Category.GetByID(ctx, product.CategoryID)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, dtos.BundleItem{
+			InventoryID: component.Item.InventoryID,
+			Quantity:    component.Item.Quantity,
+			ItemCode:    category.Name + "#" + strconv.FormatInt(component.Item.InventoryID, 10),
+			ProductName: product.Name,
+			Color:       component.Inventory.Color,
+			Price:       component.Inventory.Price.String(),
+		})
+	}
+	return items, nil
+}
+
+// bundleQuantities adds the units of the components of bundles to the
+// quantities of an order, by inventory ID.
+func bundleQuantities(quantities map[int64]int64, lines []bundleLine) {
+	for _, line := range lines {
+		for _, component := range line.Components {
+			quantities[component.Item.InventoryID] += component.Item.Quantity * line.Quantity
+		}
+	}
+}
@@ -0,0 +1,221 @@
+package purchase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/swclabs/swipex/internal/core/domain/dtos"
+	"github.com/swclabs/swipex/internal/core/domain/entity"
+	"github.com/swclabs/swipex/internal/core/domain/enum"
+	"github.com/swclabs/swipex/internal/core/domain/model"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+)
+
+// AddToCart implements IPurchase.
+func (p *Purchase) AddToCart(ctx context.Context, cart dtos.CartInsertDTO) error {
+	user, err := p.User.GetByEmail(ctx, cart.Email)
+	if err != nil {
+		return fmt.Errorf("error getting user by email: %v", err)
+	}
+	inv, err := p.Inventory.GetByID(ctx, cart.InventoryID)
+	if err != nil {
+		return err
+	}
+	quantity := cart.Quantity
+	current, err := p.Cart.GetByItemID(ctx, user.ID, cart.InventoryID)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return err
+	}
+	if current != nil {
+		quantity += current.Quantity
+	}
+	if err := checkCartStock(inv, quantity); err != nil {
+		return err
+	}
+	return p.Cart.Insert(ctx, entity.Cart{
+		UserID:      user.ID,
+		InventoryID: cart.InventoryID,
+		Quantity:    cart.Quantity,
+		Price:       inv.Price,
+	})
+}
+
+// UpdateCartItem implements IPurchase.
+func (p *Purchase) UpdateCartItem(ctx context.Context, userID int64, inventoryID int64, quantity int64) error {
+	inv, err := p.Inventory.GetByID(ctx, inventoryID)
+	if err != nil {
+		return err
+	}
+	if err := checkCartStock(inv, quantity); err != nil {
+		return err
+	}
+	if err := p.Cart.UpdateQuantity(ctx, userID, inventoryID, quantity); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrCartItemNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// MergeCart implements IPurchase.
+func (p *Purchase) MergeCart(ctx context.Context, userID int64, items []dtos.CartDTO) error {
+	for _, item := range items {
+		inv, err := p.Inventory.GetByID(ctx, item.InventoryID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				continue
+			}
+			return err
+		}
+		// the guest cart was filled without an account, so lines that can no
+		// longer be bought are dropped instead of failing the login
+		preorder := inv.Status == enum.InventoryPreorder.String()
+		if inv.Status == enum.InventoryArchived.String() || (inv.Available <= 0 && !preorder) {
+			continue
+		}
+		quantity := item.Quantity
+		if !preorder {
+			quantity = min(quantity, inv.Available)
+		}
+		if err := p.Cart.Merge(ctx, entity.Cart{
+			UserID:      userID,
+			InventoryID: item.InventoryID,
+			Quantity:    quantity,
+			Price:       inv.Price,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetCart implements IPurchase.
+func (p *Purchase) GetCart(ctx context.Context, userID int64, _ int, couponCode string) (*dtos.Carts, error) {
+	carts, err := p.Cart.GetCartInfo(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return p.cartSummary(ctx, userID, carts, couponCode)
+}
+
+// cartSummary builds the cart response: the lines with their stock and
+// price flags, and the totals of the lines that can be checked out. A zero
+// userID previews the coupon for a guest.
+func (p *Purchase) cartSummary(ctx context.Context, userID int64, carts []model.Carts, couponCode string) (*dtos.Carts, error) {
+	var (
+		err      error
+		subtotal = decimal.Zero
+		lines    = []couponLine{}
+		cartResp = dtos.Carts{
+			UserID:   userID,
+			Products: []dtos.Cart{},
+		}
+	)
+	for _, cart := range carts {
+		var specs dtos.Specs
+		if err := json.Unmarshal([]byte(cart.InventorySpecs), &specs); err != nil {
+			return nil, fmt.Errorf("error unmarshal inventory specs: %v", err)
+		}
+		price, err := decimal.NewFromString(cart.InventoryPrice)
+		if err != nil {
+			return nil, err
+		}
+		addedPrice, err := decimal.NewFromString(cart.AddedPrice)
+		if err != nil {
+			return nil, err
+		}
+		line := dtos.Cart{
+			Name:           cart.Name,
+			CartID:         cart.CartID,
+			InventoryID:    cart.InventoryID,
+			ProductID:      cart.ProductID,
+			Quantity:       cart.Quantity,
+			Color:          cart.Color,
+			InventoryPrice: cart.InventoryPrice,
+			AddedPrice:     cart.AddedPrice,
+			CurrencyCode:   cart.CurrencyCode,
+			InventoryImage: cart.InventoryImage,
+			CategoryName:   cart.CategoryName,
+			InventorySpecs: specs,
+			Available:      cart.Available,
+			OutOfStock:     cart.Status != enum.InventoryPreorder.String() && cart.Available < cart.Quantity,
+			Preorder:       cart.Status == enum.InventoryPreorder.String(),
+			Archived:       cart.Status == enum.InventoryArchived.String(),
+			Repriced:       !addedPrice.Equal(price),
+			Code:           fmt.Sprintf("%s#%d", cart.CategoryName, cart.InventoryID),
+		}
+		cartResp.Products = append(cartResp.Products, line)
+
+		// lines that cannot be checked out do not count toward the totals
+		if line.OutOfStock || line.Archived {
+			continue
+		}
+		amount := price.Mul(decimal.NewFromInt(cart.Quantity))
+		subtotal = subtotal.Add(amount)
+		lines = append(lines, couponLine{
+			InventoryID: cart.InventoryID,
+			CategoryID:  cart.CategoryID,
+			Amount:      amount,
+		})
+	}
+
+	discount := decimal.Zero
+	if couponCode != "" {
+		cartResp.CouponCode = couponCode
+		discount, err = p.cartDiscount(ctx, userID, couponCode, lines)
+		if err != nil {
+			var couponErr *CouponError
+			if !errors.As(err, &couponErr) {
+				return nil, err
+			}
+			cartResp.CouponError = couponErr.Reason
+		}
+	}
+	// bundles sell at their own price, coupons do not apply to them
+	bundleCarts, amount, err := p.cartBundles(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	cartResp.Bundles = bundleCarts
+	subtotal = subtotal.Add(amount)
+	cartResp.Subtotal = subtotal.String()
+	cartResp.Discount = discount.String()
+	cartResp.Total = subtotal.Sub(discount).String()
+	return &cartResp, nil
+}
+
+// cartDiscount previews the discount a coupon gives to the cart lines.
+func (p *Purchase) cartDiscount(ctx context.Context, userID int64, couponCode string, lines []couponLine) (decimal.Decimal, error) {
+	coupon, err := p.Coupon.GetByCode(ctx, couponCode)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return decimal.Zero, &CouponError{Code: couponCode, Reason: "coupon does not exist"}
+		}
+		return decimal.Zero, err
+	}
+	return p.checkCoupon(ctx, p.Coupon, userID, coupon, lines)
+}
+
+// checkCartStock returns an error if quantity units of inv cannot be put
+// in a cart. Pre-order items are not limited by their stock.
+func checkCartStock(inv *entity.Inventory, quantity int64) error {
+	if inv.Status == enum.InventoryArchived.String() {
+		return ErrItemArchived
+	}
+	if inv.Status == enum.InventoryPreorder.String() {
+		return nil
+	}
+	if quantity > inv.Available {
+		return &InsufficientStockError{
+			InventoryID: inv.ID,
+			Requested:   quantity,
+			Available:   inv.Available,
+		}
+	}
+	return nil
+}
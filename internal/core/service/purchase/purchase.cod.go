@@ -0,0 +1,334 @@
+package purchase
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/swclabs/swipex/internal/config"
+	"github.com/swclabs/swipex/internal/core/domain/dtos"
+	"github.com/swclabs/swipex/internal/core/domain/entity"
+	"github.com/swclabs/swipex/internal/core/domain/enum"
+	"github.com/swclabs/swipex/internal/core/repos/cod"
+	"github.com/swclabs/swipex/internal/core/repos/orders"
+	"github.com/swclabs/swipex/pkg/utils"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+)
+
+// results of the lines of a remittance report
+const (
+	RemittanceMatched   = "matched"
+	RemittanceShort     = "short"
+	RemittanceOver      = "over"
+	RemittanceUnmatched = "unmatched"
+	RemittanceDuplicate = "duplicate"
+)
+
+// sources of the remittance reports
+const (
+	remittanceCSV = "csv"
+	remittanceAPI = "api"
+)
+
+// ghnRemittance is the reference of the API remittances without a code
+const ghnRemittance = "ghn"
+
+// remittanceLine is a line of a remittance report, OrderCode is the code
+// the shop gave the carrier, the UUID of the order
+type remittanceLine struct {
+	Reference        string
+	CarrierOrderCode string
+	OrderCode        string
+	Amount           decimal.Decimal
+	RemittedAt       *time.Time
+}
+
+// ImportRemittances implements IPurchase.
+func (p *Purchase) ImportRemittances(
+	ctx context.Context, actor string, reference string, report io.Reader) (*dtos.RemittanceImport, error) {
+	lines, err := parseRemittances(reference, report)
+	if err != nil {
+		return nil, err
+	}
+	return p.reconcileRemittances(ctx, remittanceCSV, actor, lines)
+}
+
+// SyncRemittances implements IPurchase.
+func (p *Purchase) SyncRemittances(
+	ctx context.Context, actor string, from, to time.Time) (*dtos.RemittanceImport, error) {
+	resp, err := p.Ghn.Remittances(ctx, from, to)
+	if err != nil {
+		return nil, err
+	}
+	if resp.Code != 200 {
+		return nil, fmt.Errorf("ghn remittances: %s", resp.Message)
+	}
+	lines := make([]remittanceLine, 0, len(resp.Data))
+	for _, remittance := range resp.Data {
+		line := remittanceLine{
+			Reference:        remittance.RemittanceCode,
+			CarrierOrderCode: remittance.OrderCode,
+			OrderCode:        remittance.ClientOrderCode,
+			Amount:           decimal.NewFromInt(int64(remittance.CODAmount)),
+		}
+		if line.Reference == "" {
+			line.Reference = ghnRemittance
+		}
+		if remittance.CODTransferDate != "" {
+			at, err := parseRemittedAt(remittance.CODTransferDate)
+			if err != nil {
+				return nil, err
+			}
+			line.RemittedAt = &at
+		}
+		lines = append(lines, line)
+	}
+	return p.reconcileRemittances(ctx, remittanceAPI, actor, lines)
+}
+
+// GetUnreconciledCOD implements IPurchase.
+func (p *Purchase) GetUnreconciledCOD(
+	ctx context.Context, olderThan time.Duration, limit, page int) ([]dtos.CODOrder, error) {
+	now := time.Now().UTC()
+	collections, err := p.COD.GetUnreconciled(ctx, now.Add(-olderThan), limit, (page-1)*limit)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]dtos.CODOrder, 0, len(collections))
+	for _, collection := range collections {
+		result = append(result, dtos.CODOrder{
+			OrderCode:      collection.OrderCode,
+			OrderStatus:    collection.OrderStatus,
+			ExpectedAmount: collection.ExpectedAmount.String(),
+			RemittedAmount: collection.RemittedAmount.String(),
+			Outstanding:    collection.ExpectedAmount.Sub(collection.RemittedAmount).String(),
+			Status:         collection.Status,
+			Since:          utils.HanoiTimezone(collection.Since),
+			AgeDays:        int(now.Sub(collection.Since).Hours() / 24),
+		})
+	}
+	return result, nil
+}
+
+// expectCOD records the cash the carrier collects on delivery of a COD
+// order, what was not paid at checkout or as a deposit, in the base
+// currency.
+func (p *Purchase) expectCOD(ctx context.Context, codRepo cod.ICOD, orderID int64, order entity.Order) error {
+	if !strings.EqualFold(order.PaymentMethod, config.CODPaymentMethod) {
+		return nil
+	}
+	paid := order.CreditAmount.Add(order.PointsAmount)
+	if order.DepositPaidAt != nil && order.DepositAmount.GreaterThan(paid) {
+		paid = order.DepositAmount
+	}
+	expected := order.TotalAmount.Sub(paid)
+	if !expected.IsPositive() {
+		return nil
+	}
+	return codRepo.InsertCollection(ctx, entity.CODCollection{
+		OrderID:        orderID,
+		ExpectedAmount: baseAmount(&order, expected),
+	})
+}
+
+// reconcileRemittances matches the lines of a remittance report to the
+// COD orders, all of them or none.
+func (p *Purchase) reconcileRemittances(
+	ctx context.Context, source string, actor string, lines []remittanceLine) (*dtos.RemittanceImport, error) {
+	tx, err := p.begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		orderRepo = tx.Order
+		codRepo   = tx.COD
+		amount    = decimal.Zero
+		result    = &dtos.RemittanceImport{
+			Lines:   len(lines),
+			Results: make([]dtos.RemittanceLine, 0, len(lines)),
+		}
+	)
+	for _, line := range lines {
+		outcome, err := p.reconcileRemittance(ctx, orderRepo, codRepo, source, actor, line)
+		if err != nil {
+			if errTx := tx.Rollback(ctx); errTx != nil {
+				log.Fatal(errTx)
+			}
+			return nil, err
+		}
+		switch outcome {
+		case RemittanceMatched:
+			result.Matched++
+		case RemittanceShort:
+			result.Short++
+		case RemittanceOver:
+			result.Over++
+		case RemittanceUnmatched:
+			result.Unmatched++
+		case RemittanceDuplicate:
+			result.Duplicates++
+		}
+		if outcome != RemittanceDuplicate {
+			amount = amount.Add(line.Amount)
+		}
+		result.Results = append(result.Results, dtos.RemittanceLine{
+			Reference:        line.Reference,
+			CarrierOrderCode: line.CarrierOrderCode,
+			OrderCode:        line.OrderCode,
+			Amount:           line.Amount.String(),
+			Result:           outcome,
+		})
+	}
+	result.Amount = amount.String()
+	return result, tx.Commit(ctx)
+}
+
+// reconcileRemittance adds a remittance line to the collection of its
+// order. A line already imported with the same report is skipped.
+func (p *Purchase) reconcileRemittance(
+	ctx context.Context,
+	orderRepo orders.IOrders,
+	codRepo cod.ICOD,
+	source string,
+	actor string,
+	line remittanceLine,
+) (string, error) {
+	var (
+		order      *entity.Order
+		collection *entity.CODCollection
+		err        error
+	)
+	if line.OrderCode != "" {
+		order, err = orderRepo.GetByUUID(ctx, line.OrderCode)
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			return "", err
+		}
+		if err == nil {
+			collection, err = codRepo.GetCollectionForUpdate(ctx, order.ID)
+			if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+				return "", err
+			}
+		}
+	}
+
+	remittance := entity.CODRemittance{
+		Source:           source,
+		Reference:        line.Reference,
+		CarrierOrderCode: line.CarrierOrderCode,
+		OrderCode:        line.OrderCode,
+		Amount:           line.Amount,
+		Result:           RemittanceUnmatched,
+		RemittedAt:       line.RemittedAt,
+		Actor:            actor,
+	}
+	status := enum.CODPending
+	if collection != nil {
+		remittance.OrderID = &order.ID
+		collection.RemittedAmount = collection.RemittedAmount.Add(line.Amount)
+		switch collection.RemittedAmount.Cmp(collection.ExpectedAmount) {
+		case 0:
+			remittance.Result, status = RemittanceMatched, enum.CODReconciled
+		case -1:
+			remittance.Result, status = RemittanceShort, enum.CODShort
+		default:
+			remittance.Result, status = RemittanceOver, enum.CODMismatch
+		}
+	}
+	if _, err := codRepo.InsertRemittance(ctx, remittance); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return RemittanceDuplicate, nil
+		}
+		return "", err
+	}
+	if collection == nil {
+		return remittance.Result, nil
+	}
+
+	if err := codRepo.UpdateCollection(ctx, order.ID, collection.RemittedAmount, status.String()); err != nil {
+		return "", err
+	}
+	if status == enum.CODReconciled {
+		if err := orderRepo.SetPaid(ctx, order.ID, time.Now().UTC()); err != nil {
+			return "", err
+		}
+	}
+	return remittance.Result, nil
+}
+
+// parseRemittances reads a remittance report in CSV. The header names the
+// columns order_code, the carrier code, client_order_code, cod_amount and
+// optionally remitted_at.
+func parseRemittances(reference string, report io.Reader) ([]remittanceLine, error) {
+	reader := csv.NewReader(report)
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("%w: cannot read the header: %v", ErrInvalidRemittance, err)
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+	for _, name := range []string{"order_code", "client_order_code", "cod_amount"} {
+		if _, ok := columns[name]; !ok {
+			return nil, fmt.Errorf("%w: missing column %q", ErrInvalidRemittance, name)
+		}
+	}
+
+	var lines []remittanceLine
+	for row := 2; ; row++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidRemittance, err)
+		}
+		line := remittanceLine{
+			Reference:        reference,
+			CarrierOrderCode: strings.TrimSpace(record[columns["order_code"]]),
+			OrderCode:        strings.TrimSpace(record[columns["client_order_code"]]),
+		}
+		if line.CarrierOrderCode == "" {
+			return nil, fmt.Errorf("%w: row %d has no order_code", ErrInvalidRemittance, row)
+		}
+		line.Amount, err = decimal.NewFromString(strings.TrimSpace(record[columns["cod_amount"]]))
+		if err != nil || !line.Amount.IsPositive() {
+			return nil, fmt.Errorf("%w: row %d has an invalid cod_amount", ErrInvalidRemittance, row)
+		}
+		if i, ok := columns["remitted_at"]; ok && strings.TrimSpace(record[i]) != "" {
+			at, err := parseRemittedAt(strings.TrimSpace(record[i]))
+			if err != nil {
+				return nil, fmt.Errorf("%w: row %d: %v", ErrInvalidRemittance, row, err)
+			}
+			line.RemittedAt = &at
+		}
+		lines = append(lines, line)
+	}
+	if len(lines) == 0 {
+		return nil, fmt.Errorf("%w: the report has no lines", ErrInvalidRemittance)
+	}
+	return lines, nil
+}
+
+// parseRemittedAt reads the transfer time of a remittance, local dates are
+// in the timezone of the shop.
+func parseRemittedAt(s string) (time.Time, error) {
+	if at, err := time.Parse(time.RFC3339, s); err == nil {
+		return at.UTC(), nil
+	}
+	hanoi := time.FixedZone("GMT+7", 7*60*60)
+	for _, layout := range []string{time.DateTime, time.DateOnly} {
+		if at, err := time.ParseInLocation(layout, s, hanoi); err == nil {
+			return at.UTC(), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid remittance time %q", s)
+}
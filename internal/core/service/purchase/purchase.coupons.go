@@ -0,0 +1,201 @@
+package purchase
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/swclabs/swipex/internal/core/domain/dtos"
+	"github.com/swclabs/swipex/internal/core/domain/entity"
+	"github.com/swclabs/swipex/internal/core/domain/enum"
+	"github.com/swclabs/swipex/internal/core/repos/coupons"
+	"github.com/swclabs/swipex/internal/core/repos/inventories"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+)
+
+// couponLine is an order line as seen by the coupon rules.
+type couponLine struct {
+	InventoryID int64
+	CategoryID  int64
+	Amount      decimal.Decimal
+}
+
+// ValidateCoupon implements IPurchase.
+func (p *Purchase) ValidateCoupon(ctx context.Context, userID int64, req dtos.CouponValidate) (*dtos.CouponPreview, error) {
+	coupon, err := p.Coupon.GetByCode(ctx, req.CouponCode)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, &CouponError{Code: req.CouponCode, Reason: "coupon does not exist"}
+		}
+		return nil, err
+	}
+	currency, err := p.currencyOf(ctx, dtos.OrderForm{})
+	if err != nil {
+		return nil, err
+	}
+	lines, err := p.couponLines(ctx, p.Inventory.GetByID, req.Product, currency)
+	if err != nil {
+		return nil, err
+	}
+	discount, err := p.checkCoupon(ctx, p.Coupon, userID, coupon, lines)
+	if err != nil {
+		return nil, err
+	}
+	subtotal := decimal.Zero
+	for _, line := range lines {
+		subtotal = subtotal.Add(line.Amount)
+	}
+	return &dtos.CouponPreview{
+		Code:     coupon.Code,
+		Subtotal: subtotal.String(),
+		Discount: discount.String(),
+		Total:    subtotal.Sub(discount).String(),
+	}, nil
+}
+
+// applyCoupon checks the coupon of an order against its rules and returns
+// the discount in the base currency. It must run inside the order
+// transaction: the coupon row is locked so that concurrent orders cannot go
+// over its max use.
+func (p *Purchase) applyCoupon(
+	ctx context.Context,
+	couponRepo coupons.ICoupons,
+	inventory inventories.IInventories,
+	userID int64,
+	order dtos.OrderForm,
+	currency orderCurrency,
+) (decimal.Decimal, error) {
+	if order.CouponCode == "" {
+		return decimal.Zero, nil
+	}
+	coupon, err := couponRepo.GetByCodeForUpdate(ctx, order.CouponCode)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return decimal.Zero, &CouponError{Code: order.CouponCode, Reason: "coupon does not exist"}
+		}
+		return decimal.Zero, err
+	}
+	lines, err := p.couponLines(ctx, inventory.GetByIDForUpdate, order.Product, currency)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	discount, err := p.checkCoupon(ctx, couponRepo, userID, coupon, lines)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	if err := couponRepo.IncreaseUsed(ctx, coupon.Code); err != nil {
+		return decimal.Zero, err
+	}
+	return discount, nil
+}
+
+// checkCoupon runs the coupon rules that need the database, then the pure
+// rules of couponDiscount. A zero userID skips the single use check.
+func (p *Purchase) checkCoupon(
+	ctx context.Context,
+	couponRepo coupons.ICoupons,
+	userID int64,
+	coupon *entity.Coupons,
+	lines []couponLine,
+) (decimal.Decimal, error) {
+	if userID != 0 {
+		used, err := couponRepo.GetUsedByUser(ctx, userID, coupon.Code)
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			return decimal.Zero, err
+		}
+		if used != nil {
+			return decimal.Zero, &CouponError{Code: coupon.Code, Reason: "coupon already used"}
+		}
+	}
+	return couponDiscount(coupon, lines, time.Now().UTC())
+}
+
+// couponLines prices the items of an order in the base currency, the one
+// of the coupon rules, and looks up their category. lookup is
+// GetByIDForUpdate inside the order transaction and the cached GetByID for
+// previews.
+func (p *Purchase) couponLines(
+	ctx context.Context,
+	lookup func(ctx context.Context, inventoryID int64) (*entity.Inventory, error),
+	items []dtos.OrderFormProduct,
+	currency orderCurrency,
+) ([]couponLine, error) {
+	lines := []couponLine{}
+	for _, item := range items {
+		id, err := parseItemCode(item.Code)
+		if err != nil {
+			return nil, err
+		}
+		inv, err := lookup(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		product, err := p.Product.GetByID(ctx, inv.ProductID)
+		if err != nil {
+			return nil, err
+		}
+		amount, err := currency.base(inv.Price.Mul(decimal.NewFromInt(item.Quantity)), inv.CurrencyCode)
+		if err != nil {
+			return nil, err
+		}
+		lines = append(lines, couponLine{
+			InventoryID: id,
+			CategoryID:  product.CategoryID,
+			Amount:      amount,
+		})
+	}
+	return lines, nil
+}
+
+// couponDiscount returns the discount a coupon gives to the order lines at
+// time now. A coupon restricted to categories or inventories only discounts
+// the matching lines; the minimum order value applies to the whole order.
+func couponDiscount(coupon *entity.Coupons, lines []couponLine, now time.Time) (decimal.Decimal, error) {
+	if coupon.Status != "active" {
+		return decimal.Zero, &CouponError{Code: coupon.Code, Reason: "coupon is not active"}
+	}
+	if now.After(coupon.ExpiredAt) {
+		return decimal.Zero, &CouponError{Code: coupon.Code, Reason: "coupon has expired"}
+	}
+	if coupon.MaxUse > 0 && coupon.Used >= coupon.MaxUse {
+		return decimal.Zero, &CouponError{Code: coupon.Code, Reason: "coupon has reached its usage limit"}
+	}
+
+	var (
+		subtotal = decimal.Zero
+		eligible = decimal.Zero
+		scoped   = len(coupon.CategoryIDs) > 0 || len(coupon.InventoryIDs) > 0
+	)
+	for _, line := range lines {
+		subtotal = subtotal.Add(line.Amount)
+		if !scoped ||
+			slices.Contains(coupon.CategoryIDs, line.CategoryID) ||
+			slices.Contains(coupon.InventoryIDs, line.InventoryID) {
+			eligible = eligible.Add(line.Amount)
+		}
+	}
+	if subtotal.LessThan(coupon.MinOrderValue) {
+		return decimal.Zero, &CouponError{
+			Code:   coupon.Code,
+			Reason: "order must be at least " + coupon.MinOrderValue.String() + " to use this coupon",
+		}
+	}
+	if eligible.IsZero() {
+		return decimal.Zero, &CouponError{Code: coupon.Code, Reason: "coupon does not apply to any item of the order"}
+	}
+
+	var discount decimal.Decimal
+	switch enum.CouponDiscount(coupon.DiscountType) {
+	case enum.CouponFixed:
+		discount = decimal.Min(coupon.Discount, eligible)
+	default:
+		discount = eligible.Mul(coupon.Discount).Div(decimal.NewFromInt(100))
+	}
+	if coupon.MaxDiscount.IsPositive() {
+		discount = decimal.Min(discount, coupon.MaxDiscount)
+	}
+	return discount.Round(2), nil
+}
@@ -0,0 +1,497 @@
+package purchase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/swclabs/swipex/internal/config"
+	"github.com/swclabs/swipex/internal/core/domain/dtos"
+	"github.com/swclabs/swipex/internal/core/domain/entity"
+	"github.com/swclabs/swipex/internal/core/repos/credits"
+	"github.com/swclabs/swipex/internal/core/repos/orders"
+	"github.com/swclabs/swipex/pkg/infra/db"
+	"github.com/swclabs/swipex/pkg/utils"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+)
+
+// statuses of a gift card
+const (
+	GiftCardActive = "active"
+	GiftCardVoided = "voided"
+)
+
+// kinds of credit ledger entries
+const (
+	CreditIssue    = "issue"
+	CreditRedeem   = "redeem"
+	CreditRefund   = "refund"
+	CreditReversal = "reversal"
+	CreditVoid     = "void"
+)
+
+// giftCardCodeLength is the length of the generated gift card codes
+const giftCardCodeLength = 16
+
+// creditDebit is a part of an order paid with a gift card, or with the
+// wallet of the customer when GiftCard is nil
+type creditDebit struct {
+	GiftCard *entity.GiftCard
+	Wallet   *entity.Wallet
+	Amount   decimal.Decimal
+}
+
+// IssueGiftCard implements IPurchase.
+func (p *Purchase) IssueGiftCard(ctx context.Context, actor string, req dtos.IssueGiftCard) (*dtos.GiftCard, error) {
+	amount, err := decimal.NewFromString(req.Amount)
+	if err != nil || !amount.IsPositive() {
+		return nil, fmt.Errorf("%w: amount must be a positive number", ErrInvalidGiftCard)
+	}
+	card := entity.GiftCard{
+		Code:           utils.GenGiftCardCode(giftCardCodeLength),
+		InitialBalance: amount,
+		Balance:        amount,
+		CurrencyCode:   req.CurrencyCode,
+		Status:         GiftCardActive,
+		Note:           req.Note,
+		CreatedAt:      time.Now().UTC(),
+	}
+	if card.CurrencyCode == "" {
+		card.CurrencyCode = config.BaseCurrency
+	}
+	// balances are spent as amounts of the base currency at checkout
+	if !strings.EqualFold(card.CurrencyCode, config.BaseCurrency) {
+		return nil, fmt.Errorf("%w: gift cards are issued in %s only", ErrInvalidGiftCard, config.BaseCurrency)
+	}
+	card.CurrencyCode = config.BaseCurrency
+	if req.ExpiresAt != "" {
+		// the card can be used until the end of its last day in Hanoi
+		date, err := time.ParseInLocation(time.DateOnly, req.ExpiresAt, time.FixedZone("GMT+7", 7*60*60))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidGiftCard, err)
+		}
+		expires := date.AddDate(0, 0, 1).UTC()
+		card.ExpiresAt = &expires
+	}
+
+	tx, err := db.NewTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var (
+		creditRepo = credits.New(tx)
+		orderRepo  = orders.New(tx)
+	)
+	if req.OrderCode != "" {
+		order, err := orderRepo.GetByUUID(ctx, req.OrderCode)
+		if err != nil {
+			if errTx := tx.Rollback(ctx); errTx != nil {
+				log.Fatal(errTx)
+			}
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, ErrOrderNotFound
+			}
+			return nil, err
+		}
+		card.OrderID = &order.ID
+	}
+	if card.ID, err = creditRepo.InsertGiftCard(ctx, card); err != nil {
+		if errTx := tx.Rollback(ctx); errTx != nil {
+			log.Fatal(errTx)
+		}
+		return nil, err
+	}
+	if _, err := creditRepo.InsertEntry(ctx, entity.CreditEntry{
+		GiftCardID: &card.ID,
+		OrderID:    card.OrderID,
+		Amount:     amount,
+		Balance:    amount,
+		Kind:       CreditIssue,
+		Actor:      actor,
+		Note:       req.Note,
+	}); err != nil {
+		if errTx := tx.Rollback(ctx); errTx != nil {
+			log.Fatal(errTx)
+		}
+		return nil, err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return nil, err
+	}
+	return giftCardDTO(&card), nil
+}
+
+// VoidGiftCard implements IPurchase.
+func (p *Purchase) VoidGiftCard(ctx context.Context, actor string, code string, note string) error {
+	tx, err := db.NewTx(ctx)
+	if err != nil {
+		return err
+	}
+	creditRepo := credits.New(tx)
+	if err := p.voidGiftCard(ctx, creditRepo, actor, code, note); err != nil {
+		if errTx := tx.Rollback(ctx); errTx != nil {
+			log.Fatal(errTx)
+		}
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func (p *Purchase) voidGiftCard(ctx context.Context, creditRepo credits.ICredits, actor, code, note string) error {
+	card, err := creditRepo.GetGiftCardForUpdate(ctx, code)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrGiftCardNotFound
+		}
+		return err
+	}
+	if card.Status == GiftCardVoided {
+		return fmt.Errorf("%w: gift card is already voided", ErrGiftCardUnusable)
+	}
+	// the remaining balance leaves the card through the ledger
+	if card.Balance.IsPositive() {
+		if _, err := creditRepo.InsertEntry(ctx, entity.CreditEntry{
+			GiftCardID: &card.ID,
+			Amount:     card.Balance.Neg(),
+			Balance:    decimal.Zero,
+			Kind:       CreditVoid,
+			Actor:      actor,
+			Note:       note,
+		}); err != nil {
+			return err
+		}
+	}
+	return creditRepo.VoidGiftCard(ctx, card.ID)
+}
+
+// GetGiftCard implements IPurchase.
+func (p *Purchase) GetGiftCard(ctx context.Context, code string) (*dtos.GiftCard, error) {
+	card, err := p.Credit.GetGiftCard(ctx, code)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrGiftCardNotFound
+		}
+		return nil, err
+	}
+	return giftCardDTO(card), nil
+}
+
+// AuditGiftCard implements IPurchase.
+func (p *Purchase) AuditGiftCard(ctx context.Context, code string) (*dtos.GiftCardAudit, error) {
+	card, err := p.Credit.GetGiftCard(ctx, code)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrGiftCardNotFound
+		}
+		return nil, err
+	}
+	entries, err := p.Credit.GetGiftCardEntries(ctx, card.ID)
+	if err != nil {
+		return nil, err
+	}
+	result, sum := creditEntries(entries)
+	return &dtos.GiftCardAudit{
+		GiftCard:      *giftCardDTO(card),
+		Entries:       result,
+		LedgerBalance: sum.String(),
+		Consistent:    sum.Equal(card.Balance),
+	}, nil
+}
+
+// GetGiftCards implements IPurchase.
+func (p *Purchase) GetGiftCards(ctx context.Context, status string, limit, page int) ([]dtos.GiftCard, error) {
+	if page < 1 {
+		page = 1
+	}
+	cards, err := p.Credit.GetGiftCards(ctx, status, limit, (page-1)*limit)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]dtos.GiftCard, 0, len(cards))
+	for i := range cards {
+		result = append(result, *giftCardDTO(&cards[i]))
+	}
+	return result, nil
+}
+
+// GetWallet implements IPurchase.
+func (p *Purchase) GetWallet(ctx context.Context, userID int64) (*dtos.Wallet, error) {
+	wallet, err := p.Credit.GetWallet(ctx, userID)
+	if err != nil {
+		if !errors.Is(err, pgx.ErrNoRows) {
+			return nil, err
+		}
+		// users get a wallet with their first credit
+		wallet = &entity.Wallet{UserID: userID, Balance: decimal.Zero, CurrencyCode: "VND"}
+	}
+	entries, err := p.Credit.GetWalletEntries(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	result, sum := creditEntries(entries)
+	return &dtos.Wallet{
+		UserID:        userID,
+		Balance:       wallet.Balance.String(),
+		CurrencyCode:  wallet.CurrencyCode,
+		Entries:       result,
+		LedgerBalance: sum.String(),
+		Consistent:    sum.Equal(wallet.Balance),
+	}, nil
+}
+
+// planCredits locks the gift cards and the wallet an order pays with and
+// returns what each of them pays, gift cards first, up to due.
+func (p *Purchase) planCredits(
+	ctx context.Context,
+	creditRepo credits.ICredits,
+	userID int64,
+	order dtos.OrderForm,
+	due decimal.Decimal,
+) ([]creditDebit, decimal.Decimal, error) {
+	var (
+		debits []creditDebit
+		paid   = decimal.Zero
+		now    = time.Now().UTC()
+		seen   = map[string]bool{}
+	)
+	for _, code := range order.GiftCards {
+		if seen[code] {
+			continue
+		}
+		seen[code] = true
+		card, err := creditRepo.GetGiftCardForUpdate(ctx, code)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, paid, ErrGiftCardNotFound
+			}
+			return nil, paid, err
+		}
+		if err := usableGiftCard(card, now); err != nil {
+			return nil, paid, err
+		}
+		amount := decimal.Min(card.Balance, due.Sub(paid))
+		if !amount.IsPositive() {
+			continue
+		}
+		debits = append(debits, creditDebit{GiftCard: card, Amount: amount})
+		paid = paid.Add(amount)
+	}
+
+	if order.StoreCredit == "" {
+		return debits, paid, nil
+	}
+	requested, err := decimal.NewFromString(order.StoreCredit)
+	if err != nil || requested.IsNegative() {
+		return nil, paid, fmt.Errorf("%w: store credit must be a positive number", ErrInsufficientCredit)
+	}
+	if !requested.IsPositive() {
+		return debits, paid, nil
+	}
+	wallet, err := creditRepo.GetWalletForUpdate(ctx, userID)
+	if err != nil {
+		return nil, paid, err
+	}
+	if wallet.Balance.LessThan(requested) {
+		return nil, paid, fmt.Errorf("%w: the wallet holds %s", ErrInsufficientCredit, wallet.Balance)
+	}
+	amount := decimal.Min(requested, due.Sub(paid))
+	if amount.IsPositive() {
+		debits = append(debits, creditDebit{Wallet: wallet, Amount: amount})
+		paid = paid.Add(amount)
+	}
+	return debits, paid, nil
+}
+
+// usableGiftCard returns an error if a gift card cannot pay at now.
+func usableGiftCard(card *entity.GiftCard, now time.Time) error {
+	switch {
+	case card.Status != GiftCardActive:
+		return fmt.Errorf("%w: gift card %s is voided", ErrGiftCardUnusable, card.Code)
+	case card.ExpiresAt != nil && !now.Before(*card.ExpiresAt):
+		return fmt.Errorf("%w: gift card %s has expired", ErrGiftCardUnusable, card.Code)
+	case !card.Balance.IsPositive():
+		return fmt.Errorf("%w: gift card %s is empty", ErrGiftCardUnusable, card.Code)
+	}
+	return nil
+}
+
+// debitCredits takes the amounts planned by planCredits from the gift
+// cards and the wallet and records them in the ledger against the order.
+func (p *Purchase) debitCredits(
+	ctx context.Context,
+	creditRepo credits.ICredits,
+	orderID int64,
+	actor string,
+	debits []creditDebit,
+) error {
+	for _, debit := range debits {
+		entry := entity.CreditEntry{
+			OrderID: &orderID,
+			Amount:  debit.Amount.Neg(),
+			Kind:    CreditRedeem,
+			Actor:   actor,
+		}
+		if debit.GiftCard != nil {
+			entry.GiftCardID = &debit.GiftCard.ID
+			entry.Balance = debit.GiftCard.Balance.Sub(debit.Amount)
+			if err := creditRepo.SetGiftCardBalance(ctx, debit.GiftCard.ID, entry.Balance); err != nil {
+				return err
+			}
+		} else {
+			entry.UserID = &debit.Wallet.UserID
+			entry.Balance = debit.Wallet.Balance.Sub(debit.Amount)
+			if err := creditRepo.SetWalletBalance(ctx, debit.Wallet.UserID, entry.Balance); err != nil {
+				return err
+			}
+		}
+		if _, err := creditRepo.InsertEntry(ctx, entry); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// restoreCredits gives back what the gift cards and the wallet paid for an
+// order that will not be delivered. The credit of a card voided since then
+// goes to the wallet of the customer.
+func (p *Purchase) restoreCredits(
+	ctx context.Context,
+	orderRepo orders.IOrders,
+	creditRepo credits.ICredits,
+	orderCode string,
+	actor string,
+) error {
+	order, err := orderRepo.GetByUUID(ctx, orderCode)
+	if err != nil {
+		return err
+	}
+	if !order.CreditAmount.IsPositive() {
+		return nil
+	}
+	entries, err := creditRepo.GetOrderEntries(ctx, order.ID)
+	if err != nil {
+		return err
+	}
+	// net amount taken from each account by the order
+	var (
+		cards  = map[int64]decimal.Decimal{}
+		wallet = decimal.Zero
+	)
+	for _, entry := range entries {
+		switch {
+		case entry.GiftCardID != nil && entry.Kind != CreditIssue:
+			cards[*entry.GiftCardID] = cards[*entry.GiftCardID].Sub(entry.Amount)
+		case entry.UserID != nil && entry.Kind != CreditRefund:
+			wallet = wallet.Sub(entry.Amount)
+		}
+	}
+
+	for id, amount := range cards {
+		if !amount.IsPositive() {
+			continue
+		}
+		card, err := creditRepo.GetGiftCardByIDForUpdate(ctx, id)
+		if err != nil {
+			return err
+		}
+		if card.Status == GiftCardVoided {
+			wallet = wallet.Add(amount)
+			continue
+		}
+		balance := card.Balance.Add(amount)
+		if err := creditRepo.SetGiftCardBalance(ctx, id, balance); err != nil {
+			return err
+		}
+		if _, err := creditRepo.InsertEntry(ctx, entity.CreditEntry{
+			GiftCardID: &id,
+			OrderID:    &order.ID,
+			Amount:     amount,
+			Balance:    balance,
+			Kind:       CreditReversal,
+			Actor:      actor,
+		}); err != nil {
+			return err
+		}
+	}
+	if !wallet.IsPositive() {
+		return nil
+	}
+	return p.creditWallet(ctx, creditRepo, order.UserID, &order.ID, wallet, CreditReversal, actor, "")
+}
+
+// creditWallet adds amount to the wallet of a user.
+func (p *Purchase) creditWallet(
+	ctx context.Context,
+	creditRepo credits.ICredits,
+	userID int64,
+	orderID *int64,
+	amount decimal.Decimal,
+	kind string,
+	actor string,
+	note string,
+) error {
+	wallet, err := creditRepo.GetWalletForUpdate(ctx, userID)
+	if err != nil {
+		return err
+	}
+	balance := wallet.Balance.Add(amount)
+	if err := creditRepo.SetWalletBalance(ctx, userID, balance); err != nil {
+		return err
+	}
+	_, err = creditRepo.InsertEntry(ctx, entity.CreditEntry{
+		UserID:  &userID,
+		OrderID: orderID,
+		Amount:  amount,
+		Balance: balance,
+		Kind:    kind,
+		Actor:   actor,
+		Note:    note,
+	})
+	return err
+}
+
+// creditEntries converts ledger entries and returns their sum.
+func creditEntries(entries []entity.CreditEntry) ([]dtos.CreditEntry, decimal.Decimal) {
+	var (
+		result = make([]dtos.CreditEntry, 0, len(entries))
+		sum    = decimal.Zero
+	)
+	for _, entry := range entries {
+		sum = sum.Add(entry.Amount)
+		result = append(result, dtos.CreditEntry{
+			ID:        entry.ID,
+			OrderID:   entry.OrderID,
+			Amount:    entry.Amount.String(),
+			Balance:   entry.Balance.String(),
+			Kind:      entry.Kind,
+			Actor:     entry.Actor,
+			Note:      entry.Note,
+			CreatedAt: utils.HanoiTimezone(entry.CreatedAt),
+		})
+	}
+	return result, sum
+}
+
+func giftCardDTO(card *entity.GiftCard) *dtos.GiftCard {
+	result := &dtos.GiftCard{
+		Code:           card.Code,
+		InitialBalance: card.InitialBalance.String(),
+		Balance:        card.Balance.String(),
+		CurrencyCode:   card.CurrencyCode,
+		Status:         card.Status,
+		Note:           card.Note,
+		CreatedAt:      utils.HanoiTimezone(card.CreatedAt),
+	}
+	if card.ExpiresAt != nil {
+		result.ExpiresAt = utils.HanoiTimezone(*card.ExpiresAt)
+	}
+	if card.VoidedAt != nil {
+		result.VoidedAt = utils.HanoiTimezone(*card.VoidedAt)
+	}
+	return result
+}
@@ -0,0 +1,97 @@
+package purchase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/swclabs/swipex/internal/config"
+	"github.com/swclabs/swipex/internal/core/domain/dtos"
+	"github.com/swclabs/swipex/internal/core/domain/entity"
+	"github.com/swclabs/swipex/internal/core/domain/model"
+
+	"github.com/shopspring/decimal"
+)
+
+// orderCurrency is the currency an order is priced in. Rate is the value
+// of one unit in the base currency when the order is placed, it is
+// snapshotted on the order so that later rate changes leave it alone.
+type orderCurrency struct {
+	Code  string
+	Rate  decimal.Decimal
+	rates model.ExchangeRates
+}
+
+// currencyOf returns the currency of an order form, the base currency when
+// none is asked. Gift cards, store credit and loyalty points are held in
+// the base currency and only pay for orders in it.
+func (p *Purchase) currencyOf(ctx context.Context, order dtos.OrderForm) (orderCurrency, error) {
+	code := strings.ToUpper(order.Currency)
+	if code == "" {
+		code = config.BaseCurrency
+	}
+	rates, err := p.Currency.GetRates(ctx)
+	if err != nil {
+		return orderCurrency{}, err
+	}
+	rate, ok := rates[code]
+	if !ok {
+		return orderCurrency{}, fmt.Errorf("%w: %s", ErrUnknownCurrency, code)
+	}
+	if code != config.BaseCurrency && (len(order.GiftCards) > 0 || order.StoreCredit != "" || order.Points > 0) {
+		return orderCurrency{}, fmt.Errorf("%w: %s", ErrCurrencyCredits, config.BaseCurrency)
+	}
+	return orderCurrency{Code: code, Rate: rate, rates: rates}, nil
+}
+
+// price converts an amount in the currency from to the currency of the
+// order, amounts without a currency are in the base currency.
+func (c orderCurrency) price(amount decimal.Decimal, from string) (decimal.Decimal, error) {
+	if from == "" {
+		from = config.BaseCurrency
+	}
+	converted, ok := c.rates.Convert(amount, from, c.Code)
+	if !ok {
+		return decimal.Zero, fmt.Errorf("%w: no exchange rate from %s to %s", ErrUnknownCurrency, from, c.Code)
+	}
+	return converted, nil
+}
+
+// base converts an amount in the currency from to the base currency.
+func (c orderCurrency) base(amount decimal.Decimal, from string) (decimal.Decimal, error) {
+	if from == "" || from == config.BaseCurrency {
+		return amount, nil
+	}
+	converted, ok := c.rates.Convert(amount, from, config.BaseCurrency)
+	if !ok {
+		return decimal.Zero, fmt.Errorf("%w: no exchange rate from %s to %s", ErrUnknownCurrency, from, config.BaseCurrency)
+	}
+	return converted, nil
+}
+
+// fromBase converts an amount in the base currency to the currency of the
+// order.
+func (c orderCurrency) fromBase(amount decimal.Decimal) decimal.Decimal {
+	if c.Code == config.BaseCurrency {
+		return amount
+	}
+	return model.RoundCurrency(amount.Div(c.Rate), c.Code)
+}
+
+// toBase converts an amount in the currency of the order to the base
+// currency.
+func (c orderCurrency) toBase(amount decimal.Decimal) decimal.Decimal {
+	if c.Code == config.BaseCurrency {
+		return amount
+	}
+	return model.RoundCurrency(amount.Mul(c.Rate), config.BaseCurrency)
+}
+
+// baseAmount converts an amount of a placed order to the base currency at
+// the rate snapshotted on the order.
+func baseAmount(order *entity.Order, amount decimal.Decimal) decimal.Decimal {
+	if order.CurrencyCode == "" || order.CurrencyCode == config.BaseCurrency || !order.ExchangeRate.IsPositive() {
+		return amount
+	}
+	return model.RoundCurrency(amount.Mul(order.ExchangeRate), config.BaseCurrency)
+}
@@ -0,0 +1,182 @@
+package purchase
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/swclabs/swipex/internal/core/domain/enum"
+)
+
+// ErrOrderNotFound is returned when an order does not exist or does not
+// belong to the requesting user.
+var ErrOrderNotFound = errors.New("order not found")
+
+// InsufficientStockError is returned when an order requests more units
+// of an inventory item than are currently available.
+type InsufficientStockError struct {
+	InventoryID int64
+	Requested   int64
+	Available   int64
+}
+
+// Error implements error.
+func (e *InsufficientStockError) Error() string {
+	return fmt.Sprintf("insufficient stock for inventory %d: requested %d, available %d",
+		e.InventoryID, e.Requested, e.Available)
+}
+
+// InvalidTransitionError is returned when an order is asked to move to a
+// status that is not reachable from its current status.
+type InvalidTransitionError struct {
+	From enum.OrderStatus
+	To   enum.OrderStatus
+}
+
+// Error implements error.
+func (e *InvalidTransitionError) Error() string {
+	return fmt.Sprintf("order cannot move from %s to %s", e.From, e.To)
+}
+
+// ErrReturnNotFound is returned when a return request does not exist.
+var ErrReturnNotFound = errors.New("return not found")
+
+// ErrReturnNotAllowed is returned when a customer asks to return an order
+// that has not been delivered yet.
+var ErrReturnNotAllowed = errors.New("only delivered orders can be returned")
+
+// ReturnQuantityError is returned when a return asks for more units of an
+// order line than are left to be returned.
+type ReturnQuantityError struct {
+	ItemID     int64
+	Requested  int64
+	Returnable int64
+}
+
+// Error implements error.
+func (e *ReturnQuantityError) Error() string {
+	return fmt.Sprintf("cannot return %d units of item %d: %d returnable",
+		e.Requested, e.ItemID, e.Returnable)
+}
+
+// ReturnStateError is returned when an admin action does not apply to the
+// current state of a return request.
+type ReturnStateError struct {
+	ReturnID int64
+	Status   enum.ReturnStatus
+	Action   string
+}
+
+// Error implements error.
+func (e *ReturnStateError) Error() string {
+	return fmt.Sprintf("return %d is %s and cannot be %s", e.ReturnID, e.Status, e.Action)
+}
+
+// CouponError is returned when a coupon cannot be applied to an order.
+type CouponError struct {
+	Code   string
+	Reason string
+}
+
+// Error implements error.
+func (e *CouponError) Error() string {
+	return fmt.Sprintf("coupon %s: %s", e.Code, e.Reason)
+}
+
+// ErrInvalidQuantity is wrapped when an order asks for no unit or a
+// negative number of units of an item.
+var ErrInvalidQuantity = errors.New("quantity must be positive")
+
+// ErrItemArchived is returned when a customer adds an inventory item that
+// is no longer sold to the cart.
+var ErrItemArchived = errors.New("item is no longer available for sale")
+
+// ErrCartItemNotFound is returned when a cart line to update does not exist.
+var ErrCartItemNotFound = errors.New("item not found in cart")
+
+// ErrInvoiceNotAvailable is returned when the invoice of an order that was
+// never confirmed is requested.
+var ErrInvoiceNotAvailable = errors.New("invoices are only issued for confirmed orders")
+
+// ErrInvalidOrderSearch is wrapped by the errors of malformed order search
+// filters and cursors.
+var ErrInvalidOrderSearch = errors.New("invalid order search")
+
+// ErrExportNotFound is returned for an order export that does not exist or
+// has expired.
+var ErrExportNotFound = errors.New("export not found")
+
+// ErrInventoryNotFound is returned when stock is received for an inventory
+// item that does not exist.
+var ErrInventoryNotFound = errors.New("inventory not found")
+
+// ErrOrderBackordered is returned when an order is confirmed while all of
+// its units are still waiting for stock.
+var ErrOrderBackordered = errors.New("order has backordered items")
+
+// ErrShipmentNotFound is returned for a shipment that does not exist.
+var ErrShipmentNotFound = errors.New("shipment not found")
+
+// ErrShipmentNotAllowed is wrapped when a shipment is created for an order
+// that is not being packed or moved to a status it cannot reach.
+var ErrShipmentNotAllowed = errors.New("shipment not allowed")
+
+// ErrInvalidShipment is wrapped when a shipment asks for units that are not
+// in its order, backordered or already shipped.
+var ErrInvalidShipment = errors.New("invalid shipment")
+
+// ErrBundleNotFound is returned for a bundle that does not exist.
+var ErrBundleNotFound = errors.New("bundle not found")
+
+// ErrBundleArchived is returned when an archived bundle is put in a cart
+// or ordered.
+var ErrBundleArchived = errors.New("bundle is no longer sold")
+
+// ErrInvalidBundle is wrapped by the errors of malformed bundles.
+var ErrInvalidBundle = errors.New("invalid bundle")
+
+// ErrGiftCardNotFound is returned for a gift card code that does not exist.
+var ErrGiftCardNotFound = errors.New("gift card not found")
+
+// ErrGiftCardUnusable is wrapped by the errors of gift cards that are
+// voided, expired or empty.
+var ErrGiftCardUnusable = errors.New("gift card cannot be used")
+
+// ErrInvalidGiftCard is wrapped by the errors of malformed gift cards.
+var ErrInvalidGiftCard = errors.New("invalid gift card")
+
+// ErrInsufficientCredit is wrapped when an order asks for more store credit
+// than the wallet of the customer holds.
+var ErrInsufficientCredit = errors.New("insufficient store credit")
+
+// ErrStoreCreditGuest is returned when a guest order asks for store credit,
+// only signed in customers have a wallet.
+var ErrStoreCreditGuest = errors.New("store credit requires an account")
+
+// ErrStoreCreditOwner is returned when an order asks for the store credit of
+// a customer other than the signed in user.
+var ErrStoreCreditOwner = errors.New("store credit can only be spent by the owner of the account")
+
+// ErrInsufficientPoints is wrapped when an order asks to redeem more loyalty
+// points than the customer holds.
+var ErrInsufficientPoints = errors.New("insufficient loyalty points")
+
+// ErrUnknownCurrency is wrapped when an order asks for a currency without an
+// exchange rate.
+var ErrUnknownCurrency = errors.New("unknown currency")
+
+// ErrCurrencyCredits is wrapped when an order in a foreign currency asks to
+// pay with gift cards, store credit or loyalty points, they are held in the
+// base currency.
+var ErrCurrencyCredits = errors.New("gift cards, store credit and loyalty points only pay for orders in the base currency")
+
+// ErrPointsGuest is returned when a guest order asks to redeem loyalty
+// points, only signed in customers collect them.
+var ErrPointsGuest = errors.New("loyalty points require an account")
+
+// ErrPointsOwner is returned when an order asks to redeem the loyalty points
+// of a customer other than the signed in user.
+var ErrPointsOwner = errors.New("loyalty points can only be redeemed by the owner of the account")
+
+// ErrInvalidRemittance is wrapped by the errors of malformed remittance
+// reports.
+var ErrInvalidRemittance = errors.New("invalid remittance report")
@@ -0,0 +1,227 @@
+package purchase
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/swclabs/swipex/internal/config"
+	"github.com/swclabs/swipex/internal/core/domain/dtos"
+	"github.com/swclabs/swipex/internal/core/domain/model"
+	"github.com/swclabs/swipex/internal/core/tasks"
+	"github.com/swclabs/swipex/internal/workers/queue"
+	"github.com/swclabs/swipex/pkg/infra/cache"
+	"github.com/swclabs/swipex/pkg/lib/worker"
+	"github.com/swclabs/swipex/pkg/lib/xlsx"
+	"github.com/swclabs/swipex/pkg/utils"
+)
+
+// keyOrderExport stores an OrderExportJob under its ID
+const keyOrderExport = "OrderExport:%s"
+
+// statuses of an OrderExportJob
+const (
+	ExportPending = "pending"
+	ExportRunning = "running"
+	ExportDone    = "done"
+	ExportFailed  = "failed"
+)
+
+// export formats
+const (
+	ExportCSV  = "csv"
+	ExportXLSX = "xlsx"
+)
+
+// exportHeader names the columns of an order export, one row per line item
+var exportHeader = []any{
+	"Order", "Date", "Status", "Payment method", "Email", "First name", "Last name", "Phone",
+	"Coupon", "Product", "Color", "Quantity", "Unit price", "VAT rate", "Line tax", "Line amount",
+	"Order subtotal", "Order discount", "Order tax", "Order shipping", "Order total",
+}
+
+// ExportOrders implements IPurchase.
+func (p *Purchase) ExportOrders(ctx context.Context, export dtos.OrderExport, w io.Writer) (int64, error) {
+	filter, err := exportFilter(export)
+	if err != nil {
+		return 0, err
+	}
+	sheet, err := newSheet(export.Format, w)
+	if err != nil {
+		return 0, err
+	}
+	if err := sheet.Write(exportHeader); err != nil {
+		return 0, err
+	}
+	var rows int64
+	if err := p.Order.Export(ctx, filter, func(row model.OrderExportRow) error {
+		rows++
+		return sheet.Write([]any{
+			row.UUID, utils.HanoiTimezone(row.Time), row.Status, row.PaymentMethod,
+			row.Email, row.FirstName, row.LastName, row.PhoneNumber, row.CouponCode,
+			row.ProductName, row.Color, row.Quantity,
+			xlsx.Number(row.UnitPrice.String()), xlsx.Number(row.VATRate.String()),
+			xlsx.Number(row.LineTaxAmount.String()), xlsx.Number(row.LineAmount.String()),
+			xlsx.Number(row.Subtotal.String()), xlsx.Number(row.DiscountAmount.String()),
+			xlsx.Number(row.TaxAmount.String()), xlsx.Number(row.ShippingFee.String()),
+			xlsx.Number(row.TotalAmount.String()),
+		})
+	}); err != nil {
+		return rows, err
+	}
+	return rows, sheet.Close()
+}
+
+// StartOrderExport implements IPurchase.
+func (p *Purchase) StartOrderExport(ctx context.Context, export dtos.OrderExport) (*dtos.OrderExportJob, error) {
+	if _, err := exportFilter(export); err != nil {
+		return nil, err
+	}
+	if export.Format == "" {
+		export.Format = ExportCSV
+	}
+	job := &dtos.OrderExportJob{
+		ID:        utils.GenOrderCode(16),
+		Status:    ExportPending,
+		Filter:    export,
+		CreatedAt: time.Now().UTC(),
+	}
+	if err := p.saveExport(ctx, job); err != nil {
+		return nil, err
+	}
+	if err := p.Worker.Exec(ctx, queue.DefaultQueue,
+		worker.NewTask(tasks.PurchaseExportOrders, dtos.OrderExportJob{ID: job.ID}),
+	); err != nil {
+		return nil, err
+	}
+	return job, nil
+}
+
+// RunOrderExport implements IPurchase.
+func (p *Purchase) RunOrderExport(ctx context.Context, id string) error {
+	job, err := p.GetOrderExport(ctx, id)
+	if err != nil {
+		return err
+	}
+	if job.Status == ExportDone {
+		return nil
+	}
+	job.Status, job.Error = ExportRunning, ""
+	if err := p.saveExport(ctx, job); err != nil {
+		return err
+	}
+
+	// the rows go straight from the database to the upload through a pipe
+	reader, writer := io.Pipe()
+	done := make(chan error, 1)
+	go func() {
+		rows, err := p.ExportOrders(ctx, job.Filter, writer)
+		job.Rows = rows
+		writer.CloseWithError(err)
+		done <- err
+	}()
+	name := fmt.Sprintf("exports/orders-%s.%s", job.ID, job.Filter.Format)
+	url, err := p.Blob.UploadRaw(ctx, name, reader)
+	// unblocks the export when the upload stopped reading early
+	reader.CloseWithError(err)
+	if errExport := <-done; errExport != nil {
+		err = errExport
+	}
+
+	finished := time.Now().UTC()
+	job.FinishedAt = &finished
+	if err != nil {
+		job.Status, job.Error = ExportFailed, err.Error()
+		if errSave := p.saveExport(ctx, job); errSave != nil {
+			return errSave
+		}
+		return err
+	}
+	job.Status, job.URL = ExportDone, url
+	return p.saveExport(ctx, job)
+}
+
+// GetOrderExport implements IPurchase.
+func (p *Purchase) GetOrderExport(ctx context.Context, id string) (*dtos.OrderExportJob, error) {
+	job, err := cache.Get[dtos.OrderExportJob](ctx, p.Cache, fmt.Sprintf(keyOrderExport, id))
+	if err != nil {
+		if errors.Is(err, cache.ErrNotFound) {
+			return nil, ErrExportNotFound
+		}
+		return nil, err
+	}
+	return job, nil
+}
+
+func (p *Purchase) saveExport(ctx context.Context, job *dtos.OrderExportJob) error {
+	raw, err := json.Marshal(job)
+	if err != nil {
+		return err
+	}
+	return p.Cache.SetWithTTL(ctx, fmt.Sprintf(keyOrderExport, job.ID), string(raw), config.OrderExportTTL)
+}
+
+// exportFilter parses the filters of an export like those of a search
+func exportFilter(export dtos.OrderExport) (model.OrderFilter, error) {
+	return orderFilter(dtos.OrderSearch{
+		Status:        export.Status,
+		PaymentMethod: export.PaymentMethod,
+		From:          export.From,
+		To:            export.To,
+		Email:         export.Email,
+		Phone:         export.Phone,
+	})
+}
+
+// sheet is a spreadsheet written row by row
+type sheet interface {
+	Write(row []any) error
+	Close() error
+}
+
+func newSheet(format string, w io.Writer) (sheet, error) {
+	switch format {
+	case ExportXLSX:
+		return xlsx.NewWriter(w, "Orders")
+	case ExportCSV, "":
+		// the byte order mark makes Excel read the file as UTF-8
+		if _, err := io.WriteString(w, "\ufeff"); err != nil {
+			return nil, err
+		}
+		return &csvSheet{csv.NewWriter(w)}, nil
+	}
+	return nil, fmt.Errorf("unknown export format %q", format)
+}
+
+// csvSheet writes the cells of a sheet as text
+type csvSheet struct {
+	w *csv.Writer
+}
+
+func (s *csvSheet) Write(row []any) error {
+	record := make([]string, len(row))
+	for i, cell := range row {
+		switch v := cell.(type) {
+		case nil:
+		case string:
+			record[i] = v
+		case xlsx.Number:
+			record[i] = string(v)
+		case int64:
+			record[i] = strconv.FormatInt(v, 10)
+		default:
+			record[i] = fmt.Sprint(v)
+		}
+	}
+	return s.w.Write(record)
+}
+
+func (s *csvSheet) Close() error {
+	s.w.Flush()
+	return s.w.Error()
+}
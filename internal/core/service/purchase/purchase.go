@@ -3,7 +3,6 @@ package purchase
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
@@ -12,26 +11,46 @@ import (
 	"time"
 
 	"github.com/swclabs/swipex/app"
+	"github.com/swclabs/swipex/internal/config"
 	"github.com/swclabs/swipex/internal/core/domain/dtos"
 	"github.com/swclabs/swipex/internal/core/domain/entity"
+	"github.com/swclabs/swipex/internal/core/domain/enum"
 	"github.com/swclabs/swipex/internal/core/domain/x/ghn"
 	"github.com/swclabs/swipex/internal/core/repos/addresses"
+	"github.com/swclabs/swipex/internal/core/repos/bundles"
 	"github.com/swclabs/swipex/internal/core/repos/carts"
 	"github.com/swclabs/swipex/internal/core/repos/categories"
+	"github.com/swclabs/swipex/internal/core/repos/cod"
 	"github.com/swclabs/swipex/internal/core/repos/commune"
 	"github.com/swclabs/swipex/internal/core/repos/coupons"
+	"github.com/swclabs/swipex/internal/core/repos/credits"
+	"github.com/swclabs/swipex/internal/core/repos/currencies"
 	"github.com/swclabs/swipex/internal/core/repos/deliveries"
 	"github.com/swclabs/swipex/internal/core/repos/district"
 	"github.com/swclabs/swipex/internal/core/repos/inventories"
+	"github.com/swclabs/swipex/internal/core/repos/invoices"
+	"github.com/swclabs/swipex/internal/core/repos/loyalty"
 	"github.com/swclabs/swipex/internal/core/repos/orders"
 	"github.com/swclabs/swipex/internal/core/repos/products"
 	"github.com/swclabs/swipex/internal/core/repos/province"
+	"github.com/swclabs/swipex/internal/core/repos/refunds"
+	"github.com/swclabs/swipex/internal/core/repos/reminders"
+	"github.com/swclabs/swipex/internal/core/repos/returns"
+	"github.com/swclabs/swipex/internal/core/repos/shipments"
 	"github.com/swclabs/swipex/internal/core/repos/users"
+	"github.com/swclabs/swipex/internal/core/tasks"
 	"github.com/swclabs/swipex/internal/core/x/ghnx"
+	"github.com/swclabs/swipex/internal/core/x/mail"
+	"github.com/swclabs/swipex/internal/workers/queue"
+	"github.com/swclabs/swipex/pkg/infra/blob"
+	"github.com/swclabs/swipex/pkg/infra/cache"
 	"github.com/swclabs/swipex/pkg/infra/db"
+	"github.com/swclabs/swipex/pkg/lib/logger"
+	"github.com/swclabs/swipex/pkg/lib/worker"
 	"github.com/swclabs/swipex/pkg/utils"
 
 	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
 )
 
 // New creates a new Purchase object
@@ -49,8 +68,24 @@ var New = app.Service(
 		province province.IProvince,
 		district district.IDistrict,
 		commune commune.ICommune,
+		ret returns.IReturns,
+		refund refunds.IRefunds,
+		reminder reminders.IReminders,
+		invoice invoices.IInvoices,
+		bundle bundles.IBundles,
+		credit credits.ICredits,
+		points loyalty.ILoyalty,
+		collection cod.ICOD,
+		carrier ghnx.IGhnx,
+		currency currencies.ICurrencies,
+		shipment shipments.IShipments,
+		cache cache.ICache,
+		blob blob.IBlobStorage,
 	) IPurchase {
 		return &Purchase{
+			Cache:     cache,
+			Blob:      blob,
+			Worker:    worker.NewClient(config.RedisHost, config.RedisPort, config.RedisPassword),
 			Coupon:    coupon,
 			Cart:      cart,
 			Order:     order,
@@ -63,6 +98,17 @@ var New = app.Service(
 			Province:  province,
 			District:  district,
 			Commune:   commune,
+			Return:    ret,
+			Refund:    refund,
+			Reminder:  reminder,
+			Invoice:   invoice,
+			Bundle:    bundle,
+			Credit:    credit,
+			Loyalty:   points,
+			COD:       collection,
+			Ghn:       carrier,
+			Currency:  currency,
+			Shipment:  shipment,
 		}
 	},
 )
@@ -82,6 +128,23 @@ type Purchase struct {
 	Commune   commune.ICommune
 	Province  province.IProvince
 	District  district.IDistrict
+	Return    returns.IReturns
+	Refund    refunds.IRefunds
+	Reminder  reminders.IReminders
+	Invoice   invoices.IInvoices
+	Bundle    bundles.IBundles
+	Credit    credits.ICredits
+	Loyalty   loyalty.ILoyalty
+	COD       cod.ICOD
+	Currency  currencies.ICurrencies
+	Shipment  shipments.IShipments
+	Cache     cache.ICache
+	Blob      blob.IBlobStorage
+	Worker    worker.IWorkerClient
+
+	// Begin starts the transactions of the service, a transaction of the
+	// database when nil
+	Begin func(ctx context.Context) (*Tx, error)
 }
 
 // DeleteCoupon implements IPurchase.
@@ -89,17 +152,182 @@ func (p *Purchase) DeleteCoupon(ctx context.Context, code string) error {
 	return p.Coupon.Delete(ctx, code)
 }
 
-// UpdateOrder implements IPurchase.
-func (p *Purchase) UpdateOrderStatus(ctx context.Context, orderCode string, status string) error {
-	return p.Order.UpdateStatus(ctx, orderCode, status)
+// UpdateOrderStatus implements IPurchase.
+func (p *Purchase) UpdateOrderStatus(
+	ctx context.Context, orderCode string, status enum.OrderStatus, actor string, reason string) error {
+	tx, err := p.begin(ctx)
+	if err != nil {
+		return err
+	}
+	var (
+		orderRepo     = tx.Order
+		inventoryRepo = tx.Inventory
+		couponRepo    = tx.Coupon
+		creditRepo    = tx.Credit
+		loyaltyRepo   = tx.Loyalty
+	)
+	if err := p.transitionOrder(ctx, orderRepo, inventoryRepo, orderCode, status, actor, reason); err != nil {
+		if errTx := tx.Rollback(ctx); errTx != nil {
+			log.Fatal(errTx)
+		}
+		return err
+	}
+	// the checkout is given back with the stock
+	if status.ReleasesStock() {
+		if err := p.releaseCheckout(ctx, orderRepo, couponRepo, creditRepo, loyaltyRepo, orderCode, actor); err != nil {
+			if errTx := tx.Rollback(ctx); errTx != nil {
+				log.Fatal(errTx)
+			}
+			return err
+		}
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+	if status == enum.OrderDelivered {
+		return p.delivered(ctx, orderCode)
+	}
+	return nil
 }
 
-func (p *Purchase) GetUsersByAdmin(ctx context.Context, limit int) ([]dtos.OrderInfo, error) {
-	orderList, err := p.Order.GetLimit(ctx, limit)
+// CancelOrder implements IPurchase.
+func (p *Purchase) CancelOrder(ctx context.Context, userID int64, orderCode string, reason string) error {
+	tx, err := p.begin(ctx)
 	if err != nil {
-		return nil, err
+		return err
+	}
+	var (
+		orderRepo     = tx.Order
+		inventoryRepo = tx.Inventory
+		couponRepo    = tx.Coupon
+		creditRepo    = tx.Credit
+		loyaltyRepo   = tx.Loyalty
+	)
+	order, err := orderRepo.GetByUUIDForUpdate(ctx, orderCode)
+	if err != nil {
+		if errTx := tx.Rollback(ctx); errTx != nil {
+			log.Fatal(errTx)
+		}
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrOrderNotFound
+		}
+		return err
+	}
+	if order.UserID != userID {
+		if errTx := tx.Rollback(ctx); errTx != nil {
+			log.Fatal(errTx)
+		}
+		return ErrOrderNotFound
+	}
+	// customers may only cancel orders that have not been handed to the warehouse
+	if order.Status != enum.OrderPending.String() && order.Status != enum.OrderConfirmed.String() {
+		if errTx := tx.Rollback(ctx); errTx != nil {
+			log.Fatal(errTx)
+		}
+		return &InvalidTransitionError{
+			From: enum.OrderStatus(order.Status),
+			To:   enum.OrderCancelled,
+		}
+	}
+
+	if err := p.transitionOrder(ctx, orderRepo, inventoryRepo,
+		orderCode, enum.OrderCancelled, "customer", reason); err != nil {
+		if errTx := tx.Rollback(ctx); errTx != nil {
+			log.Fatal(errTx)
+		}
+		return err
+	}
+
+	if err := p.releaseCheckout(ctx, orderRepo, couponRepo, creditRepo, loyaltyRepo, orderCode, "customer"); err != nil {
+		if errTx := tx.Rollback(ctx); errTx != nil {
+			log.Fatal(errTx)
+		}
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+	return p.Worker.Exec(ctx, queue.OrderQueue,
+		worker.NewTask(tasks.PurchaseNotifyOrderCancelled, dtos.OrderStatus{
+			OrderCode: orderCode,
+			Status:    enum.OrderCancelled.String(),
+			Reason:    reason,
+		}),
+	)
+}
+
+// orderExpiryBatch is the number of unpaid orders expired per run
+const orderExpiryBatch = 500
+
+// ExpireOrders implements IPurchase.
+func (p *Purchase) ExpireOrders(ctx context.Context) error {
+	codes, err := p.Order.GetUnpaidPending(ctx, time.Now().UTC(), orderExpiryBatch)
+	if err != nil {
+		return err
 	}
-	return p.getListOrder(ctx, orderList)
+	var expired int
+	for _, orderCode := range codes {
+		ok, err := p.expireOrder(ctx, orderCode)
+		if err != nil {
+			return err
+		}
+		if ok {
+			expired++
+		}
+	}
+	logger.Info(fmt.Sprintf("orders: %d of %d unpaid pending orders expired", expired, len(codes)))
+	return nil
+}
+
+// expireOrder expires a pending order whose payment never came. The order is
+// checked again once locked: it may have been paid or moved on meanwhile.
+func (p *Purchase) expireOrder(ctx context.Context, orderCode string) (bool, error) {
+	tx, err := p.begin(ctx)
+	if err != nil {
+		return false, err
+	}
+	order, err := tx.Order.GetByUUIDForUpdate(ctx, orderCode)
+	if err != nil {
+		if errTx := tx.Rollback(ctx); errTx != nil {
+			log.Fatal(errTx)
+		}
+		return false, err
+	}
+	if order.Status != enum.OrderPending.String() || order.PaidAt != nil || order.DepositPaidAt != nil {
+		if errTx := tx.Rollback(ctx); errTx != nil {
+			log.Fatal(errTx)
+		}
+		return false, nil
+	}
+	if err := p.transitionOrder(ctx, tx.Order, tx.Inventory,
+		orderCode, enum.OrderExpired, "system", "payment not received"); err != nil {
+		if errTx := tx.Rollback(ctx); errTx != nil {
+			log.Fatal(errTx)
+		}
+		return false, err
+	}
+	if err := p.releaseCheckout(ctx, tx.Order, tx.Coupon, tx.Credit, tx.Loyalty, orderCode, "system"); err != nil {
+		if errTx := tx.Rollback(ctx); errTx != nil {
+			log.Fatal(errTx)
+		}
+		return false, err
+	}
+	return true, tx.Commit(ctx)
+}
+
+// NotifyOrderCancelled implements IPurchase.
+func (p *Purchase) NotifyOrderCancelled(ctx context.Context, orderCode string, reason string) error {
+	order, err := p.Order.GetByUUID(ctx, orderCode)
+	if err != nil {
+		return err
+	}
+	user, err := p.User.GetByID(ctx, order.UserID)
+	if err != nil {
+		return err
+	}
+	customer := strings.TrimSpace(user.FirstName + " " + user.LastName)
+	return mail.New().SendOrderCancelled(user.Email, customer, orderCode, reason)
 }
 
 // AddressDistrict implements IPurchase.
@@ -119,16 +347,45 @@ func (p *Purchase) AddressWard(ctx context.Context, districtID string) ([]entity
 
 // CreateCoupon implements IPurchase.
 func (p *Purchase) CreateCoupon(ctx context.Context, coupon dtos.CreateCoupon) (code string, err error) {
+	discountType := enum.CouponPercent
+	if coupon.DiscountType != "" {
+		if err := discountType.Load(coupon.DiscountType); err != nil {
+			return "", err
+		}
+	}
+	if discountType == enum.CouponPercent && coupon.Discount > 100 {
+		return "", fmt.Errorf("percentage discount cannot exceed 100: %d", coupon.Discount)
+	}
+	minOrderValue, err := decimalOrZero(coupon.MinOrderValue)
+	if err != nil {
+		return "", err
+	}
+	maxDiscount, err := decimalOrZero(coupon.MaxDiscount)
+	if err != nil {
+		return "", err
+	}
+	if coupon.CategoryIDs == nil {
+		coupon.CategoryIDs = []int64{}
+	}
+	if coupon.InventoryIDs == nil {
+		coupon.InventoryIDs = []int64{}
+	}
+
 	code = utils.GenCouponsCode(10)
 	exp := time.Now().UTC().Add(time.Hour * 24 * time.Duration(coupon.MaxDay))
 	err = p.Coupon.Create(ctx, entity.Coupons{
-		Code:        code,
-		Discount:    coupon.Discount,
-		Status:      coupon.Status,
-		Used:        0,
-		MaxUse:      coupon.MaxUse,
-		Description: coupon.Description,
-		ExpiredAt:   exp,
+		Code:          code,
+		Discount:      decimal.NewFromInt(int64(coupon.Discount)),
+		Status:        coupon.Status,
+		Used:          0,
+		MaxUse:        coupon.MaxUse,
+		Description:   coupon.Description,
+		ExpiredAt:     exp,
+		DiscountType:  discountType.String(),
+		MinOrderValue: minOrderValue,
+		MaxDiscount:   maxDiscount,
+		CategoryIDs:   coupon.CategoryIDs,
+		InventoryIDs:  coupon.InventoryIDs,
 	})
 	return
 }
@@ -143,12 +400,17 @@ func (p *Purchase) GetCoupon(ctx context.Context) (coupons []dtos.Coupon, err er
 	for _, coupon := range _coupons {
 		if coupon.Status == "active" {
 			coupons = append(coupons, dtos.Coupon{
-				Code:        coupon.Code,
-				Discount:    coupon.Discount,
-				ID:          coupon.ID,
-				ExpiredAt:   utils.HanoiTimezone(coupon.ExpiredAt),
-				Description: coupon.Description,
-				Status:      coupon.Status,
+				Code:          coupon.Code,
+				Discount:      int(coupon.Discount.IntPart()),
+				ID:            coupon.ID,
+				ExpiredAt:     utils.HanoiTimezone(coupon.ExpiredAt),
+				Description:   coupon.Description,
+				Status:        coupon.Status,
+				DiscountType:  coupon.DiscountType,
+				MinOrderValue: coupon.MinOrderValue.String(),
+				MaxDiscount:   coupon.MaxDiscount.String(),
+				CategoryIDs:   coupon.CategoryIDs,
+				InventoryIDs:  coupon.InventoryIDs,
 			})
 		}
 	}
@@ -157,16 +419,12 @@ func (p *Purchase) GetCoupon(ctx context.Context) (coupons []dtos.Coupon, err er
 
 // UseCoupon implements IPurchase.
 func (p *Purchase) UseCoupon(ctx context.Context, userID int64, couponCode string) error {
-	coupons, err := p.Coupon.GetByUser(ctx, userID)
-	if !errors.Is(err, pgx.ErrNoRows) {
+	used, err := p.Coupon.GetUsedByUser(ctx, userID, couponCode)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
 		return err
 	}
-	if len(coupons) > 0 {
-		for _, coupon := range coupons {
-			if coupon.CouponCode == couponCode {
-				return errors.New("coupon already used")
-			}
-		}
+	if used != nil {
+		return &CouponError{Code: couponCode, Reason: "coupon already used"}
 	}
 	return nil
 }
@@ -199,8 +457,30 @@ func (p *Purchase) GetOrderByCode(ctx context.Context, orderCode string) (*dtos.
 			return nil, err
 		}
 
+		history, err := p.Order.GetStatusHistory(ctx, order.ID)
+		if err != nil {
+			return nil, err
+		}
+		shipments, err := p.orderShipments(ctx, order.ID, items)
+		if err != nil {
+			return nil, err
+		}
+
+		timeline := []dtos.OrderStatusEvent{}
+		for _, event := range history {
+			timeline = append(timeline, dtos.OrderStatusEvent{
+				From:   event.FromStatus,
+				To:     event.ToStatus,
+				Actor:  event.Actor,
+				Reason: event.Reason,
+				Time:   utils.HanoiTimezone(event.CreatedAt),
+			})
+		}
+
 		return &dtos.OrderInfo{
+			Timeline:      timeline,
 			Items:         items,
+			Shipments:     shipments,
 			UUID:          order.UUID,
 			PaymentMethod: order.PaymentMethod,
 			CreatedAt:     utils.HanoiTimezone(order.Time),
@@ -222,24 +502,57 @@ func (p *Purchase) GetOrderByCode(ctx context.Context, orderCode string) (*dtos.
 				District: address.District,
 				Street:   address.Street,
 			},
-			TotalAmount: order.TotalAmount.String(),
+			Subtotal:       order.Subtotal.String(),
+			Discount:       order.DiscountAmount.String(),
+			TaxAmount:      order.TaxAmount.String(),
+			ShippingFee:    order.ShippingFee.String(),
+			TotalAmount:    order.TotalAmount.String(),
+			DepositAmount:  order.DepositAmount.String(),
+			CreditAmount:   order.CreditAmount.String(),
+			PointsRedeemed: order.PointsRedeemed,
+			PointsAmount:   order.PointsAmount.String(),
+			Currency:       order.CurrencyCode,
+			ExchangeRate:   order.ExchangeRate.String(),
 		}, nil
 	}
-	return nil, errors.New("order not found")
+	return nil, ErrOrderNotFound
+}
+
+// CreateAdminOrder implements IPurchase.
+func (p *Purchase) CreateAdminOrder(ctx context.Context, caller string, order dtos.OrderForm) (string, error) {
+	// the wallet and the points of the customer are only spent by the customer
+	if caller == "" || !strings.EqualFold(caller, order.Customer.Email) {
+		if order.StoreCredit != "" {
+			return "", ErrStoreCreditOwner
+		}
+		if order.Points > 0 {
+			return "", ErrPointsOwner
+		}
+	}
+	return p.CreateOrderForm(ctx, order)
 }
 
 // CreateOrderForm implements IPurchase.
 func (p *Purchase) CreateOrderForm(ctx context.Context, order dtos.OrderForm) (string, error) {
-	tx, err := db.NewTx(ctx)
+	currency, err := p.currencyOf(ctx, order)
+	if err != nil {
+		return "", err
+	}
+	tx, err := p.begin(ctx)
 	if err != nil {
 		return "", err
 	}
 	var (
-		userRepo      = users.New(tx)
-		addressRepo   = addresses.New(tx)
-		orderRepo     = orders.New(tx)
-		deliveryRepo  = deliveries.New(tx)
-		inventoryRepo = inventories.New(tx)
+		userRepo      = tx.User
+		addressRepo   = tx.Address
+		orderRepo     = tx.Order
+		deliveryRepo  = tx.Delivery
+		couponRepo    = tx.Coupon
+		inventoryRepo = tx.Inventory
+		bundleRepo    = tx.Bundle
+		creditRepo    = tx.Credit
+		loyaltyRepo   = tx.Loyalty
+		codRepo       = tx.COD
 	)
 
 	user, err := userRepo.GetByEmail(ctx, order.Customer.Email)
@@ -293,7 +606,7 @@ func (p *Purchase) CreateOrderForm(ctx context.Context, order dtos.OrderForm) (s
 		return "", err
 	}
 
-	totalAmount, listTotalAmount, err := p.calculateTotalAmount(ctx, inventoryRepo, order)
+	bundleLines, err := p.orderBundles(ctx, bundleRepo, inventoryRepo.GetByID, order)
 	if err != nil {
 		if errTx := tx.Rollback(ctx); errTx != nil {
 			log.Fatal(errTx)
@@ -301,9 +614,48 @@ func (p *Purchase) CreateOrderForm(ctx context.Context, order dtos.OrderForm) (s
 		return "", err
 	}
 
-	print("totalAmount", totalAmount.String())
+	backordered, err := p.reserveStock(ctx, inventoryRepo, order, bundleLines)
+	if err != nil {
+		if errTx := tx.Rollback(ctx); errTx != nil {
+			log.Fatal(errTx)
+		}
+		return "", err
+	}
+
+	lines, err := p.priceLines(ctx, inventoryRepo, order, bundleLines, currency)
+	if err != nil {
+		if errTx := tx.Rollback(ctx); errTx != nil {
+			log.Fatal(errTx)
+		}
+		return "", err
+	}
+	backorderLines(lines, backordered)
+
+	// coupons discount amounts of the base currency
+	discount, err := p.applyCoupon(ctx, couponRepo, inventoryRepo, user.ID, order, currency)
+	if err != nil {
+		if errTx := tx.Rollback(ctx); errTx != nil {
+			log.Fatal(errTx)
+		}
+		return "", err
+	}
+	pricing := priceOrder(lines, currency.fromBase(discount), currency)
 
-	totalAmount, err = p.useCoupon(ctx, totalAmount, order, order.CouponCode)
+	// loyalty points, then gift cards and store credit pay for what is
+	// due at checkout
+	due := pricing.Total
+	if pricing.Deposit.IsPositive() {
+		due = pricing.Deposit
+	}
+	points, pointsAmount, err := p.planPoints(ctx, loyaltyRepo, user.ID, order, due)
+	if err != nil {
+		if errTx := tx.Rollback(ctx); errTx != nil {
+			log.Fatal(errTx)
+		}
+		return "", err
+	}
+	due = due.Sub(pointsAmount)
+	debits, credit, err := p.planCredits(ctx, creditRepo, user.ID, order, due)
 	if err != nil {
 		if errTx := tx.Rollback(ctx); errTx != nil {
 			log.Fatal(errTx)
@@ -313,14 +665,37 @@ func (p *Purchase) CreateOrderForm(ctx context.Context, order dtos.OrderForm) (s
 
 	uuid := p.genUUID(ctx, orderRepo)
 
-	orderID, err := orderRepo.Create(ctx, entity.Order{
-		UUID:          uuid,
-		DeliveryID:    deliveryID,
-		UserID:        user.ID,
-		Status:        "pending",
-		TotalAmount:   totalAmount,
-		PaymentMethod: order.PaymentMethod,
-	})
+	placed := entity.Order{
+		UUID:           uuid,
+		DeliveryID:     deliveryID,
+		UserID:         user.ID,
+		Status:         enum.OrderPending.String(),
+		TotalAmount:    pricing.Total,
+		Subtotal:       pricing.Subtotal,
+		DiscountAmount: pricing.Discount,
+		TaxAmount:      pricing.TaxAmount,
+		ShippingFee:    pricing.ShippingFee,
+		DepositAmount:  pricing.Deposit,
+		CreditAmount:   credit,
+		PointsRedeemed: points,
+		PointsAmount:   pointsAmount,
+		PaymentMethod:  order.PaymentMethod,
+		CurrencyCode:   currency.Code,
+		ExchangeRate:   currency.Rate,
+	}
+	// an order left to pay online expires unless it is paid in time, an
+	// order settled at checkout or paid on delivery does not
+	if order.PaymentMethod != config.CODPaymentMethod && due.Sub(credit).IsPositive() {
+		dueAt := time.Now().UTC().Add(config.OrderPaymentTimeout)
+		placed.PaymentDueAt = &dueAt
+	}
+	// the deposit of a pre-order settled at checkout is paid, the balance is
+	// left to pay
+	if pricing.Deposit.IsPositive() && !due.Sub(credit).IsPositive() {
+		paidAt := time.Now().UTC()
+		placed.DepositPaidAt = &paidAt
+	}
+	orderID, err := orderRepo.Create(ctx, placed)
 	if err != nil {
 		if errTx := tx.Rollback(ctx); errTx != nil {
 			log.Fatal(errTx)
@@ -328,7 +703,54 @@ func (p *Purchase) CreateOrderForm(ctx context.Context, order dtos.OrderForm) (s
 		return "", err
 	}
 
-	if err := p.saveProductOrder(ctx, orderRepo, orderID, order, listTotalAmount); err != nil {
+	if order.CouponCode != "" {
+		if err := couponRepo.Use(ctx, entity.CouponsUsed{
+			UserID:     user.ID,
+			CouponCode: order.CouponCode,
+			OrderID:    orderID,
+			UsedAt:     time.Now().UTC(),
+		}); err != nil {
+			if errTx := tx.Rollback(ctx); errTx != nil {
+				log.Fatal(errTx)
+			}
+			return "", err
+		}
+	}
+
+	if err := p.saveProductOrder(ctx, orderRepo, orderID, currency.Code, pricing.Lines); err != nil {
+		if errTx := tx.Rollback(ctx); errTx != nil {
+			log.Fatal(errTx)
+		}
+		return "", err
+	}
+
+	if err := p.debitCredits(ctx, creditRepo, orderID, order.Customer.Email, debits); err != nil {
+		if errTx := tx.Rollback(ctx); errTx != nil {
+			log.Fatal(errTx)
+		}
+		return "", err
+	}
+
+	if err := p.redeemPoints(ctx, loyaltyRepo, user.ID, orderID, points); err != nil {
+		if errTx := tx.Rollback(ctx); errTx != nil {
+			log.Fatal(errTx)
+		}
+		return "", err
+	}
+
+	if err := p.expectCOD(ctx, codRepo, orderID, placed); err != nil {
+		if errTx := tx.Rollback(ctx); errTx != nil {
+			log.Fatal(errTx)
+		}
+		return "", err
+	}
+
+	if err := orderRepo.InsertStatusHistory(ctx, entity.OrderStatusHistory{
+		OrderID:  orderID,
+		ToStatus: enum.OrderPending.String(),
+		Actor:    order.Customer.Email,
+		Reason:   "order created",
+	}); err != nil {
 		if errTx := tx.Rollback(ctx); errTx != nil {
 			log.Fatal(errTx)
 		}
@@ -449,59 +871,16 @@ func (p *Purchase) DeleteItemFromCart(ctx context.Context, inventoryID int64, us
 	return p.Cart.RemoveByItemID(ctx, userID, inventoryID)
 }
 
-// AddToCart implements IPurchaseService.
-func (p *Purchase) AddToCart(ctx context.Context, cart dtos.CartInsertDTO) error {
-	user, err := p.User.GetByEmail(ctx, cart.Email)
-	if err != nil {
-		return fmt.Errorf("error getting user by email: %v", err)
-	}
-	return p.Cart.Insert(ctx, entity.Cart{
-		UserID:      user.ID,
-		InventoryID: cart.InventoryID,
-		Quantity:    cart.Quantity,
-	})
-}
-
-// GetCart implements IPurchaseService.
-func (p *Purchase) GetCart(ctx context.Context, userID int64, limit int) (*dtos.Carts, error) {
-	carts, err := p.Cart.GetCartInfo(ctx, userID)
-	if err != nil {
-		return nil, err
-	}
-	var cartResp = dtos.Carts{
-		UserID:   userID,
-		Products: []dtos.Cart{},
-	}
-	for _, cart := range carts {
-		var specs dtos.Specs
-		if err := json.Unmarshal([]byte(cart.InventorySpecs), &specs); err != nil {
-			return nil, fmt.Errorf("error unmarshal inventory specs: %v", err)
-		}
-		cartResp.Products = append(cartResp.Products, dtos.Cart{
-			Name:           cart.Name,
-			CartID:         cart.CartID,
-			InventoryID:    cart.InventoryID,
-			ProductID:      cart.ProductID,
-			Quantity:       cart.Quantity,
-			Color:          cart.Color,
-			InventoryPrice: cart.InventoryPrice,
-			CurrencyCode:   cart.CurrencyCode,
-			InventoryImage: cart.InventoryImage,
-			CategoryName:   cart.CategoryName,
-			InventorySpecs: specs,
-			Code:           fmt.Sprintf("%s#%d", cart.CategoryName, cart.InventoryID),
-		})
-	}
-	return &cartResp, nil
-}
-
 // CreateOrders implements IPurchaseService.
 func (p *Purchase) CreateOrders(ctx context.Context, userID int64, createOrder dtos.Order) (string, error) {
 	tx, err := db.NewTx(ctx)
 	if err != nil {
 		return "", err
 	}
-	var cartRepo = carts.New(tx)
+	var (
+		cartRepo   = carts.New(tx)
+		bundleRepo = bundles.New(tx)
+	)
 	code, err := p.CreateOrderForm(ctx, dtos.OrderForm(createOrder))
 	if err != nil {
 		if errTx := tx.Rollback(ctx); errTx != nil {
@@ -531,5 +910,13 @@ func (p *Purchase) CreateOrders(ctx context.Context, userID int64, createOrder d
 			return "", err
 		}
 	}
+	for _, item := range createOrder.Bundles {
+		if err := bundleRepo.RemoveFromCart(ctx, userID, item.BundleID); err != nil {
+			if errTx := tx.Rollback(ctx); errTx != nil {
+				log.Fatal(errTx)
+			}
+			return "", err
+		}
+	}
 	return code, tx.Commit(ctx)
 }
@@ -0,0 +1,204 @@
+package purchase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/swclabs/swipex/internal/config"
+	"github.com/swclabs/swipex/internal/core/domain/dtos"
+	"github.com/swclabs/swipex/internal/core/domain/model"
+	"github.com/swclabs/swipex/pkg/infra/cache"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+)
+
+// keyGuestCart is the redis key of the cart of an anonymous visitor
+const keyGuestCart = "GuestCart:%s"
+
+// guestCartLine is a line of a guest cart, with the price the item had when
+// it was added.
+type guestCartLine struct {
+	InventoryID int64           `json:"inventory_id"`
+	Quantity    int64           `json:"quantity"`
+	Price       decimal.Decimal `json:"price"`
+}
+
+// GetGuestCart implements IPurchase.
+func (p *Purchase) GetGuestCart(ctx context.Context, guestID string, couponCode string) (*dtos.Carts, error) {
+	items, err := p.guestCart(ctx, guestID)
+	if err != nil {
+		return nil, err
+	}
+	carts := []model.Carts{}
+	for _, item := range items {
+		inv, err := p.Inventory.GetByID(ctx, item.InventoryID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				continue
+			}
+			return nil, err
+		}
+		product, err := p.Product.GetByID(ctx, inv.ProductID)
+		if err != nil {
+			return nil, err
+		}
+		category, err := p.Category.GetByID(ctx, product.CategoryID)
+		if err != nil {
+			return nil, err
+		}
+		carts = append(carts, model.Carts{
+			Name:           product.Name,
+			InventoryID:    inv.ID,
+			ProductID:      inv.ProductID,
+			Quantity:       item.Quantity,
+			Color:          inv.Color,
+			InventoryPrice: inv.Price.String(),
+			AddedPrice:     item.Price.String(),
+			CurrencyCode:   inv.CurrencyCode,
+			InventoryImage: inv.Image,
+			InventorySpecs: inv.Specs,
+			CategoryID:     category.ID,
+			CategoryName:   category.Name,
+			Available:      inv.Available,
+			Status:         inv.Status,
+		})
+	}
+	return p.cartSummary(ctx, 0, carts, couponCode)
+}
+
+// AddToGuestCart implements IPurchase.
+func (p *Purchase) AddToGuestCart(ctx context.Context, guestID string, item dtos.CartDTO) error {
+	inv, err := p.Inventory.GetByID(ctx, item.InventoryID)
+	if err != nil {
+		return err
+	}
+	items, err := p.guestCart(ctx, guestID)
+	if err != nil {
+		return err
+	}
+	idx := slices.IndexFunc(items, func(line guestCartLine) bool {
+		return line.InventoryID == item.InventoryID
+	})
+	if idx == -1 {
+		items = append(items, guestCartLine{InventoryID: item.InventoryID})
+		idx = len(items) - 1
+	}
+	if err := checkCartStock(inv, items[idx].Quantity+item.Quantity); err != nil {
+		return err
+	}
+	items[idx].Quantity += item.Quantity
+	items[idx].Price = inv.Price
+	return p.saveGuestCart(ctx, guestID, items)
+}
+
+// UpdateGuestCartItem implements IPurchase.
+func (p *Purchase) UpdateGuestCartItem(ctx context.Context, guestID string, inventoryID int64, quantity int64) error {
+	items, err := p.guestCart(ctx, guestID)
+	if err != nil {
+		return err
+	}
+	idx := slices.IndexFunc(items, func(line guestCartLine) bool {
+		return line.InventoryID == inventoryID
+	})
+	if idx == -1 {
+		return ErrCartItemNotFound
+	}
+	inv, err := p.Inventory.GetByID(ctx, inventoryID)
+	if err != nil {
+		return err
+	}
+	if err := checkCartStock(inv, quantity); err != nil {
+		return err
+	}
+	items[idx].Quantity = quantity
+	return p.saveGuestCart(ctx, guestID, items)
+}
+
+// DeleteItemFromGuestCart implements IPurchase.
+func (p *Purchase) DeleteItemFromGuestCart(ctx context.Context, guestID string, inventoryID int64) error {
+	items, err := p.guestCart(ctx, guestID)
+	if err != nil {
+		return err
+	}
+	items = slices.DeleteFunc(items, func(line guestCartLine) bool {
+		return line.InventoryID == inventoryID
+	})
+	return p.saveGuestCart(ctx, guestID, items)
+}
+
+// MergeGuestCart implements IPurchase.
+func (p *Purchase) MergeGuestCart(ctx context.Context, userID int64, guestID string) error {
+	items, err := p.guestCart(ctx, guestID)
+	if err != nil {
+		return err
+	}
+	cart := make([]dtos.CartDTO, 0, len(items))
+	for _, item := range items {
+		cart = append(cart, dtos.CartDTO{InventoryID: item.InventoryID, Quantity: item.Quantity})
+	}
+	if err := p.MergeCart(ctx, userID, cart); err != nil {
+		return err
+	}
+	return cache.Delete(ctx, p.Cache, fmt.Sprintf(keyGuestCart, guestID))
+}
+
+// CreateGuestOrder implements IPurchase.
+func (p *Purchase) CreateGuestOrder(ctx context.Context, guestID string, order dtos.OrderForm) (string, error) {
+	// the wallet and the points of the email owner are not the guest's to spend
+	if order.StoreCredit != "" {
+		return "", ErrStoreCreditGuest
+	}
+	if order.Points > 0 {
+		return "", ErrPointsGuest
+	}
+	code, err := p.CreateOrderForm(ctx, order)
+	if err != nil {
+		return "", err
+	}
+	if guestID == "" {
+		return code, nil
+	}
+	items, err := p.guestCart(ctx, guestID)
+	if err != nil {
+		return "", err
+	}
+	for _, product := range order.Product {
+		id, err := parseItemCode(product.Code)
+		if err != nil {
+			return "", err
+		}
+		items = slices.DeleteFunc(items, func(line guestCartLine) bool {
+			return line.InventoryID == id
+		})
+	}
+	return code, p.saveGuestCart(ctx, guestID, items)
+}
+
+// guestCart loads the cart of a guest, an empty cart if it has none.
+func (p *Purchase) guestCart(ctx context.Context, guestID string) ([]guestCartLine, error) {
+	items, err := cache.GetSlice[guestCartLine](ctx, p.Cache, fmt.Sprintf(keyGuestCart, guestID))
+	if err != nil {
+		if errors.Is(err, cache.ErrNotFound) {
+			return []guestCartLine{}, nil
+		}
+		return nil, err
+	}
+	return items, nil
+}
+
+// saveGuestCart stores the cart of a guest and extends its lifetime.
+func (p *Purchase) saveGuestCart(ctx context.Context, guestID string, items []guestCartLine) error {
+	key := fmt.Sprintf(keyGuestCart, guestID)
+	if len(items) == 0 {
+		return cache.Delete(ctx, p.Cache, key)
+	}
+	raw, err := json.Marshal(items)
+	if err != nil {
+		return err
+	}
+	return p.Cache.SetWithTTL(ctx, key, string(raw), config.GuestCartTTL)
+}
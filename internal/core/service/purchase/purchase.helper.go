@@ -4,12 +4,17 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 
 	"github.com/swclabs/swipex/internal/core/domain/dtos"
 	"github.com/swclabs/swipex/internal/core/domain/entity"
+	"github.com/swclabs/swipex/internal/core/domain/enum"
+	"github.com/swclabs/swipex/internal/core/repos/coupons"
+	"github.com/swclabs/swipex/internal/core/repos/credits"
 	"github.com/swclabs/swipex/internal/core/repos/inventories"
+	"github.com/swclabs/swipex/internal/core/repos/loyalty"
 	"github.com/swclabs/swipex/internal/core/repos/orders"
 	"github.com/swclabs/swipex/pkg/utils"
 
@@ -29,91 +34,232 @@ func (p *Purchase) genUUID(ctx context.Context, orderRepo orders.IOrders) string
 	return uuid
 }
 
-func (p *Purchase) calculateTotalAmount(
+// parseItemCode returns the inventory ID encoded in an item code ("category#id").
+func parseItemCode(itemCode string) (int64, error) {
+	code := strings.Split(itemCode, "#")
+	if len(code) != 2 {
+		return -1, fmt.Errorf("invalid product code: %s", itemCode)
+	}
+	return strconv.ParseInt(code[1], 10, 64)
+}
+
+// reserveStock locks the inventory rows of an order and decrements their
+// available quantity. Rows are locked in ascending ID order so that
+// concurrent checkouts cannot deadlock each other.
+// Pre-order items take what is in stock and the units missing are returned
+// as backordered, by inventory ID; other items never go below zero.
+// The components of the bundles of the order are reserved with the items.
+func (p *Purchase) reserveStock(
 	ctx context.Context,
 	inventory inventories.IInventories,
 	order dtos.OrderForm,
-) (totalAmount decimal.Decimal, listTotaAmount []decimal.Decimal, err error) {
-
-	totalAmount = decimal.NewFromInt(0)
-	listTotaAmount = []decimal.Decimal{}
-
+	bundles []bundleLine,
+) (map[int64]int64, error) {
+	quantities := map[int64]int64{}
 	for _, product := range order.Product {
-		code := strings.Split(product.Code, "#")
-		if len(code) != 2 {
-			return decimal.NewFromInt(0), nil, fmt.Errorf("invalid product code : %s", product.Code)
-		}
-
-		id, err := strconv.ParseInt(code[1], 10, 64)
+		id, err := parseItemCode(product.Code)
 		if err != nil {
-			return decimal.NewFromInt(0), nil, err
+			return nil, err
 		}
+		quantities[id] += product.Quantity
+	}
+	bundleQuantities(quantities, bundles)
 
-		inven, err := inventory.GetByID(ctx, id)
+	ids := make([]int64, 0, len(quantities))
+	for id := range quantities {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+
+	backordered := map[int64]int64{}
+	for _, id := range ids {
+		inven, err := inventory.GetByIDForUpdate(ctx, id)
 		if err != nil {
-			return decimal.NewFromInt(0), nil, err
+			return nil, err
+		}
+		reserved := quantities[id]
+		if reserved <= 0 {
+			return nil, fmt.Errorf("%w: %d units of inventory %d", ErrInvalidQuantity, reserved, id)
+		}
+		if inven.Status == enum.InventoryPreorder.String() {
+			reserved = min(reserved, inven.Available)
+			backordered[id] = quantities[id] - reserved
+		} else if inven.Available < reserved {
+			return nil, &InsufficientStockError{
+				InventoryID: id,
+				Requested:   quantities[id],
+				Available:   inven.Available,
+			}
+		}
+		if reserved == 0 {
+			continue
+		}
+		if _, err := inventory.Reserve(ctx, id, reserved); err != nil {
+			return nil, err
 		}
-
-		totalAmount = totalAmount.Add(inven.Price.Mul(decimal.NewFromInt32(int32(product.Quantity))))
-		listTotaAmount = append(listTotaAmount,
-			inven.Price.Mul(decimal.NewFromInt32(int32(product.Quantity))))
 	}
+	return backordered, nil
+}
 
-	return totalAmount, listTotaAmount, nil
+// backorderLines spreads the backordered quantities of the inventories
+// over the lines of an order.
+func backorderLines(lines []orderLine, backordered map[int64]int64) {
+	for i := range lines {
+		units := min(lines[i].Quantity, backordered[lines[i].InventoryID])
+		lines[i].Backordered = units
+		backordered[lines[i].InventoryID] -= units
+	}
 }
 
-func (p *Purchase) saveProductOrder(
+// releaseStock gives the quantities reserved by an order back to the inventories.
+func (p *Purchase) releaseStock(
 	ctx context.Context,
 	orderRepo orders.IOrders,
+	inventory inventories.IInventories,
 	orderID int64,
-	order dtos.OrderForm,
-	listTotalAmount []decimal.Decimal,
 ) error {
+	items, err := orderRepo.GetProductByOrderID(ctx, orderID)
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		// backordered units were never taken from the stock
+		reserved := item.Quantity - item.Backordered
+		if reserved == 0 {
+			continue
+		}
+		if _, err := inventory.Release(ctx, item.InventoryID, reserved); err != nil {
+			return err
+		}
+	}
+	return nil
+}
 
-	for idx, product := range order.Product {
-
-		code := strings.Split(product.Code, "#")
-		if len(code) != 2 {
-			return fmt.Errorf("invalid product code: %s", product.Code)
+// releaseCheckout gives back what was spent to place an order that will not
+// be delivered: its gift cards, store credit and loyalty points, and the use
+// of its coupon.
+func (p *Purchase) releaseCheckout(
+	ctx context.Context,
+	orderRepo orders.IOrders,
+	couponRepo coupons.ICoupons,
+	creditRepo credits.ICredits,
+	loyaltyRepo loyalty.ILoyalty,
+	orderCode string,
+	actor string,
+) error {
+	if err := p.restoreCredits(ctx, orderRepo, creditRepo, orderCode, actor); err != nil {
+		return err
+	}
+	if err := p.restorePoints(ctx, orderRepo, loyaltyRepo, orderCode); err != nil {
+		return err
+	}
+	order, err := orderRepo.GetByUUID(ctx, orderCode)
+	if err != nil {
+		return err
+	}
+	used, err := couponRepo.GetUsedByOrderID(ctx, order.ID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil
 		}
+		return err
+	}
+	if err := couponRepo.DeleteUsed(ctx, used.ID); err != nil {
+		return err
+	}
+	return couponRepo.DecreaseUsed(ctx, used.CouponCode)
+}
+
+// transitionOrder moves an order to the next status of its lifecycle and
+// records the transition. It must run inside a transaction: the order row
+// is locked so that concurrent transitions are applied one after another.
+func (p *Purchase) transitionOrder(
+	ctx context.Context,
+	orderRepo orders.IOrders,
+	inventory inventories.IInventories,
+	orderCode string,
+	next enum.OrderStatus,
+	actor string,
+	reason string,
+) error {
+	order, err := orderRepo.GetByUUIDForUpdate(ctx, orderCode)
+	if err != nil {
+		return err
+	}
+
+	var current enum.OrderStatus
+	if err := current.Load(order.Status); err != nil {
+		return err
+	}
+	if !current.CanTransitionTo(next) {
+		return &InvalidTransitionError{From: current, To: next}
+	}
 
-		id, err := strconv.ParseInt(code[1], 10, 64)
+	if next == enum.OrderConfirmed {
+		// the units in stock may be shipped first, the backordered units
+		// follow in a later shipment
+		waiting, units, err := p.backordered(ctx, orderRepo, order.ID)
 		if err != nil {
 			return err
 		}
+		if waiting > 0 && waiting == units {
+			return ErrOrderBackordered
+		}
+	}
 
-		if err := orderRepo.InsertProduct(ctx, entity.ProductInOrder{
-			OrderID:     orderID,
-			InventoryID: id,
-			Quantity:    product.Quantity,
-			TotalAmount: listTotalAmount[idx],
-		}); err != nil {
+	if next.ReleasesStock() {
+		if err := p.releaseStock(ctx, orderRepo, inventory, order.ID); err != nil {
 			return err
 		}
 	}
 
-	return nil
-}
-
-func (p *Purchase) useCoupon(
-	ctx context.Context, totalAmount decimal.Decimal,
-	order dtos.OrderForm, couponCode string) (newTotalAmount decimal.Decimal, err error) {
-
-	if couponCode == "" {
-		return totalAmount, nil
+	if err := orderRepo.UpdateStatus(ctx, orderCode, next.String()); err != nil {
+		return err
 	}
+	return orderRepo.InsertStatusHistory(ctx, entity.OrderStatusHistory{
+		OrderID:    order.ID,
+		FromStatus: current.String(),
+		ToStatus:   next.String(),
+		Actor:      actor,
+		Reason:     reason,
+	})
+}
 
-	coupon, err := p.Coupon.GetByCode(ctx, order.CouponCode)
-	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
-		return decimal.NewFromInt32(0), err
+func (p *Purchase) saveProductOrder(
+	ctx context.Context,
+	orderRepo orders.IOrders,
+	orderID int64,
+	currency string,
+	lines []orderLine,
+) error {
+	for _, line := range lines {
+		product := entity.ProductInOrder{
+			OrderID:      orderID,
+			InventoryID:  line.InventoryID,
+			Quantity:     line.Quantity,
+			CurrencyCode: currency,
+			UnitPrice:    line.UnitPrice,
+			TotalAmount:  line.Amount,
+			VATRate:      line.VATRate,
+			TaxAmount:    line.TaxAmount,
+			Backordered:  line.Backordered,
+		}
+		if line.BundleID != 0 {
+			product.BundleID = &line.BundleID
+		}
+		if err := orderRepo.InsertProduct(ctx, product); err != nil {
+			return err
+		}
 	}
+	return nil
+}
 
-	if coupon != nil {
-		discount := decimal.NewFromInt32(int32(coupon.Discount)).Div(decimal.NewFromInt32(100)).Mul(totalAmount)
-		newAmount := totalAmount.Copy().Sub(discount)
-		return newAmount, nil
+// decimalOrZero parses an optional decimal form field.
+func decimalOrZero(value string) (decimal.Decimal, error) {
+	if value == "" {
+		return decimal.Zero, nil
 	}
-	return totalAmount, nil
+	return decimal.NewFromString(value)
 }
 
 func (p *Purchase) getListOrder(ctx context.Context, orders []entity.Order) ([]dtos.OrderInfo, error) {
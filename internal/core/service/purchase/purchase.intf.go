@@ -3,9 +3,13 @@ package purchase
 
 import (
 	"context"
+	"io"
+	"mime/multipart"
+	"time"
 
 	"github.com/swclabs/swipex/internal/core/domain/dtos"
 	"github.com/swclabs/swipex/internal/core/domain/entity"
+	"github.com/swclabs/swipex/internal/core/domain/enum"
 	"github.com/swclabs/swipex/internal/core/domain/x/ghn"
 )
 
@@ -21,8 +25,47 @@ type IPurchase interface {
 	// ctx is the context to manage the request's lifecycle.
 	// limit is the maximum number of cart items to retrieve.
 	// userId is the user ID of cart item to retrieve.
+	// couponCode, if not empty, is used to preview the discount of the cart.
 	// Returns a slice of Carts objects and an error if any issues occur during the retrieval process.
-	GetCart(ctx context.Context, userID int64, limit int) (*dtos.Carts, error)
+	GetCart(ctx context.Context, userID int64, limit int, couponCode string) (*dtos.Carts, error)
+
+	// UpdateCartItem sets the quantity of an item in the shopping cart.
+	// ctx is the context to manage the request's lifecycle.
+	// Returns an InsufficientStockError if the quantity is more than the available stock.
+	UpdateCartItem(ctx context.Context, userID int64, inventoryID int64, quantity int64) error
+
+	// MergeCart folds the items of a guest cart into the cart of a user.
+	// ctx is the context to manage the request's lifecycle.
+	// Items no longer for sale are skipped and quantities are capped at the available stock.
+	MergeCart(ctx context.Context, userID int64, items []dtos.CartDTO) error
+
+	// GetGuestCart retrieves the cart of an anonymous visitor.
+	// ctx is the context to manage the request's lifecycle.
+	// guestID is the id kept in the visitor's session cookie.
+	GetGuestCart(ctx context.Context, guestID string, couponCode string) (*dtos.Carts, error)
+
+	// AddToGuestCart adds a product to the cart of an anonymous visitor.
+	// ctx is the context to manage the request's lifecycle.
+	AddToGuestCart(ctx context.Context, guestID string, item dtos.CartDTO) error
+
+	// UpdateGuestCartItem sets the quantity of an item in the cart of an anonymous visitor.
+	// ctx is the context to manage the request's lifecycle.
+	UpdateGuestCartItem(ctx context.Context, guestID string, inventoryID int64, quantity int64) error
+
+	// DeleteItemFromGuestCart deletes an item from the cart of an anonymous visitor.
+	// ctx is the context to manage the request's lifecycle.
+	DeleteItemFromGuestCart(ctx context.Context, guestID string, inventoryID int64) error
+
+	// MergeGuestCart folds the cart of an anonymous visitor into the cart of a user,
+	// then deletes the guest cart.
+	// ctx is the context to manage the request's lifecycle.
+	MergeGuestCart(ctx context.Context, userID int64, guestID string) error
+
+	// CreateGuestOrder creates an order for a visitor without an account and
+	// removes the ordered items from the guest cart.
+	// ctx is the context to manage the request's lifecycle.
+	// Returns the code of the new order.
+	CreateGuestOrder(ctx context.Context, guestID string, order dtos.OrderForm) (string, error)
 
 	// CreateOrders creates a new order.
 	// ctx is the context to manage the request's lifecycle.
@@ -30,6 +73,14 @@ type IPurchase interface {
 	// Returns the UUID of the newly created order and an error if any issues occur during the creation process.
 	CreateOrders(ctx context.Context, userID int64, createOrder dtos.Order) (string, error)
 
+	// CreateAdminOrder creates an order from the order form of the admin
+	// page. caller is the email of the signed in user, empty for anonymous
+	// requests: the store credit and the loyalty points of the customer are
+	// only spent by the customer.
+	// ctx is the context to manage the request's lifecycle.
+	// Returns the code of the new order.
+	CreateAdminOrder(ctx context.Context, caller string, order dtos.OrderForm) (string, error)
+
 	CreateOrderForm(ctx context.Context, order dtos.OrderForm) (string, error)
 
 	// DeleteItemFromCart deletes an item from the shopping cart.
@@ -46,11 +97,223 @@ type IPurchase interface {
 	// Returns a slice of OrderSchema objects and an error if any issues occur during the retrieval process.
 	GetOrdersByUserID(ctx context.Context, userID int64, limit int) ([]dtos.OrderInfo, error)
 
-	GetUsersByAdmin(ctx context.Context, limit int) ([]dtos.OrderInfo, error)
+	// SearchOrders lists the orders matching the filters of an admin search,
+	// one page at a time.
+	// ctx is the context to manage the request's lifecycle.
+	// Returns an error wrapping ErrInvalidOrderSearch for malformed filters or cursors.
+	SearchOrders(ctx context.Context, search dtos.OrderSearch) (*dtos.OrderPage, error)
+
+	// ExportOrders writes the line items of the orders matching export to w
+	// as CSV or XLSX, streaming them from the database.
+	// ctx is the context to manage the request's lifecycle.
+	// Returns the number of line items written.
+	ExportOrders(ctx context.Context, export dtos.OrderExport, w io.Writer) (int64, error)
+
+	// StartOrderExport queues an export to be run by the worker.
+	// ctx is the context to manage the request's lifecycle.
+	// Returns the pending job, to be followed with GetOrderExport.
+	StartOrderExport(ctx context.Context, export dtos.OrderExport) (*dtos.OrderExportJob, error)
+
+	// RunOrderExport runs a queued export and uploads the file to blob storage.
+	// ctx is the context to manage the request's lifecycle.
+	RunOrderExport(ctx context.Context, id string) error
+
+	// GetOrderExport returns the status of an export, with its download link once done.
+	// ctx is the context to manage the request's lifecycle.
+	// Returns ErrExportNotFound for unknown or expired exports.
+	GetOrderExport(ctx context.Context, id string) (*dtos.OrderExportJob, error)
+
+	// ReceiveStock adds the units received to an inventory and allocates
+	// them to the pre-orders waiting for them in the same transaction.
+	// ctx is the context to manage the request's lifecycle.
+	// Returns ErrInventoryNotFound for unknown inventories.
+	ReceiveStock(ctx context.Context, receipt dtos.StockReceipt) error
+
+	// CreateBundle creates a bundle of inventory items sold at its own price.
+	// ctx is the context to manage the request's lifecycle.
+	// Returns an error wrapping ErrInvalidBundle for malformed bundles.
+	CreateBundle(ctx context.Context, bundle dtos.CreateBundle) (int64, error)
+
+	// UpdateBundle replaces a bundle and its items.
+	// ctx is the context to manage the request's lifecycle.
+	// Returns ErrBundleNotFound for unknown bundles.
+	UpdateBundle(ctx context.Context, bundleID int64, bundle dtos.CreateBundle) error
+
+	// DeleteBundle deletes a bundle, the orders keep their lines.
+	// ctx is the context to manage the request's lifecycle.
+	DeleteBundle(ctx context.Context, bundleID int64) error
+
+	// GetBundle returns a bundle with its items and stock.
+	// ctx is the context to manage the request's lifecycle.
+	GetBundle(ctx context.Context, bundleID int64) (*dtos.Bundle, error)
+
+	// GetBundles returns a page of bundles, all of them for an empty status.
+	// ctx is the context to manage the request's lifecycle.
+	GetBundles(ctx context.Context, status string, limit, page int) ([]dtos.Bundle, error)
+
+	// AddBundleToCart adds bundles to the cart of a user.
+	// ctx is the context to manage the request's lifecycle.
+	AddBundleToCart(ctx context.Context, userID int64, bundle dtos.CartBundleDTO) error
+
+	// UpdateCartBundle sets the quantity of a bundle in the cart of a user.
+	// ctx is the context to manage the request's lifecycle.
+	// Returns ErrCartItemNotFound when the bundle is not in the cart.
+	UpdateCartBundle(ctx context.Context, userID int64, bundleID int64, quantity int64) error
+
+	// DeleteBundleFromCart removes a bundle from the cart of a user.
+	// ctx is the context to manage the request's lifecycle.
+	DeleteBundleFromCart(ctx context.Context, userID int64, bundleID int64) error
+
+	// IssueGiftCard issues a gift card with a generated code.
+	// ctx is the context to manage the request's lifecycle.
+	// actor is who issued the card, recorded in the ledger.
+	// Returns an error wrapping ErrInvalidGiftCard for malformed requests
+	// and for currencies other than the base currency.
+	IssueGiftCard(ctx context.Context, actor string, card dtos.IssueGiftCard) (*dtos.GiftCard, error)
+
+	// VoidGiftCard voids a gift card and writes its balance off.
+	// ctx is the context to manage the request's lifecycle.
+	// Returns ErrGiftCardNotFound for unknown codes.
+	VoidGiftCard(ctx context.Context, actor string, code string, note string) error
+
+	// GetGiftCard returns the balance of a gift card.
+	// ctx is the context to manage the request's lifecycle.
+	// Returns ErrGiftCardNotFound for unknown codes.
+	GetGiftCard(ctx context.Context, code string) (*dtos.GiftCard, error)
+
+	// AuditGiftCard returns a gift card with its ledger and checks that
+	// the ledger adds up to its balance.
+	// ctx is the context to manage the request's lifecycle.
+	// Returns ErrGiftCardNotFound for unknown codes.
+	AuditGiftCard(ctx context.Context, code string) (*dtos.GiftCardAudit, error)
+
+	// GetGiftCards returns a page of gift cards, all of them for an empty
+	// status.
+	// ctx is the context to manage the request's lifecycle.
+	GetGiftCards(ctx context.Context, status string, limit, page int) ([]dtos.GiftCard, error)
+
+	// GetWallet returns the store credit of a user with its ledger.
+	// ctx is the context to manage the request's lifecycle.
+	GetWallet(ctx context.Context, userID int64) (*dtos.Wallet, error)
+
+	// EarnPoints credits the loyalty points of a delivered order, once.
+	// ctx is the context to manage the request's lifecycle.
+	// orderCode is the UUID of the delivered order.
+	EarnPoints(ctx context.Context, orderCode string) error
+
+	// ExpirePoints takes back the loyalty points older than
+	// config.LoyaltyPointsTTL that were not redeemed.
+	// ctx is the context to manage the request's lifecycle.
+	ExpirePoints(ctx context.Context) error
+
+	// ExpireOrders expires the pending orders whose online payment was due
+	// and never came, their stock and checkout are released.
+	// ctx is the context to manage the request's lifecycle.
+	ExpireOrders(ctx context.Context) error
+
+	// ImportRemittances reconciles the COD orders with a carrier remittance
+	// report in CSV, the lines already imported with reference are skipped.
+	// ctx is the context to manage the request's lifecycle.
+	// Returns an error wrapping ErrInvalidRemittance for malformed reports.
+	ImportRemittances(ctx context.Context, actor string, reference string, report io.Reader) (*dtos.RemittanceImport, error)
+
+	// SyncRemittances reconciles the COD orders with the remittances GHN
+	// transferred between from and to.
+	// ctx is the context to manage the request's lifecycle.
+	SyncRemittances(ctx context.Context, actor string, from, to time.Time) (*dtos.RemittanceImport, error)
+
+	// GetUnreconciledCOD returns a page of the COD orders whose cash is not
+	// reconciled for longer than olderThan, oldest first.
+	// ctx is the context to manage the request's lifecycle.
+	GetUnreconciledCOD(ctx context.Context, olderThan time.Duration, limit, page int) ([]dtos.CODOrder, error)
 
 	GetOrderByCode(ctx context.Context, orderCode string) (*dtos.OrderInfo, error)
 
-	UpdateOrderStatus(ctx context.Context, orderCode string, status string) error
+	// UpdateOrderStatus moves an order to the given status.
+	// ctx is the context to manage the request's lifecycle.
+	// actor and reason are stored in the order status history.
+	// Returns an InvalidTransitionError if the order lifecycle does not allow the transition.
+	UpdateOrderStatus(ctx context.Context, orderCode string, status enum.OrderStatus, actor string, reason string) error
+
+	// CreateShipment packs some units of an order in a shipment with its own
+	// delivery.
+	// ctx is the context to manage the request's lifecycle.
+	// actor is the staff member packing the shipment.
+	// Returns ErrShipmentNotAllowed unless the order is being packed, and
+	// ErrInvalidShipment for units that are backordered or already shipped.
+	CreateShipment(ctx context.Context, actor string, orderCode string, form dtos.ShipmentForm) (int64, error)
+
+	// UpdateShipment sets the carrier order code or the status of a shipment,
+	// the order follows the status derived from all its shipments.
+	// ctx is the context to manage the request's lifecycle.
+	// Returns ErrShipmentNotFound for an unknown shipment.
+	UpdateShipment(ctx context.Context, actor string, shipmentID int64, update dtos.ShipmentUpdate) error
+
+	// CancelOrder cancels an order on behalf of its owner.
+	// ctx is the context to manage the request's lifecycle.
+	// userID is the owner of the order, orderCode the order to cancel.
+	// The reserved stock and the applied coupon are given back, and the
+	// customer is notified asynchronously.
+	// Returns an InvalidTransitionError if the order is no longer pending or confirmed.
+	CancelOrder(ctx context.Context, userID int64, orderCode string, reason string) error
+
+	// NotifyOrderCancelled sends the cancellation email of an order to its customer.
+	NotifyOrderCancelled(ctx context.Context, orderCode string, reason string) error
+
+	// RemindAbandonedCarts finds the carts left untouched for config.CartAbandonedAfter
+	// and queues a reminder email for each, at most once per config.CartReminderWindow.
+	// ctx is the context to manage the request's lifecycle.
+	RemindAbandonedCarts(ctx context.Context) error
+
+	// SendCartReminder sends the abandoned cart email of a user, unless the user opted out.
+	// ctx is the context to manage the request's lifecycle.
+	SendCartReminder(ctx context.Context, userID int64) error
+
+	// CartReminderStats summarizes the abandoned cart reminders sent.
+	// ctx is the context to manage the request's lifecycle.
+	CartReminderStats(ctx context.Context) (*dtos.CartReminderStats, error)
+
+	// GetInvoice renders the invoice of an order as "html" or "pdf", issuing
+	// its invoice number on the first request.
+	// ctx is the context to manage the request's lifecycle.
+	// Returns ErrInvoiceNotAvailable when the order was never confirmed.
+	GetInvoice(ctx context.Context, orderCode string, format string) (*dtos.InvoiceFile, error)
+
+	// GenerateInvoice issues the invoice of an order and stores its HTML and
+	// PDF files in blob storage, run by the worker when an order is delivered.
+	// ctx is the context to manage the request's lifecycle.
+	GenerateInvoice(ctx context.Context, orderCode string) error
+
+	// RequestReturn opens a return request for some lines of a delivered order.
+	// ctx is the context to manage the request's lifecycle.
+	// userID is the owner of the order, req the lines and quantities to send back.
+	// photos are uploaded to the blob storage and attached to the request.
+	// Returns the ID of the return request, or a ReturnQuantityError if a line
+	// is not part of the order or has fewer units left to return.
+	RequestReturn(ctx context.Context, userID int64, req dtos.ReturnRequest, photos []*multipart.FileHeader) (int64, error)
+
+	// GetReturns retrieves the return requests of a customer, newest first.
+	GetReturns(ctx context.Context, userID int64, limit int) ([]dtos.Return, error)
+
+	// GetReturnsByAdmin retrieves return requests, filtered by status when it is not empty.
+	GetReturnsByAdmin(ctx context.Context, status enum.ReturnStatus, limit int) ([]dtos.Return, error)
+
+	// ApproveReturn accepts a return request.
+	// ctx is the context to manage the request's lifecycle.
+	// A pending refund is recorded for the returned units and, unless
+	// decision.SkipRestock is set, the units are put back into available stock.
+	// The order moves to returned once all of its units have been returned.
+	ApproveReturn(ctx context.Context, returnID int64, actor string, decision dtos.ReturnDecision) error
+
+	// RejectReturn declines a return request that is still waiting for review.
+	RejectReturn(ctx context.Context, returnID int64, note string) error
+
+	// ReceiveReturn records that the returned parcel arrived at the warehouse.
+	ReceiveReturn(ctx context.Context, returnID int64) error
+
+	// RestockReturn puts the units of an approved return that skipped
+	// restocking back into available stock.
+	RestockReturn(ctx context.Context, returnID int64) error
 
 	DeliveryOrderInfo(ctx context.Context, orderCode string) (*ghn.OrderInfoDTO, error)
 
@@ -91,4 +354,10 @@ type IPurchase interface {
 	GetCoupon(ctx context.Context) (coupons []dtos.Coupon, err error)
 
 	DeleteCoupon(ctx context.Context, code string) error
+
+	// ValidateCoupon previews the discount of a coupon for a cart.
+	// ctx is the context to manage the request's lifecycle.
+	// userID is the customer, or zero for a guest, whose past use is checked.
+	// Returns a CouponError when a rule of the coupon is not met.
+	ValidateCoupon(ctx context.Context, userID int64, req dtos.CouponValidate) (*dtos.CouponPreview, error)
 }
@@ -0,0 +1,258 @@
+package purchase
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/swclabs/swipex/internal/config"
+	"github.com/swclabs/swipex/internal/core/domain/dtos"
+	"github.com/swclabs/swipex/internal/core/domain/entity"
+	"github.com/swclabs/swipex/internal/core/domain/enum"
+	"github.com/swclabs/swipex/internal/core/repos/invoices"
+	"github.com/swclabs/swipex/pkg/components"
+	"github.com/swclabs/swipex/pkg/infra/db"
+	"github.com/swclabs/swipex/pkg/utils"
+
+	"github.com/a-h/templ"
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+)
+
+// invoice formats
+const (
+	InvoiceHTML = "html"
+	InvoicePDF  = "pdf"
+)
+
+// GetInvoice implements IPurchase.
+func (p *Purchase) GetInvoice(ctx context.Context, orderCode string, format string) (*dtos.InvoiceFile, error) {
+	data, email, err := p.invoiceData(ctx, orderCode)
+	if err != nil {
+		return nil, err
+	}
+	file := &dtos.InvoiceFile{
+		Number: data.Number,
+		Email:  email,
+	}
+	switch format {
+	case InvoiceHTML:
+		html, err := templ.ToGoHTML(ctx, components.Invoice(*data))
+		if err != nil {
+			return nil, err
+		}
+		file.FileName = data.Number + ".html"
+		file.ContentType = "text/html; charset=utf-8"
+		file.Content = []byte(html)
+	case InvoicePDF:
+		file.FileName = data.Number + ".pdf"
+		file.ContentType = "application/pdf"
+		file.Content = components.InvoicePDF(*data)
+	default:
+		return nil, fmt.Errorf("unknown invoice format %q", format)
+	}
+	return file, nil
+}
+
+// GenerateInvoice implements IPurchase.
+func (p *Purchase) GenerateInvoice(ctx context.Context, orderCode string) error {
+	order, err := p.Order.GetByUUID(ctx, orderCode)
+	if err != nil {
+		return err
+	}
+	invoice, err := p.issueInvoice(ctx, order.ID)
+	if err != nil {
+		return err
+	}
+	// retries of the task must not upload the files again
+	if invoice.HTMLURL != "" && invoice.PDFURL != "" {
+		return nil
+	}
+
+	// the copies are kept private under names that cannot be guessed from
+	// the sequential invoice numbers, customers get their invoice from
+	// GetInvoice
+	var (
+		urls   [2]string
+		prefix = "invoices/" + utils.GenSecretName(32) + "-"
+	)
+	for i, format := range []string{InvoiceHTML, InvoicePDF} {
+		file, err := p.GetInvoice(ctx, orderCode, format)
+		if err != nil {
+			return err
+		}
+		urls[i], err = p.Blob.UploadPrivate(ctx, prefix+file.FileName, bytes.NewReader(file.Content))
+		if err != nil {
+			return err
+		}
+	}
+	return p.Invoice.UpdateURLs(ctx, invoice.ID, urls[0], urls[1])
+}
+
+// issueInvoice returns the invoice of an order, issuing it with the next
+// invoice number on the first call.
+func (p *Purchase) issueInvoice(ctx context.Context, orderID int64) (*entity.Invoice, error) {
+	invoice, err := p.Invoice.GetByOrderID(ctx, orderID)
+	if err == nil {
+		return invoice, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, err
+	}
+
+	tx, err := db.NewTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	invoiceRepo := invoices.New(tx)
+	number, err := invoiceRepo.NextNumber(ctx)
+	if err != nil {
+		if errTx := tx.Rollback(ctx); errTx != nil {
+			log.Fatal(errTx)
+		}
+		return nil, err
+	}
+	// the counter is locked now, an invoice issued for the order while
+	// waiting for it is returned and the number given back
+	invoice, err = invoiceRepo.GetByOrderID(ctx, orderID)
+	if err == nil {
+		if errTx := tx.Rollback(ctx); errTx != nil {
+			log.Fatal(errTx)
+		}
+		return invoice, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		if errTx := tx.Rollback(ctx); errTx != nil {
+			log.Fatal(errTx)
+		}
+		return nil, err
+	}
+	if _, err := invoiceRepo.Insert(ctx, entity.Invoice{
+		OrderID: orderID,
+		Number:  number,
+	}); err != nil {
+		if errTx := tx.Rollback(ctx); errTx != nil {
+			log.Fatal(errTx)
+		}
+		return nil, err
+	}
+	invoice, err = invoiceRepo.GetByOrderID(ctx, orderID)
+	if err != nil {
+		if errTx := tx.Rollback(ctx); errTx != nil {
+			log.Fatal(errTx)
+		}
+		return nil, err
+	}
+	return invoice, tx.Commit(ctx)
+}
+
+// invoiceData collects the content of the invoice of an order and the email
+// of its customer.
+func (p *Purchase) invoiceData(ctx context.Context, orderCode string) (*components.InvoiceData, string, error) {
+	order, err := p.Order.GetByUUID(ctx, orderCode)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, "", ErrOrderNotFound
+		}
+		return nil, "", err
+	}
+	if !invoiceable(enum.OrderStatus(order.Status)) {
+		return nil, "", ErrInvoiceNotAvailable
+	}
+	items, err := p.Order.GetItemByCode(ctx, orderCode)
+	if err != nil {
+		return nil, "", err
+	}
+	user, err := p.User.GetByID(ctx, order.UserID)
+	if err != nil {
+		return nil, "", err
+	}
+	delivery, err := p.Delivery.GetByID(ctx, order.DeliveryID)
+	if err != nil {
+		return nil, "", err
+	}
+	address, err := p.Address.GetByID(ctx, delivery.AddressID)
+	if err != nil {
+		return nil, "", err
+	}
+	invoice, err := p.issueInvoice(ctx, order.ID)
+	if err != nil {
+		return nil, "", err
+	}
+
+	currency := "VND"
+	lines := make([]components.InvoiceLine, 0, len(items))
+	for _, item := range items {
+		if item.CurrencyCode != "" {
+			currency = item.CurrencyCode
+		}
+		lines = append(lines, components.InvoiceLine{
+			Name:      item.Name,
+			Color:     item.Color,
+			Quantity:  int64(item.Quantity),
+			UnitPrice: money(item.UnitPrice),
+			VATRate:   item.VATRate.String(),
+			TaxAmount: money(item.TaxAmount),
+			Amount:    money(item.TotalAmount),
+		})
+	}
+	return &components.InvoiceData{
+		Number:        invoiceNumber(invoice.Number),
+		IssuedAt:      utils.HanoiTimezone(invoice.IssuedAt),
+		OrderCode:     order.UUID,
+		PaymentMethod: order.PaymentMethod,
+		Currency:      currency,
+		Seller: components.InvoiceParty{
+			Name:    config.SellerName,
+			Address: config.SellerAddress,
+			TaxCode: config.SellerTaxCode,
+			Email:   config.SellerEmail,
+			Phone:   config.SellerPhone,
+		},
+		Buyer: components.InvoiceParty{
+			Name:    strings.TrimSpace(user.FirstName + " " + user.LastName),
+			Address: joinAddress(address.Street, address.Ward, address.District, address.City),
+			Email:   user.Email,
+			Phone:   user.PhoneNumber,
+		},
+		Lines:       lines,
+		Subtotal:    money(order.Subtotal),
+		Discount:    money(order.DiscountAmount),
+		TaxAmount:   money(order.TaxAmount),
+		ShippingFee: money(order.ShippingFee),
+		Total:       money(order.TotalAmount),
+	}, user.Email, nil
+}
+
+// invoiceable reports whether an order in the given status gets an invoice.
+// Orders that never got past pending are not sales.
+func invoiceable(status enum.OrderStatus) bool {
+	switch status {
+	case enum.OrderPending, enum.OrderCancelled, enum.OrderExpired:
+		return false
+	}
+	return true
+}
+
+// invoiceNumber formats the sequential number of an invoice
+func invoiceNumber(n int64) string {
+	return fmt.Sprintf("INV-%06d", n)
+}
+
+// money formats an amount for an invoice
+func money(d decimal.Decimal) string {
+	return d.StringFixed(2)
+}
+
+func joinAddress(parts ...string) string {
+	filled := make([]string, 0, len(parts))
+	for _, part := range parts {
+		if part = strings.TrimSpace(part); part != "" {
+			filled = append(filled, part)
+		}
+	}
+	return strings.Join(filled, ", ")
+}
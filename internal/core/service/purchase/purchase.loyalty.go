@@ -0,0 +1,323 @@
+package purchase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/swclabs/swipex/internal/config"
+	"github.com/swclabs/swipex/internal/core/domain/dtos"
+	"github.com/swclabs/swipex/internal/core/domain/entity"
+	"github.com/swclabs/swipex/internal/core/domain/enum"
+	"github.com/swclabs/swipex/internal/core/repos/loyalty"
+	"github.com/swclabs/swipex/internal/core/repos/orders"
+	"github.com/swclabs/swipex/pkg/lib/logger"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+)
+
+// kinds of loyalty ledger entries
+const (
+	LoyaltyEarn    = "earn"
+	LoyaltyRedeem  = "redeem"
+	LoyaltyReverse = "reverse"
+	LoyaltyRestore = "restore"
+	LoyaltyExpire  = "expire"
+)
+
+// expiryBatch is the number of users whose points are expired per run
+const expiryBatch = 500
+
+// EarnPoints implements IPurchase.
+func (p *Purchase) EarnPoints(ctx context.Context, orderCode string) error {
+	tx, err := p.begin(ctx)
+	if err != nil {
+		return err
+	}
+	if err := p.earnPoints(ctx, tx.Order, tx.Loyalty, orderCode); err != nil {
+		if errTx := tx.Rollback(ctx); errTx != nil {
+			log.Fatal(errTx)
+		}
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func (p *Purchase) earnPoints(
+	ctx context.Context,
+	orderRepo orders.IOrders,
+	loyaltyRepo loyalty.ILoyalty,
+	orderCode string,
+) error {
+	order, err := orderRepo.GetByUUIDForUpdate(ctx, orderCode)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrOrderNotFound
+		}
+		return err
+	}
+	// the order may have been returned since the task was queued
+	if order.Status != enum.OrderDelivered.String() {
+		return nil
+	}
+	entries, err := loyaltyRepo.GetOrderEntries(ctx, order.ID)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if entry.Kind == LoyaltyEarn {
+			return nil
+		}
+	}
+	points := pointsFor(baseAmount(order, order.Subtotal.Sub(order.DiscountAmount)))
+	if points <= 0 {
+		return nil
+	}
+	if _, err := loyaltyRepo.GetBalanceForUpdate(ctx, order.UserID); err != nil {
+		return err
+	}
+	expires := time.Now().UTC().Add(config.LoyaltyPointsTTL)
+	if _, err := loyaltyRepo.InsertEntry(ctx, entity.LoyaltyEntry{
+		UserID:    order.UserID,
+		OrderID:   &order.ID,
+		Points:    points,
+		Remaining: points,
+		Kind:      LoyaltyEarn,
+		ExpiresAt: &expires,
+	}); err != nil {
+		return err
+	}
+	return loyaltyRepo.AddPoints(ctx, order.UserID, points, points)
+}
+
+// ExpirePoints implements IPurchase.
+func (p *Purchase) ExpirePoints(ctx context.Context) error {
+	now := time.Now().UTC()
+	users, err := p.Loyalty.GetExpiredUsers(ctx, now, expiryBatch)
+	if err != nil {
+		return err
+	}
+	var expired int64
+	for _, userID := range users {
+		tx, err := p.begin(ctx)
+		if err != nil {
+			return err
+		}
+		points, err := p.expirePoints(ctx, tx.Loyalty, userID, now)
+		if err != nil {
+			if errTx := tx.Rollback(ctx); errTx != nil {
+				log.Fatal(errTx)
+			}
+			return err
+		}
+		if err := tx.Commit(ctx); err != nil {
+			return err
+		}
+		expired += points
+	}
+	logger.Info(fmt.Sprintf("loyalty points: %d expired from %d users", expired, len(users)))
+	return nil
+}
+
+func (p *Purchase) expirePoints(ctx context.Context, loyaltyRepo loyalty.ILoyalty, userID int64, now time.Time) (int64, error) {
+	balance, err := loyaltyRepo.GetBalanceForUpdate(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	lots, err := loyaltyRepo.GetExpiredLots(ctx, userID, now)
+	if err != nil {
+		return 0, err
+	}
+	var points int64
+	for _, lot := range lots {
+		if err := loyaltyRepo.SetRemaining(ctx, lot.ID, 0); err != nil {
+			return 0, err
+		}
+		points += lot.Remaining
+	}
+	points = min(points, balance)
+	if points == 0 {
+		return 0, nil
+	}
+	if _, err := loyaltyRepo.InsertEntry(ctx, entity.LoyaltyEntry{
+		UserID: userID,
+		Points: -points,
+		Kind:   LoyaltyExpire,
+	}); err != nil {
+		return 0, err
+	}
+	return points, loyaltyRepo.AddPoints(ctx, userID, -points, 0)
+}
+
+// planPoints locks the points balance of the customer of an order and
+// returns the points redeemed and what they pay for, up to due.
+func (p *Purchase) planPoints(
+	ctx context.Context,
+	loyaltyRepo loyalty.ILoyalty,
+	userID int64,
+	order dtos.OrderForm,
+	due decimal.Decimal,
+) (int64, decimal.Decimal, error) {
+	if order.Points <= 0 {
+		return 0, decimal.Zero, nil
+	}
+	balance, err := loyaltyRepo.GetBalanceForUpdate(ctx, userID)
+	if err != nil {
+		return 0, decimal.Zero, err
+	}
+	if balance < order.Points {
+		return 0, decimal.Zero, fmt.Errorf("%w: the balance is %d points", ErrInsufficientPoints, balance)
+	}
+	// only the points needed to pay what is due are redeemed
+	points := min(order.Points, due.Div(config.LoyaltyBurnRate).IntPart())
+	if points <= 0 {
+		return 0, decimal.Zero, nil
+	}
+	return points, config.LoyaltyBurnRate.Mul(decimal.NewFromInt(points)), nil
+}
+
+// redeemPoints takes the points planned by planPoints from the lots of a
+// user, the first to expire first, and records them against the order.
+func (p *Purchase) redeemPoints(
+	ctx context.Context,
+	loyaltyRepo loyalty.ILoyalty,
+	userID int64,
+	orderID int64,
+	points int64,
+) error {
+	if points <= 0 {
+		return nil
+	}
+	if err := consumeLots(ctx, loyaltyRepo, userID, points); err != nil {
+		return err
+	}
+	if _, err := loyaltyRepo.InsertEntry(ctx, entity.LoyaltyEntry{
+		UserID:  userID,
+		OrderID: &orderID,
+		Points:  -points,
+		Kind:    LoyaltyRedeem,
+	}); err != nil {
+		return err
+	}
+	return loyaltyRepo.AddPoints(ctx, userID, -points, 0)
+}
+
+// restorePoints gives back the points redeemed on an order that will not
+// be delivered, as a new lot.
+func (p *Purchase) restorePoints(
+	ctx context.Context,
+	orderRepo orders.IOrders,
+	loyaltyRepo loyalty.ILoyalty,
+	orderCode string,
+) error {
+	order, err := orderRepo.GetByUUID(ctx, orderCode)
+	if err != nil {
+		return err
+	}
+	if order.PointsRedeemed == 0 {
+		return nil
+	}
+	entries, err := loyaltyRepo.GetOrderEntries(ctx, order.ID)
+	if err != nil {
+		return err
+	}
+	var points int64
+	for _, entry := range entries {
+		if entry.Kind == LoyaltyRedeem || entry.Kind == LoyaltyRestore {
+			points -= entry.Points
+		}
+	}
+	if points <= 0 {
+		return nil
+	}
+	if _, err := loyaltyRepo.GetBalanceForUpdate(ctx, order.UserID); err != nil {
+		return err
+	}
+	expires := time.Now().UTC().Add(config.LoyaltyPointsTTL)
+	if _, err := loyaltyRepo.InsertEntry(ctx, entity.LoyaltyEntry{
+		UserID:    order.UserID,
+		OrderID:   &order.ID,
+		Points:    points,
+		Remaining: points,
+		Kind:      LoyaltyRestore,
+		ExpiresAt: &expires,
+	}); err != nil {
+		return err
+	}
+	return loyaltyRepo.AddPoints(ctx, order.UserID, points, 0)
+}
+
+// reversePoints takes back the points earned on the refunded part of an
+// order, refund is in the currency of the order. The points already spent cannot be taken back, only the tier
+// loses them.
+func (p *Purchase) reversePoints(
+	ctx context.Context,
+	loyaltyRepo loyalty.ILoyalty,
+	order *entity.Order,
+	refund decimal.Decimal,
+) error {
+	entries, err := loyaltyRepo.GetOrderEntries(ctx, order.ID)
+	if err != nil {
+		return err
+	}
+	var earned int64
+	for _, entry := range entries {
+		if entry.Kind == LoyaltyEarn || entry.Kind == LoyaltyReverse {
+			earned += entry.Points
+		}
+	}
+	points := min(pointsFor(baseAmount(order, refund)), earned)
+	if points <= 0 {
+		return nil
+	}
+	balance, err := loyaltyRepo.GetBalanceForUpdate(ctx, order.UserID)
+	if err != nil {
+		return err
+	}
+	debit := min(points, balance)
+	if debit > 0 {
+		if err := consumeLots(ctx, loyaltyRepo, order.UserID, debit); err != nil {
+			return err
+		}
+		if _, err := loyaltyRepo.InsertEntry(ctx, entity.LoyaltyEntry{
+			UserID:  order.UserID,
+			OrderID: &order.ID,
+			Points:  -debit,
+			Kind:    LoyaltyReverse,
+		}); err != nil {
+			return err
+		}
+	}
+	return loyaltyRepo.AddPoints(ctx, order.UserID, -debit, -points)
+}
+
+// consumeLots takes points from the lots of a user, the first to expire
+// first. The user row must be locked.
+func consumeLots(ctx context.Context, loyaltyRepo loyalty.ILoyalty, userID int64, points int64) error {
+	lots, err := loyaltyRepo.GetLots(ctx, userID)
+	if err != nil {
+		return err
+	}
+	for _, lot := range lots {
+		if points == 0 {
+			break
+		}
+		taken := min(points, lot.Remaining)
+		if err := loyaltyRepo.SetRemaining(ctx, lot.ID, lot.Remaining-taken); err != nil {
+			return err
+		}
+		points -= taken
+	}
+	return nil
+}
+
+// pointsFor returns the points earned by spending amount.
+func pointsFor(amount decimal.Decimal) int64 {
+	if !amount.IsPositive() {
+		return 0
+	}
+	return amount.Div(config.LoyaltyEarnRate).IntPart()
+}
@@ -0,0 +1,122 @@
+package purchase
+
+import (
+	"context"
+	"errors"
+	"log"
+	"slices"
+
+	"github.com/swclabs/swipex/internal/core/domain/dtos"
+	"github.com/swclabs/swipex/internal/core/domain/enum"
+	"github.com/swclabs/swipex/internal/core/repos/inventories"
+	"github.com/swclabs/swipex/internal/core/repos/orders"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// ReceiveStock implements IPurchase.
+func (p *Purchase) ReceiveStock(ctx context.Context, receipt dtos.StockReceipt) error {
+	tx, err := p.begin(ctx)
+	if err != nil {
+		return err
+	}
+	var (
+		orderRepo     = tx.Order
+		inventoryRepo = tx.Inventory
+	)
+	// the backorders are served before the received units can be sold, so
+	// that orders placed meanwhile cannot take them
+	if _, err := inventoryRepo.Release(ctx, receipt.InventoryID, receipt.Quantity); err != nil {
+		if errTx := tx.Rollback(ctx); errTx != nil {
+			log.Fatal(errTx)
+		}
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrInventoryNotFound
+		}
+		return err
+	}
+	if err := p.allocatePreorders(ctx, orderRepo, inventoryRepo, receipt.InventoryID); err != nil {
+		if errTx := tx.Rollback(ctx); errTx != nil {
+			log.Fatal(errTx)
+		}
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// allocatePreorders hands the available units of an inventory to its
+// backorders, oldest orders first, and confirms the orders left with no
+// backordered unit.
+func (p *Purchase) allocatePreorders(
+	ctx context.Context,
+	orderRepo orders.IOrders,
+	inventory inventories.IInventories,
+	inventoryID int64,
+) error {
+	inv, err := inventory.GetByIDForUpdate(ctx, inventoryID)
+	if err != nil {
+		return err
+	}
+	backorders, err := orderRepo.GetBackorders(ctx, inventoryID)
+	if err != nil {
+		return err
+	}
+
+	available := inv.Available
+	var filled []string
+	for _, backorder := range backorders {
+		if available == 0 {
+			break
+		}
+		units := min(available, backorder.Backordered)
+		if _, err := inventory.Reserve(ctx, inventoryID, units); err != nil {
+			return err
+		}
+		if err := orderRepo.AllocateBackorder(ctx, backorder.ID, units); err != nil {
+			return err
+		}
+		available -= units
+		if units == backorder.Backordered && !slices.Contains(filled, backorder.OrderCode) {
+			filled = append(filled, backorder.OrderCode)
+		}
+	}
+
+	for _, orderCode := range filled {
+		order, err := orderRepo.GetByUUID(ctx, orderCode)
+		if err != nil {
+			return err
+		}
+		// the order may still wait for other items, or have been confirmed
+		// to ship its units in stock first
+		if order.Status != enum.OrderPending.String() {
+			continue
+		}
+		waiting, _, err := p.backordered(ctx, orderRepo, order.ID)
+		if err != nil {
+			return err
+		}
+		if waiting > 0 {
+			continue
+		}
+		if err := p.transitionOrder(ctx, orderRepo, inventory, orderCode,
+			enum.OrderConfirmed, "system", "pre-order stock allocated"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// backordered returns the number of units of an order waiting for stock
+// and the number of units of the order.
+func (p *Purchase) backordered(ctx context.Context, orderRepo orders.IOrders, orderID int64) (int64, int64, error) {
+	items, err := orderRepo.GetProductByOrderID(ctx, orderID)
+	if err != nil {
+		return 0, 0, err
+	}
+	var waiting, units int64
+	for _, item := range items {
+		waiting += item.Backordered
+		units += item.Quantity
+	}
+	return waiting, units, nil
+}
@@ -0,0 +1,163 @@
+package purchase
+
+import (
+	"context"
+
+	"github.com/swclabs/swipex/internal/config"
+	"github.com/swclabs/swipex/internal/core/domain/dtos"
+	"github.com/swclabs/swipex/internal/core/domain/enum"
+	"github.com/swclabs/swipex/internal/core/domain/model"
+	"github.com/swclabs/swipex/internal/core/repos/inventories"
+
+	"github.com/shopspring/decimal"
+)
+
+var hundred = decimal.NewFromInt(100)
+
+// orderLine is a line of an order priced at checkout. VATRate is a percent.
+// Backordered units of a pre-order line only pay DepositPercent of their
+// price at checkout, or all of it when DepositPercent is zero.
+// Lines sold in a bundle carry its ID and their share of the bundle price.
+type orderLine struct {
+	InventoryID    int64
+	BundleID       int64
+	CategoryID     int64
+	Quantity       int64
+	Backordered    int64
+	UnitPrice      decimal.Decimal
+	VATRate        decimal.Decimal
+	DepositPercent decimal.Decimal
+	Amount         decimal.Decimal
+	TaxAmount      decimal.Decimal
+}
+
+// orderPricing is the price breakdown persisted on an order.
+type orderPricing struct {
+	Subtotal    decimal.Decimal
+	Discount    decimal.Decimal
+	TaxAmount   decimal.Decimal
+	ShippingFee decimal.Decimal
+	Total       decimal.Decimal
+	Deposit     decimal.Decimal
+	Lines       []orderLine
+}
+
+// priceLines snapshots the unit price, in the currency of the order, and
+// the category VAT rate of the items of an order. It must run inside the
+// order transaction, after the inventory rows have been locked by
+// reserveStock. The components of the bundles follow the items, priced by
+// priceBundle.
+func (p *Purchase) priceLines(
+	ctx context.Context,
+	inventory inventories.IInventories,
+	order dtos.OrderForm,
+	bundles []bundleLine,
+	currency orderCurrency,
+) ([]orderLine, error) {
+	lines := make([]orderLine, 0, len(order.Product))
+	for _, item := range order.Product {
+		id, err := parseItemCode(item.Code)
+		if err != nil {
+			return nil, err
+		}
+		inv, err := inventory.GetByIDForUpdate(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		product, err := p.Product.GetByID(ctx, inv.ProductID)
+		if err != nil {
+			return nil, err
+		}
+		category, err := p.Category.GetByID(ctx, product.CategoryID)
+		if err != nil {
+			return nil, err
+		}
+		price, err := currency.price(inv.Price, inv.CurrencyCode)
+		if err != nil {
+			return nil, err
+		}
+		line := orderLine{
+			InventoryID: id,
+			CategoryID:  product.CategoryID,
+			Quantity:    item.Quantity,
+			UnitPrice:   price,
+			VATRate:     category.VATRate.Decimal,
+		}
+		if inv.Status == enum.InventoryPreorder.String() {
+			line.DepositPercent = inv.DepositPercent
+		}
+		lines = append(lines, line)
+	}
+	for _, bundle := range bundles {
+		bundleLines, err := p.priceBundle(ctx, bundle, currency)
+		if err != nil {
+			return nil, err
+		}
+		lines = append(lines, bundleLines...)
+	}
+	return lines, nil
+}
+
+// shippingFee returns the shipping fee of an order with the given subtotal.
+func shippingFee(subtotal decimal.Decimal) decimal.Decimal {
+	if config.FreeShippingThreshold.IsPositive() && subtotal.GreaterThanOrEqual(config.FreeShippingThreshold) {
+		return decimal.Zero
+	}
+	return config.ShippingFee
+}
+
+// priceOrder computes the breakdown of an order. Prices are before tax: the
+// discount is spread over the lines in proportion to their amount and VAT
+// is charged on what is left of each line. The shipping fee is not taxed.
+// Bundle lines keep the amount priceBundle gave them and take no part of
+// the discount, coupons only apply to items sold on their own.
+// Deposit is what is due at checkout when backordered units take a deposit,
+// zero when the whole total is due. The lines and the discount are in the
+// currency of the order, the shipping fee is converted from the base
+// currency. The discount, the tax of each line, the total and the deposit
+// are rounded to the minor unit of the currency, so that they can be
+// charged as they are.
+func priceOrder(lines []orderLine, discount decimal.Decimal, currency orderCurrency) orderPricing {
+	discount = model.RoundCurrency(discount, currency.Code)
+	pricing := orderPricing{
+		Subtotal:  decimal.Zero,
+		Discount:  discount,
+		TaxAmount: decimal.Zero,
+		Deposit:   decimal.Zero,
+		Lines:     make([]orderLine, len(lines)),
+	}
+	var (
+		deferred   = decimal.Zero
+		discounted = decimal.Zero
+	)
+	for i, line := range lines {
+		if line.BundleID == 0 {
+			line.Amount = line.UnitPrice.Mul(decimal.NewFromInt(line.Quantity))
+			discounted = discounted.Add(line.Amount)
+		}
+		pricing.Subtotal = pricing.Subtotal.Add(line.Amount)
+		pricing.Lines[i] = line
+	}
+	for i, line := range pricing.Lines {
+		taxable := line.Amount
+		if line.BundleID == 0 && discounted.IsPositive() {
+			taxable = taxable.Sub(discount.Mul(line.Amount).Div(discounted))
+		}
+		pricing.Lines[i].TaxAmount = model.RoundCurrency(taxable.Mul(line.VATRate).Div(hundred), currency.Code)
+		pricing.TaxAmount = pricing.TaxAmount.Add(pricing.Lines[i].TaxAmount)
+
+		if line.Backordered > 0 && line.DepositPercent.IsPositive() {
+			gross := taxable.Add(pricing.Lines[i].TaxAmount)
+			deferred = deferred.Add(gross.
+				Mul(decimal.NewFromInt(line.Backordered)).Div(decimal.NewFromInt(line.Quantity)).
+				Mul(hundred.Sub(line.DepositPercent)).Div(hundred))
+		}
+	}
+	pricing.ShippingFee = currency.fromBase(shippingFee(currency.toBase(pricing.Subtotal)))
+	pricing.Total = model.RoundCurrency(
+		pricing.Subtotal.Sub(discount).Add(pricing.TaxAmount).Add(pricing.ShippingFee), currency.Code)
+	if deferred.IsPositive() {
+		pricing.Deposit = model.RoundCurrency(pricing.Total.Sub(deferred), currency.Code)
+	}
+	return pricing
+}
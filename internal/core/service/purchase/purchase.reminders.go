@@ -0,0 +1,105 @@
+package purchase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/swclabs/swipex/internal/config"
+	"github.com/swclabs/swipex/internal/core/domain/dtos"
+	"github.com/swclabs/swipex/internal/core/domain/entity"
+	"github.com/swclabs/swipex/internal/core/tasks"
+	"github.com/swclabs/swipex/internal/core/x/mail"
+	"github.com/swclabs/swipex/internal/workers/queue"
+	"github.com/swclabs/swipex/pkg/components"
+	"github.com/swclabs/swipex/pkg/lib/logger"
+	"github.com/swclabs/swipex/pkg/lib/worker"
+)
+
+// reminderBatch is the maximum number of carts reminded by a single run of
+// the abandoned cart job, the next run picks up the rest.
+const reminderBatch = 500
+
+// RemindAbandonedCarts implements IPurchase.
+func (p *Purchase) RemindAbandonedCarts(ctx context.Context) error {
+	now := time.Now().UTC()
+	carts, err := p.Cart.GetAbandoned(ctx,
+		now.Add(-config.CartAbandonedAfter), now.Add(-config.CartReminderWindow), reminderBatch)
+	if err != nil {
+		return err
+	}
+	var sent int
+	for _, cart := range carts {
+		// the reminder is recorded before the email is queued so that a
+		// failing run cannot remind the same cart twice, a cart that fails
+		// is left to the next run
+		reminderID, err := p.Reminder.Create(ctx, entity.CartReminder{
+			UserID:        cart.UserID,
+			Items:         cart.Items,
+			CartUpdatedAt: cart.UpdatedAt,
+		})
+		if err != nil {
+			logger.Error(fmt.Sprintf("abandoned carts: cannot record the reminder of user %d: %v", cart.UserID, err))
+			continue
+		}
+		if err := p.Worker.Exec(ctx, queue.CartQueue,
+			worker.NewTask(tasks.PurchaseSendCartReminder, dtos.CartReminder{UserID: cart.UserID}),
+		); err != nil {
+			logger.Error(fmt.Sprintf("abandoned carts: cannot queue the reminder of user %d: %v", cart.UserID, err))
+			if err := p.Reminder.Delete(ctx, reminderID); err != nil {
+				logger.Error(fmt.Sprintf("abandoned carts: cannot delete reminder %d: %v", reminderID, err))
+			}
+			continue
+		}
+		sent++
+	}
+	logger.Info(fmt.Sprintf("abandoned carts: %d found, %d reminders queued", len(carts), sent))
+	return nil
+}
+
+// SendCartReminder implements IPurchase.
+func (p *Purchase) SendCartReminder(ctx context.Context, userID int64) error {
+	user, err := p.User.GetByID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	// the user may have opted out since the reminder was queued
+	if user.CartReminderOptOut {
+		return nil
+	}
+	carts, err := p.Cart.GetCartInfo(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if len(carts) == 0 {
+		return nil
+	}
+	items := make([]components.CartReminderItem, 0, len(carts))
+	for _, cart := range carts {
+		items = append(items, components.CartReminderItem{
+			Name:     cart.Name,
+			Color:    cart.Color,
+			Quantity: cart.Quantity,
+			Price:    cart.InventoryPrice,
+			Currency: cart.CurrencyCode,
+			Image:    strings.Split(cart.InventoryImage, ",")[0],
+		})
+	}
+	customer := strings.TrimSpace(user.FirstName + " " + user.LastName)
+	return mail.New().SendCartReminder(user.Email, customer, items, config.FeHomepage+"/cart")
+}
+
+// CartReminderStats implements IPurchase.
+func (p *Purchase) CartReminderStats(ctx context.Context) (*dtos.CartReminderStats, error) {
+	stats, err := p.Reminder.Stats(ctx, time.Now().UTC().Add(-24*time.Hour))
+	if err != nil {
+		return nil, err
+	}
+	return &dtos.CartReminderStats{
+		Total:      stats.Total,
+		LastDay:    stats.Recent,
+		Items:      stats.Items,
+		LastSentAt: stats.LastSentAt,
+	}, nil
+}
@@ -0,0 +1,537 @@
+package purchase
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"log"
+	"mime/multipart"
+	"slices"
+	"strings"
+
+	"github.com/swclabs/swipex/internal/core/domain/dtos"
+	"github.com/swclabs/swipex/internal/core/domain/entity"
+	"github.com/swclabs/swipex/internal/core/domain/enum"
+	"github.com/swclabs/swipex/internal/core/repos/inventories"
+	"github.com/swclabs/swipex/internal/core/repos/orders"
+	"github.com/swclabs/swipex/internal/core/repos/returns"
+	"github.com/swclabs/swipex/pkg/infra/db"
+	"github.com/swclabs/swipex/pkg/utils"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+)
+
+// RequestReturn implements IPurchase.
+func (p *Purchase) RequestReturn(
+	ctx context.Context, userID int64, req dtos.ReturnRequest, photos []*multipart.FileHeader) (int64, error) {
+	urls := []string{}
+	for _, photo := range photos {
+		url, err := p.Blob.UploadFile(ctx, photo)
+		if err != nil {
+			return 0, err
+		}
+		urls = append(urls, url)
+	}
+
+	tx, err := db.NewTx(ctx)
+	if err != nil {
+		return 0, err
+	}
+	var (
+		orderRepo  = orders.New(tx)
+		returnRepo = returns.New(tx)
+	)
+	order, err := orderRepo.GetByUUIDForUpdate(ctx, req.OrderCode)
+	if err != nil {
+		if errTx := tx.Rollback(ctx); errTx != nil {
+			log.Fatal(errTx)
+		}
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, ErrOrderNotFound
+		}
+		return 0, err
+	}
+	if order.UserID != userID {
+		if errTx := tx.Rollback(ctx); errTx != nil {
+			log.Fatal(errTx)
+		}
+		return 0, ErrOrderNotFound
+	}
+	if order.Status != enum.OrderDelivered.String() {
+		if errTx := tx.Rollback(ctx); errTx != nil {
+			log.Fatal(errTx)
+		}
+		return 0, ErrReturnNotAllowed
+	}
+
+	if err := p.checkReturnable(ctx, orderRepo, returnRepo, order.ID, req.Items); err != nil {
+		if errTx := tx.Rollback(ctx); errTx != nil {
+			log.Fatal(errTx)
+		}
+		return 0, err
+	}
+
+	returnID, err := returnRepo.Create(ctx, entity.Return{
+		OrderID: order.ID,
+		UserID:  userID,
+		Status:  enum.ReturnRequested.String(),
+		Reason:  req.Reason,
+		Photos:  strings.Join(urls, ","),
+	})
+	if err != nil {
+		if errTx := tx.Rollback(ctx); errTx != nil {
+			log.Fatal(errTx)
+		}
+		return 0, err
+	}
+	for _, item := range req.Items {
+		if err := returnRepo.InsertItem(ctx, entity.ReturnItem{
+			ReturnID:         returnID,
+			ProductInOrderID: item.ItemID,
+			Quantity:         item.Quantity,
+		}); err != nil {
+			if errTx := tx.Rollback(ctx); errTx != nil {
+				log.Fatal(errTx)
+			}
+			return 0, err
+		}
+	}
+	return returnID, tx.Commit(ctx)
+}
+
+// GetReturns implements IPurchase.
+func (p *Purchase) GetReturns(ctx context.Context, userID int64, limit int) ([]dtos.Return, error) {
+	rets, err := p.Return.GetByUserID(ctx, userID, limit)
+	if err != nil {
+		return nil, err
+	}
+	return p.getListReturn(ctx, rets)
+}
+
+// GetReturnsByAdmin implements IPurchase.
+func (p *Purchase) GetReturnsByAdmin(ctx context.Context, status enum.ReturnStatus, limit int) ([]dtos.Return, error) {
+	rets, err := p.Return.GetLimit(ctx, status.String(), limit)
+	if err != nil {
+		return nil, err
+	}
+	return p.getListReturn(ctx, rets)
+}
+
+// ApproveReturn implements IPurchase.
+func (p *Purchase) ApproveReturn(
+	ctx context.Context, returnID int64, actor string, decision dtos.ReturnDecision) error {
+	tx, err := p.begin(ctx)
+	if err != nil {
+		return err
+	}
+	var (
+		orderRepo     = tx.Order
+		returnRepo    = tx.Return
+		refundRepo    = tx.Refund
+		inventoryRepo = tx.Inventory
+	)
+	ret, err := p.lockReturn(ctx, returnRepo, returnID)
+	if err != nil {
+		if errTx := tx.Rollback(ctx); errTx != nil {
+			log.Fatal(errTx)
+		}
+		return err
+	}
+	if ret.Status != enum.ReturnRequested.String() {
+		if errTx := tx.Rollback(ctx); errTx != nil {
+			log.Fatal(errTx)
+		}
+		return &ReturnStateError{ReturnID: ret.ID, Status: enum.ReturnStatus(ret.Status), Action: "approved"}
+	}
+
+	order, err := orderRepo.GetByID(ctx, ret.OrderID)
+	if err != nil {
+		if errTx := tx.Rollback(ctx); errTx != nil {
+			log.Fatal(errTx)
+		}
+		return err
+	}
+	lines, err := orderRepo.GetProductByOrderID(ctx, order.ID)
+	if err != nil {
+		if errTx := tx.Rollback(ctx); errTx != nil {
+			log.Fatal(errTx)
+		}
+		return err
+	}
+	items, err := returnRepo.GetItems(ctx, ret.ID)
+	if err != nil {
+		if errTx := tx.Rollback(ctx); errTx != nil {
+			log.Fatal(errTx)
+		}
+		return err
+	}
+
+	refund := entity.Refund{
+		OrderID:      order.ID,
+		ReturnID:     &ret.ID,
+		Amount:       refundAmount(order, lines, items),
+		CurrencyCode: lines[0].CurrencyCode,
+		Status:       enum.RefundPending.String(),
+		Reason:       ret.Reason,
+	}
+	// a refund to store credit is paid at once, without the payment provider
+	if decision.StoreCredit && refund.Amount.IsPositive() {
+		refund.Status = enum.RefundSucceeded.String()
+		if err := p.creditWallet(ctx, tx.Credit, ret.UserID, &order.ID,
+			baseAmount(order, refund.Amount), CreditRefund, actor, ret.Reason); err != nil {
+			if errTx := tx.Rollback(ctx); errTx != nil {
+				log.Fatal(errTx)
+			}
+			return err
+		}
+	}
+	if _, err := refundRepo.Create(ctx, refund); err != nil {
+		if errTx := tx.Rollback(ctx); errTx != nil {
+			log.Fatal(errTx)
+		}
+		return err
+	}
+
+	// the points earned on what comes back are taken back
+	if err := p.reversePoints(ctx, tx.Loyalty, order, refund.Amount); err != nil {
+		if errTx := tx.Rollback(ctx); errTx != nil {
+			log.Fatal(errTx)
+		}
+		return err
+	}
+
+	if !decision.SkipRestock {
+		if _, err := p.restockReturn(ctx, returnRepo, inventoryRepo, lines, items); err != nil {
+			if errTx := tx.Rollback(ctx); errTx != nil {
+				log.Fatal(errTx)
+			}
+			return err
+		}
+	}
+
+	if err := returnRepo.UpdateStatus(ctx, ret.ID, enum.ReturnApproved.String(), decision.Note); err != nil {
+		if errTx := tx.Rollback(ctx); errTx != nil {
+			log.Fatal(errTx)
+		}
+		return err
+	}
+
+	// the whole order came back, so it leaves the delivered state
+	returned, err := p.fullyReturned(ctx, returnRepo, lines)
+	if err != nil {
+		if errTx := tx.Rollback(ctx); errTx != nil {
+			log.Fatal(errTx)
+		}
+		return err
+	}
+	if returned && enum.OrderStatus(order.Status).CanTransitionTo(enum.OrderReturned) {
+		if err := p.transitionOrder(ctx, orderRepo, inventoryRepo,
+			order.UUID, enum.OrderReturned, actor, ret.Reason); err != nil {
+			if errTx := tx.Rollback(ctx); errTx != nil {
+				log.Fatal(errTx)
+			}
+			return err
+		}
+	}
+	return tx.Commit(ctx)
+}
+
+// RejectReturn implements IPurchase.
+func (p *Purchase) RejectReturn(ctx context.Context, returnID int64, note string) error {
+	tx, err := p.begin(ctx)
+	if err != nil {
+		return err
+	}
+	var returnRepo = tx.Return
+	ret, err := p.lockReturn(ctx, returnRepo, returnID)
+	if err != nil {
+		if errTx := tx.Rollback(ctx); errTx != nil {
+			log.Fatal(errTx)
+		}
+		return err
+	}
+	if ret.Status != enum.ReturnRequested.String() {
+		if errTx := tx.Rollback(ctx); errTx != nil {
+			log.Fatal(errTx)
+		}
+		return &ReturnStateError{ReturnID: ret.ID, Status: enum.ReturnStatus(ret.Status), Action: "rejected"}
+	}
+	if err := returnRepo.UpdateStatus(ctx, ret.ID, enum.ReturnRejected.String(), note); err != nil {
+		if errTx := tx.Rollback(ctx); errTx != nil {
+			log.Fatal(errTx)
+		}
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// ReceiveReturn implements IPurchase.
+func (p *Purchase) ReceiveReturn(ctx context.Context, returnID int64) error {
+	tx, err := p.begin(ctx)
+	if err != nil {
+		return err
+	}
+	var returnRepo = tx.Return
+	ret, err := p.lockReturn(ctx, returnRepo, returnID)
+	if err != nil {
+		if errTx := tx.Rollback(ctx); errTx != nil {
+			log.Fatal(errTx)
+		}
+		return err
+	}
+	if ret.Status == enum.ReturnRejected.String() || ret.ReceivedAt != nil {
+		if errTx := tx.Rollback(ctx); errTx != nil {
+			log.Fatal(errTx)
+		}
+		action := "received"
+		if ret.ReceivedAt != nil {
+			action = "received again"
+		}
+		return &ReturnStateError{ReturnID: ret.ID, Status: enum.ReturnStatus(ret.Status), Action: action}
+	}
+	if err := returnRepo.MarkReceived(ctx, ret.ID); err != nil {
+		if errTx := tx.Rollback(ctx); errTx != nil {
+			log.Fatal(errTx)
+		}
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// RestockReturn implements IPurchase.
+func (p *Purchase) RestockReturn(ctx context.Context, returnID int64) error {
+	tx, err := p.begin(ctx)
+	if err != nil {
+		return err
+	}
+	var (
+		orderRepo     = tx.Order
+		returnRepo    = tx.Return
+		inventoryRepo = tx.Inventory
+	)
+	ret, err := p.lockReturn(ctx, returnRepo, returnID)
+	if err != nil {
+		if errTx := tx.Rollback(ctx); errTx != nil {
+			log.Fatal(errTx)
+		}
+		return err
+	}
+	if ret.Status != enum.ReturnApproved.String() {
+		if errTx := tx.Rollback(ctx); errTx != nil {
+			log.Fatal(errTx)
+		}
+		return &ReturnStateError{ReturnID: ret.ID, Status: enum.ReturnStatus(ret.Status), Action: "restocked"}
+	}
+	lines, err := orderRepo.GetProductByOrderID(ctx, ret.OrderID)
+	if err != nil {
+		if errTx := tx.Rollback(ctx); errTx != nil {
+			log.Fatal(errTx)
+		}
+		return err
+	}
+	items, err := returnRepo.GetItems(ctx, ret.ID)
+	if err != nil {
+		if errTx := tx.Rollback(ctx); errTx != nil {
+			log.Fatal(errTx)
+		}
+		return err
+	}
+	restocked, err := p.restockReturn(ctx, returnRepo, inventoryRepo, lines, items)
+	if err != nil {
+		if errTx := tx.Rollback(ctx); errTx != nil {
+			log.Fatal(errTx)
+		}
+		return err
+	}
+	if restocked == 0 {
+		if errTx := tx.Rollback(ctx); errTx != nil {
+			log.Fatal(errTx)
+		}
+		return &ReturnStateError{ReturnID: ret.ID, Status: enum.ReturnStatus(ret.Status), Action: "restocked again"}
+	}
+	return tx.Commit(ctx)
+}
+
+// lockReturn loads a return request and locks it until the transaction ends.
+func (p *Purchase) lockReturn(ctx context.Context, returnRepo returns.IReturns, returnID int64) (*entity.Return, error) {
+	ret, err := returnRepo.GetByIDForUpdate(ctx, returnID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, ErrReturnNotFound
+		}
+		return nil, err
+	}
+	return ret, nil
+}
+
+// checkReturnable makes sure every requested line belongs to the order and
+// that the customer does not send back more units than were bought, counting
+// the units of earlier returns that are still open or approved.
+func (p *Purchase) checkReturnable(
+	ctx context.Context,
+	orderRepo orders.IOrders,
+	returnRepo returns.IReturns,
+	orderID int64,
+	items []dtos.ReturnItem,
+) error {
+	lines, err := orderRepo.GetProductByOrderID(ctx, orderID)
+	if err != nil {
+		return err
+	}
+	bought := map[int64]int64{}
+	for _, line := range lines {
+		bought[line.ID] = line.Quantity
+	}
+
+	requested := map[int64]int64{}
+	for _, item := range items {
+		requested[item.ItemID] += item.Quantity
+	}
+	for itemID, quantity := range requested {
+		if _, ok := bought[itemID]; !ok {
+			return &ReturnQuantityError{ItemID: itemID, Requested: quantity}
+		}
+		returned, err := returnRepo.GetReturnedQuantity(ctx, itemID,
+			[]string{enum.ReturnRequested.String(), enum.ReturnApproved.String()})
+		if err != nil {
+			return err
+		}
+		if returnable := bought[itemID] - returned; quantity > returnable {
+			return &ReturnQuantityError{ItemID: itemID, Requested: quantity, Returnable: returnable}
+		}
+	}
+	return nil
+}
+
+// restockReturn puts the units of a return back into available stock and
+// reports how many lines were restocked. Lines restocked before are skipped.
+func (p *Purchase) restockReturn(
+	ctx context.Context,
+	returnRepo returns.IReturns,
+	inventory inventories.IInventories,
+	lines []entity.ProductInOrder,
+	items []entity.ReturnItem,
+) (int, error) {
+	inventoryOf := map[int64]int64{}
+	for _, line := range lines {
+		inventoryOf[line.ID] = line.InventoryID
+	}
+	// restock in inventory id order, like reserveStock, to avoid deadlocks
+	items = slices.Clone(items)
+	slices.SortFunc(items, func(a, b entity.ReturnItem) int {
+		return cmp.Compare(inventoryOf[a.ProductInOrderID], inventoryOf[b.ProductInOrderID])
+	})
+
+	restocked := 0
+	for _, item := range items {
+		if item.Restocked {
+			continue
+		}
+		if _, err := inventory.Release(ctx, inventoryOf[item.ProductInOrderID], item.Quantity); err != nil {
+			return 0, err
+		}
+		if err := returnRepo.MarkItemRestocked(ctx, item.ID); err != nil {
+			return 0, err
+		}
+		restocked++
+	}
+	return restocked, nil
+}
+
+// fullyReturned reports whether every unit of the order lines belongs to an
+// approved return.
+func (p *Purchase) fullyReturned(
+	ctx context.Context, returnRepo returns.IReturns, lines []entity.ProductInOrder) (bool, error) {
+	for _, line := range lines {
+		returned, err := returnRepo.GetReturnedQuantity(ctx, line.ID, []string{enum.ReturnApproved.String()})
+		if err != nil {
+			return false, err
+		}
+		if returned < line.Quantity {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// refundAmount returns the money owed for the returned units. Line totals
+// are taken before the coupon discount, so the amount is scaled by the ratio
+// the customer actually paid for the order.
+func refundAmount(order *entity.Order, lines []entity.ProductInOrder, items []entity.ReturnItem) decimal.Decimal {
+	var (
+		byID       = map[int64]entity.ProductInOrder{}
+		linesTotal = decimal.Zero
+		amount     = decimal.Zero
+	)
+	for _, line := range lines {
+		byID[line.ID] = line
+		linesTotal = linesTotal.Add(line.TotalAmount)
+	}
+	for _, item := range items {
+		line := byID[item.ProductInOrderID]
+		amount = amount.Add(line.TotalAmount.
+			Mul(decimal.NewFromInt(item.Quantity)).
+			Div(decimal.NewFromInt(line.Quantity)))
+	}
+	if linesTotal.IsZero() {
+		return amount
+	}
+	return amount.Mul(order.TotalAmount).Div(linesTotal).Round(2)
+}
+
+// getListReturn converts return requests into their response form.
+func (p *Purchase) getListReturn(ctx context.Context, rets []entity.Return) ([]dtos.Return, error) {
+	list := []dtos.Return{}
+	for _, ret := range rets {
+		order, err := p.Order.GetByID(ctx, ret.OrderID)
+		if err != nil {
+			return nil, err
+		}
+		lines, err := p.Order.GetProductByOrderID(ctx, ret.OrderID)
+		if err != nil {
+			return nil, err
+		}
+		inventoryOf := map[int64]int64{}
+		for _, line := range lines {
+			inventoryOf[line.ID] = line.InventoryID
+		}
+		items, err := p.Return.GetItems(ctx, ret.ID)
+		if err != nil {
+			return nil, err
+		}
+		info := dtos.Return{
+			ID:        ret.ID,
+			OrderCode: order.UUID,
+			Status:    ret.Status,
+			Reason:    ret.Reason,
+			Note:      ret.Note,
+			Photos:    []string{},
+			Items:     []dtos.ReturnItemInfo{},
+			CreatedAt: utils.HanoiTimezone(ret.CreatedAt),
+		}
+		if ret.Photos != "" {
+			info.Photos = strings.Split(ret.Photos, ",")
+		}
+		if ret.ReceivedAt != nil {
+			info.ReceivedAt = utils.HanoiTimezone(*ret.ReceivedAt)
+		}
+		for _, item := range items {
+			info.Items = append(info.Items, dtos.ReturnItemInfo{
+				ItemID:      item.ProductInOrderID,
+				InventoryID: inventoryOf[item.ProductInOrderID],
+				Quantity:    item.Quantity,
+				Restocked:   item.Restocked,
+			})
+		}
+		refund, err := p.Refund.GetByReturnID(ctx, ret.ID)
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			return nil, err
+		}
+		if refund != nil {
+			info.RefundAmount = refund.Amount.String()
+		}
+		list = append(list, info)
+	}
+	return list, nil
+}
@@ -0,0 +1,180 @@
+package purchase
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/swclabs/swipex/internal/core/domain/dtos"
+	"github.com/swclabs/swipex/internal/core/domain/enum"
+	"github.com/swclabs/swipex/internal/core/domain/model"
+	"github.com/swclabs/swipex/pkg/utils"
+
+	"github.com/shopspring/decimal"
+)
+
+// defaultOrderPage is the number of orders listed when no limit is given
+const defaultOrderPage = 20
+
+// vietnam is the time zone of the days given in order searches
+var vietnam = time.FixedZone("GMT+7", 7*60*60)
+
+// SearchOrders implements IPurchase.
+func (p *Purchase) SearchOrders(ctx context.Context, search dtos.OrderSearch) (*dtos.OrderPage, error) {
+	filter, err := orderFilter(search)
+	if err != nil {
+		return nil, err
+	}
+	limit := filter.Limit
+	// one more order tells whether there is a next page
+	filter.Limit++
+	orders, err := p.Order.Search(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &dtos.OrderPage{Orders: []dtos.OrderSummary{}}
+	if len(orders) > limit {
+		orders = orders[:limit]
+		page.NextCursor = orderCursor(filter.Sort, orders[limit-1])
+	}
+	for _, order := range orders {
+		page.Orders = append(page.Orders, dtos.OrderSummary{
+			Code:          order.UUID,
+			Time:          utils.HanoiTimezone(order.Time),
+			Status:        order.Status,
+			PaymentMethod: order.PaymentMethod,
+			Customer: dtos.OrderFormCustomer{
+				Email:     order.Email,
+				FirstName: order.FirstName,
+				LastName:  order.LastName,
+				Phone:     order.PhoneNumber,
+			},
+			CouponCode:  order.CouponCode,
+			Items:       order.Items,
+			Subtotal:    order.Subtotal.String(),
+			Discount:    order.DiscountAmount.String(),
+			TaxAmount:   order.TaxAmount.String(),
+			ShippingFee: order.ShippingFee.String(),
+			TotalAmount: order.TotalAmount.String(),
+		})
+	}
+	return page, nil
+}
+
+// orderFilter parses the filters of an order search
+func orderFilter(search dtos.OrderSearch) (model.OrderFilter, error) {
+	filter := model.OrderFilter{
+		Status:        search.Status,
+		PaymentMethod: search.PaymentMethod,
+		Email:         strings.TrimSpace(search.Email),
+		Phone:         strings.TrimSpace(search.Phone),
+		CouponCode:    search.CouponCode,
+		Sort:          search.Sort,
+		Limit:         search.Limit,
+	}
+	if filter.Sort == "" {
+		filter.Sort = "-time"
+	}
+	if filter.Limit == 0 {
+		filter.Limit = defaultOrderPage
+	}
+	if filter.Status != "" {
+		var status enum.OrderStatus
+		if err := status.Load(filter.Status); err != nil {
+			return filter, fmt.Errorf("%w: %w", ErrInvalidOrderSearch, err)
+		}
+	}
+
+	var err error
+	if search.From != "" {
+		if filter.From, _, err = parseSearchTime(search.From); err != nil {
+			return filter, err
+		}
+	}
+	if search.To != "" {
+		var day bool
+		if filter.To, day, err = parseSearchTime(search.To); err != nil {
+			return filter, err
+		}
+		// a day is included up to its end
+		if day {
+			filter.To = filter.To.AddDate(0, 0, 1)
+		}
+	}
+	for _, bound := range []struct {
+		value string
+		dst   *decimal.NullDecimal
+	}{{search.MinTotal, &filter.MinTotal}, {search.MaxTotal, &filter.MaxTotal}} {
+		if bound.value == "" {
+			continue
+		}
+		total, err := decimal.NewFromString(bound.value)
+		if err != nil {
+			return filter, fmt.Errorf("%w: %w", ErrInvalidOrderSearch, err)
+		}
+		*bound.dst = decimal.NewNullDecimal(total)
+	}
+
+	if search.Cursor != "" {
+		if err := parseOrderCursor(search.Cursor, &filter); err != nil {
+			return filter, err
+		}
+	}
+	return filter, nil
+}
+
+// parseSearchTime parses an RFC 3339 time or a day in Vietnam time, day
+// reports which one it was.
+func parseSearchTime(s string) (t time.Time, day bool, err error) {
+	if t, err = time.Parse(time.RFC3339, s); err == nil {
+		return t, false, nil
+	}
+	if t, err = time.ParseInLocation(time.DateOnly, s, vietnam); err == nil {
+		return t, true, nil
+	}
+	return t, false, fmt.Errorf("%w: time %q is neither RFC 3339 nor a day", ErrInvalidOrderSearch, s)
+}
+
+// orderCursor encodes the sort key of the last order of a page. The sort is
+// part of the cursor so that it cannot be replayed with another sort.
+func orderCursor(sort string, order model.OrderSummary) string {
+	value := order.Time.Format(time.RFC3339Nano)
+	if strings.TrimPrefix(sort, "-") == "total" {
+		value = order.TotalAmount.String()
+	}
+	return base64.RawURLEncoding.EncodeToString(
+		[]byte(sort + "|" + value + "|" + strconv.FormatInt(order.ID, 10)))
+}
+
+// parseOrderCursor sets the After fields of filter from a cursor
+func parseOrderCursor(cursor string, filter *model.OrderFilter) error {
+	invalid := fmt.Errorf("%w: malformed cursor", ErrInvalidOrderSearch)
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return invalid
+	}
+	parts := strings.Split(string(raw), "|")
+	if len(parts) != 3 {
+		return invalid
+	}
+	if parts[0] != filter.Sort {
+		return fmt.Errorf("%w: cursor is for sort %q", ErrInvalidOrderSearch, parts[0])
+	}
+	if filter.AfterID, err = strconv.ParseInt(parts[2], 10, 64); err != nil {
+		return invalid
+	}
+	if strings.TrimPrefix(filter.Sort, "-") == "total" {
+		if filter.AfterTotal, err = decimal.NewFromString(parts[1]); err != nil {
+			return invalid
+		}
+		return nil
+	}
+	if filter.AfterTime, err = time.Parse(time.RFC3339Nano, parts[1]); err != nil {
+		return invalid
+	}
+	return nil
+}
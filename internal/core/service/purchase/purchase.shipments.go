@@ -0,0 +1,347 @@
+package purchase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/swclabs/swipex/internal/config"
+	"github.com/swclabs/swipex/internal/core/domain/dtos"
+	"github.com/swclabs/swipex/internal/core/domain/entity"
+	"github.com/swclabs/swipex/internal/core/domain/enum"
+	"github.com/swclabs/swipex/internal/core/domain/model"
+	"github.com/swclabs/swipex/internal/core/repos/deliveries"
+	"github.com/swclabs/swipex/internal/core/repos/inventories"
+	"github.com/swclabs/swipex/internal/core/repos/orders"
+	"github.com/swclabs/swipex/internal/core/repos/shipments"
+	"github.com/swclabs/swipex/internal/core/tasks"
+	"github.com/swclabs/swipex/internal/workers/queue"
+	"github.com/swclabs/swipex/pkg/lib/worker"
+	"github.com/swclabs/swipex/pkg/utils"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// CreateShipment implements IPurchase.
+func (p *Purchase) CreateShipment(
+	ctx context.Context, actor string, orderCode string, form dtos.ShipmentForm) (int64, error) {
+	tx, err := p.begin(ctx)
+	if err != nil {
+		return 0, err
+	}
+	shipmentID, err := p.createShipment(ctx, tx.Order, tx.Delivery, tx.Shipment, actor, orderCode, form)
+	if err != nil {
+		if errTx := tx.Rollback(ctx); errTx != nil {
+			log.Fatal(errTx)
+		}
+		return 0, err
+	}
+	return shipmentID, tx.Commit(ctx)
+}
+
+func (p *Purchase) createShipment(
+	ctx context.Context,
+	orderRepo orders.IOrders,
+	deliveryRepo deliveries.IDeliveries,
+	shipmentRepo shipments.IShipments,
+	actor string,
+	orderCode string,
+	form dtos.ShipmentForm,
+) (int64, error) {
+	order, err := orderRepo.GetByUUIDForUpdate(ctx, orderCode)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return 0, ErrOrderNotFound
+		}
+		return 0, err
+	}
+	// the units are picked while the order is packed
+	if order.Status != enum.OrderPacking.String() && order.Status != enum.OrderPartiallyShipped.String() {
+		return 0, fmt.Errorf("%w: the order is %s", ErrShipmentNotAllowed, order.Status)
+	}
+
+	lines, err := orderRepo.GetProductByOrderID(ctx, order.ID)
+	if err != nil {
+		return 0, err
+	}
+	shipped, err := shipmentRepo.GetItemsByOrderID(ctx, order.ID)
+	if err != nil {
+		return 0, err
+	}
+	// backordered units wait for stock, they go in a later shipment
+	left := make(map[int64]int64, len(lines))
+	for _, line := range lines {
+		left[line.ID] = line.Quantity - line.Backordered
+	}
+	for _, item := range shipped {
+		left[item.ItemID] -= item.Quantity
+	}
+	requested := make(map[int64]int64, len(form.Items))
+	for _, item := range form.Items {
+		requested[item.ItemID] += item.Quantity
+	}
+	for itemID, quantity := range requested {
+		available, ok := left[itemID]
+		if !ok {
+			return 0, fmt.Errorf("%w: item %d is not in order %s", ErrInvalidShipment, itemID, orderCode)
+		}
+		if quantity > available {
+			return 0, fmt.Errorf("%w: only %d units of item %d can be shipped", ErrInvalidShipment, max(available, 0), itemID)
+		}
+	}
+
+	delivery, err := deliveryRepo.GetByID(ctx, order.DeliveryID)
+	if err != nil {
+		return 0, err
+	}
+	method := form.Method
+	if method == "" {
+		method = delivery.Method
+	}
+	deliveryID, err := deliveryRepo.Create(ctx, entity.Delivery{
+		UserID:    delivery.UserID,
+		AddressID: delivery.AddressID,
+		Status:    enum.ShipmentPending.String(),
+		Method:    method,
+		Note:      form.Note,
+		SentDate:  time.Now().UTC(),
+	})
+	if err != nil {
+		return 0, err
+	}
+	shipment := entity.Shipment{
+		OrderID:    order.ID,
+		DeliveryID: deliveryID,
+		Status:     enum.ShipmentPending.String(),
+		Actor:      actor,
+	}
+	if form.CarrierOrderCode != "" {
+		shipment.CarrierOrderCode = &form.CarrierOrderCode
+	}
+	shipmentID, err := shipmentRepo.Create(ctx, shipment)
+	if err != nil {
+		return 0, err
+	}
+	for itemID, quantity := range requested {
+		if err := shipmentRepo.InsertItem(ctx, entity.ShipmentItem{
+			ShipmentID: shipmentID,
+			ItemID:     itemID,
+			Quantity:   quantity,
+		}); err != nil {
+			return 0, err
+		}
+	}
+	return shipmentID, nil
+}
+
+// UpdateShipment implements IPurchase.
+func (p *Purchase) UpdateShipment(ctx context.Context, actor string, shipmentID int64, update dtos.ShipmentUpdate) error {
+	tx, err := p.begin(ctx)
+	if err != nil {
+		return err
+	}
+	var (
+		orderRepo     = tx.Order
+		inventoryRepo = tx.Inventory
+		shipmentRepo  = tx.Shipment
+	)
+	order, status, err := p.updateShipment(ctx, orderRepo, inventoryRepo, shipmentRepo, actor, shipmentID, update)
+	if err != nil {
+		if errTx := tx.Rollback(ctx); errTx != nil {
+			log.Fatal(errTx)
+		}
+		return err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+	if status == enum.OrderDelivered {
+		return p.delivered(ctx, order.UUID)
+	}
+	return nil
+}
+
+// updateShipment moves a shipment to its next status and the order to the
+// status derived from all its shipments. It returns the new status of the
+// order, empty when the order keeps its status.
+func (p *Purchase) updateShipment(
+	ctx context.Context,
+	orderRepo orders.IOrders,
+	inventory inventories.IInventories,
+	shipmentRepo shipments.IShipments,
+	actor string,
+	shipmentID int64,
+	update dtos.ShipmentUpdate,
+) (*entity.Order, enum.OrderStatus, error) {
+	shipment, err := shipmentRepo.GetByIDForUpdate(ctx, shipmentID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, "", ErrShipmentNotFound
+		}
+		return nil, "", err
+	}
+	order, err := orderRepo.GetByID(ctx, shipment.OrderID)
+	if err != nil {
+		return nil, "", err
+	}
+	if update.CarrierOrderCode != "" {
+		if err := shipmentRepo.SetCarrierOrderCode(ctx, shipment.ID, update.CarrierOrderCode); err != nil {
+			return nil, "", err
+		}
+	}
+	if update.Status == "" {
+		return order, "", nil
+	}
+
+	var current, next enum.ShipmentStatus
+	if err := current.Load(shipment.Status); err != nil {
+		return nil, "", err
+	}
+	if err := next.Load(update.Status); err != nil {
+		return nil, "", err
+	}
+	if !current.CanTransitionTo(next) {
+		return nil, "", fmt.Errorf("%w: shipment cannot move from %s to %s", ErrShipmentNotAllowed, current, next)
+	}
+	if err := shipmentRepo.UpdateStatus(ctx, shipment.ID, next.String(), time.Now().UTC()); err != nil {
+		return nil, "", err
+	}
+
+	lines, err := orderRepo.GetProductByOrderID(ctx, order.ID)
+	if err != nil {
+		return nil, "", err
+	}
+	all, err := shipmentRepo.GetByOrderID(ctx, order.ID)
+	if err != nil {
+		return nil, "", err
+	}
+	items, err := shipmentRepo.GetItemsByOrderID(ctx, order.ID)
+	if err != nil {
+		return nil, "", err
+	}
+	// an order moved by hand past the derived status is left alone
+	var status enum.OrderStatus
+	if err := status.Load(order.Status); err != nil {
+		return nil, "", err
+	}
+	derived := shippedStatus(lines, all, items)
+	if derived == "" || !status.CanTransitionTo(derived) {
+		return order, "", nil
+	}
+	if err := p.transitionOrder(ctx, orderRepo, inventory, order.UUID, derived, actor,
+		fmt.Sprintf("shipment %d is %s", shipment.ID, next)); err != nil {
+		return nil, "", err
+	}
+	return order, derived, nil
+}
+
+// shippedStatus derives the status of an order from its shipments: shipping
+// once all units left the warehouse, delivered once all shipments arrived,
+// partially shipped while some units are still in the warehouse. It is
+// empty before the first shipment leaves.
+func shippedStatus(lines []entity.ProductInOrder, all []entity.Shipment, items []entity.ShipmentItem) enum.OrderStatus {
+	var units, out int64
+	for _, line := range lines {
+		units += line.Quantity
+	}
+	statuses := make(map[int64]enum.ShipmentStatus, len(all))
+	delivered := true
+	for _, shipment := range all {
+		statuses[shipment.ID] = enum.ShipmentStatus(shipment.Status)
+		if shipment.Status != enum.ShipmentDelivered.String() {
+			delivered = false
+		}
+	}
+	for _, item := range items {
+		if statuses[item.ShipmentID].Shipped() {
+			out += item.Quantity
+		}
+	}
+	switch {
+	case out == 0:
+		return ""
+	case out < units:
+		return enum.OrderPartiallyShipped
+	case delivered:
+		return enum.OrderDelivered
+	default:
+		return enum.OrderShipping
+	}
+}
+
+// delivered queues the work done once an order is delivered: the loyalty
+// points it earns and its invoice.
+func (p *Purchase) delivered(ctx context.Context, orderCode string) error {
+	if err := p.Worker.Exec(ctx, queue.OrderQueue,
+		worker.NewTask(tasks.PurchaseEarnPoints, dtos.OrderStatus{
+			OrderCode: orderCode,
+			Status:    enum.OrderDelivered.String(),
+		}),
+	); err != nil {
+		return err
+	}
+	return p.Worker.Exec(ctx, queue.OrderQueue,
+		worker.NewTask(tasks.PurchaseGenerateInvoice, dtos.OrderStatus{
+			OrderCode: orderCode,
+			Status:    enum.OrderDelivered.String(),
+		}),
+	)
+}
+
+// orderShipments returns the shipments of an order with their tracking,
+// items are the lines of the order.
+func (p *Purchase) orderShipments(ctx context.Context, orderID int64, items []model.Order) ([]dtos.Shipment, error) {
+	all, err := p.Shipment.GetByOrderID(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	if len(all) == 0 {
+		return []dtos.Shipment{}, nil
+	}
+	shipped, err := p.Shipment.GetItemsByOrderID(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+	names := make(map[int64]string, len(items))
+	for _, item := range items {
+		names[item.ID] = item.Name
+	}
+	byShipment := make(map[int64][]dtos.ShipmentItem, len(all))
+	for _, item := range shipped {
+		byShipment[item.ShipmentID] = append(byShipment[item.ShipmentID], dtos.ShipmentItem{
+			ItemID:   item.ItemID,
+			Name:     names[item.ItemID],
+			Quantity: item.Quantity,
+		})
+	}
+
+	result := make([]dtos.Shipment, 0, len(all))
+	for _, shipment := range all {
+		delivery, err := p.Delivery.GetByID(ctx, shipment.DeliveryID)
+		if err != nil {
+			return nil, err
+		}
+		info := dtos.Shipment{
+			ID:        shipment.ID,
+			Status:    shipment.Status,
+			Method:    delivery.Method,
+			Note:      delivery.Note,
+			CreatedAt: utils.HanoiTimezone(shipment.CreatedAt),
+			Items:     byShipment[shipment.ID],
+		}
+		if shipment.CarrierOrderCode != nil {
+			info.CarrierOrderCode = *shipment.CarrierOrderCode
+			info.TrackingURL = fmt.Sprintf(config.DeliveryTrackingURL, *shipment.CarrierOrderCode)
+		}
+		if shipment.ShippedAt != nil {
+			info.ShippedAt = utils.HanoiTimezone(*shipment.ShippedAt)
+		}
+		if shipment.DeliveredAt != nil {
+			info.DeliveredAt = utils.HanoiTimezone(*shipment.DeliveredAt)
+		}
+		result = append(result, info)
+	}
+	return result, nil
+}
@@ -3,10 +3,14 @@ package purchase
 
 import (
 	"context"
+	"io"
+	"mime/multipart"
+	"time"
 
 	"github.com/swclabs/swipex/internal/config"
 	"github.com/swclabs/swipex/internal/core/domain/dtos"
 	"github.com/swclabs/swipex/internal/core/domain/entity"
+	"github.com/swclabs/swipex/internal/core/domain/enum"
 	"github.com/swclabs/swipex/internal/core/domain/x/ghn"
 	"github.com/swclabs/swipex/internal/workers/queue"
 	"github.com/swclabs/swipex/pkg/lib/worker"
@@ -33,13 +37,157 @@ func (t *Task) DeleteCoupon(ctx context.Context, code string) error {
 	return t.service.DeleteCoupon(ctx, code)
 }
 
+// ValidateCoupon implements IPurchase.
+func (t *Task) ValidateCoupon(ctx context.Context, userID int64, req dtos.CouponValidate) (*dtos.CouponPreview, error) {
+	return t.service.ValidateCoupon(ctx, userID, req)
+}
+
 // UpdateOrderStatus implements IPurchase.
-func (t *Task) UpdateOrderStatus(ctx context.Context, orderCode string, status string) error {
-	return t.service.UpdateOrderStatus(ctx, orderCode, status)
+func (t *Task) UpdateOrderStatus(
+	ctx context.Context, orderCode string, status enum.OrderStatus, actor string, reason string) error {
+	return t.service.UpdateOrderStatus(ctx, orderCode, status, actor, reason)
+}
+
+// CreateShipment implements IPurchase.
+func (t *Task) CreateShipment(
+	ctx context.Context, actor string, orderCode string, form dtos.ShipmentForm) (int64, error) {
+	return t.service.CreateShipment(ctx, actor, orderCode, form)
+}
+
+// UpdateShipment implements IPurchase.
+func (t *Task) UpdateShipment(ctx context.Context, actor string, shipmentID int64, update dtos.ShipmentUpdate) error {
+	return t.service.UpdateShipment(ctx, actor, shipmentID, update)
+}
+
+// CancelOrder implements IPurchase.
+func (t *Task) CancelOrder(ctx context.Context, userID int64, orderCode string, reason string) error {
+	return t.service.CancelOrder(ctx, userID, orderCode, reason)
+}
+
+// NotifyOrderCancelled implements IPurchase.
+func (t *Task) NotifyOrderCancelled(ctx context.Context, orderCode string, reason string) error {
+	return t.service.NotifyOrderCancelled(ctx, orderCode, reason)
+}
+
+// RemindAbandonedCarts implements IPurchase.
+func (t *Task) RemindAbandonedCarts(ctx context.Context) error {
+	return t.service.RemindAbandonedCarts(ctx)
+}
+
+// SendCartReminder implements IPurchase.
+func (t *Task) SendCartReminder(ctx context.Context, userID int64) error {
+	return t.service.SendCartReminder(ctx, userID)
+}
+
+// CartReminderStats implements IPurchase.
+func (t *Task) CartReminderStats(ctx context.Context) (*dtos.CartReminderStats, error) {
+	return t.service.CartReminderStats(ctx)
+}
+
+// RequestReturn implements IPurchase.
+func (t *Task) RequestReturn(
+	ctx context.Context, userID int64, req dtos.ReturnRequest, photos []*multipart.FileHeader) (int64, error) {
+	return t.service.RequestReturn(ctx, userID, req, photos)
+}
+
+// GetReturns implements IPurchase.
+func (t *Task) GetReturns(ctx context.Context, userID int64, limit int) ([]dtos.Return, error) {
+	return t.service.GetReturns(ctx, userID, limit)
+}
+
+// GetReturnsByAdmin implements IPurchase.
+func (t *Task) GetReturnsByAdmin(ctx context.Context, status enum.ReturnStatus, limit int) ([]dtos.Return, error) {
+	return t.service.GetReturnsByAdmin(ctx, status, limit)
+}
+
+// ApproveReturn implements IPurchase.
+func (t *Task) ApproveReturn(ctx context.Context, returnID int64, actor string, decision dtos.ReturnDecision) error {
+	return t.service.ApproveReturn(ctx, returnID, actor, decision)
+}
+
+// RejectReturn implements IPurchase.
+func (t *Task) RejectReturn(ctx context.Context, returnID int64, note string) error {
+	return t.service.RejectReturn(ctx, returnID, note)
+}
+
+// ReceiveReturn implements IPurchase.
+func (t *Task) ReceiveReturn(ctx context.Context, returnID int64) error {
+	return t.service.ReceiveReturn(ctx, returnID)
+}
+
+// RestockReturn implements IPurchase.
+func (t *Task) RestockReturn(ctx context.Context, returnID int64) error {
+	return t.service.RestockReturn(ctx, returnID)
+}
+
+// ExportOrders implements IPurchase.
+func (t *Task) ExportOrders(ctx context.Context, export dtos.OrderExport, w io.Writer) (int64, error) {
+	return t.service.ExportOrders(ctx, export, w)
+}
+
+// StartOrderExport implements IPurchase.
+func (t *Task) StartOrderExport(ctx context.Context, export dtos.OrderExport) (*dtos.OrderExportJob, error) {
+	return t.service.StartOrderExport(ctx, export)
+}
+
+// RunOrderExport implements IPurchase.
+func (t *Task) RunOrderExport(ctx context.Context, id string) error {
+	return t.service.RunOrderExport(ctx, id)
+}
+
+// ReceiveStock implements IPurchase.
+func (t *Task) ReceiveStock(ctx context.Context, receipt dtos.StockReceipt) error {
+	return t.service.ReceiveStock(ctx, receipt)
+}
+
+// CreateBundle implements IPurchase.
+func (t *Task) CreateBundle(ctx context.Context, bundle dtos.CreateBundle) (int64, error) {
+	return t.service.CreateBundle(ctx, bundle)
+}
+
+// UpdateBundle implements IPurchase.
+func (t *Task) UpdateBundle(ctx context.Context, bundleID int64, bundle dtos.CreateBundle) error {
+	return t.service.UpdateBundle(ctx, bundleID, bundle)
+}
+
+// DeleteBundle implements IPurchase.
+func (t *Task) DeleteBundle(ctx context.Context, bundleID int64) error {
+	return t.service.DeleteBundle(ctx, bundleID)
+}
+
+// GetBundle implements IPurchase.
+func (t *Task) GetBundle(ctx context.Context, bundleID int64) (*dtos.Bundle, error) {
+	return t.service.GetBundle(ctx, bundleID)
+}
+
+// GetBundles implements IPurchase.
+func (t *Task) GetBundles(ctx context.Context, status string, limit, page int) ([]dtos.Bundle, error) {
+	return t.service.GetBundles(ctx, status, limit, page)
+}
+
+// AddBundleToCart implements IPurchase.
+func (t *Task) AddBundleToCart(ctx context.Context, userID int64, bundle dtos.CartBundleDTO) error {
+	return t.service.AddBundleToCart(ctx, userID, bundle)
+}
+
+// UpdateCartBundle implements IPurchase.
+func (t *Task) UpdateCartBundle(ctx context.Context, userID int64, bundleID int64, quantity int64) error {
+	return t.service.UpdateCartBundle(ctx, userID, bundleID, quantity)
+}
+
+// DeleteBundleFromCart implements IPurchase.
+func (t *Task) DeleteBundleFromCart(ctx context.Context, userID int64, bundleID int64) error {
+	return t.service.DeleteBundleFromCart(ctx, userID, bundleID)
 }
 
-func (t *Task) GetUsersByAdmin(ctx context.Context, limit int) ([]dtos.OrderInfo, error) {
-	return t.service.GetUsersByAdmin(ctx, limit)
+// GetOrderExport implements IPurchase.
+func (t *Task) GetOrderExport(ctx context.Context, id string) (*dtos.OrderExportJob, error) {
+	return t.service.GetOrderExport(ctx, id)
+}
+
+// SearchOrders implements IPurchase.
+func (t *Task) SearchOrders(ctx context.Context, search dtos.OrderSearch) (*dtos.OrderPage, error) {
+	return t.service.SearchOrders(ctx, search)
 }
 
 // AddressDistrict implements IPurchase.
@@ -72,6 +220,11 @@ func (t *Task) GetOrderByCode(ctx context.Context, orderCode string) (*dtos.Orde
 	return t.service.GetOrderByCode(ctx, orderCode)
 }
 
+// CreateAdminOrder implements IPurchase.
+func (t *Task) CreateAdminOrder(ctx context.Context, caller string, order dtos.OrderForm) (string, error) {
+	return t.service.CreateAdminOrder(ctx, caller, order)
+}
+
 // CreateOrderForm implements IPurchase.
 func (t *Task) CreateOrderForm(ctx context.Context, order dtos.OrderForm) (string, error) {
 	return t.service.CreateOrderForm(ctx, order)
@@ -126,11 +279,123 @@ func (t *Task) DeleteItemFromCart(ctx context.Context, inventoryID int64, userID
 }
 
 // GetCart implements IPurchaseService.
-func (t *Task) GetCart(ctx context.Context, userID int64, limit int) (*dtos.Carts, error) {
-	return t.service.GetCart(ctx, userID, limit)
+func (t *Task) GetCart(ctx context.Context, userID int64, limit int, couponCode string) (*dtos.Carts, error) {
+	return t.service.GetCart(ctx, userID, limit, couponCode)
+}
+
+// UpdateCartItem implements IPurchase.
+func (t *Task) UpdateCartItem(ctx context.Context, userID int64, inventoryID int64, quantity int64) error {
+	return t.service.UpdateCartItem(ctx, userID, inventoryID, quantity)
+}
+
+// MergeCart implements IPurchase.
+func (t *Task) MergeCart(ctx context.Context, userID int64, items []dtos.CartDTO) error {
+	return t.service.MergeCart(ctx, userID, items)
+}
+
+// GetGuestCart implements IPurchase.
+func (t *Task) GetGuestCart(ctx context.Context, guestID string, couponCode string) (*dtos.Carts, error) {
+	return t.service.GetGuestCart(ctx, guestID, couponCode)
+}
+
+// AddToGuestCart implements IPurchase.
+func (t *Task) AddToGuestCart(ctx context.Context, guestID string, item dtos.CartDTO) error {
+	return t.service.AddToGuestCart(ctx, guestID, item)
+}
+
+// UpdateGuestCartItem implements IPurchase.
+func (t *Task) UpdateGuestCartItem(ctx context.Context, guestID string, inventoryID int64, quantity int64) error {
+	return t.service.UpdateGuestCartItem(ctx, guestID, inventoryID, quantity)
+}
+
+// DeleteItemFromGuestCart implements IPurchase.
+func (t *Task) DeleteItemFromGuestCart(ctx context.Context, guestID string, inventoryID int64) error {
+	return t.service.DeleteItemFromGuestCart(ctx, guestID, inventoryID)
+}
+
+// MergeGuestCart implements IPurchase.
+func (t *Task) MergeGuestCart(ctx context.Context, userID int64, guestID string) error {
+	return t.service.MergeGuestCart(ctx, userID, guestID)
+}
+
+// CreateGuestOrder implements IPurchase.
+func (t *Task) CreateGuestOrder(ctx context.Context, guestID string, order dtos.OrderForm) (string, error) {
+	return t.service.CreateGuestOrder(ctx, guestID, order)
 }
 
 // GetOrdersByUserID implements IPurchaseService.
 func (t *Task) GetOrdersByUserID(ctx context.Context, userID int64, limit int) ([]dtos.OrderInfo, error) {
 	return t.service.GetOrdersByUserID(ctx, userID, limit)
 }
+
+// GetInvoice implements IPurchase.
+func (t *Task) GetInvoice(ctx context.Context, orderCode string, format string) (*dtos.InvoiceFile, error) {
+	return t.service.GetInvoice(ctx, orderCode, format)
+}
+
+// GenerateInvoice implements IPurchase.
+func (t *Task) GenerateInvoice(ctx context.Context, orderCode string) error {
+	return t.service.GenerateInvoice(ctx, orderCode)
+}
+
+// IssueGiftCard implements IPurchase.
+func (t *Task) IssueGiftCard(ctx context.Context, actor string, card dtos.IssueGiftCard) (*dtos.GiftCard, error) {
+	return t.service.IssueGiftCard(ctx, actor, card)
+}
+
+// VoidGiftCard implements IPurchase.
+func (t *Task) VoidGiftCard(ctx context.Context, actor string, code string, note string) error {
+	return t.service.VoidGiftCard(ctx, actor, code, note)
+}
+
+// GetGiftCard implements IPurchase.
+func (t *Task) GetGiftCard(ctx context.Context, code string) (*dtos.GiftCard, error) {
+	return t.service.GetGiftCard(ctx, code)
+}
+
+// AuditGiftCard implements IPurchase.
+func (t *Task) AuditGiftCard(ctx context.Context, code string) (*dtos.GiftCardAudit, error) {
+	return t.service.AuditGiftCard(ctx, code)
+}
+
+// GetGiftCards implements IPurchase.
+func (t *Task) GetGiftCards(ctx context.Context, status string, limit, page int) ([]dtos.GiftCard, error) {
+	return t.service.GetGiftCards(ctx, status, limit, page)
+}
+
+// GetWallet implements IPurchase.
+func (t *Task) GetWallet(ctx context.Context, userID int64) (*dtos.Wallet, error) {
+	return t.service.GetWallet(ctx, userID)
+}
+
+// EarnPoints implements IPurchase.
+func (t *Task) EarnPoints(ctx context.Context, orderCode string) error {
+	return t.service.EarnPoints(ctx, orderCode)
+}
+
+// ExpirePoints implements IPurchase.
+func (t *Task) ExpirePoints(ctx context.Context) error {
+	return t.service.ExpirePoints(ctx)
+}
+
+// ExpireOrders implements IPurchase.
+func (t *Task) ExpireOrders(ctx context.Context) error {
+	return t.service.ExpireOrders(ctx)
+}
+
+// ImportRemittances implements IPurchase.
+func (t *Task) ImportRemittances(
+	ctx context.Context, actor string, reference string, report io.Reader) (*dtos.RemittanceImport, error) {
+	return t.service.ImportRemittances(ctx, actor, reference, report)
+}
+
+// SyncRemittances implements IPurchase.
+func (t *Task) SyncRemittances(ctx context.Context, actor string, from, to time.Time) (*dtos.RemittanceImport, error) {
+	return t.service.SyncRemittances(ctx, actor, from, to)
+}
+
+// GetUnreconciledCOD implements IPurchase.
+func (t *Task) GetUnreconciledCOD(
+	ctx context.Context, olderThan time.Duration, limit, page int) ([]dtos.CODOrder, error) {
+	return t.service.GetUnreconciledCOD(ctx, olderThan, limit, page)
+}
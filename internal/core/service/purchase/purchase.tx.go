@@ -0,0 +1,91 @@
+package purchase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/swclabs/swipex/internal/core/repos/addresses"
+	"github.com/swclabs/swipex/internal/core/repos/bundles"
+	"github.com/swclabs/swipex/internal/core/repos/cod"
+	"github.com/swclabs/swipex/internal/core/repos/coupons"
+	"github.com/swclabs/swipex/internal/core/repos/credits"
+	"github.com/swclabs/swipex/internal/core/repos/deliveries"
+	"github.com/swclabs/swipex/internal/core/repos/inventories"
+	"github.com/swclabs/swipex/internal/core/repos/loyalty"
+	"github.com/swclabs/swipex/internal/core/repos/orders"
+	"github.com/swclabs/swipex/internal/core/repos/refunds"
+	"github.com/swclabs/swipex/internal/core/repos/returns"
+	"github.com/swclabs/swipex/internal/core/repos/shipments"
+	"github.com/swclabs/swipex/internal/core/repos/users"
+	"github.com/swclabs/swipex/pkg/infra/db"
+	"github.com/swclabs/swipex/pkg/lib/logger"
+)
+
+// Tx is a transaction of the service with the repositories bound to it.
+// The functions given to AfterCommit run once the transaction is committed.
+type Tx struct {
+	db.ITransaction
+	Order     orders.IOrders
+	Inventory inventories.IInventories
+	Coupon    coupons.ICoupons
+	Credit    credits.ICredits
+	Loyalty   loyalty.ILoyalty
+	User      users.IUsers
+	Address   addresses.IAddress
+	Delivery  deliveries.IDeliveries
+	Bundle    bundles.IBundles
+	COD       cod.ICOD
+	Return    returns.IReturns
+	Refund    refunds.IRefunds
+	Shipment  shipments.IShipments
+
+	afterCommit []func(ctx context.Context) error
+}
+
+// AfterCommit runs fn once the transaction is committed.
+func (t *Tx) AfterCommit(fn func(ctx context.Context) error) {
+	t.afterCommit = append(t.afterCommit, fn)
+}
+
+// Commit commits the transaction and runs the functions given to
+// AfterCommit. Their errors are logged: the transaction is committed.
+func (t *Tx) Commit(ctx context.Context) error {
+	if err := t.ITransaction.Commit(ctx); err != nil {
+		return err
+	}
+	for _, fn := range t.afterCommit {
+		if err := fn(ctx); err != nil {
+			logger.Error(fmt.Sprintf("after commit: %v", err))
+		}
+	}
+	return nil
+}
+
+// begin starts a transaction of the service, through Begin when it is set.
+func (p *Purchase) begin(ctx context.Context) (*Tx, error) {
+	if p.Begin != nil {
+		return p.Begin(ctx)
+	}
+	conn, err := db.NewTx(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tx := &Tx{
+		ITransaction: conn,
+		Order:        orders.New(conn),
+		Coupon:       coupons.New(conn),
+		Credit:       credits.New(conn),
+		Loyalty:      loyalty.New(conn),
+		User:         users.New(conn),
+		Address:      addresses.New(conn),
+		Delivery:     deliveries.New(conn),
+		Bundle:       bundles.New(conn),
+		COD:          cod.New(conn),
+		Return:       returns.New(conn),
+		Refund:       refunds.New(conn),
+		Shipment:     shipments.New(conn),
+	}
+	// the cached stock is removed once the transaction is committed
+	tx.Inventory = inventories.InitTx(conn, p.Cache, tx.AfterCommit)
+	return tx, nil
+}
@@ -0,0 +1,5 @@
+package tasks
+
+const (
+	PaymentRefund = "payment.Refund"
+)
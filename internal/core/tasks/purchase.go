@@ -1,5 +1,13 @@
 package tasks
 
 const (
-	PurchaseAddToCart = "purchase.AddToCart"
-)
\ No newline at end of file
+	PurchaseAddToCart            = "purchase.AddToCart"
+	PurchaseNotifyOrderCancelled = "purchase.NotifyOrderCancelled"
+	PurchaseRemindAbandonedCarts = "purchase.RemindAbandonedCarts"
+	PurchaseSendCartReminder     = "purchase.SendCartReminder"
+	PurchaseGenerateInvoice      = "purchase.GenerateInvoice"
+	PurchaseExportOrders         = "purchase.ExportOrders"
+	PurchaseEarnPoints           = "purchase.EarnPoints"
+	PurchaseExpirePoints         = "purchase.ExpirePoints"
+	PurchaseExpireOrders         = "purchase.ExpireOrders"
+)
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/swclabs/swipex/app"
 	"github.com/swclabs/swipex/internal/config"
@@ -16,6 +17,7 @@ import (
 type IGhnx interface {
 	CreateOrder(ctx context.Context, shopID int, order ghn.CreateOrderDTO) (*ghn.OrderDTO, error)
 	OrderInfo(ctx context.Context, orderCode string) (*ghn.OrderInfoDTO, error)
+	Remittances(ctx context.Context, from, to time.Time) (*ghn.RemittanceDTO, error)
 }
 
 var New = app.Service(func() IGhnx {
@@ -62,3 +64,20 @@ func (g *Ghnx) CreateOrder(ctx context.Context, shopID int, order ghn.CreateOrde
 		)
 	}
 }
+
+// Remittances implements IGhnx.
+func (g *Ghnx) Remittances(ctx context.Context, from, to time.Time) (*ghn.RemittanceDTO, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	default:
+		body, _ := json.Marshal(ghn.RemittanceQuery{
+			FromTime: from.Unix(),
+			ToTime:   to.Unix(),
+		})
+		return call[ghn.RemittanceDTO](g.client,
+			"POST", config.DeliveryRemittanceAPI,
+			bytes.NewBuffer(body),
+		)
+	}
+}
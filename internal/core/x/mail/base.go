@@ -42,3 +42,35 @@ func (m *Mailer) SendPurchaseOrder(to string) error {
 	}
 	return nil
 }
+
+// SendOrderCancelled sends an order cancellation email
+func (m *Mailer) SendOrderCancelled(to, customer, orderCode, reason string) error {
+	html := components.OrderCancelled(customer, orderCode, reason)
+	t, err := templ.ToGoHTML(context.Background(), html)
+	if err != nil {
+		return err
+	}
+
+	m.Message.SetHeader("From", m.Email)
+	m.Message.SetHeader("To", to)
+	m.Message.SetHeader("Subject", "Your order "+orderCode+" has been cancelled")
+	m.Message.SetBody("text/html", string(t))
+
+	return m.Dialer.DialAndSend(m.Message)
+}
+
+// SendCartReminder sends an abandoned cart reminder email
+func (m *Mailer) SendCartReminder(to, customer string, items []components.CartReminderItem, cartURL string) error {
+	html := components.CartReminder(customer, items, cartURL)
+	t, err := templ.ToGoHTML(context.Background(), html)
+	if err != nil {
+		return err
+	}
+
+	m.Message.SetHeader("From", m.Email)
+	m.Message.SetHeader("To", to)
+	m.Message.SetHeader("Subject", "You left something in your cart")
+	m.Message.SetBody("text/html", string(t))
+
+	return m.Dialer.DialAndSend(m.Message)
+}
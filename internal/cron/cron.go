@@ -9,5 +9,6 @@ import (
 func NewApp() app.IApplication {
 	cron := server.New()
 	register.Statistic(cron)
+	register.Purchase(cron)
 	return cron
 }
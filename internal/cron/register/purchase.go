@@ -0,0 +1,28 @@
+package register
+
+import (
+	"github.com/swclabs/swipex/internal/config"
+	"github.com/swclabs/swipex/internal/core/tasks"
+	"github.com/swclabs/swipex/internal/cron/server"
+	"github.com/swclabs/swipex/internal/workers/queue"
+	"github.com/swclabs/swipex/pkg/lib/worker"
+
+	"github.com/hibiken/asynq"
+)
+
+// Purchase registers the scheduled jobs of the purchase module, they are
+// handled by the worker server.
+func Purchase(cron server.ICron) {
+	cron.Register(config.CartReminderSchedule,
+		worker.NewTask(tasks.PurchaseRemindAbandonedCarts, nil),
+		asynq.Queue(queue.CartQueue),
+	)
+	cron.Register(config.LoyaltyExpirySchedule,
+		worker.NewTask(tasks.PurchaseExpirePoints, nil),
+		asynq.Queue(queue.DefaultQueue),
+	)
+	cron.Register(config.OrderExpirySchedule,
+		worker.NewTask(tasks.PurchaseExpireOrders, nil),
+		asynq.Queue(queue.OrderQueue),
+	)
+}
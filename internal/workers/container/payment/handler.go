@@ -0,0 +1,34 @@
+// Package payment implements handler of worker
+package payment
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/swclabs/swipex/app"
+	"github.com/swclabs/swipex/internal/core/domain/dtos"
+	"github.com/swclabs/swipex/internal/core/service/payment"
+	"github.com/swclabs/swipex/pkg/lib/worker"
+)
+
+var _ = app.Controller(NewHandler)
+
+// NewHandler creates a new Payment object
+func NewHandler(service *payment.Payment) *Handler {
+	return &Handler{service: service}
+}
+
+// Handler is a struct for Handler.
+type Handler struct {
+	service *payment.Payment
+}
+
+// Refund pays back a refund through the provider of its payment, the task
+// is retried while the provider cannot be reached.
+func (p *Handler) Refund(c worker.Context) error {
+	var req dtos.PaymentRefundJob
+	if err := json.Unmarshal(c.Payload(), &req); err != nil {
+		return err
+	}
+	return p.service.ProcessRefund(context.Background(), req.ID)
+}
@@ -0,0 +1,33 @@
+// Package payment define tasks - queue
+package payment
+
+import (
+	"github.com/swclabs/swipex/app"
+	"github.com/swclabs/swipex/internal/core/tasks"
+	"github.com/swclabs/swipex/internal/workers/server"
+	"github.com/swclabs/swipex/pkg/lib/worker"
+)
+
+var _ = app.Router(NewRouter)
+
+// NewRouter creates a new Payment object
+func NewRouter(handler *Handler) IRouter {
+	return &Router{
+		handler: handler,
+	}
+}
+
+// IRouter interface for Payment objects
+type IRouter interface {
+	server.IRouter
+}
+
+// Router struct define the Router object
+type Router struct {
+	handler *Handler
+}
+
+// Register implements IRouter.
+func (r *Router) Register(eng worker.IEngine) {
+	eng.HandlerFunc(tasks.PaymentRefund, r.handler.Refund)
+}
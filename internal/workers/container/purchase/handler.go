@@ -31,3 +31,67 @@ func (p *Handler) AddToCart(c worker.Context) error {
 	}
 	return p.service.AddToCart(context.Background(), req)
 }
+
+// NotifyOrderCancelled sends the cancellation email of an order.
+func (p *Handler) NotifyOrderCancelled(c worker.Context) error {
+	var req dtos.OrderStatus
+	if err := json.Unmarshal(c.Payload(), &req); err != nil {
+		return err
+	}
+	return p.service.NotifyOrderCancelled(context.Background(), req.OrderCode, req.Reason)
+}
+
+// RemindAbandonedCarts queues the reminder emails of the abandoned carts,
+// scheduled by the cron server.
+func (p *Handler) RemindAbandonedCarts(_ worker.Context) error {
+	return p.service.RemindAbandonedCarts(context.Background())
+}
+
+// SendCartReminder sends the abandoned cart email of a user.
+func (p *Handler) SendCartReminder(c worker.Context) error {
+	var req dtos.CartReminder
+	if err := json.Unmarshal(c.Payload(), &req); err != nil {
+		return err
+	}
+	return p.service.SendCartReminder(context.Background(), req.UserID)
+}
+
+// GenerateInvoice issues the invoice of a delivered order and stores it in
+// blob storage.
+func (p *Handler) GenerateInvoice(c worker.Context) error {
+	var req dtos.OrderStatus
+	if err := json.Unmarshal(c.Payload(), &req); err != nil {
+		return err
+	}
+	return p.service.GenerateInvoice(context.Background(), req.OrderCode)
+}
+
+// ExportOrders writes a queued order export to blob storage.
+func (p *Handler) ExportOrders(c worker.Context) error {
+	var req dtos.OrderExportJob
+	if err := json.Unmarshal(c.Payload(), &req); err != nil {
+		return err
+	}
+	return p.service.RunOrderExport(context.Background(), req.ID)
+}
+
+// EarnPoints credits the loyalty points of a delivered order.
+func (p *Handler) EarnPoints(c worker.Context) error {
+	var req dtos.OrderStatus
+	if err := json.Unmarshal(c.Payload(), &req); err != nil {
+		return err
+	}
+	return p.service.EarnPoints(context.Background(), req.OrderCode)
+}
+
+// ExpirePoints takes back the expired loyalty points, scheduled by the
+// cron server.
+func (p *Handler) ExpirePoints(_ worker.Context) error {
+	return p.service.ExpirePoints(context.Background())
+}
+
+// ExpireOrders expires the pending orders whose online payment never came,
+// scheduled by the cron server.
+func (p *Handler) ExpireOrders(_ worker.Context) error {
+	return p.service.ExpireOrders(context.Background())
+}
@@ -3,6 +3,7 @@ package purchase
 
 import (
 	"github.com/swclabs/swipex/app"
+	"github.com/swclabs/swipex/internal/core/tasks"
 	"github.com/swclabs/swipex/internal/workers/server"
 	"github.com/swclabs/swipex/pkg/lib/worker"
 )
@@ -29,4 +30,12 @@ type Router struct {
 // Register implements IPurchase.
 func (r *Router) Register(eng worker.IEngine) {
 	eng.HandlerFunc("purchase.AddToCart", r.handler.AddToCart)
+	eng.HandlerFunc(tasks.PurchaseNotifyOrderCancelled, r.handler.NotifyOrderCancelled)
+	eng.HandlerFunc(tasks.PurchaseRemindAbandonedCarts, r.handler.RemindAbandonedCarts)
+	eng.HandlerFunc(tasks.PurchaseSendCartReminder, r.handler.SendCartReminder)
+	eng.HandlerFunc(tasks.PurchaseGenerateInvoice, r.handler.GenerateInvoice)
+	eng.HandlerFunc(tasks.PurchaseExportOrders, r.handler.ExportOrders)
+	eng.HandlerFunc(tasks.PurchaseEarnPoints, r.handler.EarnPoints)
+	eng.HandlerFunc(tasks.PurchaseExpirePoints, r.handler.ExpirePoints)
+	eng.HandlerFunc(tasks.PurchaseExpireOrders, r.handler.ExpireOrders)
 }
@@ -51,7 +51,8 @@ func New() worker.Priority {
 		// CriticalQueue: 6, // processed 60% of the time
 		// DefaultQueue:  3, // processed 30% of the time
 		// LowQueue:      1, // processed 10% of the time
-		DefaultQueue: 5, // processed 50% of the time
-		CartQueue:    5, // processed 50% of the time
+		DefaultQueue: 4, // processed 40% of the time
+		CartQueue:    3, // processed 30% of the time
+		OrderQueue:   3, // processed 30% of the time
 	}
 }
@@ -4,6 +4,7 @@ import (
 	"github.com/swclabs/swipex/app"
 	"github.com/swclabs/swipex/internal/workers/container/authentication"
 	"github.com/swclabs/swipex/internal/workers/container/healthcheck"
+	"github.com/swclabs/swipex/internal/workers/container/payment"
 	"github.com/swclabs/swipex/internal/workers/container/purchase"
 	"github.com/swclabs/swipex/internal/workers/server"
 )
@@ -12,11 +13,13 @@ func NewApp(
 	base healthcheck.IRouter,
 	auth authentication.IRouter,
 	purchase purchase.IRouter,
+	payment payment.IRouter,
 ) app.IApplication {
 	mux := server.NewServeMux()
 	mux.Handle(base)
 	mux.Handle(auth)
 	mux.Handle(purchase)
+	mux.Handle(payment)
 	worker := server.New(mux)
 	return worker
 }
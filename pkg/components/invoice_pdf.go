@@ -0,0 +1,108 @@
+package components
+
+import (
+	"strconv"
+
+	"github.com/swclabs/swipex/pkg/lib/pdf"
+)
+
+// invoice PDF layout, in points
+const (
+	invoiceMargin = 40.0
+	invoiceRight  = pdf.PageWidth - invoiceMargin
+	invoiceBottom = pdf.PageHeight - 60
+	invoiceRow    = 16.0
+)
+
+// InvoicePDF renders the invoice with the same content as the Invoice
+// component, laid out for print.
+func InvoicePDF(inv InvoiceData) []byte {
+	doc := pdf.New()
+	y := 60.0
+
+	doc.Text(invoiceMargin, y, pdf.Bold, 20, "Invoice "+inv.Number)
+	y += 28
+	for _, row := range [][2]string{
+		{"Date", inv.IssuedAt},
+		{"Order", inv.OrderCode},
+		{"Payment method", inv.PaymentMethod},
+	} {
+		doc.Text(invoiceMargin, y, pdf.Bold, 10, row[0])
+		doc.Text(invoiceMargin+100, y, pdf.Regular, 10, row[1])
+		y += invoiceRow
+	}
+
+	y += invoiceRow
+	sellerEnd := invoicePartyPDF(doc, invoiceMargin, y, "Seller", inv.Seller)
+	buyerEnd := invoicePartyPDF(doc, pdf.PageWidth/2, y, "Bill to", inv.Buyer)
+	y = max(sellerEnd, buyerEnd) + invoiceRow
+
+	columns := func(y float64, font pdf.Font, item, qty, price, vat, amount string) {
+		doc.Text(invoiceMargin, y, font, 10, item)
+		doc.TextRight(300, y, font, 10, qty)
+		doc.TextRight(390, y, font, 10, price)
+		doc.TextRight(440, y, font, 10, vat)
+		doc.TextRight(invoiceRight, y, font, 10, amount)
+	}
+	header := func() {
+		columns(y, pdf.Bold, "Item", "Qty", "Unit price", "VAT", "Amount")
+		doc.Line(invoiceMargin, y+5, invoiceRight, y+5)
+		y += invoiceRow + 4
+	}
+	header()
+	for _, line := range inv.Lines {
+		if y > invoiceBottom {
+			doc.AddPage()
+			y = 60
+			header()
+		}
+		name := line.Name
+		if line.Color != "" {
+			name += " (" + line.Color + ")"
+		}
+		columns(y, pdf.Regular, name, strconv.FormatInt(line.Quantity, 10),
+			line.UnitPrice, line.VATRate+"%", line.Amount)
+		y += invoiceRow
+	}
+	doc.Line(invoiceMargin, y-10, invoiceRight, y-10)
+
+	if y > invoiceBottom-5*invoiceRow {
+		doc.AddPage()
+		y = 60
+	}
+	y += 6
+	for _, row := range [][2]string{
+		{"Subtotal", inv.Subtotal},
+		{"Discount", "-" + inv.Discount},
+		{"VAT", inv.TaxAmount},
+		{"Shipping", inv.ShippingFee},
+	} {
+		doc.TextRight(440, y, pdf.Regular, 10, row[0])
+		doc.TextRight(invoiceRight, y, pdf.Regular, 10, row[1]+" "+inv.Currency)
+		y += invoiceRow
+	}
+	doc.TextRight(440, y, pdf.Bold, 12, "Total")
+	doc.TextRight(invoiceRight, y, pdf.Bold, 12, inv.Total+" "+inv.Currency)
+	return doc.Bytes()
+}
+
+// invoicePartyPDF writes a party block at x, y and returns where it ends
+func invoicePartyPDF(doc *pdf.Document, x, y float64, title string, party InvoiceParty) float64 {
+	doc.Text(x, y, pdf.Bold, 10, title)
+	y += invoiceRow
+	for _, line := range []string{party.Name, party.Address, taxCode(party.TaxCode), party.Email, party.Phone} {
+		if line == "" {
+			continue
+		}
+		doc.Text(x, y, pdf.Regular, 10, line)
+		y += invoiceRow
+	}
+	return y
+}
+
+func taxCode(code string) string {
+	if code == "" {
+		return ""
+	}
+	return "Tax code: " + code
+}
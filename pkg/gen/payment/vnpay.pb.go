@@ -182,19 +182,20 @@ type PaymentReturnRequest struct {
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	Vnp_TmnCode           string `protobuf:"bytes,1,opt,name=vnp_TmnCode,json=vnpTmnCode,proto3" json:"vnp_TmnCode,omitempty"`                                // Mã website của merchant trên hệ thống của VNPAY
-	Vnp_Amount            uint64 `protobuf:"varint,2,opt,name=vnp_Amount,json=vnpAmount,proto3" json:"vnp_Amount,omitempty"`                                  // Số tiền thanh toán (nhân thêm 100 lần)
-	Vnp_BankCode          string `protobuf:"bytes,3,opt,name=vnp_BankCode,json=vnpBankCode,proto3" json:"vnp_BankCode,omitempty"`                             // Mã Ngân hàng thanh toán
-	Vnp_BankTranNo        string `protobuf:"bytes,4,opt,name=vnp_BankTranNo,json=vnpBankTranNo,proto3" json:"vnp_BankTranNo,omitempty"`                       // Mã giao dịch tại Ngân hàng
-	Vnp_CardType          string `protobuf:"bytes,5,opt,name=vnp_CardType,json=vnpCardType,proto3" json:"vnp_CardType,omitempty"`                             // Loại tài khoản/thẻ khách hàng sử dụng: ATM, QRCODE
-	Vnp_PayDate           string `protobuf:"bytes,6,opt,name=vnp_PayDate,json=vnpPayDate,proto3" json:"vnp_PayDate,omitempty"`                                // Thời gian thanh toán (yyyyMMddHHmmss)
-	Vnp_OrderInfo         string `protobuf:"bytes,7,opt,name=vnp_OrderInfo,json=vnpOrderInfo,proto3" json:"vnp_OrderInfo,omitempty"`                          // Thông tin mô tả nội dung thanh toán (không dấu)
-	Vnp_TransactionNo     uint64 `protobuf:"varint,8,opt,name=vnp_TransactionNo,json=vnpTransactionNo,proto3" json:"vnp_TransactionNo,omitempty"`             // Mã giao dịch ghi nhận tại hệ thống VNPAY
-	Vnp_ResponseCode      string `protobuf:"bytes,9,opt,name=vnp_ResponseCode,json=vnpResponseCode,proto3" json:"vnp_ResponseCode,omitempty"`                 // Mã phản hồi kết quả thanh toán
-	Vnp_TransactionStatus string `protobuf:"bytes,10,opt,name=vnp_TransactionStatus,json=vnpTransactionStatus,proto3" json:"vnp_TransactionStatus,omitempty"` // Tình trạng giao dịch tại VNPAY
-	Vnp_TxnRef            string `protobuf:"bytes,11,opt,name=vnp_TxnRef,json=vnpTxnRef,proto3" json:"vnp_TxnRef,omitempty"`                                  // Mã tham chiếu giao dịch (giống mã gửi sang VNPAY)
-	Vnp_SecureHashType    string `protobuf:"bytes,12,opt,name=vnp_SecureHashType,json=vnpSecureHashType,proto3" json:"vnp_SecureHashType,omitempty"`          // Loại mã băm sử dụng: SHA256, HmacSHA512
-	Vnp_SecureHash        string `protobuf:"bytes,13,opt,name=vnp_SecureHash,json=vnpSecureHash,proto3" json:"vnp_SecureHash,omitempty"`                      // Giá trị mã băm để xác thực giao dịch
+	Vnp_TmnCode           string            `protobuf:"bytes,1,opt,name=vnp_TmnCode,json=vnpTmnCode,proto3" json:"vnp_TmnCode,omitempty"`                                                                // Mã website của merchant trên hệ thống của VNPAY
+	Vnp_Amount            uint64            `protobuf:"varint,2,opt,name=vnp_Amount,json=vnpAmount,proto3" json:"vnp_Amount,omitempty"`                                                                  // Số tiền thanh toán (nhân thêm 100 lần)
+	Vnp_BankCode          string            `protobuf:"bytes,3,opt,name=vnp_BankCode,json=vnpBankCode,proto3" json:"vnp_BankCode,omitempty"`                                                             // Mã Ngân hàng thanh toán
+	Vnp_BankTranNo        string            `protobuf:"bytes,4,opt,name=vnp_BankTranNo,json=vnpBankTranNo,proto3" json:"vnp_BankTranNo,omitempty"`                                                       // Mã giao dịch tại Ngân hàng
+	Vnp_CardType          string            `protobuf:"bytes,5,opt,name=vnp_CardType,json=vnpCardType,proto3" json:"vnp_CardType,omitempty"`                                                             // Loại tài khoản/thẻ khách hàng sử dụng: ATM, QRCODE
+	Vnp_PayDate           string            `protobuf:"bytes,6,opt,name=vnp_PayDate,json=vnpPayDate,proto3" json:"vnp_PayDate,omitempty"`                                                                // Thời gian thanh toán (yyyyMMddHHmmss)
+	Vnp_OrderInfo         string            `protobuf:"bytes,7,opt,name=vnp_OrderInfo,json=vnpOrderInfo,proto3" json:"vnp_OrderInfo,omitempty"`                                                          // Thông tin mô tả nội dung thanh toán (không dấu)
+	Vnp_TransactionNo     uint64            `protobuf:"varint,8,opt,name=vnp_TransactionNo,json=vnpTransactionNo,proto3" json:"vnp_TransactionNo,omitempty"`                                             // Mã giao dịch ghi nhận tại hệ thống VNPAY
+	Vnp_ResponseCode      string            `protobuf:"bytes,9,opt,name=vnp_ResponseCode,json=vnpResponseCode,proto3" json:"vnp_ResponseCode,omitempty"`                                                 // Mã phản hồi kết quả thanh toán
+	Vnp_TransactionStatus string            `protobuf:"bytes,10,opt,name=vnp_TransactionStatus,json=vnpTransactionStatus,proto3" json:"vnp_TransactionStatus,omitempty"`                                 // Tình trạng giao dịch tại VNPAY
+	Vnp_TxnRef            string            `protobuf:"bytes,11,opt,name=vnp_TxnRef,json=vnpTxnRef,proto3" json:"vnp_TxnRef,omitempty"`                                                                  // Mã tham chiếu giao dịch (giống mã gửi sang VNPAY)
+	Vnp_SecureHashType    string            `protobuf:"bytes,12,opt,name=vnp_SecureHashType,json=vnpSecureHashType,proto3" json:"vnp_SecureHashType,omitempty"`                                          // Loại mã băm sử dụng: SHA256, HmacSHA512
+	Vnp_SecureHash        string            `protobuf:"bytes,13,opt,name=vnp_SecureHash,json=vnpSecureHash,proto3" json:"vnp_SecureHash,omitempty"`                                                      // Giá trị mã băm để xác thực giao dịch
+	Params                map[string]string `protobuf:"bytes,14,rep,name=params,proto3" json:"params,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"` // Các tham số vnp_ nhận được, dữ liệu VNPAY đã ký
 }
 
 func (x *PaymentReturnRequest) Reset() {
@@ -318,6 +319,13 @@ func (x *PaymentReturnRequest) GetVnp_SecureHash() string {
 	return ""
 }
 
+func (x *PaymentReturnRequest) GetParams() map[string]string {
+	if x != nil {
+		return x.Params
+	}
+	return nil
+}
+
 // Message phản hồi cho Payment Return
 type PaymentReturnResponse struct {
 	state         protoimpl.MessageState
@@ -331,6 +339,7 @@ type PaymentReturnResponse struct {
 	OrderDesc         string `protobuf:"bytes,5,opt,name=order_desc,json=orderDesc,proto3" json:"order_desc,omitempty"`                      // Mô tả đơn hàng
 	Vnp_TransactionNo string `protobuf:"bytes,6,opt,name=vnp_TransactionNo,json=vnpTransactionNo,proto3" json:"vnp_TransactionNo,omitempty"` // Mã giao dịch VNPAY
 	Vnp_ResponseCode  string `protobuf:"bytes,7,opt,name=vnp_ResponseCode,json=vnpResponseCode,proto3" json:"vnp_ResponseCode,omitempty"`    // Mã phản hồi VNPAY
+	Success           bool   `protobuf:"varint,8,opt,name=success,proto3" json:"success,omitempty"`                                          // Trạng thái thành công hay thất bại
 }
 
 func (x *PaymentReturnResponse) Reset() {
@@ -412,6 +421,193 @@ func (x *PaymentReturnResponse) GetVnp_ResponseCode() string {
 	return ""
 }
 
+func (x *PaymentReturnResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
+// Message chứa thông tin đầu vào của yêu cầu hoàn tiền
+type RefundRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	OrderId         string `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`                         // ID đơn hàng (vnp_TxnRef của giao dịch thanh toán)
+	TransactionNo   string `protobuf:"bytes,2,opt,name=transaction_no,json=transactionNo,proto3" json:"transaction_no,omitempty"`       // Mã giao dịch VNPAY của giao dịch thanh toán
+	TransactionDate string `protobuf:"bytes,3,opt,name=transaction_date,json=transactionDate,proto3" json:"transaction_date,omitempty"` // Thời gian tạo giao dịch thanh toán (yyyyMMddHHmmss)
+	Amount          int64  `protobuf:"varint,4,opt,name=amount,proto3" json:"amount,omitempty"`                                         // Số tiền hoàn (VND)
+	Full            bool   `protobuf:"varint,5,opt,name=full,proto3" json:"full,omitempty"`                                             // Hoàn toàn phần hay một phần
+	OrderInfo       string `protobuf:"bytes,6,opt,name=order_info,json=orderInfo,proto3" json:"order_info,omitempty"`                   // Nội dung hoàn tiền
+	CreateBy        string `protobuf:"bytes,7,opt,name=create_by,json=createBy,proto3" json:"create_by,omitempty"`                      // Người thực hiện hoàn tiền
+	IpAddress       string `protobuf:"bytes,8,opt,name=ip_address,json=ipAddress,proto3" json:"ip_address,omitempty"`                   // Địa chỉ IP của máy chủ gửi yêu cầu
+	RequestId       string `protobuf:"bytes,9,opt,name=request_id,json=requestId,proto3" json:"request_id,omitempty"`                   // Mã yêu cầu hoàn tiền (vnp_RequestId), cố định cho mỗi lần hoàn
+}
+
+func (x *RefundRequest) Reset() {
+	*x = RefundRequest{}
+	mi := &file_proto_vnpay_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefundRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefundRequest) ProtoMessage() {}
+
+func (x *RefundRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_vnpay_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefundRequest.ProtoReflect.Descriptor instead.
+func (*RefundRequest) Descriptor() ([]byte, []int) {
+	return file_proto_vnpay_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *RefundRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *RefundRequest) GetTransactionNo() string {
+	if x != nil {
+		return x.TransactionNo
+	}
+	return ""
+}
+
+func (x *RefundRequest) GetTransactionDate() string {
+	if x != nil {
+		return x.TransactionDate
+	}
+	return ""
+}
+
+func (x *RefundRequest) GetAmount() int64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *RefundRequest) GetFull() bool {
+	if x != nil {
+		return x.Full
+	}
+	return false
+}
+
+func (x *RefundRequest) GetOrderInfo() string {
+	if x != nil {
+		return x.OrderInfo
+	}
+	return ""
+}
+
+func (x *RefundRequest) GetCreateBy() string {
+	if x != nil {
+		return x.CreateBy
+	}
+	return ""
+}
+
+func (x *RefundRequest) GetIpAddress() string {
+	if x != nil {
+		return x.IpAddress
+	}
+	return ""
+}
+
+func (x *RefundRequest) GetRequestId() string {
+	if x != nil {
+		return x.RequestId
+	}
+	return ""
+}
+
+// Message phản hồi cho yêu cầu hoàn tiền
+type RefundResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	ResponseCode  string `protobuf:"bytes,1,opt,name=response_code,json=responseCode,proto3" json:"response_code,omitempty"`    // Mã phản hồi VNPAY
+	Message       string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`                                  // Thông báo
+	TransactionNo string `protobuf:"bytes,3,opt,name=transaction_no,json=transactionNo,proto3" json:"transaction_no,omitempty"` // Mã giao dịch hoàn tiền tại VNPAY
+	Success       bool   `protobuf:"varint,4,opt,name=success,proto3" json:"success,omitempty"`                                 // Trạng thái thành công hay thất bại
+}
+
+func (x *RefundResponse) Reset() {
+	*x = RefundResponse{}
+	mi := &file_proto_vnpay_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefundResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefundResponse) ProtoMessage() {}
+
+func (x *RefundResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_vnpay_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefundResponse.ProtoReflect.Descriptor instead.
+func (*RefundResponse) Descriptor() ([]byte, []int) {
+	return file_proto_vnpay_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *RefundResponse) GetResponseCode() string {
+	if x != nil {
+		return x.ResponseCode
+	}
+	return ""
+}
+
+func (x *RefundResponse) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *RefundResponse) GetTransactionNo() string {
+	if x != nil {
+		return x.TransactionNo
+	}
+	return ""
+}
+
+func (x *RefundResponse) GetSuccess() bool {
+	if x != nil {
+		return x.Success
+	}
+	return false
+}
+
 type StatusRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -420,7 +616,7 @@ type StatusRequest struct {
 
 func (x *StatusRequest) Reset() {
 	*x = StatusRequest{}
-	mi := &file_proto_vnpay_proto_msgTypes[4]
+	mi := &file_proto_vnpay_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -432,7 +628,7 @@ func (x *StatusRequest) String() string {
 func (*StatusRequest) ProtoMessage() {}
 
 func (x *StatusRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_vnpay_proto_msgTypes[4]
+	mi := &file_proto_vnpay_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -445,7 +641,7 @@ func (x *StatusRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StatusRequest.ProtoReflect.Descriptor instead.
 func (*StatusRequest) Descriptor() ([]byte, []int) {
-	return file_proto_vnpay_proto_rawDescGZIP(), []int{4}
+	return file_proto_vnpay_proto_rawDescGZIP(), []int{6}
 }
 
 type StatusResponse struct {
@@ -459,7 +655,7 @@ type StatusResponse struct {
 
 func (x *StatusResponse) Reset() {
 	*x = StatusResponse{}
-	mi := &file_proto_vnpay_proto_msgTypes[5]
+	mi := &file_proto_vnpay_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -471,7 +667,7 @@ func (x *StatusResponse) String() string {
 func (*StatusResponse) ProtoMessage() {}
 
 func (x *StatusResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_proto_vnpay_proto_msgTypes[5]
+	mi := &file_proto_vnpay_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -484,7 +680,7 @@ func (x *StatusResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StatusResponse.ProtoReflect.Descriptor instead.
 func (*StatusResponse) Descriptor() ([]byte, []int) {
-	return file_proto_vnpay_proto_rawDescGZIP(), []int{5}
+	return file_proto_vnpay_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *StatusResponse) GetMessage() string {
@@ -526,7 +722,7 @@ var file_proto_vnpay_proto_rawDesc = []byte{
 	0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d,
 	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73,
 	0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73,
-	0x22, 0x8b, 0x04, 0x0a, 0x14, 0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x74, 0x75,
+	0x22, 0x89, 0x05, 0x0a, 0x14, 0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x74, 0x75,
 	0x72, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x1f, 0x0a, 0x0b, 0x76, 0x6e, 0x70,
 	0x5f, 0x54, 0x6d, 0x6e, 0x43, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0a,
 	0x76, 0x6e, 0x70, 0x54, 0x6d, 0x6e, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x76, 0x6e,
@@ -558,28 +754,65 @@ var file_proto_vnpay_proto_rawDesc = []byte{
 	0x20, 0x01, 0x28, 0x09, 0x52, 0x11, 0x76, 0x6e, 0x70, 0x53, 0x65, 0x63, 0x75, 0x72, 0x65, 0x48,
 	0x61, 0x73, 0x68, 0x54, 0x79, 0x70, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x76, 0x6e, 0x70, 0x5f, 0x53,
 	0x65, 0x63, 0x75, 0x72, 0x65, 0x48, 0x61, 0x73, 0x68, 0x18, 0x0d, 0x20, 0x01, 0x28, 0x09, 0x52,
-	0x0d, 0x76, 0x6e, 0x70, 0x53, 0x65, 0x63, 0x75, 0x72, 0x65, 0x48, 0x61, 0x73, 0x68, 0x22, 0xf3,
-	0x01, 0x0a, 0x15, 0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x74, 0x75, 0x72, 0x6e,
-	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75,
-	0x6c, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74,
-	0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x6f, 0x72,
-	0x64, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6f, 0x72,
-	0x64, 0x65, 0x72, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18,
-	0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1d, 0x0a,
-	0x0a, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x64, 0x65, 0x73, 0x63, 0x18, 0x05, 0x20, 0x01, 0x28,
-	0x09, 0x52, 0x09, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x44, 0x65, 0x73, 0x63, 0x12, 0x2b, 0x0a, 0x11,
-	0x76, 0x6e, 0x70, 0x5f, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e,
-	0x6f, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x76, 0x6e, 0x70, 0x54, 0x72, 0x61, 0x6e,
-	0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x6f, 0x12, 0x29, 0x0a, 0x10, 0x76, 0x6e, 0x70,
-	0x5f, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x43, 0x6f, 0x64, 0x65, 0x18, 0x07, 0x20,
-	0x01, 0x28, 0x09, 0x52, 0x0f, 0x76, 0x6e, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
-	0x43, 0x6f, 0x64, 0x65, 0x22, 0x0f, 0x0a, 0x0d, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65,
+	0x0d, 0x76, 0x6e, 0x70, 0x53, 0x65, 0x63, 0x75, 0x72, 0x65, 0x48, 0x61, 0x73, 0x68, 0x12, 0x41,
+	0x0a, 0x06, 0x70, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x18, 0x0e, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x29,
+	0x2e, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74,
+	0x52, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x2e, 0x50, 0x61,
+	0x72, 0x61, 0x6d, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x70, 0x61, 0x72, 0x61, 0x6d,
+	0x73, 0x1a, 0x39, 0x0a, 0x0b, 0x50, 0x61, 0x72, 0x61, 0x6d, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x8d, 0x02, 0x0a,
+	0x15, 0x50, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x72, 0x65, 0x73, 0x75, 0x6c, 0x74, 0x12, 0x18,
+	0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x19, 0x0a, 0x08, 0x6f, 0x72, 0x64, 0x65,
+	0x72, 0x5f, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6f, 0x72, 0x64, 0x65,
+	0x72, 0x49, 0x64, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x03, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x6f,
+	0x72, 0x64, 0x65, 0x72, 0x5f, 0x64, 0x65, 0x73, 0x63, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x09, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x44, 0x65, 0x73, 0x63, 0x12, 0x2b, 0x0a, 0x11, 0x76, 0x6e,
+	0x70, 0x5f, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x6f, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x10, 0x76, 0x6e, 0x70, 0x54, 0x72, 0x61, 0x6e, 0x73, 0x61,
+	0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x6f, 0x12, 0x29, 0x0a, 0x10, 0x76, 0x6e, 0x70, 0x5f, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x43, 0x6f, 0x64, 0x65, 0x18, 0x07, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0f, 0x76, 0x6e, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x43, 0x6f,
+	0x64, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x08, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x22, 0xa2, 0x02, 0x0a,
+	0x0d, 0x52, 0x65, 0x66, 0x75, 0x6e, 0x64, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x19,
+	0x0a, 0x08, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x5f, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x6f, 0x72, 0x64, 0x65, 0x72, 0x49, 0x64, 0x12, 0x25, 0x0a, 0x0e, 0x74, 0x72, 0x61,
+	0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6e, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0d, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x6f,
+	0x12, 0x29, 0x0a, 0x10, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x5f,
+	0x64, 0x61, 0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x74, 0x72, 0x61, 0x6e,
+	0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x44, 0x61, 0x74, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x61,
+	0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x03, 0x52, 0x06, 0x61, 0x6d, 0x6f,
+	0x75, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x66, 0x75, 0x6c, 0x6c, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x08, 0x52, 0x04, 0x66, 0x75, 0x6c, 0x6c, 0x12, 0x1d, 0x0a, 0x0a, 0x6f, 0x72, 0x64, 0x65, 0x72,
+	0x5f, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x06, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x6f, 0x72, 0x64,
+	0x65, 0x72, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x1b, 0x0a, 0x09, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65,
+	0x5f, 0x62, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x63, 0x72, 0x65, 0x61, 0x74,
+	0x65, 0x42, 0x79, 0x12, 0x1d, 0x0a, 0x0a, 0x69, 0x70, 0x5f, 0x61, 0x64, 0x64, 0x72, 0x65, 0x73,
+	0x73, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x69, 0x70, 0x41, 0x64, 0x64, 0x72, 0x65,
+	0x73, 0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x5f, 0x69, 0x64,
+	0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x49,
+	0x64, 0x22, 0x90, 0x01, 0x0a, 0x0e, 0x52, 0x65, 0x66, 0x75, 0x6e, 0x64, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x23, 0x0a, 0x0d, 0x72, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x5f, 0x63, 0x6f, 0x64, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x72, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x43, 0x6f, 0x64, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x12, 0x25, 0x0a, 0x0e, 0x74, 0x72, 0x61, 0x6e, 0x73, 0x61, 0x63, 0x74, 0x69,
+	0x6f, 0x6e, 0x5f, 0x6e, 0x6f, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x74, 0x72, 0x61,
+	0x6e, 0x73, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x4e, 0x6f, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75,
+	0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x08, 0x52, 0x07, 0x73, 0x75, 0x63,
+	0x63, 0x65, 0x73, 0x73, 0x22, 0x0f, 0x0a, 0x0d, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65,
 	0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x44, 0x0a, 0x0e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52,
 	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61,
 	0x67, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6d, 0x65, 0x73, 0x73, 0x61, 0x67,
 	0x65, 0x12, 0x18, 0x0a, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01,
-	0x28, 0x08, 0x52, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x32, 0xe3, 0x01, 0x0a, 0x05,
+	0x28, 0x08, 0x52, 0x07, 0x73, 0x75, 0x63, 0x63, 0x65, 0x73, 0x73, 0x32, 0x9e, 0x02, 0x0a, 0x05,
 	0x56, 0x4e, 0x50, 0x61, 0x79, 0x12, 0x3e, 0x0a, 0x0b, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x53, 0x74,
 	0x61, 0x74, 0x75, 0x73, 0x12, 0x16, 0x2e, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x53,
 	0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x70,
@@ -594,8 +827,11 @@ var file_proto_vnpay_proto_rawDesc = []byte{
 	0x6d, 0x65, 0x6e, 0x74, 0x52, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73,
 	0x74, 0x1a, 0x1e, 0x2e, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x50, 0x61, 0x79, 0x6d,
 	0x65, 0x6e, 0x74, 0x52, 0x65, 0x74, 0x75, 0x72, 0x6e, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x42, 0x0a, 0x5a, 0x08, 0x2f, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x62, 0x06, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x65, 0x12, 0x39, 0x0a, 0x06, 0x52, 0x65, 0x66, 0x75, 0x6e, 0x64, 0x12, 0x16, 0x2e, 0x70, 0x61,
+	0x79, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x52, 0x65, 0x66, 0x75, 0x6e, 0x64, 0x52, 0x65, 0x71, 0x75,
+	0x65, 0x73, 0x74, 0x1a, 0x17, 0x2e, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x2e, 0x52, 0x65,
+	0x66, 0x75, 0x6e, 0x64, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x0a, 0x5a, 0x08,
+	0x2f, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -610,27 +846,33 @@ func file_proto_vnpay_proto_rawDescGZIP() []byte {
 	return file_proto_vnpay_proto_rawDescData
 }
 
-var file_proto_vnpay_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_proto_vnpay_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
 var file_proto_vnpay_proto_goTypes = []any{
 	(*PaymentRequest)(nil),        // 0: payment.PaymentRequest
 	(*PaymentResponse)(nil),       // 1: payment.PaymentResponse
 	(*PaymentReturnRequest)(nil),  // 2: payment.PaymentReturnRequest
 	(*PaymentReturnResponse)(nil), // 3: payment.PaymentReturnResponse
-	(*StatusRequest)(nil),         // 4: payment.StatusRequest
-	(*StatusResponse)(nil),        // 5: payment.StatusResponse
+	(*RefundRequest)(nil),         // 4: payment.RefundRequest
+	(*RefundResponse)(nil),        // 5: payment.RefundResponse
+	(*StatusRequest)(nil),         // 6: payment.StatusRequest
+	(*StatusResponse)(nil),        // 7: payment.StatusResponse
+	nil,                           // 8: payment.PaymentReturnRequest.ParamsEntry
 }
 var file_proto_vnpay_proto_depIdxs = []int32{
-	4, // 0: payment.VNPay.CheckStatus:input_type -> payment.StatusRequest
-	0, // 1: payment.VNPay.ProcessPayment:input_type -> payment.PaymentRequest
-	2, // 2: payment.VNPay.ProcessPaymentReturn:input_type -> payment.PaymentReturnRequest
-	5, // 3: payment.VNPay.CheckStatus:output_type -> payment.StatusResponse
-	1, // 4: payment.VNPay.ProcessPayment:output_type -> payment.PaymentResponse
-	3, // 5: payment.VNPay.ProcessPaymentReturn:output_type -> payment.PaymentReturnResponse
-	3, // [3:6] is the sub-list for method output_type
-	0, // [0:3] is the sub-list for method input_type
-	0, // [0:0] is the sub-list for extension type_name
-	0, // [0:0] is the sub-list for extension extendee
-	0, // [0:0] is the sub-list for field type_name
+	8, // 0: payment.PaymentReturnRequest.params:type_name -> payment.PaymentReturnRequest.ParamsEntry
+	6, // 1: payment.VNPay.CheckStatus:input_type -> payment.StatusRequest
+	0, // 2: payment.VNPay.ProcessPayment:input_type -> payment.PaymentRequest
+	2, // 3: payment.VNPay.ProcessPaymentReturn:input_type -> payment.PaymentReturnRequest
+	4, // 4: payment.VNPay.Refund:input_type -> payment.RefundRequest
+	7, // 5: payment.VNPay.CheckStatus:output_type -> payment.StatusResponse
+	1, // 6: payment.VNPay.ProcessPayment:output_type -> payment.PaymentResponse
+	3, // 7: payment.VNPay.ProcessPaymentReturn:output_type -> payment.PaymentReturnResponse
+	5, // 8: payment.VNPay.Refund:output_type -> payment.RefundResponse
+	5, // [5:9] is the sub-list for method output_type
+	1, // [1:5] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
 }
 
 func init() { file_proto_vnpay_proto_init() }
@@ -644,7 +886,7 @@ func file_proto_vnpay_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_proto_vnpay_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   6,
+			NumMessages:   9,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -22,6 +22,7 @@ const (
 	VNPay_CheckStatus_FullMethodName          = "/payment.VNPay/CheckStatus"
 	VNPay_ProcessPayment_FullMethodName       = "/payment.VNPay/ProcessPayment"
 	VNPay_ProcessPaymentReturn_FullMethodName = "/payment.VNPay/ProcessPaymentReturn"
+	VNPay_Refund_FullMethodName               = "/payment.VNPay/Refund"
 )
 
 // VNPayClient is the client API for VNPay service.
@@ -33,6 +34,7 @@ type VNPayClient interface {
 	CheckStatus(ctx context.Context, in *StatusRequest, opts ...grpc.CallOption) (*StatusResponse, error)
 	ProcessPayment(ctx context.Context, in *PaymentRequest, opts ...grpc.CallOption) (*PaymentResponse, error)
 	ProcessPaymentReturn(ctx context.Context, in *PaymentReturnRequest, opts ...grpc.CallOption) (*PaymentReturnResponse, error)
+	Refund(ctx context.Context, in *RefundRequest, opts ...grpc.CallOption) (*RefundResponse, error)
 }
 
 type vNPayClient struct {
@@ -73,6 +75,16 @@ func (c *vNPayClient) ProcessPaymentReturn(ctx context.Context, in *PaymentRetur
 	return out, nil
 }
 
+func (c *vNPayClient) Refund(ctx context.Context, in *RefundRequest, opts ...grpc.CallOption) (*RefundResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RefundResponse)
+	err := c.cc.Invoke(ctx, VNPay_Refund_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // VNPayServer is the server API for VNPay service.
 // All implementations must embed UnimplementedVNPayServer
 // for forward compatibility.
@@ -82,6 +94,7 @@ type VNPayServer interface {
 	CheckStatus(context.Context, *StatusRequest) (*StatusResponse, error)
 	ProcessPayment(context.Context, *PaymentRequest) (*PaymentResponse, error)
 	ProcessPaymentReturn(context.Context, *PaymentReturnRequest) (*PaymentReturnResponse, error)
+	Refund(context.Context, *RefundRequest) (*RefundResponse, error)
 	mustEmbedUnimplementedVNPayServer()
 }
 
@@ -101,6 +114,9 @@ func (UnimplementedVNPayServer) ProcessPayment(context.Context, *PaymentRequest)
 func (UnimplementedVNPayServer) ProcessPaymentReturn(context.Context, *PaymentReturnRequest) (*PaymentReturnResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method ProcessPaymentReturn not implemented")
 }
+func (UnimplementedVNPayServer) Refund(context.Context, *RefundRequest) (*RefundResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Refund not implemented")
+}
 func (UnimplementedVNPayServer) mustEmbedUnimplementedVNPayServer() {}
 func (UnimplementedVNPayServer) testEmbeddedByValue()               {}
 
@@ -176,6 +192,24 @@ func _VNPay_ProcessPaymentReturn_Handler(srv interface{}, ctx context.Context, d
 	return interceptor(ctx, in, info, handler)
 }
 
+func _VNPay_Refund_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RefundRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(VNPayServer).Refund(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: VNPay_Refund_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(VNPayServer).Refund(ctx, req.(*RefundRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // VNPay_ServiceDesc is the grpc.ServiceDesc for VNPay service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -195,6 +229,10 @@ var VNPay_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ProcessPaymentReturn",
 			Handler:    _VNPay_ProcessPaymentReturn_Handler,
 		},
+		{
+			MethodName: "Refund",
+			Handler:    _VNPay_Refund_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "proto/vnpay.proto",
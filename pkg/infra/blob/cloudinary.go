@@ -4,6 +4,7 @@ package blob
 import (
 	"context"
 	"fmt"
+	"io"
 	"mime/multipart"
 	"sync"
 
@@ -12,6 +13,7 @@ import (
 	"github.com/swclabs/swipex/pkg/lib/logger"
 
 	"github.com/cloudinary/cloudinary-go/v2"
+	"github.com/cloudinary/cloudinary-go/v2/api"
 	"github.com/cloudinary/cloudinary-go/v2/api/uploader"
 	"go.uber.org/fx"
 )
@@ -90,3 +92,35 @@ func (blob *Storage) UploadFile(ctx context.Context, fileHeader *multipart.FileH
 	}
 	return resp.SecureURL, nil
 }
+
+// UploadRaw upload a document to cloudinary under the given name, an
+// existing document with the same name is replaced
+func (blob *Storage) UploadRaw(ctx context.Context, name string, file io.Reader) (url string, err error) {
+	resp, err := blob.Conn.Upload.Upload(ctx, file, uploader.UploadParams{
+		ResourceType: "raw",
+		Folder:       "swc-storage",
+		PublicID:     name,
+		Overwrite:    api.Bool(true),
+	})
+	if err != nil {
+		return "", err
+	}
+	return resp.SecureURL, nil
+}
+
+// UploadPrivate upload a document to cloudinary under the given name, it is
+// only delivered through signed URLs: the returned url alone does not give
+// access to it
+func (blob *Storage) UploadPrivate(ctx context.Context, name string, file io.Reader) (url string, err error) {
+	resp, err := blob.Conn.Upload.Upload(ctx, file, uploader.UploadParams{
+		ResourceType: "raw",
+		Type:         api.Authenticated,
+		Folder:       "swc-storage",
+		PublicID:     name,
+		Overwrite:    api.Bool(true),
+	})
+	if err != nil {
+		return "", err
+	}
+	return resp.SecureURL, nil
+}
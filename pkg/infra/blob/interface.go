@@ -2,6 +2,7 @@ package blob
 
 import (
 	"context"
+	"io"
 	"mime/multipart"
 
 	"github.com/cloudinary/cloudinary-go/v2/api/uploader"
@@ -17,4 +18,6 @@ type IBlobStorage interface {
 	UploadImages(file interface{}) (UploadResult, error)
 	UploadImagesWithContext(ctx context.Context, file interface{}) (UploadResult, error)
 	UploadFile(ctx context.Context, fileHeader *multipart.FileHeader) (url string, err error)
+	UploadRaw(ctx context.Context, name string, file io.Reader) (url string, err error)
+	UploadPrivate(ctx context.Context, name string, file io.Reader) (url string, err error)
 }
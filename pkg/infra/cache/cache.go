@@ -44,10 +44,15 @@ type ICache interface {
 	Set(ctx context.Context, key, val string) error
 	Get(ctx context.Context, key string) (string, error)
 	Del(ctx context.Context, key string) error
+	SetWithTTL(ctx context.Context, key, val string, ttl time.Duration) error
+	SetNX(ctx context.Context, key, val string, ttl time.Duration) (bool, error)
 }
 
 var _ ICache = (*Cache)(nil)
 
+// ErrNotFound is returned by Get when the key does not exist
+var ErrNotFound = redis.Nil
+
 // Cache struct for cache
 type Cache struct {
 	conn *redis.Client
@@ -67,3 +72,13 @@ func (c *Cache) Get(ctx context.Context, key string) (string, error) {
 func (c *Cache) Set(ctx context.Context, key string, val string) error {
 	return c.conn.Set(ctx, key, val, time.Duration(time.Second*5)).Err()
 }
+
+// SetWithTTL implements ICache.
+func (c *Cache) SetWithTTL(ctx context.Context, key string, val string, ttl time.Duration) error {
+	return c.conn.Set(ctx, key, val, ttl).Err()
+}
+
+// SetNX implements ICache.
+func (c *Cache) SetNX(ctx context.Context, key string, val string, ttl time.Duration) (bool, error) {
+	return c.conn.SetNX(ctx, key, val, ttl).Result()
+}
@@ -0,0 +1,60 @@
+package db
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+var _ IDatabase = (*Mock)(nil)
+
+// Mock represents a mock for IDatabase.
+type Mock struct {
+	mock.Mock
+}
+
+// NewDatabaseMock creates a new mock for IDatabase.
+func NewDatabaseMock() *Mock {
+	return &Mock{}
+}
+
+// Query implements IDatabase.
+func (d *Mock) Query(ctx context.Context, sql string, args ...interface{}) (Rows, error) {
+	called := d.Called(ctx, sql, args)
+	rows, _ := called.Get(0).(Rows)
+	return rows, called.Error(1)
+}
+
+// SafeWrite implements IDatabase.
+func (d *Mock) SafeWrite(ctx context.Context, sql string, args ...interface{}) error {
+	called := d.Called(ctx, sql, args)
+	return called.Error(0)
+}
+
+// SafeWriteReturn implements IDatabase.
+func (d *Mock) SafeWriteReturn(ctx context.Context, sql string, args ...interface{}) (int64, error) {
+	called := d.Called(ctx, sql, args)
+	return called.Get(0).(int64), called.Error(1)
+}
+
+var _ ITransaction = (*TxMock)(nil)
+
+// TxMock represents a mock for ITransaction.
+type TxMock struct {
+	Mock
+}
+
+// NewTransactionMock creates a new mock for ITransaction.
+func NewTransactionMock() *TxMock {
+	return &TxMock{}
+}
+
+// Commit implements ITransaction.
+func (t *TxMock) Commit(ctx context.Context) error {
+	return t.Called(ctx).Error(0)
+}
+
+// Rollback implements ITransaction.
+func (t *TxMock) Rollback(ctx context.Context) error {
+	return t.Called(ctx).Error(0)
+}
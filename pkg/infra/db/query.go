@@ -12,3 +12,29 @@ func CollectRow[T any](rows Rows) (T, error) {
 func CollectRows[T any](rows Rows) ([]T, error) {
 	return pgx.CollectRows[T](rows, pgx.RowToStructByName[T])
 }
+
+// CollectValue collects the single column of the first row from the given Rows object
+func CollectValue[T any](rows Rows) (T, error) {
+	return pgx.CollectOneRow[T](rows, pgx.RowTo[T])
+}
+
+// CollectValues collects the single column of all rows from the given Rows object
+func CollectValues[T any](rows Rows) ([]T, error) {
+	return pgx.CollectRows[T](rows, pgx.RowTo[T])
+}
+
+// EachRow calls fn for each row of the given Rows object as it is read, so
+// that large results are never held in memory
+func EachRow[T any](rows Rows, fn func(T) error) error {
+	defer rows.Close()
+	for rows.Next() {
+		row, err := pgx.RowToStructByName[T](rows)
+		if err != nil {
+			return err
+		}
+		if err := fn(row); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
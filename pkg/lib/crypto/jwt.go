@@ -52,8 +52,13 @@ func ParseToken(tokenString string) (userID int64, email string, err error) {
 		return -1, "", err
 	}
 	if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
-		email = claims["email"].(string)
-		userID = int64(claims["user_id"].(float64))
+		email, okEmail := claims["email"].(string)
+		id, okID := claims["user_id"].(float64)
+		if !okEmail || !okID {
+			// e.g. a tracking token sent in place of a login token
+			return -1, "", errors.New("token invalid")
+		}
+		userID = int64(id)
 		// _ = claims["exp"]
 		if float64(time.Now().Unix()) > claims["exp"].(float64) {
 			return -1, "", errors.New("token has expired")
@@ -63,6 +68,26 @@ func ParseToken(tokenString string) (userID int64, email string, err error) {
 	return -1, "", errors.New("token invalid")
 }
 
+// ParseRole returns the role of a valid login token
+func ParseRole(tokenString string) (string, error) {
+	if _, _, err := ParseToken(tokenString); err != nil {
+		return "", err
+	}
+	token, err := claims(RemoveBearerPrefix(tokenString))
+	if err != nil {
+		return "", err
+	}
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid {
+		return "", errors.New("token invalid")
+	}
+	role, ok := claims["role"].(string)
+	if !ok {
+		return "", errors.New("token invalid")
+	}
+	return role, nil
+}
+
 func Authenticate(c echo.Context) (userID int64, email string, err error) {
 	authHeader := c.Request().Header.Get("Authorization")
 	// fmt.Println(authHeader)
@@ -75,3 +100,42 @@ func Authenticate(c echo.Context) (userID int64, email string, err error) {
 	}
 	return userID, email, nil
 }
+
+// trackingSubject is the subject of order tracking tokens, so that they
+// cannot be used in place of a login token and the other way around
+const trackingSubject = "order-tracking"
+
+// GenerateTrackingToken returns a signed token that grants read access to
+// the order orderCode until ttl has passed
+func GenerateTrackingToken(orderCode string, ttl time.Duration) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"sub":        trackingSubject,
+		"order_code": orderCode,
+		"exp":        time.Now().Add(ttl).Unix(),
+	})
+	tokenString, errToken := token.SignedString([]byte(config.JwtSecret))
+	if errToken != nil {
+		return "", errors.New("failed to create token")
+	}
+	return tokenString, nil
+}
+
+// ParseTrackingToken returns the order code of a valid tracking token
+func ParseTrackingToken(tokenString string) (string, error) {
+	token, err := claims(tokenString)
+	if err != nil {
+		return "", err
+	}
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok || !token.Valid {
+		return "", errors.New("token invalid")
+	}
+	if sub, _ := claims.GetSubject(); sub != trackingSubject {
+		return "", errors.New("token invalid")
+	}
+	orderCode, ok := claims["order_code"].(string)
+	if !ok || orderCode == "" {
+		return "", errors.New("token invalid")
+	}
+	return orderCode, nil
+}
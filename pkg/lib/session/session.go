@@ -2,6 +2,8 @@
 package session
 
 import (
+	"crypto/rand"
+	"encoding/hex"
 	"sync"
 
 	"github.com/swclabs/swipex/internal/config"
@@ -13,6 +15,9 @@ import (
 // Base is the base session name
 const Base = "swipe_session"
 
+// GuestKey is the session key of the anonymous visitor id
+const GuestKey = "guest_id"
+
 var store *sessions.CookieStore
 var lock = &sync.Mutex{}
 
@@ -48,3 +53,32 @@ func Get(c echo.Context, sessionName, key string) string {
 	}
 	return value.(string)
 }
+
+// Guest returns the anonymous id of the visitor, empty when the visitor has
+// none yet.
+func Guest(c echo.Context) string {
+	sess, _ := New().Get(c.Request(), Base)
+	id, _ := sess.Values[GuestKey].(string)
+	return id
+}
+
+// GuestID returns the anonymous id of the visitor. On the first visit a new
+// id is generated and saved in the session cookie.
+func GuestID(c echo.Context) (string, error) {
+	sess, _ := New().Get(c.Request(), Base)
+	if id, ok := sess.Values[GuestKey].(string); ok && id != "" {
+		return id, nil
+	}
+	raw := make([]byte, 16)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	id := hex.EncodeToString(raw)
+	sess.Values[GuestKey] = id
+	sess.Options = &sessions.Options{
+		Path:     "/",
+		MaxAge:   int(config.GuestCartTTL.Seconds()),
+		HttpOnly: true,
+	}
+	return id, sess.Save(c.Request(), c.Response())
+}
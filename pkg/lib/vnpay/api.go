@@ -0,0 +1,195 @@
+package vnpay
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidHash is returned when the hash of an answer of the merchant API
+// does not match its content
+var ErrInvalidHash = errors.New("vnpay: invalid hash")
+
+// types of the refunds of the merchant API
+const (
+	RefundFull    = "02"
+	RefundPartial = "03"
+)
+
+var client = &http.Client{Timeout: 30 * time.Second}
+
+// QueryRequest asks the merchant API for the status of a payment.
+// TransactionDate is the vnp_CreateDate of the payment.
+type QueryRequest struct {
+	TxnRef          string
+	TransactionNo   string
+	TransactionDate string
+	OrderInfo       string
+	IPAddress       string
+}
+
+// RefundRequest asks the merchant API to pay back Amount VND of a payment.
+// RequestID is the vnp_RequestId of the refund, a new one when empty: a
+// refund asked again with the same one is a duplicate VNPay declines.
+type RefundRequest struct {
+	RequestID       string
+	TxnRef          string
+	TransactionNo   string
+	TransactionDate string
+	Amount          int64
+	Full            bool
+	OrderInfo       string
+	CreateBy        string
+	IPAddress       string
+}
+
+// TransactionResponse is the answer of the merchant API to a query or a
+// refund
+type TransactionResponse struct {
+	ResponseID        string `json:"vnp_ResponseId"`
+	Command           string `json:"vnp_Command"`
+	ResponseCode      string `json:"vnp_ResponseCode"`
+	Message           string `json:"vnp_Message"`
+	TmnCode           string `json:"vnp_TmnCode"`
+	TxnRef            string `json:"vnp_TxnRef"`
+	Amount            string `json:"vnp_Amount"`
+	OrderInfo         string `json:"vnp_OrderInfo"`
+	BankCode          string `json:"vnp_BankCode"`
+	PayDate           string `json:"vnp_PayDate"`
+	TransactionNo     string `json:"vnp_TransactionNo"`
+	TransactionType   string `json:"vnp_TransactionType"`
+	TransactionStatus string `json:"vnp_TransactionStatus"`
+	PromotionCode     string `json:"vnp_PromotionCode"`
+	PromotionAmount   string `json:"vnp_PromotionAmount"`
+	SecureHash        string `json:"vnp_SecureHash"`
+}
+
+// QueryDR asks the merchant API for the status of a payment
+func (m Merchant) QueryDR(ctx context.Context, req QueryRequest) (*TransactionResponse, error) {
+	body := map[string]string{
+		"vnp_RequestId":       requestID(),
+		"vnp_Version":         Version,
+		"vnp_Command":         "querydr",
+		"vnp_TmnCode":         m.TmnCode,
+		"vnp_TxnRef":          req.TxnRef,
+		"vnp_OrderInfo":       req.OrderInfo,
+		"vnp_TransactionNo":   req.TransactionNo,
+		"vnp_TransactionDate": req.TransactionDate,
+		"vnp_CreateDate":      time.Now().In(Location).Format(CreateDate),
+		"vnp_IpAddr":          req.IPAddress,
+	}
+	body["vnp_SecureHash"] = Sign(pipe(body,
+		"vnp_RequestId", "vnp_Version", "vnp_Command", "vnp_TmnCode", "vnp_TxnRef",
+		"vnp_TransactionDate", "vnp_CreateDate", "vnp_IpAddr", "vnp_OrderInfo",
+	), m.HashSecret)
+	resp, err := m.transaction(ctx, body)
+	if err != nil {
+		return nil, err
+	}
+	return resp, m.verifyAnswer(resp, resp.PromotionCode, resp.PromotionAmount)
+}
+
+// Refund asks the merchant API to pay back a payment in full or in part
+func (m Merchant) Refund(ctx context.Context, req RefundRequest) (*TransactionResponse, error) {
+	kind := RefundPartial
+	if req.Full {
+		kind = RefundFull
+	}
+	id := req.RequestID
+	if id == "" {
+		id = requestID()
+	}
+	body := map[string]string{
+		"vnp_RequestId":       id,
+		"vnp_Version":         Version,
+		"vnp_Command":         "refund",
+		"vnp_TmnCode":         m.TmnCode,
+		"vnp_TransactionType": kind,
+		"vnp_TxnRef":          req.TxnRef,
+		"vnp_Amount":          strconv.FormatInt(req.Amount*100, 10),
+		"vnp_OrderInfo":       req.OrderInfo,
+		"vnp_TransactionNo":   req.TransactionNo,
+		"vnp_TransactionDate": req.TransactionDate,
+		"vnp_CreateBy":        req.CreateBy,
+		"vnp_CreateDate":      time.Now().In(Location).Format(CreateDate),
+		"vnp_IpAddr":          req.IPAddress,
+	}
+	body["vnp_SecureHash"] = Sign(pipe(body,
+		"vnp_RequestId", "vnp_Version", "vnp_Command", "vnp_TmnCode", "vnp_TransactionType",
+		"vnp_TxnRef", "vnp_Amount", "vnp_TransactionNo", "vnp_TransactionDate", "vnp_CreateBy",
+		"vnp_CreateDate", "vnp_IpAddr", "vnp_OrderInfo",
+	), m.HashSecret)
+	resp, err := m.transaction(ctx, body)
+	if err != nil {
+		return nil, err
+	}
+	return resp, m.verifyAnswer(resp)
+}
+
+// transaction posts a request to the merchant API
+func (m Merchant) transaction(ctx context.Context, body map[string]string) (*TransactionResponse, error) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, m.APIURL, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("vnpay: merchant API answered %s", resp.Status)
+	}
+	var answer TransactionResponse
+	if err := json.NewDecoder(resp.Body).Decode(&answer); err != nil {
+		return nil, err
+	}
+	return &answer, nil
+}
+
+// verifyAnswer checks the hash of an answer of the merchant API, extra are
+// the fields signed after vnp_OrderInfo. An answer without a hash is an
+// error of the request, which VNPay does not sign.
+func (m Merchant) verifyAnswer(resp *TransactionResponse, extra ...string) error {
+	if resp.SecureHash == "" {
+		return nil
+	}
+	data := strings.Join(append([]string{
+		resp.ResponseID, resp.Command, resp.ResponseCode, resp.Message, resp.TmnCode, resp.TxnRef,
+		resp.Amount, resp.BankCode, resp.PayDate, resp.TransactionNo, resp.TransactionType,
+		resp.TransactionStatus, resp.OrderInfo,
+	}, extra...), "|")
+	if !strings.EqualFold(Sign(data, m.HashSecret), resp.SecureHash) {
+		return ErrInvalidHash
+	}
+	return nil
+}
+
+// pipe joins the values of keys with |, the data signed by the merchant API
+func pipe(body map[string]string, keys ...string) string {
+	values := make([]string, 0, len(keys))
+	for _, key := range keys {
+		values = append(values, body[key])
+	}
+	return strings.Join(values, "|")
+}
+
+// requestID returns a unique vnp_RequestId
+func requestID() string {
+	id := make([]byte, 16)
+	_, _ = rand.Read(id)
+	return hex.EncodeToString(id)
+}
@@ -0,0 +1,196 @@
+// Package vnpay implements the VNPay payment service in Go, it builds the
+// signed payment URLs and verifies the signature of the callbacks as the
+// Python service in x/vnpay does.
+package vnpay
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/hex"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/swclabs/swipex/pkg/gen/payment"
+)
+
+// Version of the VNPay payment API
+const Version = "2.1.0"
+
+// CreateDate is the layout of the dates of the VNPay API, in the time zone
+// of Vietnam
+const CreateDate = "20060102150405"
+
+// Location is the time zone of the dates of the VNPay API
+var Location = time.FixedZone("ICT", 7*60*60)
+
+// Merchant is the merchant account of the payment gateway, APIURL is the
+// merchant API querying and refunding the payments
+type Merchant struct {
+	TmnCode    string
+	HashSecret string
+	PaymentURL string
+	ReturnURL  string
+	APIURL     string
+}
+
+// Server implements payment.VNPayServer
+type Server struct {
+	payment.UnimplementedVNPayServer
+	merchant Merchant
+	now      func() time.Time
+}
+
+var _ payment.VNPayServer = (*Server)(nil)
+
+// New creates a VNPay server for a merchant account
+func New(merchant Merchant) *Server {
+	return &Server{merchant: merchant, now: time.Now}
+}
+
+// CheckStatus implements payment.VNPayServer.
+func (s *Server) CheckStatus(context.Context, *payment.StatusRequest) (*payment.StatusResponse, error) {
+	return &payment.StatusResponse{Success: true, Message: "Order is processing"}, nil
+}
+
+// ProcessPayment implements payment.VNPayServer.
+func (s *Server) ProcessPayment(_ context.Context, req *payment.PaymentRequest) (*payment.PaymentResponse, error) {
+	params := url.Values{}
+	params.Set("vnp_Version", Version)
+	params.Set("vnp_Command", "pay")
+	params.Set("vnp_TmnCode", s.merchant.TmnCode)
+	params.Set("vnp_Amount", strconv.FormatInt(req.Amount*100, 10))
+	params.Set("vnp_CurrCode", "VND")
+	params.Set("vnp_TxnRef", req.OrderId)
+	params.Set("vnp_OrderInfo", req.OrderDesc)
+	params.Set("vnp_OrderType", req.OrderType)
+	params.Set("vnp_Locale", "vn")
+	if req.Language != "" {
+		params.Set("vnp_Locale", req.Language)
+	}
+	// without a bank code the customer picks the bank on VNPay
+	if req.BankCode != "" {
+		params.Set("vnp_BankCode", req.BankCode)
+	}
+	params.Set("vnp_CreateDate", s.now().In(Location).Format(CreateDate))
+	params.Set("vnp_IpAddr", req.IpAddress)
+	params.Set("vnp_ReturnUrl", s.merchant.ReturnURL)
+
+	query := Query(params)
+	return &payment.PaymentResponse{
+		PaymentUrl: s.merchant.PaymentURL + "?" + query + "&vnp_SecureHash=" + Sign(query, s.merchant.HashSecret),
+		Message:    "Success",
+		Success:    true,
+	}, nil
+}
+
+// ProcessPaymentReturn implements payment.VNPayServer.
+func (s *Server) ProcessPaymentReturn(
+	_ context.Context, req *payment.PaymentReturnRequest) (*payment.PaymentReturnResponse, error) {
+	if !Verify(ReturnParams(req), req.Vnp_SecureHash, s.merchant.HashSecret) {
+		return &payment.PaymentReturnResponse{Result: "fail", Message: "Checksum failed"}, nil
+	}
+	return &payment.PaymentReturnResponse{
+		Success:           true,
+		Result:            "success",
+		Amount:            int64(req.Vnp_Amount),
+		OrderId:           req.Vnp_TxnRef,
+		OrderDesc:         req.Vnp_OrderInfo,
+		Vnp_TransactionNo: strconv.FormatUint(req.Vnp_TransactionNo, 10),
+		Vnp_ResponseCode:  req.Vnp_ResponseCode,
+	}, nil
+}
+
+// Refund implements payment.VNPayServer. The refund is asked to the
+// merchant API, an error is only returned when the API cannot be reached.
+func (s *Server) Refund(ctx context.Context, req *payment.RefundRequest) (*payment.RefundResponse, error) {
+	resp, err := s.merchant.Refund(ctx, RefundRequest{
+		RequestID:       req.RequestId,
+		TxnRef:          req.OrderId,
+		TransactionNo:   req.TransactionNo,
+		TransactionDate: req.TransactionDate,
+		Amount:          req.Amount,
+		Full:            req.Full,
+		OrderInfo:       req.OrderInfo,
+		CreateBy:        req.CreateBy,
+		IPAddress:       req.IpAddress,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &payment.RefundResponse{
+		ResponseCode:  resp.ResponseCode,
+		Message:       resp.Message,
+		TransactionNo: resp.TransactionNo,
+		Success:       resp.ResponseCode == "00",
+	}, nil
+}
+
+// ReturnParams returns the signed parameters of a callback: the parameters
+// as received when the request carries them, else its fields, the fields
+// left empty taken as not sent by VNPay.
+func ReturnParams(req *payment.PaymentReturnRequest) url.Values {
+	params := url.Values{}
+	if len(req.Params) > 0 {
+		for key, value := range req.Params {
+			params.Set(key, value)
+		}
+		return params
+	}
+	set := func(key, value string) {
+		if value != "" {
+			params.Set(key, value)
+		}
+	}
+	set("vnp_TmnCode", req.Vnp_TmnCode)
+	if req.Vnp_Amount != 0 {
+		params.Set("vnp_Amount", strconv.FormatUint(req.Vnp_Amount, 10))
+	}
+	set("vnp_BankCode", req.Vnp_BankCode)
+	set("vnp_BankTranNo", req.Vnp_BankTranNo)
+	set("vnp_CardType", req.Vnp_CardType)
+	set("vnp_PayDate", req.Vnp_PayDate)
+	set("vnp_OrderInfo", req.Vnp_OrderInfo)
+	if req.Vnp_TransactionNo != 0 {
+		params.Set("vnp_TransactionNo", strconv.FormatUint(req.Vnp_TransactionNo, 10))
+	}
+	set("vnp_ResponseCode", req.Vnp_ResponseCode)
+	set("vnp_TransactionStatus", req.Vnp_TransactionStatus)
+	set("vnp_TxnRef", req.Vnp_TxnRef)
+	return params
+}
+
+// Query encodes the vnp_ parameters sorted by key, the hash parameters are
+// left out. It is the data VNPay signs.
+func Query(params url.Values) string {
+	keys := make([]string, 0, len(params))
+	for key := range params {
+		if strings.HasPrefix(key, "vnp_") && key != "vnp_SecureHash" && key != "vnp_SecureHashType" {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	var query strings.Builder
+	for i, key := range keys {
+		if i > 0 {
+			query.WriteByte('&')
+		}
+		query.WriteString(key + "=" + url.QueryEscape(params.Get(key)))
+	}
+	return query.String()
+}
+
+// Sign returns the HMAC-SHA512 of data in hex
+func Sign(data string, secret string) string {
+	mac := hmac.New(sha512.New, []byte(secret))
+	mac.Write([]byte(data))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// Verify checks the hash of the parameters of a callback
+func Verify(params url.Values, hash string, secret string) bool {
+	return hmac.Equal([]byte(Sign(Query(params), secret)), []byte(strings.ToLower(hash)))
+}
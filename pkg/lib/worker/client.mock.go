@@ -0,0 +1,38 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/stretchr/testify/mock"
+)
+
+var _ IWorkerClient = (*Mock)(nil)
+
+// Mock represents a mock for IWorkerClient, the tasks are matched by their
+// type name.
+type Mock struct {
+	mock.Mock
+}
+
+// NewClientMock creates a new mock for IWorkerClient.
+func NewClientMock() *Mock {
+	return &Mock{}
+}
+
+// Exec implements IWorkerClient.
+func (w *Mock) Exec(ctx context.Context, queue string, task *asynq.Task) error {
+	args := w.Called(ctx, queue, task.Type(), task.Payload())
+	return args.Error(0)
+}
+
+// ExecGetResult implements IWorkerClient.
+func (w *Mock) ExecGetResult(_ context.Context, _ string, _ *asynq.Task) ([]byte, error) {
+	panic("unimplemented")
+}
+
+// Delay implements IWorkerClient.
+func (w *Mock) Delay(_ *time.Duration, _ string, _ *asynq.Task) error {
+	panic("unimplemented")
+}
@@ -0,0 +1,134 @@
+// Package xlsx implements a streaming writer of single sheet XLSX files
+package xlsx
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Number is a cell holding a decimal number, such as "1250.50"
+type Number string
+
+const (
+	contentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+		`</Types>`
+
+	rootRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+		`</Relationships>`
+
+	workbook = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" ` +
+		`xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+		`<sheets><sheet name="%s" sheetId="1" r:id="rId1"/></sheets></workbook>`
+
+	workbookRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+		`</Relationships>`
+
+	sheetStart = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`
+
+	sheetEnd = `</sheetData></worksheet>`
+)
+
+// Writer writes the rows of a sheet as they come, only the compressor
+// state is kept in memory
+type Writer struct {
+	zip   *zip.Writer
+	sheet io.Writer
+}
+
+// NewWriter starts an XLSX file with one sheet named sheet on w
+func NewWriter(w io.Writer, sheet string) (*Writer, error) {
+	zw := zip.NewWriter(w)
+	var name strings.Builder
+	_ = xml.EscapeText(&name, []byte(sheet))
+	for _, part := range []struct{ name, body string }{
+		{"[Content_Types].xml", contentTypes},
+		{"_rels/.rels", rootRels},
+		{"xl/workbook.xml", fmt.Sprintf(workbook, name.String())},
+		{"xl/_rels/workbook.xml.rels", workbookRels},
+	} {
+		f, err := zw.Create(part.name)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := io.WriteString(f, part.body); err != nil {
+			return nil, err
+		}
+	}
+	sheetWriter, err := zw.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.WriteString(sheetWriter, sheetStart); err != nil {
+		return nil, err
+	}
+	return &Writer{zip: zw, sheet: sheetWriter}, nil
+}
+
+// Write appends a row. Cells are strings, Number or integers, nil leaves
+// the cell empty.
+func (w *Writer) Write(row []any) error {
+	if _, err := io.WriteString(w.sheet, "<row>"); err != nil {
+		return err
+	}
+	for _, cell := range row {
+		if err := w.writeCell(cell); err != nil {
+			return err
+		}
+	}
+	_, err := io.WriteString(w.sheet, "</row>")
+	return err
+}
+
+func (w *Writer) writeCell(cell any) error {
+	var number string
+	switch v := cell.(type) {
+	case nil:
+		_, err := io.WriteString(w.sheet, "<c/>")
+		return err
+	case Number:
+		number = string(v)
+	case int:
+		number = strconv.Itoa(v)
+	case int64:
+		number = strconv.FormatInt(v, 10)
+	case string:
+		if _, err := io.WriteString(w.sheet, `<c t="inlineStr"><is><t xml:space="preserve">`); err != nil {
+			return err
+		}
+		if err := xml.EscapeText(w.sheet, []byte(v)); err != nil {
+			return err
+		}
+		_, err := io.WriteString(w.sheet, "</t></is></c>")
+		return err
+	default:
+		return fmt.Errorf("xlsx: unsupported cell type %T", cell)
+	}
+	if _, err := strconv.ParseFloat(number, 64); err != nil {
+		return fmt.Errorf("xlsx: %q is not a number", number)
+	}
+	_, err := io.WriteString(w.sheet, "<c><v>"+number+"</v></c>")
+	return err
+}
+
+// Close ends the sheet and the file, it does not close the underlying writer
+func (w *Writer) Close() error {
+	if _, err := io.WriteString(w.sheet, sheetEnd); err != nil {
+		return err
+	}
+	return w.zip.Close()
+}
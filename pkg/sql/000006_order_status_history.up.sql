@@ -0,0 +1,15 @@
+CREATE TABLE "order_status_history" (
+  "id" bigserial PRIMARY KEY,
+  "order_id" bigint NOT NULL,
+  "from_status" varchar NOT NULL,
+  "to_status" varchar NOT NULL,
+  "actor" varchar NOT NULL,
+  "reason" varchar NOT NULL DEFAULT '',
+  "created_at" timestamptz NOT NULL DEFAULT (now() at time zone 'utc')
+);
+
+ALTER TABLE "order_status_history" ADD FOREIGN KEY ("order_id") REFERENCES "orders" ("id") ON DELETE CASCADE ON UPDATE CASCADE;
+
+CREATE INDEX ON "order_status_history" ("order_id");
+
+UPDATE "orders" SET "status" = 'pending' WHERE "status" = 'active';
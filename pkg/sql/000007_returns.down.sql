@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS "refunds" CASCADE;
+
+DROP TABLE IF EXISTS "return_items" CASCADE;
+
+DROP TABLE IF EXISTS "returns" CASCADE;
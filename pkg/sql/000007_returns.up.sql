@@ -0,0 +1,52 @@
+CREATE TABLE "returns" (
+  "id" bigserial PRIMARY KEY,
+  "order_id" bigint NOT NULL,
+  "user_id" bigint NOT NULL,
+  "status" varchar NOT NULL,
+  "reason" varchar NOT NULL,
+  "photos" varchar NOT NULL DEFAULT '',
+  "note" varchar NOT NULL DEFAULT '',
+  "received_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now() at time zone 'utc')
+);
+
+CREATE TABLE "return_items" (
+  "id" bigserial PRIMARY KEY,
+  "return_id" bigint NOT NULL,
+  "product_in_order_id" bigint NOT NULL,
+  "quantity" bigint NOT NULL,
+  "restocked" boolean NOT NULL DEFAULT false
+);
+
+CREATE TABLE "refunds" (
+  "id" bigserial PRIMARY KEY,
+  "order_id" bigint NOT NULL,
+  "return_id" bigint,
+  "amount" NUMERIC(19, 4) NOT NULL,
+  "currency_code" varchar(3) NOT NULL,
+  "status" varchar NOT NULL,
+  "reason" varchar NOT NULL DEFAULT '',
+  "created_at" timestamptz NOT NULL DEFAULT (now() at time zone 'utc')
+);
+
+ALTER TABLE "returns" ADD FOREIGN KEY ("order_id") REFERENCES "orders" ("id") ON DELETE CASCADE ON UPDATE CASCADE;
+
+ALTER TABLE "returns" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE ON UPDATE CASCADE;
+
+ALTER TABLE "return_items" ADD FOREIGN KEY ("return_id") REFERENCES "returns" ("id") ON DELETE CASCADE ON UPDATE CASCADE;
+
+ALTER TABLE "return_items" ADD FOREIGN KEY ("product_in_order_id") REFERENCES "product_in_order" ("id") ON DELETE CASCADE ON UPDATE CASCADE;
+
+ALTER TABLE "refunds" ADD FOREIGN KEY ("order_id") REFERENCES "orders" ("id") ON DELETE CASCADE ON UPDATE CASCADE;
+
+ALTER TABLE "refunds" ADD FOREIGN KEY ("return_id") REFERENCES "returns" ("id") ON DELETE SET NULL ON UPDATE CASCADE;
+
+CREATE INDEX ON "returns" ("order_id");
+
+CREATE INDEX ON "returns" ("user_id");
+
+CREATE INDEX ON "return_items" ("return_id");
+
+CREATE INDEX ON "return_items" ("product_in_order_id");
+
+CREATE INDEX ON "refunds" ("order_id");
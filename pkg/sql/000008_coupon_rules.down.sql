@@ -0,0 +1,6 @@
+ALTER TABLE "coupons"
+  DROP COLUMN IF EXISTS "discount_type",
+  DROP COLUMN IF EXISTS "min_order_value",
+  DROP COLUMN IF EXISTS "max_discount",
+  DROP COLUMN IF EXISTS "category_ids",
+  DROP COLUMN IF EXISTS "inventory_ids";
@@ -0,0 +1,6 @@
+ALTER TABLE "coupons"
+  ADD COLUMN "discount_type" varchar NOT NULL DEFAULT 'percent',
+  ADD COLUMN "min_order_value" NUMERIC(19, 4) NOT NULL DEFAULT 0,
+  ADD COLUMN "max_discount" NUMERIC(19, 4) NOT NULL DEFAULT 0,
+  ADD COLUMN "category_ids" bigint[] NOT NULL DEFAULT '{}',
+  ADD COLUMN "inventory_ids" bigint[] NOT NULL DEFAULT '{}';
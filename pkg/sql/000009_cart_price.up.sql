@@ -0,0 +1,5 @@
+ALTER TABLE "carts" ADD COLUMN "price" NUMERIC(19, 4) NOT NULL DEFAULT 0;
+
+UPDATE "carts" SET "price" = "inventories"."price"
+FROM "inventories"
+WHERE "inventories"."id" = "carts"."inventory_id";
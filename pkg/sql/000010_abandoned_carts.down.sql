@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS "cart_reminders" CASCADE;
+
+ALTER TABLE "users" DROP COLUMN IF EXISTS "cart_reminder_opt_out";
+
+ALTER TABLE "carts" DROP COLUMN IF EXISTS "updated_at";
@@ -0,0 +1,17 @@
+ALTER TABLE "carts" ADD COLUMN "updated_at" timestamptz NOT NULL DEFAULT (now() at time zone 'utc');
+
+ALTER TABLE "users" ADD COLUMN "cart_reminder_opt_out" boolean NOT NULL DEFAULT false;
+
+CREATE TABLE "cart_reminders" (
+  "id" bigserial PRIMARY KEY,
+  "user_id" bigint NOT NULL,
+  "items" bigint NOT NULL,
+  "cart_updated_at" timestamptz NOT NULL,
+  "sent_at" timestamptz NOT NULL DEFAULT (now() at time zone 'utc')
+);
+
+ALTER TABLE "cart_reminders" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE;
+
+CREATE INDEX ON "carts" ("updated_at");
+
+CREATE INDEX ON "cart_reminders" ("user_id", "sent_at");
@@ -0,0 +1,12 @@
+ALTER TABLE "product_in_order"
+  DROP COLUMN IF EXISTS "tax_amount",
+  DROP COLUMN IF EXISTS "vat_rate",
+  DROP COLUMN IF EXISTS "unit_price";
+
+ALTER TABLE "orders"
+  DROP COLUMN IF EXISTS "shipping_fee",
+  DROP COLUMN IF EXISTS "tax_amount",
+  DROP COLUMN IF EXISTS "discount_amount",
+  DROP COLUMN IF EXISTS "subtotal";
+
+ALTER TABLE "categories" DROP COLUMN IF EXISTS "vat_rate";
@@ -0,0 +1,28 @@
+ALTER TABLE "categories" ADD COLUMN "vat_rate" NUMERIC(5, 2) NOT NULL DEFAULT 10;
+
+ALTER TABLE "orders"
+  ADD COLUMN "subtotal" NUMERIC(19, 4) NOT NULL DEFAULT 0,
+  ADD COLUMN "discount_amount" NUMERIC(19, 4) NOT NULL DEFAULT 0,
+  ADD COLUMN "tax_amount" NUMERIC(19, 4) NOT NULL DEFAULT 0,
+  ADD COLUMN "shipping_fee" NUMERIC(19, 4) NOT NULL DEFAULT 0;
+
+ALTER TABLE "product_in_order"
+  ADD COLUMN "unit_price" NUMERIC(19, 4) NOT NULL DEFAULT 0,
+  ADD COLUMN "vat_rate" NUMERIC(5, 2) NOT NULL DEFAULT 0,
+  ADD COLUMN "tax_amount" NUMERIC(19, 4) NOT NULL DEFAULT 0;
+
+-- orders placed before the breakdown were not taxed: whatever separates
+-- the lines from the total is the coupon discount
+UPDATE "product_in_order"
+SET "unit_price" = "total_amount" / "quantity"
+WHERE "quantity" > 0;
+
+UPDATE "orders"
+SET "subtotal" = "lines"."subtotal",
+    "discount_amount" = GREATEST("lines"."subtotal" - "orders"."total_amount", 0)
+FROM (
+  SELECT "order_id", SUM("total_amount") AS "subtotal"
+  FROM "product_in_order"
+  GROUP BY "order_id"
+) AS "lines"
+WHERE "lines"."order_id" = "orders"."id";
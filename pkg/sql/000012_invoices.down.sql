@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS "invoices" CASCADE;
+
+DROP TABLE IF EXISTS "invoice_counter" CASCADE;
@@ -0,0 +1,19 @@
+-- invoice numbers are taken from a single counter row so that they stay
+-- gapless: the increment is rolled back together with a failed invoice
+CREATE TABLE "invoice_counter" (
+  "id" int PRIMARY KEY DEFAULT 1 CHECK ("id" = 1),
+  "last_number" bigint NOT NULL DEFAULT 0
+);
+
+INSERT INTO "invoice_counter" ("id", "last_number") VALUES (1, 0);
+
+CREATE TABLE "invoices" (
+  "id" bigserial PRIMARY KEY,
+  "order_id" bigint NOT NULL UNIQUE,
+  "number" bigint NOT NULL UNIQUE,
+  "issued_at" timestamptz NOT NULL DEFAULT (now() at time zone 'utc'),
+  "html_url" varchar NOT NULL DEFAULT '',
+  "pdf_url" varchar NOT NULL DEFAULT ''
+);
+
+ALTER TABLE "invoices" ADD FOREIGN KEY ("order_id") REFERENCES "orders" ("id") ON DELETE CASCADE;
@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS "product_in_order_order_id_idx";
+
+DROP INDEX IF EXISTS "coupons_used_order_id_idx";
+
+DROP INDEX IF EXISTS "orders_status_idx";
+
+DROP INDEX IF EXISTS "orders_total_amount_id_idx";
+
+DROP INDEX IF EXISTS "orders_time_id_idx";
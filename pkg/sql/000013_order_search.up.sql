@@ -0,0 +1,9 @@
+CREATE INDEX "orders_time_id_idx" ON "orders" ("time", "id");
+
+CREATE INDEX "orders_total_amount_id_idx" ON "orders" ("total_amount", "id");
+
+CREATE INDEX "orders_status_idx" ON "orders" ("status");
+
+CREATE INDEX "coupons_used_order_id_idx" ON "coupons_used" ("order_id");
+
+CREATE INDEX "product_in_order_order_id_idx" ON "product_in_order" ("order_id");
@@ -0,0 +1,13 @@
+DROP INDEX IF EXISTS "product_in_order_backordered_idx";
+
+ALTER TABLE "orders" DROP COLUMN IF EXISTS "deposit_amount";
+
+ALTER TABLE "product_in_order" DROP COLUMN IF EXISTS "backordered";
+
+ALTER TABLE "inventories" DROP COLUMN IF EXISTS "deposit_percent";
+
+ALTER TABLE "inventories" DROP COLUMN IF EXISTS "expected_ship_date";
+
+ALTER TABLE "inventories" DROP CONSTRAINT IF EXISTS "inventories_available_check";
+
+ALTER TABLE "inventories" DROP CONSTRAINT IF EXISTS "inventories_status_check";
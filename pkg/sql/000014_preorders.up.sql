@@ -0,0 +1,26 @@
+-- statuses other than the known ones were free text before, they are
+-- treated as active
+UPDATE "inventories" SET "status" = 'active'
+WHERE "status" NOT IN ('active', 'draft', 'archived', 'preorder');
+
+ALTER TABLE "inventories" ADD CONSTRAINT "inventories_status_check"
+  CHECK ("status" IN ('active', 'draft', 'archived', 'preorder'));
+
+ALTER TABLE "inventories" ADD CONSTRAINT "inventories_available_check"
+  CHECK ("available" >= 0);
+
+ALTER TABLE "inventories" ADD COLUMN "expected_ship_date" date;
+
+ALTER TABLE "inventories" ADD COLUMN "deposit_percent" NUMERIC(5, 2) NOT NULL DEFAULT 0
+  CHECK ("deposit_percent" >= 0 AND "deposit_percent" <= 100);
+
+-- units of a pre-order line still waiting for stock
+ALTER TABLE "product_in_order" ADD COLUMN "backordered" int NOT NULL DEFAULT 0
+  CHECK ("backordered" >= 0 AND "backordered" <= "quantity");
+
+-- part of the total paid at checkout when pre-order lines take a deposit,
+-- 0 when the whole total is due
+ALTER TABLE "orders" ADD COLUMN "deposit_amount" NUMERIC(19, 4) NOT NULL DEFAULT 0;
+
+CREATE INDEX "product_in_order_backordered_idx" ON "product_in_order" ("inventory_id")
+  WHERE "backordered" > 0;
@@ -0,0 +1,7 @@
+ALTER TABLE "product_in_order" DROP COLUMN IF EXISTS "bundle_id";
+
+DROP TABLE IF EXISTS "cart_bundles";
+
+DROP TABLE IF EXISTS "bundle_items";
+
+DROP TABLE IF EXISTS "bundles";
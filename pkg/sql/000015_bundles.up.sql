@@ -0,0 +1,40 @@
+CREATE TABLE "bundles" (
+  "id" bigserial PRIMARY KEY,
+  "name" varchar NOT NULL,
+  "description" varchar NOT NULL DEFAULT '',
+  "image" varchar NOT NULL DEFAULT '',
+  "price" NUMERIC(19, 4) NOT NULL CHECK ("price" >= 0),
+  "currency_code" varchar(3) NOT NULL DEFAULT 'VND',
+  "status" varchar NOT NULL DEFAULT 'active' CHECK ("status" IN ('active', 'archived')),
+  "created_at" timestamptz NOT NULL DEFAULT (now() at time zone 'utc')
+);
+
+CREATE TABLE "bundle_items" (
+  "bundle_id" bigint NOT NULL,
+  "inventory_id" bigint NOT NULL,
+  "quantity" int NOT NULL CHECK ("quantity" > 0),
+  PRIMARY KEY ("bundle_id", "inventory_id")
+);
+
+ALTER TABLE "bundle_items" ADD FOREIGN KEY ("bundle_id") REFERENCES "bundles" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "bundle_items" ADD FOREIGN KEY ("inventory_id") REFERENCES "inventories" ("id");
+
+CREATE TABLE "cart_bundles" (
+  "id" bigserial PRIMARY KEY,
+  "user_id" bigint NOT NULL,
+  "bundle_id" bigint NOT NULL,
+  "quantity" bigint NOT NULL CHECK ("quantity" > 0),
+  "updated_at" timestamptz NOT NULL DEFAULT (now() at time zone 'utc'),
+  CONSTRAINT "unique_cart_bundle" UNIQUE ("user_id", "bundle_id")
+);
+
+ALTER TABLE "cart_bundles" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "cart_bundles" ADD FOREIGN KEY ("bundle_id") REFERENCES "bundles" ("id") ON DELETE CASCADE;
+
+-- the rows of a bundle bought in an order point to it, the bundle price
+-- is spread over them
+ALTER TABLE "product_in_order" ADD COLUMN "bundle_id" bigint;
+
+ALTER TABLE "product_in_order" ADD FOREIGN KEY ("bundle_id") REFERENCES "bundles" ("id") ON DELETE SET NULL;
@@ -0,0 +1,7 @@
+ALTER TABLE "orders" DROP COLUMN IF EXISTS "credit_amount";
+
+DROP TABLE IF EXISTS "credit_ledger";
+
+DROP TABLE IF EXISTS "wallets";
+
+DROP TABLE IF EXISTS "gift_cards";
@@ -0,0 +1,58 @@
+CREATE TABLE "gift_cards" (
+  "id" bigserial PRIMARY KEY,
+  "code" varchar NOT NULL UNIQUE,
+  "initial_balance" NUMERIC(19, 4) NOT NULL CHECK ("initial_balance" > 0),
+  "balance" NUMERIC(19, 4) NOT NULL CHECK ("balance" >= 0),
+  "currency_code" varchar(3) NOT NULL DEFAULT 'VND',
+  "status" varchar NOT NULL DEFAULT 'active' CHECK ("status" IN ('active', 'voided')),
+  "expires_at" timestamptz,
+  "order_id" bigint,
+  "note" varchar NOT NULL DEFAULT '',
+  "voided_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now() at time zone 'utc')
+);
+
+-- the order a sold gift card was paid with, NULL for issued cards
+ALTER TABLE "gift_cards" ADD FOREIGN KEY ("order_id") REFERENCES "orders" ("id") ON DELETE SET NULL;
+
+CREATE TABLE "wallets" (
+  "user_id" bigint PRIMARY KEY,
+  "balance" NUMERIC(19, 4) NOT NULL DEFAULT 0 CHECK ("balance" >= 0),
+  "currency_code" varchar(3) NOT NULL DEFAULT 'VND',
+  "updated_at" timestamptz NOT NULL DEFAULT (now() at time zone 'utc')
+);
+
+ALTER TABLE "wallets" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE;
+
+-- every movement of a gift card or wallet balance, the balances are the sum
+-- of their entries. balance is the balance of the account after the entry.
+CREATE TABLE "credit_ledger" (
+  "id" bigserial PRIMARY KEY,
+  "gift_card_id" bigint,
+  "user_id" bigint,
+  "order_id" bigint,
+  "amount" NUMERIC(19, 4) NOT NULL CHECK ("amount" <> 0),
+  "balance" NUMERIC(19, 4) NOT NULL CHECK ("balance" >= 0),
+  "kind" varchar NOT NULL CHECK ("kind" IN ('issue', 'redeem', 'refund', 'reversal', 'void')),
+  "actor" varchar NOT NULL DEFAULT '',
+  "note" varchar NOT NULL DEFAULT '',
+  "created_at" timestamptz NOT NULL DEFAULT (now() at time zone 'utc'),
+  CONSTRAINT "credit_ledger_account_check" CHECK (num_nonnulls("gift_card_id", "user_id") = 1)
+);
+
+ALTER TABLE "credit_ledger" ADD FOREIGN KEY ("gift_card_id") REFERENCES "gift_cards" ("id");
+
+ALTER TABLE "credit_ledger" ADD FOREIGN KEY ("user_id") REFERENCES "wallets" ("user_id") ON DELETE CASCADE;
+
+ALTER TABLE "credit_ledger" ADD FOREIGN KEY ("order_id") REFERENCES "orders" ("id") ON DELETE SET NULL;
+
+CREATE INDEX ON "credit_ledger" ("gift_card_id");
+
+CREATE INDEX ON "credit_ledger" ("user_id");
+
+CREATE INDEX ON "credit_ledger" ("order_id");
+
+-- part of the total paid with gift cards and store credit, the rest is
+-- paid with payment_method
+ALTER TABLE "orders" ADD COLUMN "credit_amount" NUMERIC(19, 4) NOT NULL DEFAULT 0
+  CHECK ("credit_amount" >= 0);
@@ -0,0 +1,9 @@
+ALTER TABLE "orders" DROP COLUMN IF EXISTS "points_amount";
+
+ALTER TABLE "orders" DROP COLUMN IF EXISTS "points_redeemed";
+
+DROP TABLE IF EXISTS "loyalty_ledger";
+
+ALTER TABLE "users" DROP COLUMN IF EXISTS "lifetime_points";
+
+ALTER TABLE "users" DROP COLUMN IF EXISTS "loyalty_points";
@@ -0,0 +1,39 @@
+-- loyalty_points is the balance that can be redeemed, lifetime_points the
+-- points earned and not reversed, which set the tier
+ALTER TABLE "users" ADD COLUMN "loyalty_points" bigint NOT NULL DEFAULT 0
+  CHECK ("loyalty_points" >= 0);
+
+ALTER TABLE "users" ADD COLUMN "lifetime_points" bigint NOT NULL DEFAULT 0
+  CHECK ("lifetime_points" >= 0);
+
+-- every movement of the loyalty points of a user. Points are earned in
+-- lots: remaining is what is left of an earned lot, spent oldest expiry
+-- first, and expires_at is when it is left to expire.
+CREATE TABLE "loyalty_ledger" (
+  "id" bigserial PRIMARY KEY,
+  "user_id" bigint NOT NULL,
+  "order_id" bigint,
+  "points" bigint NOT NULL CHECK ("points" <> 0),
+  "remaining" bigint NOT NULL DEFAULT 0 CHECK ("remaining" >= 0),
+  "kind" varchar NOT NULL CHECK ("kind" IN ('earn', 'redeem', 'reverse', 'restore', 'expire')),
+  "expires_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now() at time zone 'utc')
+);
+
+ALTER TABLE "loyalty_ledger" ADD FOREIGN KEY ("user_id") REFERENCES "users" ("id") ON DELETE CASCADE;
+
+ALTER TABLE "loyalty_ledger" ADD FOREIGN KEY ("order_id") REFERENCES "orders" ("id") ON DELETE SET NULL;
+
+CREATE INDEX ON "loyalty_ledger" ("user_id");
+
+CREATE INDEX ON "loyalty_ledger" ("order_id");
+
+CREATE INDEX "loyalty_ledger_lots_idx" ON "loyalty_ledger" ("expires_at")
+  WHERE "remaining" > 0;
+
+-- points redeemed at checkout and the part of the total they paid
+ALTER TABLE "orders" ADD COLUMN "points_redeemed" bigint NOT NULL DEFAULT 0
+  CHECK ("points_redeemed" >= 0);
+
+ALTER TABLE "orders" ADD COLUMN "points_amount" NUMERIC(19, 4) NOT NULL DEFAULT 0
+  CHECK ("points_amount" >= 0);
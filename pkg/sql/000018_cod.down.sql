@@ -0,0 +1,5 @@
+ALTER TABLE "orders" DROP COLUMN IF EXISTS "paid_at";
+
+DROP TABLE IF EXISTS "cod_remittances";
+
+DROP TABLE IF EXISTS "cod_collections";
@@ -0,0 +1,40 @@
+-- the cash collected by the carrier on delivery of a COD order, reconciled
+-- against its remittances. remitted_amount is the sum of the matched
+-- remittance lines.
+CREATE TABLE "cod_collections" (
+  "order_id" bigint PRIMARY KEY,
+  "expected_amount" NUMERIC(19, 4) NOT NULL CHECK ("expected_amount" >= 0),
+  "remitted_amount" NUMERIC(19, 4) NOT NULL DEFAULT 0,
+  "status" varchar NOT NULL DEFAULT 'pending'
+    CHECK ("status" IN ('pending', 'reconciled', 'short', 'mismatch')),
+  "reconciled_at" timestamptz,
+  "created_at" timestamptz NOT NULL DEFAULT (now() at time zone 'utc')
+);
+
+ALTER TABLE "cod_collections" ADD FOREIGN KEY ("order_id") REFERENCES "orders" ("id") ON DELETE CASCADE;
+
+CREATE INDEX "cod_collections_open_idx" ON "cod_collections" ("created_at")
+  WHERE "status" <> 'reconciled';
+
+-- the lines of the carrier remittance reports, a line is only imported once
+-- per report. order_id is NULL for the lines matching no COD order.
+CREATE TABLE "cod_remittances" (
+  "id" bigserial PRIMARY KEY,
+  "source" varchar NOT NULL CHECK ("source" IN ('csv', 'api')),
+  "reference" varchar NOT NULL,
+  "carrier_order_code" varchar NOT NULL,
+  "order_code" varchar NOT NULL DEFAULT '',
+  "order_id" bigint,
+  "amount" NUMERIC(19, 4) NOT NULL,
+  "result" varchar NOT NULL CHECK ("result" IN ('matched', 'short', 'over', 'unmatched')),
+  "remitted_at" timestamptz,
+  "actor" varchar NOT NULL DEFAULT '',
+  "created_at" timestamptz NOT NULL DEFAULT (now() at time zone 'utc'),
+  UNIQUE ("reference", "carrier_order_code")
+);
+
+ALTER TABLE "cod_remittances" ADD FOREIGN KEY ("order_id") REFERENCES "orders" ("id") ON DELETE SET NULL;
+
+CREATE INDEX ON "cod_remittances" ("order_id");
+
+ALTER TABLE "orders" ADD COLUMN "paid_at" timestamptz;
@@ -0,0 +1,5 @@
+ALTER TABLE "orders"
+  DROP COLUMN IF EXISTS "exchange_rate",
+  DROP COLUMN IF EXISTS "currency_code";
+
+DROP TABLE IF EXISTS "exchange_rates";
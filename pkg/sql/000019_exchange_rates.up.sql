@@ -0,0 +1,15 @@
+-- the value of one unit of a currency in the base currency of the shop,
+-- which has no row and a rate of 1
+CREATE TABLE "exchange_rates" (
+  "currency_code" varchar(3) PRIMARY KEY,
+  "rate" NUMERIC(24, 10) NOT NULL CHECK ("rate" > 0),
+  "source" varchar NOT NULL DEFAULT 'admin' CHECK ("source" IN ('admin', 'file')),
+  "updated_by" varchar NOT NULL DEFAULT '',
+  "updated_at" timestamptz NOT NULL DEFAULT (now() at time zone 'utc')
+);
+
+-- the currency an order is priced in and the rate of that currency when it
+-- was placed
+ALTER TABLE "orders"
+  ADD COLUMN "currency_code" varchar(3) NOT NULL DEFAULT 'VND',
+  ADD COLUMN "exchange_rate" NUMERIC(24, 10) NOT NULL DEFAULT 1 CHECK ("exchange_rate" > 0);
@@ -0,0 +1,4 @@
+UPDATE "orders" SET "status" = 'shipping' WHERE "status" = 'partially_shipped';
+
+DROP TABLE IF EXISTS "shipment_items";
+DROP TABLE IF EXISTS "shipments";
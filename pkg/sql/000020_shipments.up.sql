@@ -0,0 +1,40 @@
+-- an order is fulfilled by one or more shipments, each with its own
+-- delivery and carrier order, covering part of the units of its lines
+CREATE TABLE "shipments" (
+  "id" bigserial PRIMARY KEY,
+  "order_id" bigint NOT NULL,
+  "delivery_id" bigint NOT NULL,
+  "carrier_order_code" varchar UNIQUE,
+  "status" varchar NOT NULL DEFAULT 'pending' CHECK ("status" IN ('pending', 'shipping', 'delivered')),
+  "actor" varchar NOT NULL DEFAULT '',
+  "created_at" timestamptz NOT NULL DEFAULT (now() at time zone 'utc'),
+  "shipped_at" timestamptz,
+  "delivered_at" timestamptz
+);
+
+CREATE TABLE "shipment_items" (
+  "shipment_id" bigint NOT NULL,
+  "item_id" bigint NOT NULL,
+  "quantity" bigint NOT NULL CHECK ("quantity" > 0),
+  PRIMARY KEY ("shipment_id", "item_id")
+);
+
+ALTER TABLE "shipments" ADD FOREIGN KEY ("order_id") REFERENCES "orders" ("id") ON DELETE CASCADE ON UPDATE CASCADE;
+ALTER TABLE "shipments" ADD FOREIGN KEY ("delivery_id") REFERENCES "deliveries" ("id") ON DELETE CASCADE ON UPDATE CASCADE;
+ALTER TABLE "shipment_items" ADD FOREIGN KEY ("shipment_id") REFERENCES "shipments" ("id") ON DELETE CASCADE ON UPDATE CASCADE;
+ALTER TABLE "shipment_items" ADD FOREIGN KEY ("item_id") REFERENCES "product_in_order" ("id") ON DELETE CASCADE ON UPDATE CASCADE;
+
+CREATE INDEX ON "shipments" ("order_id");
+CREATE INDEX ON "shipment_items" ("item_id");
+
+-- the orders already handed to the carrier went out in a single shipment
+-- with the delivery of the order
+INSERT INTO "shipments" ("order_id", "delivery_id", "status", "actor", "shipped_at", "delivered_at")
+SELECT "id", "delivery_id", "status", 'migration', "time",
+  CASE WHEN "status" = 'delivered' THEN "time" END
+FROM "orders" WHERE "status" IN ('shipping', 'delivered');
+
+INSERT INTO "shipment_items" ("shipment_id", "item_id", "quantity")
+SELECT "shipments"."id", "product_in_order"."id", "product_in_order"."quantity"
+FROM "shipments" JOIN "product_in_order" ON "product_in_order"."order_id" = "shipments"."order_id"
+WHERE "shipments"."actor" = 'migration';
@@ -0,0 +1,6 @@
+-- the result of the online payment of an order, a failed payment can still
+-- be retried until the order is paid.
+ALTER TABLE "orders" ADD COLUMN "payment_status" varchar NOT NULL DEFAULT 'unpaid'
+  CHECK ("payment_status" IN ('unpaid', 'paid', 'failed'));
+
+UPDATE "orders" SET "payment_status" = 'paid' WHERE "paid_at" IS NOT NULL;
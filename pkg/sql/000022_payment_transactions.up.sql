@@ -0,0 +1,21 @@
+-- the ledger of the payments of the orders, a row for each payment created
+-- and each callback of the provider. order_uuid is not a foreign key, the
+-- callbacks naming no known order are recorded too.
+CREATE TABLE "payment_transactions" (
+  "id" bigserial PRIMARY KEY,
+  "order_uuid" varchar NOT NULL,
+  "provider" varchar NOT NULL,
+  "event" varchar NOT NULL CHECK ("event" IN ('create', 'return', 'ipn')),
+  "status" varchar NOT NULL
+    CHECK ("status" IN ('pending', 'succeeded', 'failed', 'rejected')),
+  "amount" NUMERIC(19, 4) NOT NULL DEFAULT 0,
+  "currency_code" varchar(3) NOT NULL DEFAULT 'VND',
+  "transaction_no" varchar NOT NULL DEFAULT '',
+  "bank_code" varchar NOT NULL DEFAULT '',
+  "response_code" varchar NOT NULL DEFAULT '',
+  "message" varchar NOT NULL DEFAULT '',
+  "payload" jsonb NOT NULL DEFAULT '{}',
+  "created_at" timestamptz NOT NULL DEFAULT (now() at time zone 'utc')
+);
+
+CREATE INDEX ON "payment_transactions" ("order_uuid", "id");
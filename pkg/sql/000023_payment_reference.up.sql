@@ -0,0 +1,3 @@
+-- the reference the provider of a payment needs to query or refund it: the
+-- date VNPay created the payment, the id of the MoMo or ZaloPay payment.
+ALTER TABLE "payment_transactions" ADD COLUMN "reference" varchar NOT NULL DEFAULT '';
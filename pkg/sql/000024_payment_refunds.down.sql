@@ -0,0 +1,17 @@
+DELETE FROM "payment_transactions" WHERE "event" = 'refund';
+
+ALTER TABLE "payment_transactions" DROP CONSTRAINT IF EXISTS "payment_transactions_event_check";
+
+ALTER TABLE "payment_transactions" ADD CONSTRAINT "payment_transactions_event_check"
+  CHECK ("event" IN ('create', 'return', 'ipn'));
+
+ALTER TABLE "refunds"
+  DROP COLUMN IF EXISTS "provider",
+  DROP COLUMN IF EXISTS "transaction_no",
+  DROP COLUMN IF EXISTS "full_refund",
+  DROP COLUMN IF EXISTS "refund_no",
+  DROP COLUMN IF EXISTS "response_code",
+  DROP COLUMN IF EXISTS "message",
+  DROP COLUMN IF EXISTS "attempts",
+  DROP COLUMN IF EXISTS "actor",
+  DROP COLUMN IF EXISTS "updated_at";
@@ -0,0 +1,19 @@
+-- a refund paid back through the provider of the payment of its order,
+-- transaction_no is the payment refunded and refund_no the reference of the
+-- refund at the provider. The refunds of the returns have no provider until
+-- they are paid out.
+ALTER TABLE "refunds"
+  ADD COLUMN "provider" varchar NOT NULL DEFAULT '',
+  ADD COLUMN "transaction_no" varchar NOT NULL DEFAULT '',
+  ADD COLUMN "full_refund" boolean NOT NULL DEFAULT false,
+  ADD COLUMN "refund_no" varchar NOT NULL DEFAULT '',
+  ADD COLUMN "response_code" varchar NOT NULL DEFAULT '',
+  ADD COLUMN "message" varchar NOT NULL DEFAULT '',
+  ADD COLUMN "attempts" integer NOT NULL DEFAULT 0,
+  ADD COLUMN "actor" varchar NOT NULL DEFAULT '',
+  ADD COLUMN "updated_at" timestamptz NOT NULL DEFAULT (now() at time zone 'utc');
+
+ALTER TABLE "payment_transactions" DROP CONSTRAINT IF EXISTS "payment_transactions_event_check";
+
+ALTER TABLE "payment_transactions" ADD CONSTRAINT "payment_transactions_event_check"
+  CHECK ("event" IN ('create', 'return', 'ipn', 'refund'));
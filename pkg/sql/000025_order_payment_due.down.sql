@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS "orders_payment_due_at_idx";
+ALTER TABLE "orders" DROP COLUMN IF EXISTS "payment_due_at";
@@ -0,0 +1,7 @@
+-- the time an order placed for an online payment expires unless it is paid.
+-- It is not set for COD orders, for orders with nothing left to pay online,
+-- nor for the orders placed before it existed: these never expire.
+ALTER TABLE "orders" ADD COLUMN "payment_due_at" timestamptz;
+
+CREATE INDEX "orders_payment_due_at_idx" ON "orders" ("payment_due_at")
+  WHERE "paid_at" IS NULL;
@@ -0,0 +1,4 @@
+UPDATE "payment_transactions" SET "status" = 'succeeded' WHERE "status" = 'refund_due';
+ALTER TABLE "payment_transactions" DROP CONSTRAINT IF EXISTS "payment_transactions_status_check";
+ALTER TABLE "payment_transactions" ADD CONSTRAINT "payment_transactions_status_check"
+  CHECK ("status" IN ('pending', 'succeeded', 'failed', 'rejected'));
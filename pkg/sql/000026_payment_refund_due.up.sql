@@ -0,0 +1,5 @@
+-- a payment received for an order that was cancelled or expired meanwhile
+-- is recorded as refund_due, the money has to be given back.
+ALTER TABLE "payment_transactions" DROP CONSTRAINT IF EXISTS "payment_transactions_status_check";
+ALTER TABLE "payment_transactions" ADD CONSTRAINT "payment_transactions_status_check"
+  CHECK ("status" IN ('pending', 'succeeded', 'failed', 'rejected', 'refund_due'));
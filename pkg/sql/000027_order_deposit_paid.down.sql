@@ -0,0 +1,4 @@
+UPDATE "orders" SET "paid_at" = "deposit_paid_at", "payment_status" = 'paid'
+WHERE "paid_at" IS NULL AND "deposit_paid_at" IS NOT NULL;
+
+ALTER TABLE "orders" DROP COLUMN IF EXISTS "deposit_paid_at";
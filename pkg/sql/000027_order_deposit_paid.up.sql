@@ -0,0 +1,7 @@
+-- the time the deposit of a pre-order was paid, the order is paid once its
+-- balance is paid too. The pre-orders paid before record their deposit.
+ALTER TABLE "orders" ADD COLUMN "deposit_paid_at" timestamptz;
+
+UPDATE "orders"
+SET "deposit_paid_at" = "paid_at", "paid_at" = NULL, "payment_status" = 'unpaid', "payment_due_at" = NULL
+WHERE "deposit_amount" > 0 AND "paid_at" IS NOT NULL;
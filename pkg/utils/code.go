@@ -1,6 +1,7 @@
 package utils
 
 import (
+	cryptorand "crypto/rand"
 	"math/rand"
 	"time"
 )
@@ -28,3 +29,31 @@ func GenCouponsCode(length int) string {
 
 	return string(orderCode)
 }
+
+// GenGiftCardCode returns a code read from crypto/rand: whoever knows the
+// code of a gift card can spend it, so it must not be guessable.
+func GenGiftCardCode(length int) string {
+	const charset = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+	buf := make([]byte, length)
+	if _, err := cryptorand.Read(buf); err != nil {
+		panic(err)
+	}
+	for i := range buf {
+		buf[i] = charset[int(buf[i])%len(charset)]
+	}
+	return string(buf)
+}
+
+// GenSecretName returns a name read from crypto/rand, for the files that
+// must not be found by guessing their name.
+func GenSecretName(length int) string {
+	const charset = "abcdefghijklmnopqrstuvwxyz0123456789"
+	buf := make([]byte, length)
+	if _, err := cryptorand.Read(buf); err != nil {
+		panic(err)
+	}
+	for i := range buf {
+		buf[i] = charset[int(buf[i])%len(charset)]
+	}
+	return string(buf)
+}
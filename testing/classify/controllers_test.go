@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	classifyContainer "github.com/swclabs/swipex/internal/apis/container/classify"
@@ -44,3 +45,21 @@ func TestGetSuppliers(t *testing.T) {
 	expected := "{\"body\":[{\"id\":1,\"name\":\"apple\",\"email\":\"apple@example.com\"}]}\n"
 	assert.Equal(t, expected, rr.Body.String(), "response body should match expected")
 }
+
+func TestUpdateCategoryVATRate(t *testing.T) {
+	// an out of range VAT rate is rejected before reaching the repository
+	services := classifyService.New(nil, nil)
+	controllers := classifyContainer.NewController(services)
+
+	e.PUT("/categories", controllers.UpdateCategory)
+
+	body := `{"id":1,"name":"phone","description":"smartphones","vat_rate":"150"}`
+	req := httptest.NewRequest(http.MethodPut, "/categories", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rr := httptest.NewRecorder()
+
+	e.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Contains(t, rr.Body.String(), "vat_rate")
+}
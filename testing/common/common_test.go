@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"maps"
 	"testing"
+	"time"
 
 	"github.com/swclabs/swipex/pkg/lib/crypto"
 
@@ -75,3 +76,27 @@ func TestDecimal(t *testing.T) {
 	fmt.Println(totalAmount, newAmount)
 	t.Log(totalAmount, newAmount)
 }
+
+func TestTrackingToken(t *testing.T) {
+	token, err := crypto.GenerateTrackingToken("ORDER123", time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	code, err := crypto.ParseTrackingToken(token)
+	if err != nil || code != "ORDER123" {
+		t.Errorf("should have order code ORDER123, got %q (%v)", code, err)
+	}
+	if _, _, err := crypto.ParseToken(token); err == nil {
+		t.Error("tracking token should not be accepted as a login token")
+	}
+
+	expired, _ := crypto.GenerateTrackingToken("ORDER123", -time.Hour)
+	if _, err := crypto.ParseTrackingToken(expired); err == nil {
+		t.Error("expired tracking token should be rejected")
+	}
+
+	login, _ := crypto.GenerateToken(1, "user@example.com", "customer")
+	if _, err := crypto.ParseTrackingToken(login); err == nil {
+		t.Error("login token should not be accepted as a tracking token")
+	}
+}
@@ -0,0 +1,42 @@
+package test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/swclabs/swipex/internal/apis/middleware"
+	"github.com/swclabs/swipex/internal/config"
+	"github.com/swclabs/swipex/pkg/lib/crypto"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+// adminRequest sends a request to a route behind the admin middleware,
+// signed in with the role when it is not empty.
+func adminRequest(t *testing.T, role string) int {
+	t.Helper()
+	config.JwtSecret = "secret"
+	e := echo.New()
+	e.GET("/admin", func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	}, middleware.Admin)
+
+	req := httptest.NewRequest(http.MethodGet, "/admin", nil)
+	if role != "" {
+		token, err := crypto.GenerateToken(1, "sa@sa.com", role)
+		assert.NoError(t, err)
+		req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+	}
+	rr := httptest.NewRecorder()
+	e.ServeHTTP(rr, req)
+	return rr.Code
+}
+
+func TestAdminMiddleware(t *testing.T) {
+	assert.Equal(t, http.StatusUnauthorized, adminRequest(t, ""))
+	assert.Equal(t, http.StatusForbidden, adminRequest(t, "customer"))
+	// the seeded admin account is stored as "Admin"
+	assert.Equal(t, http.StatusOK, adminRequest(t, "Admin"))
+}
@@ -0,0 +1,216 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/swclabs/swipex/internal/apis/middleware"
+
+	"github.com/labstack/echo/v4"
+	echomw "github.com/labstack/echo/v4/middleware"
+	"github.com/stretchr/testify/assert"
+)
+
+// memoryCache is an in-memory cache.ICache without expiration, its writes
+// fail on a cancelled context like those of redis
+type memoryCache struct {
+	mu   sync.Mutex
+	data map[string]string
+}
+
+func (m *memoryCache) Set(ctx context.Context, key, val string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.data[key] = val
+	return nil
+}
+
+func (m *memoryCache) Get(_ context.Context, key string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	val, ok := m.data[key]
+	if !ok {
+		return "", errors.New("key not found")
+	}
+	return val, nil
+}
+
+func (m *memoryCache) Del(ctx context.Context, key string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.data, key)
+	return nil
+}
+
+func (m *memoryCache) SetWithTTL(ctx context.Context, key, val string, _ time.Duration) error {
+	return m.Set(ctx, key, val)
+}
+
+func (m *memoryCache) SetNX(_ context.Context, key, val string, _ time.Duration) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if _, ok := m.data[key]; ok {
+		return false, nil
+	}
+	m.data[key] = val
+	return true, nil
+}
+
+func TestIdempotency(t *testing.T) {
+	var (
+		e     = echo.New()
+		calls = 0
+	)
+	e.POST("/orders", func(c echo.Context) error {
+		calls++
+		return c.JSON(http.StatusCreated, map[string]int{"order": calls})
+	}, middleware.Idempotency(&memoryCache{data: map[string]string{}}, time.Hour))
+
+	send := func(key, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		if key != "" {
+			req.Header.Set(middleware.HeaderIdempotencyKey, key)
+		}
+		rr := httptest.NewRecorder()
+		e.ServeHTTP(rr, req)
+		return rr
+	}
+
+	first := send("key-1", `{"quantity":1}`)
+	assert.Equal(t, http.StatusCreated, first.Code)
+
+	retry := send("key-1", `{"quantity":1}`)
+	assert.Equal(t, http.StatusCreated, retry.Code)
+	assert.Equal(t, first.Body.String(), retry.Body.String(), "retry should replay the first response")
+	assert.Equal(t, "true", retry.Header().Get(middleware.HeaderIdempotentReplayed))
+	assert.Equal(t, 1, calls, "retry must not reach the handler")
+
+	mismatch := send("key-1", `{"quantity":2}`)
+	assert.Equal(t, http.StatusUnprocessableEntity, mismatch.Code)
+
+	send("", `{"quantity":1}`)
+	send("key-2", `{"quantity":1}`)
+	assert.Equal(t, 3, calls, "requests without a key or with a new key must reach the handler")
+}
+
+func TestIdempotencyScopedToCaller(t *testing.T) {
+	var (
+		e     = echo.New()
+		calls = 0
+	)
+	e.POST("/payment", func(c echo.Context) error {
+		calls++
+		return c.JSON(http.StatusCreated, map[string]int{"payment": calls})
+	}, middleware.Idempotency(&memoryCache{data: map[string]string{}}, time.Hour))
+
+	send := func(address, auth string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/payment", strings.NewReader(`{"order":"ORD1"}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req.Header.Set(middleware.HeaderIdempotencyKey, "shared-key")
+		req.RemoteAddr = address
+		if auth != "" {
+			req.Header.Set(echo.HeaderAuthorization, auth)
+		}
+		rr := httptest.NewRecorder()
+		e.ServeHTTP(rr, req)
+		return rr
+	}
+
+	first := send("10.0.0.1:4000", "")
+	other := send("10.0.0.2:4000", "")
+	assert.NotEqual(t, first.Body.String(), other.Body.String(), "guests must not get each other's response")
+	assert.Empty(t, other.Header().Get(middleware.HeaderIdempotentReplayed))
+
+	signedIn := send("10.0.0.1:4000", "Bearer token")
+	assert.Empty(t, signedIn.Header().Get(middleware.HeaderIdempotentReplayed))
+	assert.Equal(t, 3, calls)
+
+	retry := send("10.0.0.1:4000", "")
+	assert.Equal(t, first.Body.String(), retry.Body.String())
+	assert.Equal(t, 3, calls, "the retry of the same caller is replayed")
+}
+
+func TestIdempotencyReleasesPanickedKey(t *testing.T) {
+	var (
+		e     = echo.New()
+		calls = 0
+	)
+	e.Use(echomw.Recover())
+	e.POST("/orders", func(c echo.Context) error {
+		calls++
+		if calls == 1 {
+			panic("lost connection")
+		}
+		return c.JSON(http.StatusCreated, map[string]int{"order": calls})
+	}, middleware.Idempotency(&memoryCache{data: map[string]string{}}, time.Hour))
+
+	send := func() *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(`{"quantity":1}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req.Header.Set(middleware.HeaderIdempotencyKey, "key-1")
+		rr := httptest.NewRecorder()
+		e.ServeHTTP(rr, req)
+		return rr
+	}
+
+	assert.Equal(t, http.StatusInternalServerError, send().Code)
+	assert.Equal(t, http.StatusCreated, send().Code, "the retry must not be locked out by the panicked request")
+	assert.Equal(t, 2, calls)
+}
+
+func TestIdempotencyClientGone(t *testing.T) {
+	// the client disconnects while its order is created: the key must still
+	// be completed, else a retry after the pending window orders twice
+	var (
+		e      = echo.New()
+		calls  = 0
+		status = http.StatusCreated
+	)
+	e.POST("/orders", func(c echo.Context) error {
+		calls++
+		c.Get("cancel").(context.CancelFunc)()
+		return c.JSON(status, map[string]int{"order": calls})
+	}, func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			ctx, cancel := context.WithCancel(c.Request().Context())
+			c.SetRequest(c.Request().WithContext(ctx))
+			c.Set("cancel", cancel)
+			return next(c)
+		}
+	}, middleware.Idempotency(&memoryCache{data: map[string]string{}}, time.Hour))
+
+	send := func(key string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(`{"quantity":1}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req.Header.Set(middleware.HeaderIdempotencyKey, key)
+		rr := httptest.NewRecorder()
+		e.ServeHTTP(rr, req)
+		return rr
+	}
+
+	send("key-1")
+	rr := send("key-1")
+	assert.Equal(t, http.StatusCreated, rr.Code)
+	assert.Equal(t, "true", rr.Header().Get(middleware.HeaderIdempotentReplayed))
+	assert.Equal(t, 1, calls)
+
+	// a server error is released for the retry
+	status = http.StatusInternalServerError
+	send("key-2")
+	status = http.StatusCreated
+	assert.Equal(t, http.StatusCreated, send("key-2").Code, "the retry must not be locked out")
+	assert.Equal(t, 3, calls)
+}
@@ -0,0 +1,219 @@
+package test
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/swclabs/swipex/internal/core/domain/entity"
+	"github.com/swclabs/swipex/internal/core/domain/enum"
+	"github.com/swclabs/swipex/internal/core/domain/model"
+	"github.com/swclabs/swipex/internal/core/repos/orders"
+	"github.com/swclabs/swipex/internal/core/service/payment"
+	"github.com/swclabs/swipex/pkg/infra/db"
+	"github.com/swclabs/swipex/pkg/lib/vnpay"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// callback holds the transaction of a VNPay callback, signed with the
+// secret of merchant.
+type callback struct {
+	conn   *db.TxMock
+	order  orders.Mock
+	ledger ledger
+}
+
+func newCallback(ctx context.Context) *callback {
+	c := &callback{conn: db.NewTransactionMock()}
+	c.conn.On("Rollback", ctx).Return(nil)
+	c.conn.On("Commit", ctx).Return(nil)
+	return c
+}
+
+func (c *callback) service() *payment.Payment {
+	service := payment.NewWithProviders(&c.ledger, nil,
+		payment.NewVNPay(payment.Native(merchant), merchant))
+	service.Begin = func(_ context.Context) (*payment.Tx, error) {
+		return &payment.Tx{ITransaction: c.conn, Order: &c.order, Ledger: &c.ledger}, nil
+	}
+	return service
+}
+
+// paid returns the query of a successful VNPay payment of the order,
+// signed with key.
+func paid(orderCode string, amount string, key string) url.Values {
+	params := url.Values{
+		"vnp_TmnCode":           {"SWIPEX01"},
+		"vnp_Amount":            {amount},
+		"vnp_BankCode":          {"NCB"},
+		"vnp_PayDate":           {"20261018093512"},
+		"vnp_OrderInfo":         {"Thanh toan don hang " + orderCode},
+		"vnp_TransactionNo":     {"14123456"},
+		"vnp_ResponseCode":      {"00"},
+		"vnp_TransactionStatus": {"00"},
+		"vnp_TxnRef":            {orderCode},
+	}
+	params.Set("vnp_SecureHash", vnpay.Sign(vnpay.Query(params), key))
+	return params
+}
+
+func (c *callback) send(t *testing.T, ctx context.Context, query url.Values) string {
+	t.Helper()
+	result, err := c.service().PaymentReturn(ctx, enum.PaymentIPN, payment.ProviderVNPay,
+		model.PaymentCallbackRequest{Query: query})
+	require.NoError(t, err)
+	require.Len(t, c.ledger.transactions, 1, "every callback is recorded")
+	return result.RspCode
+}
+
+func TestPaymentReturnConfirmsPayment(t *testing.T) {
+	ctx := context.Background()
+	c := newCallback(ctx)
+	c.order.On("GetByUUIDForUpdate", ctx, "ORD-1").
+		Return(&entity.Order{ID: 1, UUID: "ORD-1", Status: "pending", TotalAmount: decimal.NewFromInt(150000)}, nil)
+	c.order.On("SetPaid", ctx, int64(1), mock.AnythingOfType("time.Time")).Return(nil)
+
+	assert.Equal(t, payment.RspConfirmed, c.send(t, ctx, paid("ORD-1", "15000000", secret)))
+	assert.Equal(t, enum.PaymentSucceeded.String(), c.ledger.transactions[0].Status)
+	c.order.AssertExpectations(t)
+	c.conn.AssertCalled(t, "Commit", ctx)
+}
+
+func TestPaymentReturnInvalidChecksum(t *testing.T) {
+	ctx := context.Background()
+	c := newCallback(ctx)
+
+	assert.Equal(t, payment.RspInvalidChecksum, c.send(t, ctx, paid("ORD-1", "15000000", "OTHER")))
+	assert.Equal(t, enum.PaymentRejected.String(), c.ledger.transactions[0].Status)
+	c.order.AssertNotCalled(t, "GetByUUIDForUpdate", mock.Anything, mock.Anything)
+	c.order.AssertNotCalled(t, "SetPaid", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPaymentReturnReplayed(t *testing.T) {
+	// the provider sends the IPN again after the order was paid
+	ctx := context.Background()
+	c := newCallback(ctx)
+	paidAt := time.Date(2026, 10, 18, 2, 35, 12, 0, time.UTC)
+	c.order.On("GetByUUIDForUpdate", ctx, "ORD-1").Return(&entity.Order{
+		ID: 1, UUID: "ORD-1", Status: "pending", TotalAmount: decimal.NewFromInt(150000), PaidAt: &paidAt,
+	}, nil)
+
+	assert.Equal(t, payment.RspAlreadyConfirmed, c.send(t, ctx, paid("ORD-1", "15000000", secret)))
+	assert.Equal(t, enum.PaymentRejected.String(), c.ledger.transactions[0].Status)
+	c.order.AssertNotCalled(t, "SetPaid", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPaymentReturnAmountMismatch(t *testing.T) {
+	ctx := context.Background()
+	c := newCallback(ctx)
+	c.order.On("GetByUUIDForUpdate", ctx, "ORD-1").
+		Return(&entity.Order{ID: 1, UUID: "ORD-1", Status: "pending", TotalAmount: decimal.NewFromInt(150000)}, nil)
+
+	assert.Equal(t, payment.RspInvalidAmount, c.send(t, ctx, paid("ORD-1", "1500000", secret)))
+	assert.Equal(t, enum.PaymentRejected.String(), c.ledger.transactions[0].Status)
+	c.order.AssertNotCalled(t, "SetPaid", mock.Anything, mock.Anything, mock.Anything)
+	c.order.AssertNotCalled(t, "SetPaymentFailed", mock.Anything, mock.Anything)
+}
+
+func TestPaymentReturnCancelledOrder(t *testing.T) {
+	// the order was cancelled while the customer was paying: it stays
+	// unpaid and the payment is recorded to be refunded
+	ctx := context.Background()
+	c := newCallback(ctx)
+	c.order.On("GetByUUIDForUpdate", ctx, "ORD-1").
+		Return(&entity.Order{ID: 1, UUID: "ORD-1", Status: "cancelled", TotalAmount: decimal.NewFromInt(150000)}, nil)
+
+	result, err := c.service().PaymentReturn(ctx, enum.PaymentIPN, payment.ProviderVNPay,
+		model.PaymentCallbackRequest{Query: paid("ORD-1", "15000000", secret)})
+	require.NoError(t, err)
+	assert.Equal(t, payment.RspAlreadyConfirmed, result.RspCode)
+	assert.True(t, result.RefundDue)
+	assert.False(t, result.Paid)
+
+	require.Len(t, c.ledger.transactions, 1)
+	assert.Equal(t, enum.PaymentRefundDue.String(), c.ledger.transactions[0].Status)
+	assert.Equal(t, "150000", c.ledger.transactions[0].Amount.String())
+	c.order.AssertNotCalled(t, "SetPaid", mock.Anything, mock.Anything, mock.Anything)
+	c.conn.AssertCalled(t, "Commit", ctx)
+}
+
+func TestPaymentReturnPaysDeposit(t *testing.T) {
+	// the deposit of a pre-order is paid, the order is left to pay
+	ctx := context.Background()
+	c := newCallback(ctx)
+	c.order.On("GetByUUIDForUpdate", ctx, "ORD-1").Return(&entity.Order{
+		ID: 1, UUID: "ORD-1", Status: "pending",
+		TotalAmount: decimal.NewFromInt(300000), DepositAmount: decimal.NewFromInt(150000),
+	}, nil)
+	c.order.On("SetDepositPaid", ctx, int64(1), mock.AnythingOfType("time.Time")).Return(nil)
+
+	assert.Equal(t, payment.RspConfirmed, c.send(t, ctx, paid("ORD-1", "15000000", secret)))
+	assert.Equal(t, enum.PaymentSucceeded.String(), c.ledger.transactions[0].Status)
+	c.order.AssertExpectations(t)
+	c.order.AssertNotCalled(t, "SetPaid", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPaymentReturnPaysBalance(t *testing.T) {
+	ctx := context.Background()
+	c := newCallback(ctx)
+	depositPaidAt := time.Date(2026, 10, 1, 2, 35, 12, 0, time.UTC)
+	c.ledger.transactions = []entity.PaymentTransaction{{
+		OrderUUID: "ORD-1", Event: enum.PaymentIPN.String(), Status: enum.PaymentSucceeded.String(),
+		Amount: decimal.NewFromInt(150000), TransactionNo: "14000001",
+	}}
+	c.order.On("GetByUUIDForUpdate", ctx, "ORD-1").Return(&entity.Order{
+		ID: 1, UUID: "ORD-1", Status: "pending", DepositPaidAt: &depositPaidAt,
+		TotalAmount: decimal.NewFromInt(300000), DepositAmount: decimal.NewFromInt(150000),
+	}, nil)
+	c.order.On("SetPaid", ctx, int64(1), mock.AnythingOfType("time.Time")).Return(nil)
+
+	result, err := c.service().PaymentReturn(ctx, enum.PaymentIPN, payment.ProviderVNPay,
+		model.PaymentCallbackRequest{Query: paid("ORD-1", "15000000", secret)})
+	require.NoError(t, err)
+	assert.Equal(t, payment.RspConfirmed, result.RspCode)
+	c.order.AssertExpectations(t)
+	c.order.AssertNotCalled(t, "SetDepositPaid", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPaymentReturnReplayedDeposit(t *testing.T) {
+	// the IPN of the deposit is sent again: its amount is the balance due
+	// but it does not pay the order
+	ctx := context.Background()
+	c := newCallback(ctx)
+	depositPaidAt := time.Date(2026, 10, 18, 2, 35, 12, 0, time.UTC)
+	c.ledger.transactions = []entity.PaymentTransaction{{
+		OrderUUID: "ORD-1", Event: enum.PaymentIPN.String(), Status: enum.PaymentSucceeded.String(),
+		Amount: decimal.NewFromInt(150000), TransactionNo: "14123456",
+	}}
+	c.order.On("GetByUUIDForUpdate", ctx, "ORD-1").Return(&entity.Order{
+		ID: 1, UUID: "ORD-1", Status: "pending", DepositPaidAt: &depositPaidAt,
+		TotalAmount: decimal.NewFromInt(300000), DepositAmount: decimal.NewFromInt(150000),
+	}, nil)
+
+	result, err := c.service().PaymentReturn(ctx, enum.PaymentIPN, payment.ProviderVNPay,
+		model.PaymentCallbackRequest{Query: paid("ORD-1", "15000000", secret)})
+	require.NoError(t, err)
+	assert.Equal(t, payment.RspAlreadyConfirmed, result.RspCode)
+	c.order.AssertNotCalled(t, "SetPaid", mock.Anything, mock.Anything, mock.Anything)
+	c.order.AssertNotCalled(t, "SetDepositPaid", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPaymentReturnRoundedAmount(t *testing.T) {
+	// the provider is charged the due rounded to the dong, the callback
+	// paying it confirms the order
+	ctx := context.Background()
+	c := newCallback(ctx)
+	c.order.On("GetByUUIDForUpdate", ctx, "ORD-1").Return(&entity.Order{
+		ID: 1, UUID: "ORD-1", Status: "pending",
+		TotalAmount: decimal.NewFromInt(150000), PointsAmount: decimal.RequireFromString("0.4"),
+	}, nil)
+	c.order.On("SetPaid", ctx, int64(1), mock.AnythingOfType("time.Time")).Return(nil)
+
+	assert.Equal(t, payment.RspConfirmed, c.send(t, ctx, paid("ORD-1", "15000000", secret)))
+	c.order.AssertExpectations(t)
+}
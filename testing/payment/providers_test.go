@@ -0,0 +1,171 @@
+package test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/swclabs/swipex/internal/core/domain/enum"
+	"github.com/swclabs/swipex/internal/core/domain/model"
+	"github.com/swclabs/swipex/internal/core/domain/x/momo"
+	"github.com/swclabs/swipex/internal/core/domain/x/zalopay"
+	"github.com/swclabs/swipex/internal/core/service/payment"
+	"github.com/swclabs/swipex/pkg/lib/vnpay"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func hmac256(key, data string) string {
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write([]byte(data))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// standIn answers the requests posted to a provider with answer, after
+// decoding them in a map for check.
+func standIn(t *testing.T, answer func(path string, req map[string]any) any) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var req map[string]any
+		decoder := json.NewDecoder(r.Body)
+		decoder.UseNumber()
+		require.NoError(t, decoder.Decode(&req))
+		w.Header().Set("Content-Type", "application/json")
+		require.NoError(t, json.NewEncoder(w).Encode(answer(r.URL.Path, req)))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestMoMoCreateAndQuery(t *testing.T) {
+	account := momo.Account{PartnerCode: "MOMO", AccessKey: "access", SecretKey: "secret",
+		RedirectURL: "http://localhost/return", IPNURL: "http://localhost/ipn"}
+	server := standIn(t, func(path string, req map[string]any) any {
+		switch path {
+		case "/v2/gateway/api/create":
+			signed := fmt.Sprintf("accessKey=access&amount=%v&extraData=&ipnUrl=%s&orderId=%s&orderInfo=%s"+
+				"&partnerCode=MOMO&redirectUrl=%s&requestId=%s&requestType=captureWallet",
+				req["amount"], req["ipnUrl"], req["orderId"], req["orderInfo"], req["redirectUrl"], req["requestId"])
+			if hmac256("secret", signed) != req["signature"] {
+				return momo.CreateResponse{ResultCode: 11007, Message: "invalid signature"}
+			}
+			return momo.CreateResponse{ResultCode: 0, Message: "Successful.", PayURL: "https://momo/pay"}
+		case "/v2/gateway/api/query":
+			return momo.QueryResponse{ResultCode: 1000, Message: "Pending", Amount: 150000}
+		}
+		return nil
+	})
+	account.Endpoint = server.URL
+	provider := payment.NewMoMo(account)
+
+	created, err := provider.CreatePayment(context.Background(), model.PaymentOrder{
+		OrderCode: "ORD1", Amount: 150000, Description: "order ORD1"})
+	require.NoError(t, err)
+	assert.Equal(t, "https://momo/pay", created.URL)
+	assert.True(t, strings.HasPrefix(created.Reference, "ORD1_"), "reference is the MoMo order id")
+
+	state, err := provider.QueryStatus(context.Background(), model.PaymentRef{OrderCode: "ORD1", Reference: created.Reference})
+	require.NoError(t, err)
+	assert.Equal(t, enum.PaymentPending, state.Status)
+	assert.Equal(t, "150000", state.Amount.String())
+}
+
+func TestMoMoVerifyCallback(t *testing.T) {
+	provider := payment.NewMoMo(momo.Account{PartnerCode: "MOMO", AccessKey: "access", SecretKey: "secret"})
+	callback := momo.Callback{PartnerCode: "MOMO", OrderID: "ORD1_1", RequestID: "ORD1_1", Amount: 150000,
+		OrderInfo: "order ORD1", OrderType: "momo_wallet", TransID: 4088878653, ResultCode: 0,
+		Message: "Successful.", PayType: "qr", ResponseTime: 1760754600000}
+	callback.Signature = hmac256("secret", fmt.Sprintf("accessKey=access&amount=150000&extraData=&message=%s"+
+		"&orderId=ORD1_1&orderInfo=order ORD1&orderType=momo_wallet&partnerCode=MOMO&payType=qr"+
+		"&requestId=ORD1_1&responseTime=1760754600000&resultCode=0&transId=4088878653", callback.Message))
+	body, err := json.Marshal(callback)
+	require.NoError(t, err)
+
+	result, err := provider.VerifyCallback(context.Background(), model.PaymentCallbackRequest{Body: body})
+	require.NoError(t, err)
+	assert.Equal(t, "ORD1", result.OrderCode)
+	assert.True(t, result.Success)
+	assert.Equal(t, "4088878653", result.TransactionNo)
+
+	callback.Amount = 1
+	body, err = json.Marshal(callback)
+	require.NoError(t, err)
+	_, err = provider.VerifyCallback(context.Background(), model.PaymentCallbackRequest{Body: body})
+	assert.ErrorIs(t, err, payment.ErrInvalidSignature, "a tampered amount must break the signature")
+}
+
+func TestZaloPayCreateAndCallback(t *testing.T) {
+	account := zalopay.Account{AppID: 2553, Key1: "key1", Key2: "key2"}
+	server := standIn(t, func(_ string, req map[string]any) any {
+		signed := fmt.Sprintf("%v|%s|%s|%v|%v|%s|%s", req["app_id"], req["app_trans_id"], req["app_user"],
+			req["amount"], req["app_time"], req["embed_data"], req["item"])
+		if hmac256("key1", signed) != req["mac"] {
+			return zalopay.CreateResponse{ReturnCode: 2, SubReturnCode: -401, SubReturnMessage: "invalid mac"}
+		}
+		return zalopay.CreateResponse{ReturnCode: 1, ReturnMessage: "success", OrderURL: "https://zalopay/pay"}
+	})
+	account.Endpoint = server.URL
+	provider := payment.NewZaloPay(account)
+
+	created, err := provider.CreatePayment(context.Background(), model.PaymentOrder{OrderCode: "ORD1", Amount: 150000})
+	require.NoError(t, err)
+	assert.Equal(t, "https://zalopay/pay", created.URL)
+
+	data, err := json.Marshal(zalopay.CallbackData{AppID: 2553, AppTransID: created.Reference,
+		Amount: 150000, ZpTransID: 240331000000175, ServerTime: 1760754600000})
+	require.NoError(t, err)
+	body, err := json.Marshal(zalopay.Callback{Data: string(data), Mac: hmac256("key2", string(data))})
+	require.NoError(t, err)
+	result, err := provider.VerifyCallback(context.Background(), model.PaymentCallbackRequest{Body: body})
+	require.NoError(t, err)
+	assert.Equal(t, "ORD1", result.OrderCode, "the order code is read from app_trans_id")
+	assert.Equal(t, "150000", result.Amount.String())
+
+	body, err = json.Marshal(zalopay.Callback{Data: string(data), Mac: hmac256("key1", string(data))})
+	require.NoError(t, err)
+	_, err = provider.VerifyCallback(context.Background(), model.PaymentCallbackRequest{Body: body})
+	assert.ErrorIs(t, err, payment.ErrInvalidSignature)
+}
+
+func TestVNPayRefund(t *testing.T) {
+	var requestIDs []string
+	server := standIn(t, func(_ string, req map[string]any) any {
+		if req["vnp_Command"] != "refund" {
+			return vnpay.TransactionResponse{ResponseCode: "99"}
+		}
+		requestIDs = append(requestIDs, req["vnp_RequestId"].(string))
+		return vnpay.TransactionResponse{ResponseCode: "00", Message: "Refund success",
+			TransactionNo: req["vnp_TransactionType"].(string) + "14422574", Amount: req["vnp_Amount"].(string)}
+	})
+	merchant := vnpay.Merchant{TmnCode: "SWIPEX01", HashSecret: secret, APIURL: server.URL}
+	provider := payment.NewVNPay(payment.Native(merchant), merchant)
+
+	for _, full := range []bool{false, true} {
+		refunded, err := provider.Refund(context.Background(), model.PaymentRefund{
+			Payment:  model.PaymentRef{OrderCode: "ORD1", TransactionNo: "14422000", Reference: "20261018093000"},
+			RefundID: "1",
+			Amount:   decimal.NewFromInt(50000),
+			Full:     full,
+			Actor:    "admin@swipex",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, enum.RefundSucceeded, refunded.Status)
+		transactionType := vnpay.RefundPartial
+		if full {
+			transactionType = vnpay.RefundFull
+		}
+		assert.Equal(t, transactionType+"14422574", refunded.RefundNo)
+	}
+	// the refund is asked with the same request id each time
+	require.Len(t, requestIDs, 2)
+	assert.Len(t, requestIDs[0], 32)
+	assert.Equal(t, requestIDs[0], requestIDs[1])
+}
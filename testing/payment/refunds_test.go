@@ -0,0 +1,147 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/swclabs/swipex/internal/core/domain/entity"
+	"github.com/swclabs/swipex/internal/core/domain/enum"
+	"github.com/swclabs/swipex/internal/core/domain/model"
+	"github.com/swclabs/swipex/internal/core/repos/orders"
+	"github.com/swclabs/swipex/internal/core/repos/refunds"
+	"github.com/swclabs/swipex/internal/core/service/payment"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// refundService returns the payment service of a MoMo payment of order
+// ORD-1 paid with transaction 2001, whose refund 5 is claimed after
+// attempts.
+func refundService(t *testing.T, provider *provider, attempts int32) (*payment.Payment, *ledger, *refunds.Mock) {
+	ctx := context.Background()
+	ledger := &ledger{transactions: []entity.PaymentTransaction{
+		{OrderUUID: "ORD-1", Provider: payment.ProviderMoMo, Event: enum.PaymentCreate.String(),
+			Status: enum.PaymentPending.String(), Amount: decimal.NewFromInt(500000), Reference: "ORD-1_1"},
+		{OrderUUID: "ORD-1", Provider: payment.ProviderMoMo, Event: enum.PaymentIPN.String(),
+			Status: enum.PaymentSucceeded.String(), Amount: decimal.NewFromInt(500000), TransactionNo: "2001"},
+	}}
+	orderRepo := orders.NewOrdersMock()
+	orderRepo.On("GetByID", ctx, int64(1)).Return(&entity.Order{ID: 1, UUID: "ORD-1"}, nil)
+	refundRepo := refunds.NewRefundsMock()
+	refundRepo.On("Claim", ctx, int64(5), mock.Anything).Return(&entity.Refund{
+		ID: 5, OrderID: 1, Amount: decimal.NewFromInt(200000), CurrencyCode: "VND",
+		Status: enum.RefundInFlight.String(), Provider: payment.ProviderMoMo, TransactionNo: "2001",
+		Attempts: attempts, CreatedAt: time.Date(2026, 10, 18, 9, 30, 0, 0, time.UTC),
+	}, nil)
+	service := payment.NewWithProviders(ledger, orderRepo, provider)
+	service.Refund = refundRepo
+	t.Cleanup(func() { refundRepo.AssertExpectations(t) })
+	return service, ledger, refundRepo
+}
+
+// updated returns the statuses the refund was updated with
+func updated(refundRepo *refunds.Mock) []string {
+	var statuses []string
+	for _, call := range refundRepo.Calls {
+		if call.Method == "UpdateResult" {
+			statuses = append(statuses, call.Arguments.Get(1).(entity.Refund).Status)
+		}
+	}
+	return statuses
+}
+
+func TestProcessRefund(t *testing.T) {
+	provider := &provider{refunded: &model.PaymentRefunded{Status: enum.RefundSucceeded, RefundNo: "3001"}}
+	service, ledger, refundRepo := refundService(t, provider, 1)
+	refundRepo.On("UpdateResult", mock.Anything, mock.Anything).Return(nil)
+	// a refund paid back before is not the payment
+	ledger.transactions = append(ledger.transactions, entity.PaymentTransaction{
+		OrderUUID: "ORD-1", Provider: payment.ProviderMoMo, Event: enum.PaymentRefund.String(),
+		Status: enum.PaymentSucceeded.String(), Amount: decimal.NewFromInt(100000), TransactionNo: "3000",
+	})
+
+	require.NoError(t, service.ProcessRefund(context.Background(), 5))
+	require.Len(t, provider.refunds, 1)
+	assert.Empty(t, provider.queries, "a first attempt is not queried")
+	refund := provider.refunds[0]
+	assert.Equal(t, "5", refund.RefundID)
+	assert.Equal(t, "2001", refund.Payment.TransactionNo)
+	assert.Equal(t, "500000", refund.Payment.Amount.String())
+	assert.Equal(t, "200000", refund.Amount.String())
+
+	require.Len(t, ledger.transactions, 4)
+	transaction := ledger.transactions[3]
+	assert.Equal(t, enum.PaymentRefund.String(), transaction.Event)
+	assert.Equal(t, enum.PaymentSucceeded.String(), transaction.Status)
+	assert.Equal(t, "3001", transaction.TransactionNo)
+	assert.Equal(t, "5", transaction.Reference)
+	assert.Equal(t, []string{enum.RefundSucceeded.String()}, updated(refundRepo))
+}
+
+func TestProcessRefundRetryReconciles(t *testing.T) {
+	// the first attempt reached MoMo, which paid the refund back
+	provider := &provider{found: &model.PaymentRefunded{Status: enum.RefundSucceeded, RefundNo: "3001"}}
+	service, ledger, refundRepo := refundService(t, provider, 2)
+	refundRepo.On("UpdateResult", mock.Anything, mock.Anything).Return(nil)
+
+	require.NoError(t, service.ProcessRefund(context.Background(), 5))
+	assert.Len(t, provider.queries, 1)
+	assert.Empty(t, provider.refunds, "a refund the provider knows is not asked again")
+	require.Len(t, ledger.transactions, 3)
+	assert.Equal(t, "3001", ledger.transactions[2].TransactionNo)
+	assert.Equal(t, []string{enum.RefundSucceeded.String()}, updated(refundRepo))
+}
+
+func TestProcessRefundRetryNotFound(t *testing.T) {
+	// the first attempt never reached MoMo
+	provider := &provider{refunded: &model.PaymentRefunded{Status: enum.RefundSucceeded, RefundNo: "3001"}}
+	service, _, refundRepo := refundService(t, provider, 2)
+	refundRepo.On("UpdateResult", mock.Anything, mock.Anything).Return(nil)
+
+	require.NoError(t, service.ProcessRefund(context.Background(), 5))
+	assert.Len(t, provider.queries, 1)
+	assert.Len(t, provider.refunds, 1)
+}
+
+func TestProcessRefundProviderError(t *testing.T) {
+	provider := &provider{err: errors.New("momo: timeout")}
+	service, ledger, refundRepo := refundService(t, provider, 1)
+	refundRepo.On("UpdateResult", mock.Anything, mock.Anything).Return(nil)
+
+	err := service.ProcessRefund(context.Background(), 5)
+	assert.EqualError(t, err, "momo: timeout")
+	assert.Len(t, ledger.transactions, 2, "no refund is recorded in the ledger")
+	// the refund stays in flight, its next attempt is queried first
+	assert.Equal(t, []string{enum.RefundInFlight.String()}, updated(refundRepo))
+
+	provider.err = nil
+	provider.refunded = &model.PaymentRefunded{Status: enum.RefundPending}
+	err = service.ProcessRefund(context.Background(), 5)
+	assert.ErrorIs(t, err, payment.ErrRefundProcessing)
+	assert.Len(t, ledger.transactions, 2)
+}
+
+func TestProcessRefundInFlight(t *testing.T) {
+	var (
+		ctx        = context.Background()
+		refundRepo = refunds.NewRefundsMock()
+		service    = payment.NewWithProviders(&ledger{}, orders.NewOrdersMock(), &provider{})
+	)
+	refundRepo.On("Claim", ctx, int64(5), mock.Anything).Return(nil, pgx.ErrNoRows)
+	refundRepo.On("Claim", ctx, int64(6), mock.Anything).Return(nil, pgx.ErrNoRows)
+	refundRepo.On("Claim", ctx, int64(7), mock.Anything).Return(nil, pgx.ErrNoRows)
+	refundRepo.On("GetByID", ctx, int64(5)).Return(&entity.Refund{ID: 5, Status: enum.RefundInFlight.String()}, nil)
+	refundRepo.On("GetByID", ctx, int64(6)).Return(&entity.Refund{ID: 6, Status: enum.RefundSucceeded.String()}, nil)
+	refundRepo.On("GetByID", ctx, int64(7)).Return(nil, pgx.ErrNoRows)
+	service.Refund = refundRepo
+
+	assert.ErrorIs(t, service.ProcessRefund(ctx, 5), payment.ErrRefundInFlight)
+	assert.NoError(t, service.ProcessRefund(ctx, 6), "an answered refund is skipped")
+	assert.ErrorIs(t, service.ProcessRefund(ctx, 7), payment.ErrRefundNotFound)
+}
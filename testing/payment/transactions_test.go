@@ -0,0 +1,181 @@
+package test
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/swclabs/swipex/internal/core/domain/dtos"
+	"github.com/swclabs/swipex/internal/core/domain/entity"
+	"github.com/swclabs/swipex/internal/core/domain/enum"
+	"github.com/swclabs/swipex/internal/core/domain/model"
+	"github.com/swclabs/swipex/internal/core/repos/orders"
+	"github.com/swclabs/swipex/internal/core/service/payment"
+	"github.com/swclabs/swipex/pkg/lib/vnpay"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type ledger struct {
+	transactions []entity.PaymentTransaction
+}
+
+func (l *ledger) Insert(_ context.Context, transaction entity.PaymentTransaction) (int64, error) {
+	l.transactions = append(l.transactions, transaction)
+	return int64(len(l.transactions)), nil
+}
+
+func (l *ledger) GetByOrderUUID(context.Context, string) ([]entity.PaymentTransaction, error) {
+	return l.transactions, nil
+}
+
+// provider is a payment provider recording the payments and refunds it is
+// asked for, refunds are answered with refunded or err and queried ones
+// with found.
+type provider struct {
+	payments []model.PaymentOrder
+	refunds  []model.PaymentRefund
+	queries  []model.PaymentRefund
+	refunded *model.PaymentRefunded
+	found    *model.PaymentRefunded
+	err      error
+}
+
+func (p *provider) Name() string {
+	return payment.ProviderMoMo
+}
+
+func (p *provider) CreatePayment(_ context.Context, order model.PaymentOrder) (*model.PaymentCreated, error) {
+	p.payments = append(p.payments, order)
+	return &model.PaymentCreated{URL: "https://pay/" + order.OrderCode}, nil
+}
+
+func (p *provider) VerifyCallback(context.Context, model.PaymentCallbackRequest) (*model.PaymentCallback, error) {
+	panic("unimplemented")
+}
+
+func (p *provider) QueryStatus(context.Context, model.PaymentRef) (*model.PaymentState, error) {
+	panic("unimplemented")
+}
+
+func (p *provider) Refund(_ context.Context, refund model.PaymentRefund) (*model.PaymentRefunded, error) {
+	p.refunds = append(p.refunds, refund)
+	return p.refunded, p.err
+}
+
+func (p *provider) QueryRefund(_ context.Context, refund model.PaymentRefund) (*model.PaymentRefunded, error) {
+	p.queries = append(p.queries, refund)
+	return p.found, nil
+}
+
+func TestPaymentReturnRecordsRejectedCallback(t *testing.T) {
+	var (
+		ledger  = &ledger{}
+		service = payment.NewWithProviders(ledger, nil, payment.NewVNPay(nil, vnpay.Merchant{}))
+		query   = url.Values{"vnp_TxnRef": {"ORD-1"}, "vnp_Amount": {"15000000"}}
+	)
+	result, err := service.PaymentReturn(context.Background(), enum.PaymentIPN, payment.ProviderVNPay,
+		model.PaymentCallbackRequest{Query: query})
+	require.NoError(t, err)
+	assert.Equal(t, payment.RspUnknownError, result.RspCode, "unsigned callback must be rejected")
+
+	require.Len(t, ledger.transactions, 1)
+	transaction := ledger.transactions[0]
+	assert.Equal(t, "ORD-1", transaction.OrderUUID)
+	assert.Equal(t, enum.PaymentIPN.String(), transaction.Event)
+	assert.Equal(t, enum.PaymentRejected.String(), transaction.Status)
+	assert.Equal(t, "150000", transaction.Amount.String())
+
+	var payload map[string]string
+	require.NoError(t, json.Unmarshal(transaction.Payload, &payload))
+	assert.Equal(t, "15000000", payload["vnp_Amount"])
+}
+
+func TestAmountDue(t *testing.T) {
+	order := &entity.Order{
+		TotalAmount:  decimal.NewFromInt(1000000),
+		CreditAmount: decimal.NewFromInt(50000),
+		PointsAmount: decimal.NewFromInt(20000),
+	}
+	assert.Equal(t, "930000", payment.AmountDue(order).String())
+
+	// a pre-order pays its deposit online, then the balance
+	order.DepositAmount = decimal.NewFromInt(200000)
+	assert.Equal(t, "130000", payment.AmountDue(order).String())
+	paidAt := time.Now()
+	order.DepositPaidAt = &paidAt
+	assert.Equal(t, "800000", payment.AmountDue(order).String())
+}
+
+func TestAmountDueRounded(t *testing.T) {
+	// points worth a fraction of a dong leave a due rounded to the dong
+	order := &entity.Order{
+		TotalAmount:  decimal.NewFromInt(150000),
+		PointsAmount: decimal.RequireFromString("0.6"),
+	}
+	assert.Equal(t, "149999", payment.AmountDue(order).String())
+
+	// a USD order is converted and rounded once
+	order = &entity.Order{
+		TotalAmount:  decimal.RequireFromString("10.01"),
+		CurrencyCode: "USD",
+		ExchangeRate: decimal.RequireFromString("25123.45"),
+	}
+	assert.Equal(t, "251486", payment.AmountDue(order).String())
+}
+
+func TestCreatePaymentAmountOfOrder(t *testing.T) {
+	var (
+		ctx      = context.Background()
+		ledger   = &ledger{}
+		provider = &provider{}
+		paidAt   = time.Now()
+		repo     = orders.NewOrdersMock()
+	)
+	repo.On("GetByUUID", ctx, "ORD-1").Return(&entity.Order{
+		UUID: "ORD-1", Status: enum.OrderPending.String(), PaymentMethod: payment.ProviderMoMo,
+		TotalAmount: decimal.NewFromInt(1000000), DepositAmount: decimal.NewFromInt(200000),
+		CreditAmount: decimal.NewFromInt(50000),
+	}, nil)
+	repo.On("GetByUUID", ctx, "ORD-DEPOSIT").Return(&entity.Order{
+		UUID: "ORD-DEPOSIT", Status: enum.OrderPending.String(), PaymentMethod: payment.ProviderMoMo,
+		TotalAmount: decimal.NewFromInt(1000000), DepositAmount: decimal.NewFromInt(200000),
+		CreditAmount: decimal.NewFromInt(50000), DepositPaidAt: &paidAt,
+	}, nil)
+	repo.On("GetByUUID", ctx, "ORD-PAID").Return(&entity.Order{
+		UUID: "ORD-PAID", Status: enum.OrderConfirmed.String(), TotalAmount: decimal.NewFromInt(1000000), PaidAt: &paidAt,
+	}, nil)
+	repo.On("GetByUUID", ctx, "ORD-CANCELLED").Return(&entity.Order{
+		UUID: "ORD-CANCELLED", Status: enum.OrderCancelled.String(), TotalAmount: decimal.NewFromInt(1000000),
+	}, nil)
+	repo.On("GetByUUID", ctx, "ORD-404").Return(nil, pgx.ErrNoRows)
+	service := payment.NewWithProviders(ledger, repo, provider)
+
+	// the deposit of the pre-order is paid, less the store credit
+	resp, err := service.CreatePayment(ctx, dtos.PaymentRequest{OrderID: "ORD-1"})
+	require.NoError(t, err)
+	assert.Equal(t, payment.ProviderMoMo, resp.Provider)
+	require.Len(t, provider.payments, 1)
+	assert.Equal(t, int64(150000), provider.payments[0].Amount)
+	require.Len(t, ledger.transactions, 1)
+	assert.Equal(t, "150000", ledger.transactions[0].Amount.String())
+
+	// once the deposit is paid the balance is
+	_, err = service.CreatePayment(ctx, dtos.PaymentRequest{OrderID: "ORD-DEPOSIT"})
+	require.NoError(t, err)
+	require.Len(t, provider.payments, 2)
+	assert.Equal(t, int64(800000), provider.payments[1].Amount)
+
+	_, err = service.CreatePayment(ctx, dtos.PaymentRequest{OrderID: "ORD-PAID"})
+	assert.ErrorIs(t, err, payment.ErrPaymentNotAllowed)
+	_, err = service.CreatePayment(ctx, dtos.PaymentRequest{OrderID: "ORD-CANCELLED"})
+	assert.ErrorIs(t, err, payment.ErrPaymentNotAllowed)
+	_, err = service.CreatePayment(ctx, dtos.PaymentRequest{OrderID: "ORD-404"})
+	assert.ErrorIs(t, err, payment.ErrOrderNotFound)
+	assert.Len(t, provider.payments, 2, "no payment is created for these orders")
+}
@@ -0,0 +1,116 @@
+package test
+
+import (
+	"context"
+	"net/url"
+	"testing"
+
+	"github.com/swclabs/swipex/internal/core/domain/model"
+	paymentService "github.com/swclabs/swipex/internal/core/service/payment"
+	"github.com/swclabs/swipex/pkg/gen/payment"
+	"github.com/swclabs/swipex/pkg/lib/vnpay"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const secret = "SECRET"
+
+// signed by x/vnpay/vnpay.py with the same secret
+const (
+	pythonPaymentURL = "https://sandbox.vnpayment.vn/paymentv2/vpcpay.html?vnp_Amount=15000000&vnp_Command=pay" +
+		"&vnp_CreateDate=20261018093000&vnp_CurrCode=VND&vnp_IpAddr=127.0.0.1&vnp_Locale=vn" +
+		"&vnp_OrderInfo=Thanh+toan+don+hang+ORD-1&vnp_OrderType=other" +
+		"&vnp_ReturnUrl=http%3A%2F%2Flocalhost%3A8000%2Fpayment%2Freturn&vnp_TmnCode=SWIPEX01" +
+		"&vnp_TxnRef=ORD-1&vnp_Version=2.1.0&vnp_SecureHash=62c48684083f0dff53ef52cbf312ec3d28d2281f5e80dc86" +
+		"ab221cf42dd3273accaf569120e18273f67c83b91b1e44cb2a6c1da8f3e548a971cd7b22984de5c0"
+	pythonReturnHash = "da7f1df11b902ba58011942c2c39409949dfc5157d4a313ee2257664e22099d8" +
+		"dff24f1dfd89899ed30a3ca773ece32c02d48c366f2e68a998233716bee7295b"
+)
+
+var merchant = vnpay.Merchant{
+	TmnCode:    "SWIPEX01",
+	HashSecret: secret,
+	PaymentURL: "https://sandbox.vnpayment.vn/paymentv2/vpcpay.html",
+	ReturnURL:  "http://localhost:8000/payment/return",
+}
+
+func TestVNPaySignLikePython(t *testing.T) {
+	payURL, err := url.Parse(pythonPaymentURL)
+	require.NoError(t, err)
+	params := payURL.Query()
+	assert.Equal(t, params.Get("vnp_SecureHash"), vnpay.Sign(vnpay.Query(params), secret))
+	assert.True(t, vnpay.Verify(params, params.Get("vnp_SecureHash"), secret))
+}
+
+func TestVNPayProcessPayment(t *testing.T) {
+	resp, err := vnpay.New(merchant).ProcessPayment(context.Background(), &payment.PaymentRequest{
+		OrderType: "other",
+		OrderId:   "ORD-1",
+		Amount:    150000,
+		OrderDesc: "Thanh toan don hang ORD-1",
+		IpAddress: "127.0.0.1",
+	})
+	require.NoError(t, err)
+	assert.True(t, resp.Success)
+
+	payURL, err := url.Parse(resp.PaymentUrl)
+	require.NoError(t, err)
+	params := payURL.Query()
+	assert.Equal(t, "15000000", params.Get("vnp_Amount"))
+	assert.Equal(t, "vn", params.Get("vnp_Locale"))
+	assert.Equal(t, merchant.ReturnURL, params.Get("vnp_ReturnUrl"))
+	assert.True(t, vnpay.Verify(params, params.Get("vnp_SecureHash"), secret))
+}
+
+func TestVNPayProcessPaymentReturn(t *testing.T) {
+	server := vnpay.New(merchant)
+	req := &payment.PaymentReturnRequest{
+		Vnp_TmnCode:           "SWIPEX01",
+		Vnp_Amount:            15000000,
+		Vnp_BankCode:          "NCB",
+		Vnp_PayDate:           "20261018093512",
+		Vnp_OrderInfo:         "Thanh toan don hang ORD-1",
+		Vnp_TransactionNo:     14123456,
+		Vnp_ResponseCode:      "00",
+		Vnp_TransactionStatus: "00",
+		Vnp_TxnRef:            "ORD-1",
+		Vnp_SecureHash:        pythonReturnHash,
+	}
+	resp, err := server.ProcessPaymentReturn(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, "success", resp.Result)
+	assert.Equal(t, "ORD-1", resp.OrderId)
+	assert.Equal(t, "14123456", resp.Vnp_TransactionNo)
+
+	req.Vnp_Amount = 1500000
+	resp, err = server.ProcessPaymentReturn(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, "fail", resp.Result, "tampered amount must not verify")
+}
+
+func TestVNPayCancelledCallback(t *testing.T) {
+	// a payment cancelled by the customer is sent back with a zero
+	// transaction number, which is signed as sent
+	params := url.Values{
+		"vnp_TmnCode":           {"SWIPEX01"},
+		"vnp_Amount":            {"15000000"},
+		"vnp_BankCode":          {"VNPAY"},
+		"vnp_CardType":          {"QRCODE"},
+		"vnp_OrderInfo":         {"Thanh toan don hang ORD-1"},
+		"vnp_TransactionNo":     {"0"},
+		"vnp_ResponseCode":      {"24"},
+		"vnp_TransactionStatus": {"02"},
+		"vnp_TxnRef":            {"ORD-1"},
+	}
+	params.Set("vnp_SecureHash", vnpay.Sign(vnpay.Query(params), secret))
+
+	provider := paymentService.NewVNPay(paymentService.Native(merchant), merchant)
+	callback, err := provider.VerifyCallback(context.Background(), model.PaymentCallbackRequest{Query: params})
+	require.NoError(t, err)
+	assert.False(t, callback.Success)
+	assert.Equal(t, "ORD-1", callback.OrderCode)
+	assert.Equal(t, "0", callback.TransactionNo)
+	assert.Equal(t, "24", callback.ResponseCode)
+	assert.Equal(t, "150000", callback.Amount.String())
+}
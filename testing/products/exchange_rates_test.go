@@ -0,0 +1,48 @@
+package test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/swclabs/swipex/internal/core/domain/model"
+	productService "github.com/swclabs/swipex/internal/core/service/products"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConvertExchangeRates(t *testing.T) {
+	rates := model.ExchangeRates{
+		"VND": decimal.NewFromInt(1),
+		"USD": decimal.NewFromInt(25000),
+		"JPY": decimal.NewFromInt(170),
+	}
+
+	usd, ok := rates.Convert(decimal.NewFromInt(17299000), "VND", "USD")
+	assert.True(t, ok)
+	assert.Equal(t, "691.96", usd.String())
+
+	jpy, ok := rates.Convert(decimal.NewFromInt(10), "USD", "JPY")
+	assert.True(t, ok)
+	assert.Equal(t, "1471", jpy.String())
+
+	_, ok = rates.Convert(decimal.NewFromInt(1), "VND", "EUR")
+	assert.False(t, ok)
+}
+
+func TestImportInvalidExchangeRates(t *testing.T) {
+	service := &productService.Products{}
+	for name, file := range map[string]string{
+		"empty":          "",
+		"missing column": "currency_code\nUSD\n",
+		"no rates":       "currency_code,rate\n",
+		"invalid code":   "currency_code,rate\nDOLLAR,25000\n",
+		"base currency":  "currency_code,rate\nVND,1\n",
+		"negative rate":  "currency_code,rate\nUSD,-25000\n",
+		"repeated":       "currency_code,rate\nUSD,25000\nusd,25100\n",
+	} {
+		_, err := service.ImportExchangeRates(context.Background(), "admin", strings.NewReader(file))
+		assert.ErrorIs(t, err, productService.ErrInvalidExchangeRate, name)
+	}
+}
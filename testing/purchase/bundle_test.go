@@ -0,0 +1,90 @@
+package test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/swclabs/swipex/internal/config"
+	"github.com/swclabs/swipex/internal/core/domain/dtos"
+	"github.com/swclabs/swipex/internal/core/domain/entity"
+	"github.com/swclabs/swipex/internal/core/service/purchase"
+	"github.com/swclabs/swipex/pkg/lib/valid"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBundleRequestValidate(t *testing.T) {
+	bundle := dtos.CreateBundle{
+		Name:  "iPhone 15 + AirPods",
+		Price: "25990000",
+		Items: []dtos.BundleItem{
+			{InventoryID: 1, Quantity: 1},
+			{InventoryID: 2, Quantity: 1},
+		},
+	}
+	assert.NoError(t, valid.Validate(&bundle))
+
+	bundle.Items = bundle.Items[:1]
+	assert.Error(t, valid.Validate(&bundle), "a bundle needs two items")
+
+	bundle.Items = append(bundle.Items, dtos.BundleItem{InventoryID: 2})
+	assert.Error(t, valid.Validate(&bundle), "items need a quantity")
+
+	bundle.Items[1].Quantity, bundle.Status = 1, "draft"
+	assert.Error(t, valid.Validate(&bundle), "status must be active or archived")
+
+	assert.Error(t, valid.Validate(&dtos.CartBundleDTO{BundleID: 1}), "a cart bundle needs a quantity")
+}
+
+func TestCheckoutBundlePrice(t *testing.T) {
+	// two bundles at 1000000 of a phone listed at 900000 with 10% VAT and
+	// a case listed at 300000 without VAT, next to an item sold on its own
+	ctx := context.Background()
+	c := newCheckout(ctx)
+	c.stock(ctx, 1, 900000, 10)
+	c.stock(ctx, 2, 300000, 0)
+	c.stock(ctx, 7, 100000, 0)
+	for id, price := range map[int64]int64{1: 900000, 2: 300000} {
+		c.inventory.On("GetByID", ctx, id).Return(&entity.Inventory{
+			ID: id, ProductID: id, Available: 10, Status: "active",
+			Price: decimal.NewFromInt(price), CurrencyCode: config.BaseCurrency,
+		}, nil)
+	}
+	c.bundle.On("GetByID", ctx, int64(5)).Return(&entity.Bundle{
+		ID: 5, Price: decimal.NewFromInt(1000000), CurrencyCode: config.BaseCurrency, Status: purchase.BundleActive,
+	}, nil)
+	c.bundle.On("GetItems", ctx, int64(5)).Return([]entity.BundleItem{
+		{BundleID: 5, InventoryID: 1, Quantity: 1},
+		{BundleID: 5, InventoryID: 2, Quantity: 1},
+	}, nil)
+	form := orderForm(dtos.OrderFormProduct{Code: "IP15#7", Quantity: 1})
+	form.Bundles = []dtos.OrderFormBundle{{BundleID: 5, Quantity: 2}}
+
+	order, lines := c.place(t, ctx, form)
+
+	// the bundle price is spread over its components by list price
+	require.Len(t, lines, 3)
+	for i, want := range []struct {
+		inventory int64
+		quantity  int64
+		unitPrice string
+		amount    string
+		tax       string
+	}{
+		{7, 1, "100000", "100000", "0"},
+		{1, 2, "750000", "1500000", "150000"},
+		{2, 2, "250000", "500000", "0"},
+	} {
+		assert.Equal(t, want.inventory, lines[i].InventoryID)
+		assert.Equal(t, want.quantity, lines[i].Quantity)
+		assert.Equal(t, want.unitPrice, lines[i].UnitPrice.String())
+		assert.Equal(t, want.amount, lines[i].TotalAmount.String())
+		assert.Equal(t, want.tax, lines[i].TaxAmount.String())
+		assert.Equal(t, i > 0, lines[i].BundleID != nil)
+	}
+	assert.Equal(t, "2100000", order.Subtotal.String())
+	assert.Equal(t, "150000", order.TaxAmount.String())
+	assert.Equal(t, "2280000", order.TotalAmount.String(), "2100000 + 150000 of VAT + 30000 of shipping")
+}
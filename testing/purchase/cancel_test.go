@@ -0,0 +1,137 @@
+package test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/swclabs/swipex/internal/core/domain/entity"
+	"github.com/swclabs/swipex/internal/core/domain/enum"
+	"github.com/swclabs/swipex/internal/core/repos/coupons"
+	"github.com/swclabs/swipex/internal/core/repos/credits"
+	"github.com/swclabs/swipex/internal/core/repos/inventories"
+	"github.com/swclabs/swipex/internal/core/repos/orders"
+	"github.com/swclabs/swipex/internal/core/service/purchase"
+	"github.com/swclabs/swipex/internal/core/tasks"
+	"github.com/swclabs/swipex/pkg/infra/db"
+	"github.com/swclabs/swipex/pkg/lib/worker"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// cancellation holds the repositories of the transaction of an order
+// status change.
+type cancellation struct {
+	conn      *db.TxMock
+	order     orders.Mock
+	inventory inventories.Mock
+	coupon    coupons.Mock
+	credit    credits.Mock
+	worker    worker.Mock
+}
+
+func newCancellation(ctx context.Context, order *entity.Order) *cancellation {
+	c := &cancellation{conn: db.NewTransactionMock()}
+	c.conn.On("Rollback", ctx).Return(nil)
+	c.conn.On("Commit", ctx).Return(nil)
+	c.order.On("GetByUUIDForUpdate", ctx, order.UUID).Return(order, nil)
+	c.order.On("GetByUUID", ctx, order.UUID).Return(order, nil)
+	return c
+}
+
+func (c *cancellation) service() *purchase.Purchase {
+	return &purchase.Purchase{
+		Worker: &c.worker,
+		Begin: func(_ context.Context) (*purchase.Tx, error) {
+			return &purchase.Tx{
+				ITransaction: c.conn,
+				Order:        &c.order,
+				Inventory:    &c.inventory,
+				Coupon:       &c.coupon,
+				Credit:       &c.credit,
+			}, nil
+		},
+	}
+}
+
+func TestCancelOrderReleasesStockAndCredits(t *testing.T) {
+	ctx := context.Background()
+	cardID := int64(4)
+	order := &entity.Order{
+		ID: 10, UUID: "ORD10", UserID: 1, Status: "pending",
+		CreditAmount: decimal.NewFromInt(50000),
+	}
+	c := newCancellation(ctx, order)
+
+	// the stock reserved at checkout comes back, backordered units never left it
+	c.order.On("GetProductByOrderID", ctx, int64(10)).Return([]entity.ProductInOrder{
+		{InventoryID: 3, Quantity: 2},
+		{InventoryID: 5, Quantity: 3, Backordered: 1},
+	}, nil)
+	c.inventory.On("Release", ctx, int64(3), int64(2)).Return(2, nil)
+	c.inventory.On("Release", ctx, int64(5), int64(2)).Return(2, nil)
+	c.order.On("UpdateStatus", ctx, "ORD10", "cancelled").Return(nil)
+	c.order.On("InsertStatusHistory", ctx, entity.OrderStatusHistory{
+		OrderID: 10, FromStatus: "pending", ToStatus: "cancelled", Actor: "customer", Reason: "changed my mind",
+	}).Return(nil)
+
+	// the gift card spent at checkout gets its balance back
+	c.credit.On("GetOrderEntries", ctx, int64(10)).Return([]entity.CreditEntry{
+		{GiftCardID: &cardID, OrderID: &order.ID, Amount: decimal.NewFromInt(-50000), Kind: "redeem"},
+	}, nil)
+	c.credit.On("GetGiftCardByIDForUpdate", ctx, cardID).
+		Return(&entity.GiftCard{ID: cardID, Balance: decimal.NewFromInt(10000), Status: "active"}, nil)
+	c.credit.On("SetGiftCardBalance", ctx, cardID, decimal.NewFromInt(60000)).Return(nil)
+	c.credit.On("InsertEntry", ctx, mock.MatchedBy(func(entry entity.CreditEntry) bool {
+		return entry.Kind == "reversal" && entry.Amount.Equal(decimal.NewFromInt(50000))
+	})).Return(int64(1), nil)
+	c.coupon.On("GetUsedByOrderID", ctx, int64(10)).Return(nil, pgx.ErrNoRows)
+	c.worker.On("Exec", ctx, mock.Anything, tasks.PurchaseNotifyOrderCancelled, mock.Anything).Return(nil)
+
+	assert.NoError(t, c.service().CancelOrder(ctx, 1, "ORD10", "changed my mind"))
+
+	c.inventory.AssertExpectations(t)
+	c.credit.AssertExpectations(t)
+	c.order.AssertExpectations(t)
+	c.conn.AssertCalled(t, "Commit", ctx)
+	c.conn.AssertNotCalled(t, "Rollback", mock.Anything)
+}
+
+func TestCancelOrderRejectsShippedOrders(t *testing.T) {
+	ctx := context.Background()
+	c := newCancellation(ctx, &entity.Order{ID: 11, UUID: "ORD11", UserID: 1, Status: "shipping"})
+
+	err := c.service().CancelOrder(ctx, 1, "ORD11", "")
+
+	var transitionErr *purchase.InvalidTransitionError
+	assert.ErrorAs(t, err, &transitionErr)
+	assert.Equal(t, enum.OrderShipping, transitionErr.From)
+	c.inventory.AssertNotCalled(t, "Release", mock.Anything, mock.Anything, mock.Anything)
+	c.conn.AssertCalled(t, "Rollback", ctx)
+	c.conn.AssertNotCalled(t, "Commit", mock.Anything)
+}
+
+func TestCancelOrderOfAnotherUser(t *testing.T) {
+	ctx := context.Background()
+	c := newCancellation(ctx, &entity.Order{ID: 12, UUID: "ORD12", UserID: 2, Status: "pending"})
+
+	assert.ErrorIs(t, c.service().CancelOrder(ctx, 1, "ORD12", ""), purchase.ErrOrderNotFound)
+	c.conn.AssertNotCalled(t, "Commit", mock.Anything)
+}
+
+func TestUpdateOrderStatusRejectsInvalidTransition(t *testing.T) {
+	// a delivered order cannot be expired, its stock is not given back
+	ctx := context.Background()
+	c := newCancellation(ctx, &entity.Order{ID: 13, UUID: "ORD13", UserID: 1, Status: "delivered"})
+
+	err := c.service().UpdateOrderStatus(ctx, "ORD13", enum.OrderExpired, "admin@swipex.vn", "")
+
+	var transitionErr *purchase.InvalidTransitionError
+	assert.ErrorAs(t, err, &transitionErr)
+	assert.Equal(t, &purchase.InvalidTransitionError{From: enum.OrderDelivered, To: enum.OrderExpired}, transitionErr)
+	c.order.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything)
+	c.inventory.AssertNotCalled(t, "Release", mock.Anything, mock.Anything, mock.Anything)
+	c.conn.AssertCalled(t, "Rollback", ctx)
+}
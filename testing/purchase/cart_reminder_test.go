@@ -0,0 +1,59 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/swclabs/swipex/internal/core/domain/entity"
+	"github.com/swclabs/swipex/internal/core/domain/model"
+	"github.com/swclabs/swipex/internal/core/repos/carts"
+	"github.com/swclabs/swipex/internal/core/repos/reminders"
+	"github.com/swclabs/swipex/internal/core/service/purchase"
+	"github.com/swclabs/swipex/internal/core/tasks"
+	"github.com/swclabs/swipex/internal/workers/queue"
+	"github.com/swclabs/swipex/pkg/components"
+	"github.com/swclabs/swipex/pkg/lib/worker"
+
+	"github.com/a-h/templ"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCartReminderEmail(t *testing.T) {
+	html, err := templ.ToGoHTML(context.Background(), components.CartReminder("Jane Doe", []components.CartReminderItem{
+		{Name: "iPhone 15", Color: "Black", Quantity: 2, Price: "999.00", Currency: "USD"},
+	}, "https://shop.example.com/cart"))
+	assert.NoError(t, err)
+
+	body := string(html)
+	assert.Contains(t, body, "Dear Jane Doe")
+	assert.Contains(t, body, "iPhone 15")
+	assert.Contains(t, body, "x2")
+	assert.Contains(t, body, `href="https://shop.example.com/cart"`)
+}
+
+func TestRemindAbandonedCartsContinuesAfterFailure(t *testing.T) {
+	var (
+		ctx      = context.Background()
+		cart     = carts.NewCartsMock()
+		reminder = reminders.NewRemindersMock()
+		client   = worker.NewClientMock()
+		service  = &purchase.Purchase{Cart: cart, Reminder: reminder, Worker: client}
+	)
+	cart.On("GetAbandoned", ctx, mock.Anything, mock.Anything, mock.Anything).Return([]model.AbandonedCart{
+		{UserID: 1, Items: 2}, {UserID: 2, Items: 1},
+	}, nil)
+	reminder.On("Create", ctx, mock.MatchedBy(func(r entity.CartReminder) bool { return r.UserID == 1 })).Return(int64(10), nil)
+	reminder.On("Create", ctx, mock.MatchedBy(func(r entity.CartReminder) bool { return r.UserID == 2 })).Return(int64(11), nil)
+	client.On("Exec", ctx, queue.CartQueue, tasks.PurchaseSendCartReminder, []byte(`{"user_id":1}`)).
+		Return(errors.New("redis is down"))
+	client.On("Exec", ctx, queue.CartQueue, tasks.PurchaseSendCartReminder, []byte(`{"user_id":2}`)).Return(nil)
+	reminder.On("Delete", ctx, int64(10)).Return(nil)
+
+	require.NoError(t, service.RemindAbandonedCarts(ctx))
+	reminder.AssertCalled(t, "Delete", ctx, int64(10))
+	reminder.AssertNotCalled(t, "Delete", ctx, int64(11))
+	client.AssertNumberOfCalls(t, "Exec", 2)
+}
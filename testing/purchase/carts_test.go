@@ -0,0 +1,38 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/swclabs/swipex/internal/core/domain/dtos"
+	"github.com/swclabs/swipex/pkg/lib/valid"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCartRequestValidate(t *testing.T) {
+	assert.NoError(t, valid.Validate(&dtos.CartQuantity{Quantity: 2}))
+	assert.Error(t, valid.Validate(&dtos.CartQuantity{Quantity: -1}), "quantity must be positive")
+
+	merge := dtos.CartMerge{Items: []dtos.CartDTO{{InventoryID: 1, Quantity: 1}}}
+	assert.NoError(t, valid.Validate(&merge))
+
+	merge.Items = append(merge.Items, dtos.CartDTO{Quantity: 1})
+	assert.Error(t, valid.Validate(&merge), "every merged item needs an inventory id")
+
+	insert := dtos.CartInsertDTO{CartDTO: dtos.CartDTO{InventoryID: 1, Quantity: -3}, Email: "an@swipex.vn"}
+	assert.Error(t, valid.Validate(&insert), "quantity must be positive")
+}
+
+func TestOrderFormQuantity(t *testing.T) {
+	form := dtos.OrderForm{
+		PaymentMethod: "cod",
+		Customer:      dtos.OrderFormCustomer{Email: "an@swipex.vn", FirstName: "An", LastName: "Nguyen", Phone: "0901234567"},
+		Delivery:      dtos.OrderFormDelivery{Status: "pending", Method: "standard"},
+		Address:       dtos.OrderFormAddress{City: "HCM", Ward: "1", District: "1", Street: "Le Loi"},
+		Product:       []dtos.OrderFormProduct{{Code: "IP15#7", Quantity: 1}},
+	}
+	assert.NoError(t, valid.Validate(&form))
+
+	form.Product = append(form.Product, dtos.OrderFormProduct{Code: "IP15#7", Quantity: -5})
+	assert.Error(t, valid.Validate(&form), "a negative line must not lower the order")
+}
@@ -0,0 +1,209 @@
+package test
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/swclabs/swipex/internal/config"
+	"github.com/swclabs/swipex/internal/core/domain/dtos"
+	"github.com/swclabs/swipex/internal/core/domain/entity"
+	"github.com/swclabs/swipex/internal/core/domain/enum"
+	"github.com/swclabs/swipex/internal/core/repos/cod"
+	"github.com/swclabs/swipex/internal/core/repos/orders"
+	"github.com/swclabs/swipex/internal/core/service/purchase"
+	"github.com/swclabs/swipex/pkg/infra/db"
+	"github.com/swclabs/swipex/pkg/lib/valid"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportInvalidRemittances(t *testing.T) {
+	service := &purchase.Purchase{}
+	for name, report := range map[string]string{
+		"empty":          "",
+		"missing column": "order_code,cod_amount\nGHN1,500000\n",
+		"no lines":       "order_code,client_order_code,cod_amount\n",
+		"invalid amount": "order_code,client_order_code,cod_amount\nGHN1,ORDER1,five\n",
+		"no carrier":     "order_code,client_order_code,cod_amount\n,ORDER1,500000\n",
+		"invalid time":   "order_code,client_order_code,cod_amount,remitted_at\nGHN1,ORDER1,500000,yesterday\n",
+	} {
+		_, err := service.ImportRemittances(context.Background(), "admin", "REM-1", strings.NewReader(report))
+		assert.ErrorIs(t, err, purchase.ErrInvalidRemittance, name)
+	}
+}
+
+func TestRemittanceSyncValidate(t *testing.T) {
+	assert.NoError(t, valid.Validate(&dtos.RemittanceSync{From: "2026-10-01", To: "2026-10-15"}))
+	assert.Error(t, valid.Validate(&dtos.RemittanceSync{From: "2026-10-01"}))
+	assert.Error(t, valid.Validate(&dtos.RemittanceSync{From: "01/10/2026", To: "2026-10-15"}))
+}
+
+// remittances holds the transaction of a remittance import.
+type remittances struct {
+	conn  *db.TxMock
+	order orders.Mock
+	cod   cod.Mock
+}
+
+func newRemittances(ctx context.Context) *remittances {
+	r := &remittances{conn: db.NewTransactionMock()}
+	r.conn.On("Rollback", ctx).Return(nil)
+	r.conn.On("Commit", ctx).Return(nil)
+	return r
+}
+
+func (r *remittances) service() *purchase.Purchase {
+	return &purchase.Purchase{
+		Begin: func(_ context.Context) (*purchase.Tx, error) {
+			return &purchase.Tx{ITransaction: r.conn, Order: &r.order, COD: &r.cod}, nil
+		},
+	}
+}
+
+// collect sets up a COD order expecting the given amount, nothing remitted
+// yet.
+func (r *remittances) collect(ctx context.Context, id int64, code string, expected int64) {
+	r.order.On("GetByUUID", ctx, code).Return(&entity.Order{ID: id, UUID: code}, nil)
+	r.cod.On("GetCollectionForUpdate", ctx, id).Return(&entity.CODCollection{
+		OrderID: id, ExpectedAmount: decimal.NewFromInt(expected), RemittedAmount: decimal.Zero,
+		Status: enum.CODPending.String(),
+	}, nil)
+}
+
+// remitted returns the amount each collection was updated with.
+func (r *remittances) remitted() map[int64]string {
+	amounts := map[int64]string{}
+	for _, call := range r.cod.Calls {
+		if call.Method == "UpdateCollection" {
+			amounts[call.Arguments.Get(1).(int64)] = call.Arguments.Get(2).(decimal.Decimal).String()
+		}
+	}
+	return amounts
+}
+
+func TestImportRemittances(t *testing.T) {
+	ctx := context.Background()
+	r := newRemittances(ctx)
+	r.collect(ctx, 1, "ORD1", 500000)
+	r.collect(ctx, 2, "ORD2", 300000)
+	r.collect(ctx, 3, "ORD3", 100000)
+	r.order.On("GetByUUID", ctx, "ORD4").Return(nil, pgx.ErrNoRows)
+	r.cod.On("InsertRemittance", ctx, mock.Anything).Return(int64(1), nil)
+	r.cod.On("UpdateCollection", ctx, int64(1), mock.Anything, enum.CODReconciled.String()).Return(nil)
+	r.cod.On("UpdateCollection", ctx, int64(2), mock.Anything, enum.CODShort.String()).Return(nil)
+	r.cod.On("UpdateCollection", ctx, int64(3), mock.Anything, enum.CODMismatch.String()).Return(nil)
+	r.order.On("SetPaid", ctx, int64(1), mock.AnythingOfType("time.Time")).Return(nil)
+
+	result, err := r.service().ImportRemittances(ctx, "admin", "REM-1", strings.NewReader(
+		"order_code,client_order_code,cod_amount\n"+
+			"GHN1,ORD1,500000\nGHN2,ORD2,200000\nGHN3,ORD3,150000\nGHN4,ORD4,90000\n"))
+	require.NoError(t, err)
+
+	assert.Equal(t, 4, result.Lines)
+	assert.Equal(t, []int{1, 1, 1, 1}, []int{result.Matched, result.Short, result.Over, result.Unmatched})
+	assert.Equal(t, "940000", result.Amount)
+	outcomes := make([]string, 0, len(result.Results))
+	for _, line := range result.Results {
+		outcomes = append(outcomes, line.Result)
+	}
+	assert.Equal(t, []string{
+		purchase.RemittanceMatched, purchase.RemittanceShort, purchase.RemittanceOver, purchase.RemittanceUnmatched,
+	}, outcomes)
+	assert.Equal(t, map[int64]string{1: "500000", 2: "200000", 3: "150000"}, r.remitted())
+
+	// only the collection remitted in full pays its order
+	r.order.AssertNumberOfCalls(t, "SetPaid", 1)
+	// the unmatched line is kept for an admin to look at
+	unmatched := r.cod.Calls[len(r.cod.Calls)-1].Arguments.Get(1).(entity.CODRemittance)
+	assert.Equal(t, "ORD4", unmatched.OrderCode)
+	assert.Nil(t, unmatched.OrderID)
+	r.conn.AssertCalled(t, "Commit", ctx)
+}
+
+func TestImportRemittancesTwice(t *testing.T) {
+	// the lines of a report imported again are skipped, the collections
+	// are not remitted twice
+	ctx := context.Background()
+	r := newRemittances(ctx)
+	r.collect(ctx, 1, "ORD1", 500000)
+	r.cod.On("InsertRemittance", ctx, mock.Anything).Return(int64(0), pgx.ErrNoRows)
+
+	result, err := r.service().ImportRemittances(ctx, "admin", "REM-1", strings.NewReader(
+		"order_code,client_order_code,cod_amount\nGHN1,ORD1,500000\n"))
+	require.NoError(t, err)
+
+	assert.Equal(t, 1, result.Duplicates)
+	assert.Zero(t, result.Matched)
+	assert.Equal(t, "0", result.Amount)
+	assert.Equal(t, purchase.RemittanceDuplicate, result.Results[0].Result)
+	r.cod.AssertNotCalled(t, "UpdateCollection", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	r.order.AssertNotCalled(t, "SetPaid", mock.Anything, mock.Anything, mock.Anything)
+}
+
+// preorder sets up an inventory out of stock taking a 30% deposit, at
+// 1000000 without VAT, and a customer holding points and store credit.
+func (c *checkout) preorder(ctx context.Context, credit int64) {
+	c.inventory.On("GetByIDForUpdate", ctx, int64(9)).Return(&entity.Inventory{
+		ID: 9, ProductID: 9, Available: 0, Status: enum.InventoryPreorder.String(),
+		Price: decimal.NewFromInt(1000000), CurrencyCode: config.BaseCurrency, DepositPercent: decimal.NewFromInt(30),
+	}, nil)
+	c.product.On("GetByID", ctx, int64(9)).Return(&entity.Product{ID: 9, CategoryID: 9}, nil)
+	c.category.On("GetByID", ctx, int64(9)).Return(&entity.Category{ID: 9}, nil)
+
+	c.loyalty.On("GetBalanceForUpdate", ctx, int64(1)).Return(int64(5000), nil)
+	c.loyalty.On("GetLots", ctx, int64(1)).Return([]entity.LoyaltyEntry{{ID: 4, Remaining: 5000}}, nil)
+	c.loyalty.On("SetRemaining", ctx, int64(4), mock.Anything).Return(nil)
+	c.loyalty.On("InsertEntry", ctx, mock.Anything).Return(int64(1), nil)
+	c.loyalty.On("AddPoints", ctx, int64(1), mock.Anything, int64(0)).Return(nil)
+	c.credit.On("GetWalletForUpdate", ctx, int64(1)).
+		Return(&entity.Wallet{UserID: 1, Balance: decimal.NewFromInt(credit)}, nil)
+	c.credit.On("SetWalletBalance", ctx, int64(1), mock.Anything).Return(nil)
+	c.credit.On("InsertEntry", ctx, mock.Anything).Return(int64(1), nil)
+	c.cod.On("InsertCollection", ctx, mock.Anything).Return(nil)
+}
+
+// expected returns the amount the carrier is expected to collect.
+func (c *checkout) expected(t *testing.T) string {
+	t.Helper()
+	c.cod.AssertNumberOfCalls(t, "InsertCollection", 1)
+	return c.cod.Calls[0].Arguments.Get(1).(entity.CODCollection).ExpectedAmount.String()
+}
+
+func TestCheckoutExpectsCOD(t *testing.T) {
+	// 1000000 and 30000 of shipping, 330000 of deposit: 1000 points pay
+	// 100000 and the store credit 50000, the deposit is not collected
+	// before delivery
+	ctx := context.Background()
+	c := newCheckout(ctx)
+	c.preorder(ctx, 50000)
+	form := orderForm(dtos.OrderFormProduct{Code: "IP15#9", Quantity: 1})
+	form.Points, form.StoreCredit = 1000, "50000"
+
+	order, _ := c.submit(t, ctx, form)
+
+	assert.Equal(t, "1030000", order.TotalAmount.String())
+	assert.Equal(t, "330000", order.DepositAmount.String())
+	assert.Nil(t, order.DepositPaidAt)
+	assert.Equal(t, "880000", c.expected(t), "the total less the points and the store credit")
+}
+
+func TestCheckoutExpectsCODDepositPaid(t *testing.T) {
+	// the points and the store credit pay the whole deposit at checkout,
+	// the carrier collects the balance
+	ctx := context.Background()
+	c := newCheckout(ctx)
+	c.preorder(ctx, 500000)
+	form := orderForm(dtos.OrderFormProduct{Code: "IP15#9", Quantity: 1})
+	form.Points, form.StoreCredit = 1000, "500000"
+
+	order, _ := c.submit(t, ctx, form)
+
+	assert.Equal(t, "230000", order.CreditAmount.String(), "the store credit pays up to the deposit")
+	assert.NotNil(t, order.DepositPaidAt)
+	assert.Equal(t, "700000", c.expected(t), "the total less the deposit")
+}
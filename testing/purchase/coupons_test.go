@@ -0,0 +1,173 @@
+package test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/swclabs/swipex/internal/config"
+	"github.com/swclabs/swipex/internal/core/domain/dtos"
+	"github.com/swclabs/swipex/internal/core/domain/entity"
+	"github.com/swclabs/swipex/internal/core/domain/model"
+	"github.com/swclabs/swipex/internal/core/repos/coupons"
+	"github.com/swclabs/swipex/internal/core/repos/currencies"
+	"github.com/swclabs/swipex/internal/core/repos/inventories"
+	"github.com/swclabs/swipex/internal/core/repos/products"
+	"github.com/swclabs/swipex/internal/core/service/purchase"
+	"github.com/swclabs/swipex/pkg/lib/valid"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestCreateCouponValidate(t *testing.T) {
+	coupon := dtos.CreateCoupon{
+		Status:        "active",
+		MaxUse:        100,
+		Discount:      50000,
+		Description:   "50k off phones over 1M",
+		MaxDay:        7,
+		DiscountType:  "fixed",
+		MinOrderValue: "1000000",
+		CategoryIDs:   []int64{1},
+	}
+	assert.NoError(t, valid.Validate(&coupon))
+
+	coupon.DiscountType = "free-shipping"
+	assert.Error(t, valid.Validate(&coupon), "unknown discount types must be rejected")
+
+	coupon.DiscountType = "percent"
+	coupon.MaxDiscount = "a lot"
+	assert.Error(t, valid.Validate(&coupon), "max discount must be a number")
+}
+
+func TestValidateCouponRules(t *testing.T) {
+	ctx := context.Background()
+	tomorrow := time.Now().UTC().Add(24 * time.Hour)
+	active := func(coupon entity.Coupons) *entity.Coupons {
+		coupon.Code, coupon.Status = "SALE", "active"
+		if coupon.ExpiredAt.IsZero() {
+			coupon.ExpiredAt = tomorrow
+		}
+		if coupon.DiscountType == "" {
+			coupon.DiscountType = "fixed"
+		}
+		return &coupon
+	}
+	tests := []struct {
+		name     string
+		coupon   *entity.Coupons
+		used     *entity.CouponsUsed
+		reason   string
+		discount string
+	}{
+		{name: "fixed", coupon: active(entity.Coupons{Discount: decimal.NewFromInt(50000)}), discount: "50000"},
+		{
+			name: "percent capped",
+			coupon: active(entity.Coupons{
+				DiscountType: "percent", Discount: decimal.NewFromInt(10), MaxDiscount: decimal.NewFromInt(150000),
+			}),
+			discount: "150000",
+		},
+		{
+			name: "percent under cap",
+			coupon: active(entity.Coupons{
+				DiscountType: "percent", Discount: decimal.NewFromInt(5), MaxDiscount: decimal.NewFromInt(150000),
+			}),
+			discount: "100000",
+		},
+		{
+			name:   "expired",
+			coupon: active(entity.Coupons{ExpiredAt: time.Now().UTC().Add(-time.Hour)}),
+			reason: "coupon has expired",
+		},
+		{
+			name:   "minimum order",
+			coupon: active(entity.Coupons{MinOrderValue: decimal.NewFromInt(3000000)}),
+			reason: "order must be at least 3000000 to use this coupon",
+		},
+		{
+			name:   "usage limit",
+			coupon: active(entity.Coupons{MaxUse: 10, Used: 10}),
+			reason: "coupon has reached its usage limit",
+		},
+		{
+			name:   "other categories",
+			coupon: active(entity.Coupons{Discount: decimal.NewFromInt(50000), CategoryIDs: []int64{5}}),
+			reason: "coupon does not apply to any item of the order",
+		},
+		{
+			name:   "already used",
+			coupon: active(entity.Coupons{Discount: decimal.NewFromInt(50000)}),
+			used:   &entity.CouponsUsed{UserID: 1, CouponCode: "SALE"},
+			reason: "coupon already used",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var (
+				coupon    coupons.Mock
+				currency  currencies.Mock
+				inventory inventories.Mock
+				product   products.Mock
+			)
+			coupon.On("GetByCode", ctx, "SALE").Return(test.coupon, nil)
+			if test.used != nil {
+				coupon.On("GetUsedByUser", ctx, int64(1), "SALE").Return(test.used, nil)
+			} else {
+				coupon.On("GetUsedByUser", ctx, int64(1), "SALE").Return(nil, pgx.ErrNoRows)
+			}
+			currency.On("GetRates", ctx).Return(model.ExchangeRates{config.BaseCurrency: decimal.NewFromInt(1)}, nil)
+			inventory.On("GetByID", ctx, int64(7)).Return(&entity.Inventory{
+				ID: 7, ProductID: 1, Price: decimal.NewFromInt(1000000), CurrencyCode: config.BaseCurrency,
+			}, nil)
+			product.On("GetByID", ctx, int64(1)).Return(&entity.Product{ID: 1, CategoryID: 2}, nil)
+			service := purchase.Purchase{Coupon: &coupon, Currency: &currency, Inventory: &inventory, Product: &product}
+
+			preview, err := service.ValidateCoupon(ctx, 1, dtos.CouponValidate{
+				CouponCode: "SALE",
+				Product:    []dtos.OrderFormProduct{{Code: "IP15#7", Quantity: 2}},
+			})
+			if test.reason != "" {
+				var couponErr *purchase.CouponError
+				assert.ErrorAs(t, err, &couponErr)
+				assert.Equal(t, test.reason, couponErr.Reason)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, test.discount, preview.Discount)
+			assert.Equal(t, "2000000", preview.Subtotal)
+		})
+	}
+}
+
+func TestCheckoutCouponUsageLimit(t *testing.T) {
+	// the coupon is locked and checked again at checkout: the last use may
+	// have been taken since the preview
+	ctx := context.Background()
+	c := newCheckout(ctx)
+	c.inventory.On("GetByIDForUpdate", ctx, int64(7)).Return(&entity.Inventory{
+		ID: 7, ProductID: 1, Available: 10, Status: "active",
+		Price: decimal.NewFromInt(1000000), CurrencyCode: config.BaseCurrency,
+	}, nil)
+	c.inventory.On("Reserve", ctx, int64(7), int64(1)).Return(9, nil)
+	c.product.On("GetByID", ctx, int64(1)).Return(&entity.Product{ID: 1, CategoryID: 2}, nil)
+	c.category.On("GetByID", ctx, int64(2)).Return(&entity.Category{ID: 2}, nil)
+	c.coupon.On("GetByCodeForUpdate", ctx, "SALE").Return(&entity.Coupons{
+		Code: "SALE", Status: "active", ExpiredAt: time.Now().UTC().Add(time.Hour),
+		DiscountType: "fixed", Discount: decimal.NewFromInt(50000), MaxUse: 10, Used: 10,
+	}, nil)
+	c.coupon.On("GetUsedByUser", ctx, int64(1), "SALE").Return(nil, pgx.ErrNoRows)
+
+	form := orderForm(dtos.OrderFormProduct{Code: "IP15#7", Quantity: 1})
+	form.CouponCode = "SALE"
+	_, err := c.service().CreateOrderForm(ctx, form)
+
+	var couponErr *purchase.CouponError
+	assert.ErrorAs(t, err, &couponErr)
+	assert.Equal(t, "coupon has reached its usage limit", couponErr.Reason)
+	c.coupon.AssertNotCalled(t, "IncreaseUsed", mock.Anything, mock.Anything)
+	c.conn.AssertCalled(t, "Rollback", ctx)
+}
@@ -0,0 +1,84 @@
+package test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strings"
+	"testing"
+
+	purchaseContainer "github.com/swclabs/swipex/internal/apis/container/purchase"
+	"github.com/swclabs/swipex/internal/config"
+	"github.com/swclabs/swipex/internal/core/domain/dtos"
+	"github.com/swclabs/swipex/internal/core/service/purchase"
+	"github.com/swclabs/swipex/pkg/lib/crypto"
+	"github.com/swclabs/swipex/pkg/lib/valid"
+	"github.com/swclabs/swipex/pkg/utils"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenGiftCardCode(t *testing.T) {
+	code := utils.GenGiftCardCode(16)
+	assert.Regexp(t, regexp.MustCompile(`^[A-HJ-NP-Z2-9]{16}$`), code)
+	assert.NotEqual(t, code, utils.GenGiftCardCode(16))
+}
+
+func TestGiftCardRequestValidate(t *testing.T) {
+	assert.NoError(t, valid.Validate(&dtos.IssueGiftCard{Amount: "500000", ExpiresAt: "2027-12-31"}))
+	assert.Error(t, valid.Validate(&dtos.IssueGiftCard{Amount: "five"}), "amount must be a number")
+	assert.Error(t, valid.Validate(&dtos.IssueGiftCard{Amount: "500000", ExpiresAt: "31/12/2027"}))
+}
+
+func TestIssueGiftCardForeignCurrency(t *testing.T) {
+	// the balance is spent as an amount of the base currency at checkout
+	_, err := (&purchase.Purchase{}).IssueGiftCard(context.Background(), "admin@swipex.vn",
+		dtos.IssueGiftCard{Amount: "20", CurrencyCode: "USD"})
+	assert.ErrorIs(t, err, purchase.ErrInvalidGiftCard)
+}
+
+// adminOrderForm is an order form of the admin page for the customer
+// owner@example.com, spending extra.
+func adminOrderForm(extra string) string {
+	return `{"payment_method":"cod",` +
+		`"customer":{"email":"owner@example.com","first_name":"An","last_name":"Nguyen","phone":"0901234567"},` +
+		`"delivery":{"status":"pending","method":"standard"},` +
+		`"address":{"city":"HCM","ward":"1","district":"1","street":"Le Loi"},` +
+		`"product":[{"code":"phone#1","quantity":1}],` + extra + `}`
+}
+
+// postAdminOrder sends an order form to the admin order endpoint, signed in
+// as email when it is not empty.
+func postAdminOrder(t *testing.T, body, email string) *httptest.ResponseRecorder {
+	t.Helper()
+	config.JwtSecret = "secret"
+	e := echo.New()
+	e.POST("/purchase/admin/orders", purchaseContainer.NewController(&purchase.Purchase{}).CreateOrderForm)
+
+	req := httptest.NewRequest(http.MethodPost, "/purchase/admin/orders", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	if email != "" {
+		token, err := crypto.GenerateToken(1, email, "customer")
+		assert.NoError(t, err)
+		req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+	}
+	rr := httptest.NewRecorder()
+	e.ServeHTTP(rr, req)
+	return rr
+}
+
+func TestAdminOrderStoreCredit(t *testing.T) {
+	// the store credit is refused before the order is placed, unless the
+	// request is signed in as the customer
+	body := adminOrderForm(`"store_credit":"100000"`)
+
+	rr := postAdminOrder(t, body, "")
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Contains(t, rr.Body.String(), purchase.ErrStoreCreditOwner.Error())
+
+	rr = postAdminOrder(t, body, "someone@example.com")
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Contains(t, rr.Body.String(), purchase.ErrStoreCreditOwner.Error())
+}
@@ -0,0 +1,52 @@
+package test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/swclabs/swipex/pkg/components"
+
+	"github.com/a-h/templ"
+	"github.com/stretchr/testify/assert"
+)
+
+var invoice = components.InvoiceData{
+	Number:        "INV-000042",
+	IssuedAt:      "2024-05-01 10:00:00",
+	OrderCode:     "a1b2c3",
+	PaymentMethod: "cod",
+	Currency:      "VND",
+	Seller:        components.InvoiceParty{Name: "SWC Store", TaxCode: "0101234567"},
+	Buyer:         components.InvoiceParty{Name: "Nguyễn Văn A", Address: "1 Lê Duẩn, Đà Nẵng"},
+	Lines: []components.InvoiceLine{
+		{Name: "iPhone 15", Color: "Black", Quantity: 2, UnitPrice: "1000.00", VATRate: "10", TaxAmount: "200.00", Amount: "2000.00"},
+	},
+	Subtotal:    "2000.00",
+	Discount:    "0.00",
+	TaxAmount:   "200.00",
+	ShippingFee: "30000.00",
+	Total:       "32200.00",
+}
+
+func TestInvoiceHTML(t *testing.T) {
+	html, err := templ.ToGoHTML(context.Background(), components.Invoice(invoice))
+	assert.NoError(t, err)
+
+	body := string(html)
+	assert.Contains(t, body, "Invoice INV-000042")
+	assert.Contains(t, body, "Tax code: 0101234567")
+	assert.Contains(t, body, "Nguyễn Văn A")
+	assert.Contains(t, body, "32200.00 VND")
+}
+
+func TestInvoicePDF(t *testing.T) {
+	doc := components.InvoicePDF(invoice)
+
+	assert.True(t, bytes.HasPrefix(doc, []byte("%PDF-1.4")))
+	assert.True(t, bytes.HasSuffix(doc, []byte("%%EOF\n")))
+	assert.Contains(t, string(doc), "(Invoice INV-000042)")
+	// letters missing from the standard fonts lose their accents
+	assert.Contains(t, string(doc), "(1 L\xea Duan, D\xe0 Nang)")
+	assert.Contains(t, string(doc), "(32200.00 VND)")
+}
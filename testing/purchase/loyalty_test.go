@@ -0,0 +1,187 @@
+package test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/swclabs/swipex/internal/core/domain/dtos"
+	"github.com/swclabs/swipex/internal/core/domain/entity"
+	"github.com/swclabs/swipex/internal/core/domain/enum"
+	"github.com/swclabs/swipex/internal/core/repos/loyalty"
+	"github.com/swclabs/swipex/internal/core/repos/orders"
+	"github.com/swclabs/swipex/internal/core/service/purchase"
+	"github.com/swclabs/swipex/pkg/infra/db"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoyaltyTierOf(t *testing.T) {
+	assert.Equal(t, enum.LoyaltyMember, enum.LoyaltyTierOf(999, 1000, 5000))
+	assert.Equal(t, enum.LoyaltySilver, enum.LoyaltyTierOf(1000, 1000, 5000))
+	assert.Equal(t, enum.LoyaltySilver, enum.LoyaltyTierOf(4999, 1000, 5000))
+	assert.Equal(t, enum.LoyaltyGold, enum.LoyaltyTierOf(5000, 1000, 5000))
+}
+
+func TestAdminOrderPoints(t *testing.T) {
+	// the points are refused before the order is placed, unless the request
+	// is signed in as the customer
+	body := adminOrderForm(`"points":200`)
+
+	rr := postAdminOrder(t, body, "")
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Contains(t, rr.Body.String(), purchase.ErrPointsOwner.Error())
+
+	rr = postAdminOrder(t, body, "someone@example.com")
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Contains(t, rr.Body.String(), purchase.ErrPointsOwner.Error())
+}
+
+// points holds the transaction of the loyalty tasks of the order ORD20,
+// delivered to customer 1 for 1250000 less a discount of 50000.
+type points struct {
+	conn    *db.TxMock
+	order   orders.Mock
+	loyalty loyalty.Mock
+}
+
+func newPoints(ctx context.Context) *points {
+	p := &points{conn: db.NewTransactionMock()}
+	p.conn.On("Rollback", ctx).Return(nil)
+	p.conn.On("Commit", ctx).Return(nil)
+	p.order.On("GetByUUIDForUpdate", ctx, "ORD20").Return(&entity.Order{
+		ID: 20, UUID: "ORD20", UserID: 1, Status: enum.OrderDelivered.String(),
+		Subtotal: decimal.NewFromInt(1250000), DiscountAmount: decimal.NewFromInt(50000),
+	}, nil)
+	return p
+}
+
+func (p *points) service() *purchase.Purchase {
+	return &purchase.Purchase{
+		Loyalty: &p.loyalty,
+		Begin: func(_ context.Context) (*purchase.Tx, error) {
+			return &purchase.Tx{ITransaction: p.conn, Order: &p.order, Loyalty: &p.loyalty}, nil
+		},
+	}
+}
+
+// lots returns the lots whose remaining points were set, in order.
+func lots(loyaltyRepo *loyalty.Mock) [][2]int64 {
+	var lots [][2]int64
+	for _, call := range loyaltyRepo.Calls {
+		if call.Method == "SetRemaining" {
+			lots = append(lots, [2]int64{call.Arguments.Get(1).(int64), call.Arguments.Get(2).(int64)})
+		}
+	}
+	return lots
+}
+
+func TestEarnPoints(t *testing.T) {
+	// a point for each 10000 spent after the discount, as a lot that
+	// expires
+	ctx := context.Background()
+	p := newPoints(ctx)
+	p.loyalty.On("GetOrderEntries", ctx, int64(20)).Return([]entity.LoyaltyEntry{
+		{ID: 3, Points: -10, Kind: purchase.LoyaltyRedeem},
+	}, nil)
+	p.loyalty.On("GetBalanceForUpdate", ctx, int64(1)).Return(int64(0), nil)
+	p.loyalty.On("InsertEntry", ctx, mock.MatchedBy(func(entry entity.LoyaltyEntry) bool {
+		return entry.Kind == purchase.LoyaltyEarn && entry.Points == 120 && entry.Remaining == 120 &&
+			*entry.OrderID == 20 && entry.ExpiresAt != nil && entry.ExpiresAt.After(time.Now())
+	})).Return(int64(4), nil)
+	p.loyalty.On("AddPoints", ctx, int64(1), int64(120), int64(120)).Return(nil)
+
+	require.NoError(t, p.service().EarnPoints(ctx, "ORD20"))
+
+	p.loyalty.AssertExpectations(t)
+	p.conn.AssertCalled(t, "Commit", ctx)
+}
+
+func TestEarnPointsOnce(t *testing.T) {
+	// the task may run again, the order already earned its points
+	ctx := context.Background()
+	p := newPoints(ctx)
+	p.loyalty.On("GetOrderEntries", ctx, int64(20)).Return([]entity.LoyaltyEntry{
+		{ID: 4, Points: 120, Kind: purchase.LoyaltyEarn},
+	}, nil)
+
+	require.NoError(t, p.service().EarnPoints(ctx, "ORD20"))
+
+	p.loyalty.AssertNotCalled(t, "InsertEntry", mock.Anything, mock.Anything)
+	p.loyalty.AssertNotCalled(t, "AddPoints", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRedeemPointsInLotOrder(t *testing.T) {
+	// 50 points pay 5000 at checkout, taken from the lot expiring first
+	ctx := context.Background()
+	c := newCheckout(ctx)
+	c.stock(ctx, 7, 100000, 0)
+	c.loyalty.On("GetBalanceForUpdate", ctx, int64(1)).Return(int64(130), nil)
+	c.loyalty.On("GetLots", ctx, int64(1)).Return([]entity.LoyaltyEntry{
+		{ID: 4, Remaining: 30}, {ID: 5, Remaining: 100},
+	}, nil)
+	c.loyalty.On("SetRemaining", ctx, mock.Anything, mock.Anything).Return(nil)
+	c.loyalty.On("InsertEntry", ctx, mock.MatchedBy(func(entry entity.LoyaltyEntry) bool {
+		return entry.Kind == purchase.LoyaltyRedeem && entry.Points == -50 && *entry.OrderID == 30
+	})).Return(int64(6), nil)
+	c.loyalty.On("AddPoints", ctx, int64(1), int64(-50), int64(0)).Return(nil)
+	form := orderForm(dtos.OrderFormProduct{Code: "IP15#7", Quantity: 1})
+	form.Points = 50
+
+	order, _ := c.place(t, ctx, form)
+
+	assert.Equal(t, int64(50), order.PointsRedeemed)
+	assert.Equal(t, "5000", order.PointsAmount.String())
+	assert.Equal(t, [][2]int64{{4, 0}, {5, 80}}, lots(&c.loyalty))
+	c.loyalty.AssertExpectations(t)
+}
+
+func TestReverseSpentPoints(t *testing.T) {
+	// the unit returned takes back 27 of the 90 points the order earned,
+	// the customer spent all but 10 of them: the tier loses the 27 points
+	ctx := context.Background()
+	f := newReturnFlow(ctx, &entity.Return{ID: 9, OrderID: 20, UserID: 1, Status: "requested"})
+	f.returnUnit(ctx)
+	f.loyalty.On("GetOrderEntries", ctx, int64(20)).Return([]entity.LoyaltyEntry{
+		{ID: 5, UserID: 1, Points: 90, Kind: purchase.LoyaltyEarn},
+	}, nil)
+	f.loyalty.On("GetBalanceForUpdate", ctx, int64(1)).Return(int64(10), nil)
+	f.loyalty.On("GetLots", ctx, int64(1)).Return([]entity.LoyaltyEntry{{ID: 8, Remaining: 10}}, nil)
+	f.loyalty.On("SetRemaining", ctx, int64(8), int64(0)).Return(nil)
+	f.loyalty.On("InsertEntry", ctx, mock.MatchedBy(func(entry entity.LoyaltyEntry) bool {
+		return entry.Kind == purchase.LoyaltyReverse && entry.Points == -10
+	})).Return(int64(9), nil)
+	f.loyalty.On("AddPoints", ctx, int64(1), int64(-10), int64(-27)).Return(nil)
+	f.refund.On("Create", ctx, refundMatching(270000, "pending")).Return(int64(1), nil)
+
+	require.NoError(t, f.service().ApproveReturn(ctx, 9, "admin@swipex.vn", dtos.ReturnDecision{Note: "ok"}))
+
+	f.loyalty.AssertExpectations(t)
+}
+
+func TestExpirePoints(t *testing.T) {
+	// the expired lots are emptied, the balance only loses what is left of
+	// it
+	ctx := context.Background()
+	p := newPoints(ctx)
+	p.loyalty.On("GetExpiredUsers", ctx, mock.AnythingOfType("time.Time"), mock.Anything).Return([]int64{1}, nil)
+	p.loyalty.On("GetBalanceForUpdate", ctx, int64(1)).Return(int64(80), nil)
+	p.loyalty.On("GetExpiredLots", ctx, int64(1), mock.AnythingOfType("time.Time")).Return([]entity.LoyaltyEntry{
+		{ID: 4, Remaining: 50}, {ID: 5, Remaining: 60},
+	}, nil)
+	p.loyalty.On("SetRemaining", ctx, mock.Anything, int64(0)).Return(nil)
+	p.loyalty.On("InsertEntry", ctx, mock.MatchedBy(func(entry entity.LoyaltyEntry) bool {
+		return entry.Kind == purchase.LoyaltyExpire && entry.Points == -80 && entry.OrderID == nil
+	})).Return(int64(7), nil)
+	p.loyalty.On("AddPoints", ctx, int64(1), int64(-80), int64(0)).Return(nil)
+
+	require.NoError(t, p.service().ExpirePoints(ctx))
+
+	assert.Equal(t, [][2]int64{{4, 0}, {5, 0}}, lots(&p.loyalty))
+	p.loyalty.AssertExpectations(t)
+	p.conn.AssertCalled(t, "Commit", ctx)
+}
@@ -0,0 +1,68 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/swclabs/swipex/internal/core/domain/entity"
+	"github.com/swclabs/swipex/internal/core/repos/inventories"
+	"github.com/swclabs/swipex/internal/core/repos/orders"
+	"github.com/swclabs/swipex/internal/core/service/purchase"
+	"github.com/swclabs/swipex/pkg/infra/db"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestGetUnpaidPendingByDueTime(t *testing.T) {
+	// only the orders given a payment due time may expire: COD orders,
+	// orders settled at checkout and older orders have none
+	ctx := context.Background()
+	errQuery := errors.New("query")
+	before := time.Date(2026, 10, 17, 8, 0, 0, 0, time.UTC)
+
+	conn := db.NewDatabaseMock()
+	conn.On("Query", ctx, mock.Anything, []interface{}{
+		"pending", before, 500,
+	}).Return(nil, errQuery)
+
+	_, err := orders.New(conn).GetUnpaidPending(ctx, before, 500)
+	assert.ErrorIs(t, err, errQuery)
+	conn.AssertExpectations(t)
+}
+
+func TestExpireOrdersSkipsPaidOrders(t *testing.T) {
+	// an order paid between the lookup and its lock keeps its stock
+	ctx := context.Background()
+	paidAt := time.Now().UTC()
+
+	orderRepo := orders.Mock{}
+	orderRepo.On("GetUnpaidPending", ctx, mock.Anything, mock.Anything).
+		Return([]string{"paid", "confirmed"}, nil)
+
+	conn := db.NewTransactionMock()
+	conn.On("Rollback", ctx).Return(nil)
+	txOrderRepo := orders.Mock{}
+	txOrderRepo.On("GetByUUIDForUpdate", ctx, "paid").
+		Return(&entity.Order{ID: 1, UUID: "paid", Status: "pending", PaidAt: &paidAt}, nil)
+	txOrderRepo.On("GetByUUIDForUpdate", ctx, "confirmed").
+		Return(&entity.Order{ID: 2, UUID: "confirmed", Status: "confirmed"}, nil)
+
+	service := purchase.Purchase{
+		Order: &orderRepo,
+		Begin: func(_ context.Context) (*purchase.Tx, error) {
+			return &purchase.Tx{
+				ITransaction: conn,
+				Order:        &txOrderRepo,
+				Inventory:    &inventories.Mock{},
+			}, nil
+		},
+	}
+	assert.NoError(t, service.ExpireOrders(ctx))
+
+	txOrderRepo.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything)
+	conn.AssertNumberOfCalls(t, "Rollback", 2)
+	conn.AssertNotCalled(t, "Commit", mock.Anything)
+}
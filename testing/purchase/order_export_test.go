@@ -0,0 +1,83 @@
+package test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/swclabs/swipex/internal/core/domain/dtos"
+	"github.com/swclabs/swipex/internal/core/domain/model"
+	"github.com/swclabs/swipex/internal/core/repos/orders"
+	"github.com/swclabs/swipex/internal/core/service/purchase"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+// exportOrders serves Export from a fixed list of line items
+type exportOrders struct {
+	orders.IOrders
+	rows   []model.OrderExportRow
+	filter model.OrderFilter
+}
+
+func (e *exportOrders) Export(_ context.Context, filter model.OrderFilter, fn func(model.OrderExportRow) error) error {
+	e.filter = filter
+	for _, row := range e.rows {
+		if err := fn(row); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func TestExportOrders(t *testing.T) {
+	repo := &exportOrders{rows: []model.OrderExportRow{{
+		UUID:        "ORDER1",
+		Time:        time.Date(2024, 5, 1, 3, 0, 0, 0, time.UTC),
+		Status:      "delivered",
+		FirstName:   "Nguyễn",
+		LastName:    "An, Jr",
+		PhoneNumber: "0901234567",
+		ProductName: "iPhone 15",
+		Quantity:    2,
+		UnitPrice:   decimal.NewFromInt(1000),
+		LineAmount:  decimal.NewFromInt(2000),
+		TotalAmount: decimal.NewFromInt(2200),
+	}}}
+	service := &purchase.Purchase{Order: repo}
+	ctx := context.Background()
+
+	var csv bytes.Buffer
+	rows, err := service.ExportOrders(ctx, dtos.OrderExport{Status: "delivered"}, &csv)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), rows)
+	assert.Equal(t, "delivered", repo.filter.Status)
+	lines := strings.Split(strings.TrimPrefix(csv.String(), "\ufeff"), "\n")
+	assert.True(t, strings.HasPrefix(lines[0], "Order,Date,Status"))
+	assert.Contains(t, lines[1], `ORDER1,2024-05-01 10:00:00,delivered,,,Nguyễn,"An, Jr",0901234567`)
+
+	var file bytes.Buffer
+	_, err = service.ExportOrders(ctx, dtos.OrderExport{Format: "xlsx"}, &file)
+	assert.NoError(t, err)
+	archive, err := zip.NewReader(bytes.NewReader(file.Bytes()), int64(file.Len()))
+	assert.NoError(t, err)
+	var sheet string
+	for _, f := range archive.File {
+		if f.Name == "xl/worksheets/sheet1.xml" {
+			r, err := f.Open()
+			assert.NoError(t, err)
+			raw, _ := io.ReadAll(r)
+			sheet = string(raw)
+		}
+	}
+	assert.Contains(t, sheet, `<t xml:space="preserve">0901234567</t>`, "phone numbers keep their leading zero")
+	assert.Contains(t, sheet, `<c><v>2200</v></c>`)
+
+	_, err = service.ExportOrders(ctx, dtos.OrderExport{From: "yesterday"}, &file)
+	assert.ErrorIs(t, err, purchase.ErrInvalidOrderSearch)
+}
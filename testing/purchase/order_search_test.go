@@ -0,0 +1,71 @@
+package test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/swclabs/swipex/internal/core/domain/dtos"
+	"github.com/swclabs/swipex/internal/core/domain/model"
+	"github.com/swclabs/swipex/internal/core/repos/orders"
+	"github.com/swclabs/swipex/internal/core/service/purchase"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+)
+
+// searchOrders serves Search from a fixed list sorted by descending time
+type searchOrders struct {
+	orders.IOrders
+	orders []model.OrderSummary
+	filter model.OrderFilter
+}
+
+func (s *searchOrders) Search(_ context.Context, filter model.OrderFilter) ([]model.OrderSummary, error) {
+	s.filter = filter
+	var page []model.OrderSummary
+	for _, order := range s.orders {
+		if filter.AfterID != 0 && !order.Time.Before(filter.AfterTime) {
+			continue
+		}
+		if len(page) < filter.Limit {
+			page = append(page, order)
+		}
+	}
+	return page, nil
+}
+
+func TestSearchOrdersCursor(t *testing.T) {
+	now := time.Date(2024, 5, 1, 10, 0, 0, 0, time.UTC)
+	repo := &searchOrders{}
+	for i := int64(1); i <= 3; i++ {
+		repo.orders = append(repo.orders, model.OrderSummary{
+			ID:          i,
+			UUID:        "order-" + string(rune('0'+i)),
+			Time:        now.Add(-time.Duration(i) * time.Hour),
+			TotalAmount: decimal.NewFromInt(i * 1000),
+		})
+	}
+	service := &purchase.Purchase{Order: repo}
+	ctx := context.Background()
+
+	page, err := service.SearchOrders(ctx, dtos.OrderSearch{Limit: 2, To: "2024-05-01"})
+	assert.NoError(t, err)
+	assert.Len(t, page.Orders, 2)
+	assert.NotEmpty(t, page.NextCursor)
+	assert.Equal(t, "-time", repo.filter.Sort)
+	assert.Equal(t, time.Date(2024, 5, 1, 17, 0, 0, 0, time.UTC), repo.filter.To.UTC(),
+		"a day ends at midnight in Vietnam")
+
+	page, err = service.SearchOrders(ctx, dtos.OrderSearch{Limit: 2, Cursor: page.NextCursor})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), repo.filter.AfterID)
+	assert.Len(t, page.Orders, 1)
+	assert.Equal(t, "order-3", page.Orders[0].Code)
+	assert.Empty(t, page.NextCursor)
+
+	_, err = service.SearchOrders(ctx, dtos.OrderSearch{Sort: "total", Cursor: "bm9wZQ"})
+	assert.ErrorIs(t, err, purchase.ErrInvalidOrderSearch)
+	_, err = service.SearchOrders(ctx, dtos.OrderSearch{Status: "lost"})
+	assert.ErrorIs(t, err, purchase.ErrInvalidOrderSearch)
+}
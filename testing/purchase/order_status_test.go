@@ -0,0 +1,26 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/swclabs/swipex/internal/core/domain/enum"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrderStatusTransition(t *testing.T) {
+	assert.True(t, enum.OrderPending.CanTransitionTo(enum.OrderConfirmed))
+	assert.True(t, enum.OrderShipping.CanTransitionTo(enum.OrderDelivered))
+	assert.True(t, enum.OrderDelivered.CanTransitionTo(enum.OrderRefunded))
+
+	assert.False(t, enum.OrderDelivered.CanTransitionTo(enum.OrderPending), "delivered order must not go back to pending")
+	assert.False(t, enum.OrderShipping.CanTransitionTo(enum.OrderCancelled), "shipped order must not be cancelled")
+	assert.False(t, enum.OrderCancelled.CanTransitionTo(enum.OrderExpired), "stock must not be released twice")
+}
+
+func TestOrderStatusLoad(t *testing.T) {
+	var status enum.OrderStatus
+	assert.NoError(t, status.Load("packing"))
+	assert.Equal(t, enum.OrderPacking, status)
+	assert.Error(t, status.Load("active"))
+}
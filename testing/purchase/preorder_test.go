@@ -0,0 +1,53 @@
+package test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/swclabs/swipex/internal/core/domain/dtos"
+	"github.com/swclabs/swipex/internal/core/domain/enum"
+	"github.com/swclabs/swipex/internal/core/repos/orders"
+	"github.com/swclabs/swipex/pkg/infra/db"
+	"github.com/swclabs/swipex/pkg/lib/valid"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestInventoryStatusLoad(t *testing.T) {
+	var status enum.InventoryStatus
+	assert.NoError(t, status.Load("preorder"))
+	assert.Equal(t, enum.InventoryPreorder, status)
+	assert.Error(t, status.Load("backorder"))
+}
+
+func TestPreorderRequestValidate(t *testing.T) {
+	assert.NoError(t, valid.Validate(&dtos.StockReceipt{InventoryID: 1, Quantity: 20}))
+	assert.Error(t, valid.Validate(&dtos.StockReceipt{InventoryID: 1}), "a receipt needs units")
+
+	update := dtos.InvUpdate{ID: "1", Status: "preorder", ExpectedShipDate: "2026-11-20", DepositPercent: "20"}
+	assert.NoError(t, valid.Validate(&update))
+
+	update.ExpectedShipDate = "20/11/2026"
+	assert.Error(t, valid.Validate(&update), "ship date must be YYYY-MM-DD")
+
+	update.ExpectedShipDate, update.Status = "", "sold-out"
+	assert.Error(t, valid.Validate(&update), "status must be known")
+}
+
+func TestGetBackordersOfConfirmedOrders(t *testing.T) {
+	// an order confirmed to ship its units in stock first keeps waiting for
+	// its backordered units until it ships them
+	ctx := context.Background()
+	errQuery := errors.New("query")
+
+	conn := db.NewDatabaseMock()
+	conn.On("Query", ctx, mock.Anything, []interface{}{
+		int64(7), []string{"pending", "confirmed", "packing", "partially_shipped"},
+	}).Return(nil, errQuery)
+
+	_, err := orders.New(conn).GetBackorders(ctx, 7)
+	assert.ErrorIs(t, err, errQuery)
+	conn.AssertExpectations(t)
+}
@@ -0,0 +1,116 @@
+package test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/swclabs/swipex/internal/config"
+	"github.com/swclabs/swipex/internal/core/domain/dtos"
+	"github.com/swclabs/swipex/internal/core/domain/entity"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// stock sets up an inventory of the product of the same ID in stock,
+// priced in the base currency, in a category of the given VAT rate.
+func (c *checkout) stock(ctx context.Context, id int64, price int64, vatRate int64) {
+	c.inventory.On("GetByIDForUpdate", ctx, id).Return(&entity.Inventory{
+		ID: id, ProductID: id, Available: 10, Status: "active",
+		Price: decimal.NewFromInt(price), CurrencyCode: config.BaseCurrency,
+	}, nil)
+	c.inventory.On("Reserve", ctx, id, mock.Anything).Return(9, nil)
+	c.product.On("GetByID", ctx, id).Return(&entity.Product{ID: id, CategoryID: id}, nil)
+	c.category.On("GetByID", ctx, id).Return(&entity.Category{
+		ID: id, VATRate: decimal.NewNullDecimal(decimal.NewFromInt(vatRate)),
+	}, nil)
+}
+
+// place checks out an order paid online and returns the order and its
+// lines as they are stored.
+func (c *checkout) place(t *testing.T, ctx context.Context, form dtos.OrderForm) (entity.Order, []entity.ProductInOrder) {
+	t.Helper()
+	form.PaymentMethod = "vnpay"
+	return c.submit(t, ctx, form)
+}
+
+// submit checks out an order with the payment method of its form and
+// returns the order and its lines as they are stored.
+func (c *checkout) submit(t *testing.T, ctx context.Context, form dtos.OrderForm) (entity.Order, []entity.ProductInOrder) {
+	t.Helper()
+	c.conn.On("Commit", ctx).Return(nil)
+	c.order.On("GetByUUID", ctx, mock.Anything).Return(nil, pgx.ErrNoRows)
+	c.order.On("Create", ctx, mock.Anything).Return(int64(30), nil)
+	c.order.On("InsertProduct", ctx, mock.Anything).Return(nil)
+	c.order.On("InsertStatusHistory", ctx, mock.Anything).Return(nil)
+
+	_, err := c.service().CreateOrderForm(ctx, form)
+	require.NoError(t, err)
+
+	var (
+		order entity.Order
+		lines []entity.ProductInOrder
+	)
+	for _, call := range c.order.Calls {
+		switch call.Method {
+		case "Create":
+			order = call.Arguments.Get(1).(entity.Order)
+		case "InsertProduct":
+			lines = append(lines, call.Arguments.Get(1).(entity.ProductInOrder))
+		}
+	}
+	return order, lines
+}
+
+func TestPriceOrderRoundsTax(t *testing.T) {
+	// 8% of 99999 is 7999.92, VND has no minor unit
+	ctx := context.Background()
+	c := newCheckout(ctx)
+	c.stock(ctx, 7, 99999, 8)
+
+	order, lines := c.place(t, ctx, orderForm(dtos.OrderFormProduct{Code: "IP15#7", Quantity: 1}))
+
+	require.Len(t, lines, 1)
+	assert.Equal(t, "8000", lines[0].TaxAmount.String())
+	assert.Equal(t, "8000", order.TaxAmount.String())
+	assert.Equal(t, "137999", order.TotalAmount.String(), "99999 + 8000 of VAT + 30000 of shipping")
+}
+
+func TestPriceOrderBreakdown(t *testing.T) {
+	// an order in USD at 25000 VND: a 50000 VND coupon is spread over the
+	// lines before VAT, the shipping fee is not taxed
+	ctx := context.Background()
+	c := newCheckout(ctx)
+	c.stock(ctx, 7, 1234567, 10) // 49.38 USD
+	c.stock(ctx, 8, 500000, 8)   // 20 USD
+	c.coupon.On("GetByCodeForUpdate", ctx, "SALE").Return(&entity.Coupons{
+		Code: "SALE", Status: "active", ExpiredAt: time.Now().UTC().Add(time.Hour),
+		DiscountType: "fixed", Discount: decimal.NewFromInt(50000),
+	}, nil)
+	c.coupon.On("GetUsedByUser", ctx, int64(1), "SALE").Return(nil, pgx.ErrNoRows)
+	c.coupon.On("IncreaseUsed", ctx, "SALE").Return(nil)
+	c.coupon.On("Use", ctx, mock.Anything).Return(nil)
+
+	form := orderForm(
+		dtos.OrderFormProduct{Code: "IP15#7", Quantity: 1},
+		dtos.OrderFormProduct{Code: "IP15#8", Quantity: 1},
+	)
+	form.CouponCode = "SALE"
+	form.Currency = "USD"
+	order, lines := c.place(t, ctx, form)
+
+	assert.Equal(t, "USD", order.CurrencyCode)
+	assert.Equal(t, "69.38", order.Subtotal.String())
+	assert.Equal(t, "2", order.DiscountAmount.String())
+	require.Len(t, lines, 2)
+	// 10% of 49.38 - 1.42 of the discount, 8% of 20 - 0.58
+	assert.Equal(t, "4.8", lines[0].TaxAmount.String())
+	assert.Equal(t, "1.55", lines[1].TaxAmount.String())
+	assert.Equal(t, "6.35", order.TaxAmount.String())
+	assert.Equal(t, "1.2", order.ShippingFee.String())
+	assert.Equal(t, "74.93", order.TotalAmount.String())
+}
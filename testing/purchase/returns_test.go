@@ -0,0 +1,222 @@
+package test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/swclabs/swipex/internal/core/domain/dtos"
+	"github.com/swclabs/swipex/internal/core/domain/entity"
+	"github.com/swclabs/swipex/internal/core/domain/enum"
+	"github.com/swclabs/swipex/internal/core/repos/credits"
+	"github.com/swclabs/swipex/internal/core/repos/inventories"
+	"github.com/swclabs/swipex/internal/core/repos/loyalty"
+	"github.com/swclabs/swipex/internal/core/repos/orders"
+	"github.com/swclabs/swipex/internal/core/repos/refunds"
+	"github.com/swclabs/swipex/internal/core/repos/returns"
+	"github.com/swclabs/swipex/internal/core/service/purchase"
+	"github.com/swclabs/swipex/pkg/infra/db"
+	"github.com/swclabs/swipex/pkg/lib/valid"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestReturnRequestValidate(t *testing.T) {
+	req := dtos.ReturnRequest{
+		OrderCode: "ABC123",
+		Reason:    "screen is broken",
+		Items:     []dtos.ReturnItem{{ItemID: 1, Quantity: 1}},
+	}
+	assert.NoError(t, valid.Validate(&req))
+
+	req.Items = []dtos.ReturnItem{}
+	assert.Error(t, valid.Validate(&req), "a return needs at least one line")
+
+	req.Items = []dtos.ReturnItem{{ItemID: 1, Quantity: -1}}
+	assert.Error(t, valid.Validate(&req), "returned quantity must be positive")
+}
+
+func TestReturnStatusLoad(t *testing.T) {
+	var status enum.ReturnStatus
+	assert.NoError(t, status.Load("approved"))
+	assert.Equal(t, enum.ReturnApproved, status)
+	assert.Error(t, status.Load("restocked"))
+}
+
+// returnFlow holds the repositories of the transaction of a return
+// decision. The order ORD20 was paid 900000 for 1000000 of lines, a
+// coupon took the rest.
+type returnFlow struct {
+	conn      *db.TxMock
+	order     orders.Mock
+	ret       returns.Mock
+	refund    refunds.Mock
+	inventory inventories.Mock
+	credit    credits.Mock
+	loyalty   loyalty.Mock
+}
+
+func newReturnFlow(ctx context.Context, ret *entity.Return) *returnFlow {
+	f := &returnFlow{conn: db.NewTransactionMock()}
+	f.conn.On("Rollback", ctx).Return(nil)
+	f.conn.On("Commit", ctx).Return(nil)
+	f.ret.On("GetByIDForUpdate", ctx, ret.ID).Return(ret, nil)
+	f.order.On("GetByID", ctx, int64(20)).Return(&entity.Order{
+		ID: 20, UUID: "ORD20", UserID: 1, Status: "delivered", TotalAmount: decimal.NewFromInt(900000),
+	}, nil)
+	f.order.On("GetProductByOrderID", ctx, int64(20)).Return([]entity.ProductInOrder{
+		{ID: 201, OrderID: 20, InventoryID: 3, Quantity: 2, CurrencyCode: "VND", TotalAmount: decimal.NewFromInt(600000)},
+		{ID: 202, OrderID: 20, InventoryID: 5, Quantity: 1, CurrencyCode: "VND", TotalAmount: decimal.NewFromInt(400000)},
+	}, nil)
+	return f
+}
+
+func (f *returnFlow) service() *purchase.Purchase {
+	return &purchase.Purchase{
+		Begin: func(_ context.Context) (*purchase.Tx, error) {
+			return &purchase.Tx{
+				ITransaction: f.conn,
+				Order:        &f.order,
+				Return:       &f.ret,
+				Refund:       &f.refund,
+				Inventory:    &f.inventory,
+				Credit:       &f.credit,
+				Loyalty:      &f.loyalty,
+			}, nil
+		},
+	}
+}
+
+// returnOne sets up the return of one unit of the first line, which is
+// worth 270000 of what was paid and takes back 27 of the 90 points the
+// order earned.
+func (f *returnFlow) returnOne(ctx context.Context) {
+	f.returnUnit(ctx)
+	f.loyalty.On("GetOrderEntries", ctx, int64(20)).Return([]entity.LoyaltyEntry{
+		{ID: 5, UserID: 1, Points: 90, Kind: purchase.LoyaltyEarn},
+	}, nil)
+	f.loyalty.On("GetBalanceForUpdate", ctx, int64(1)).Return(int64(50), nil)
+	// the points are taken from the lot expiring first
+	f.loyalty.On("GetLots", ctx, int64(1)).Return([]entity.LoyaltyEntry{
+		{ID: 5, Remaining: 20}, {ID: 6, Remaining: 40},
+	}, nil)
+	f.loyalty.On("SetRemaining", ctx, int64(5), int64(0)).Return(nil)
+	f.loyalty.On("SetRemaining", ctx, int64(6), int64(33)).Return(nil)
+	f.loyalty.On("InsertEntry", ctx, mock.MatchedBy(func(entry entity.LoyaltyEntry) bool {
+		return entry.Kind == purchase.LoyaltyReverse && entry.Points == -27 && *entry.OrderID == 20
+	})).Return(int64(7), nil)
+	f.loyalty.On("AddPoints", ctx, int64(1), int64(-27), int64(-27)).Return(nil)
+}
+
+// returnUnit sets up the return of one unit of the first line, but for
+// the points.
+func (f *returnFlow) returnUnit(ctx context.Context) {
+	f.ret.On("GetItems", ctx, int64(9)).Return([]entity.ReturnItem{
+		{ID: 1, ReturnID: 9, ProductInOrderID: 201, Quantity: 1},
+	}, nil)
+	f.inventory.On("Release", ctx, int64(3), int64(1)).Return(1, nil)
+	f.ret.On("MarkItemRestocked", ctx, int64(1)).Return(nil)
+	f.ret.On("UpdateStatus", ctx, int64(9), "approved", "ok").Return(nil)
+	// the second unit of the line is kept, the order stays delivered
+	f.ret.On("GetReturnedQuantity", ctx, int64(201), []string{"approved"}).Return(int64(1), nil)
+}
+
+func refundMatching(amount int64, status string) interface{} {
+	return mock.MatchedBy(func(refund entity.Refund) bool {
+		return refund.OrderID == 20 && *refund.ReturnID == 9 &&
+			refund.Amount.Equal(decimal.NewFromInt(amount)) && refund.Status == status
+	})
+}
+
+func TestApproveReturnToStoreCredit(t *testing.T) {
+	ctx := context.Background()
+	f := newReturnFlow(ctx, &entity.Return{ID: 9, OrderID: 20, UserID: 1, Status: "requested", Reason: "too big"})
+	f.returnOne(ctx)
+	f.credit.On("GetWalletForUpdate", ctx, int64(1)).
+		Return(&entity.Wallet{UserID: 1, Balance: decimal.NewFromInt(10000)}, nil)
+	f.credit.On("SetWalletBalance", ctx, int64(1), mock.MatchedBy(func(balance decimal.Decimal) bool {
+		return balance.Equal(decimal.NewFromInt(280000))
+	})).Return(nil)
+	f.credit.On("InsertEntry", ctx, mock.MatchedBy(func(entry entity.CreditEntry) bool {
+		return entry.Kind == purchase.CreditRefund && entry.Amount.Equal(decimal.NewFromInt(270000)) &&
+			entry.Actor == "admin@swipex.vn"
+	})).Return(int64(1), nil)
+	// a refund to store credit is paid at once
+	f.refund.On("Create", ctx, refundMatching(270000, "succeeded")).Return(int64(1), nil)
+
+	assert.NoError(t, f.service().ApproveReturn(ctx, 9, "admin@swipex.vn",
+		dtos.ReturnDecision{Note: "ok", StoreCredit: true}))
+
+	f.credit.AssertExpectations(t)
+	f.loyalty.AssertExpectations(t)
+	f.inventory.AssertExpectations(t)
+	f.refund.AssertExpectations(t)
+	f.order.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything)
+	f.conn.AssertCalled(t, "Commit", ctx)
+}
+
+func TestApproveReturnToRefund(t *testing.T) {
+	ctx := context.Background()
+	f := newReturnFlow(ctx, &entity.Return{ID: 9, OrderID: 20, UserID: 1, Status: "requested"})
+	f.returnOne(ctx)
+	// the refund is left to the payment service
+	f.refund.On("Create", ctx, refundMatching(270000, "pending")).Return(int64(1), nil)
+
+	assert.NoError(t, f.service().ApproveReturn(ctx, 9, "admin@swipex.vn", dtos.ReturnDecision{Note: "ok"}))
+
+	f.credit.AssertNotCalled(t, "GetWalletForUpdate", mock.Anything, mock.Anything)
+	f.loyalty.AssertExpectations(t)
+	f.refund.AssertExpectations(t)
+	f.inventory.AssertCalled(t, "Release", ctx, int64(3), int64(1))
+	f.conn.AssertCalled(t, "Commit", ctx)
+}
+
+func TestRejectReturn(t *testing.T) {
+	ctx := context.Background()
+	f := newReturnFlow(ctx, &entity.Return{ID: 9, OrderID: 20, UserID: 1, Status: "requested"})
+	f.ret.On("UpdateStatus", ctx, int64(9), "rejected", "used").Return(nil)
+
+	assert.NoError(t, f.service().RejectReturn(ctx, 9, "used"))
+	f.ret.AssertExpectations(t)
+	f.conn.AssertCalled(t, "Commit", ctx)
+
+	// an approved return cannot be rejected afterwards
+	f = newReturnFlow(ctx, &entity.Return{ID: 9, OrderID: 20, UserID: 1, Status: "approved"})
+	var stateErr *purchase.ReturnStateError
+	assert.ErrorAs(t, f.service().RejectReturn(ctx, 9, "used"), &stateErr)
+	f.ret.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	f.conn.AssertCalled(t, "Rollback", ctx)
+}
+
+func TestRestockReturn(t *testing.T) {
+	// the approval skipped the restock of the second line, it is restocked
+	// once the unit is back
+	ctx := context.Background()
+	f := newReturnFlow(ctx, &entity.Return{ID: 9, OrderID: 20, UserID: 1, Status: "approved"})
+	f.ret.On("GetItems", ctx, int64(9)).Return([]entity.ReturnItem{
+		{ID: 1, ReturnID: 9, ProductInOrderID: 201, Quantity: 2, Restocked: true},
+		{ID: 2, ReturnID: 9, ProductInOrderID: 202, Quantity: 1},
+	}, nil)
+	f.inventory.On("Release", ctx, int64(5), int64(1)).Return(1, nil)
+	f.ret.On("MarkItemRestocked", ctx, int64(2)).Return(nil)
+
+	assert.NoError(t, f.service().RestockReturn(ctx, 9))
+	f.inventory.AssertNumberOfCalls(t, "Release", 1)
+	f.ret.AssertExpectations(t)
+	f.conn.AssertCalled(t, "Commit", ctx)
+}
+
+func TestRestockReturnTwice(t *testing.T) {
+	ctx := context.Background()
+	f := newReturnFlow(ctx, &entity.Return{ID: 9, OrderID: 20, UserID: 1, Status: "approved"})
+	f.ret.On("GetItems", ctx, int64(9)).Return([]entity.ReturnItem{
+		{ID: 1, ReturnID: 9, ProductInOrderID: 201, Quantity: 1, Restocked: true},
+	}, nil)
+
+	var stateErr *purchase.ReturnStateError
+	assert.ErrorAs(t, f.service().RestockReturn(ctx, 9), &stateErr)
+	f.inventory.AssertNotCalled(t, "Release", mock.Anything, mock.Anything, mock.Anything)
+	f.conn.AssertCalled(t, "Rollback", ctx)
+	f.conn.AssertNotCalled(t, "Commit", mock.Anything)
+}
@@ -0,0 +1,183 @@
+package test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/swclabs/swipex/internal/core/domain/dtos"
+	"github.com/swclabs/swipex/internal/core/domain/entity"
+	"github.com/swclabs/swipex/internal/core/domain/enum"
+	"github.com/swclabs/swipex/internal/core/repos/deliveries"
+	"github.com/swclabs/swipex/internal/core/repos/orders"
+	"github.com/swclabs/swipex/internal/core/repos/shipments"
+	"github.com/swclabs/swipex/internal/core/service/purchase"
+	"github.com/swclabs/swipex/internal/core/tasks"
+	"github.com/swclabs/swipex/pkg/infra/db"
+	"github.com/swclabs/swipex/pkg/lib/valid"
+	"github.com/swclabs/swipex/pkg/lib/worker"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShipmentStatusTransition(t *testing.T) {
+	assert.True(t, enum.ShipmentPending.CanTransitionTo(enum.ShipmentShipping))
+	assert.True(t, enum.ShipmentShipping.CanTransitionTo(enum.ShipmentDelivered))
+	assert.False(t, enum.ShipmentPending.CanTransitionTo(enum.ShipmentDelivered), "shipment must leave before it arrives")
+	assert.False(t, enum.ShipmentDelivered.CanTransitionTo(enum.ShipmentShipping))
+
+	assert.False(t, enum.ShipmentPending.Shipped())
+	assert.True(t, enum.ShipmentDelivered.Shipped())
+}
+
+func TestOrderPartiallyShipped(t *testing.T) {
+	var status enum.OrderStatus
+	assert.NoError(t, status.Load("partially_shipped"))
+	assert.True(t, enum.OrderPacking.CanTransitionTo(enum.OrderPartiallyShipped))
+	assert.True(t, enum.OrderPartiallyShipped.CanTransitionTo(enum.OrderShipping))
+	assert.False(t, enum.OrderPartiallyShipped.CanTransitionTo(enum.OrderCancelled), "shipped units must not be cancelled")
+}
+
+func TestShipmentForm(t *testing.T) {
+	form := dtos.ShipmentForm{Items: []dtos.ShipmentFormItem{{ItemID: 1, Quantity: 2}}}
+	assert.NoError(t, valid.Validate(&form))
+
+	assert.Error(t, valid.Validate(&dtos.ShipmentForm{}), "shipment must carry units")
+	form.Items[0].Quantity = 0
+	assert.Error(t, valid.Validate(&form))
+	assert.Error(t, valid.Validate(&dtos.ShipmentUpdate{Status: "pending"}))
+}
+
+// shipping holds the transaction of the shipments of order ORD40: three
+// units of item 401, one of them backordered, and one unit of item 402.
+type shipping struct {
+	conn     *db.TxMock
+	order    orders.Mock
+	delivery deliveries.Mock
+	shipment shipments.Mock
+	worker   worker.Mock
+}
+
+func newShipping(ctx context.Context, status enum.OrderStatus) *shipping {
+	s := &shipping{conn: db.NewTransactionMock()}
+	s.conn.On("Rollback", ctx).Return(nil)
+	s.conn.On("Commit", ctx).Return(nil)
+	order := &entity.Order{ID: 40, UUID: "ORD40", DeliveryID: 4, Status: status.String()}
+	s.order.On("GetByID", ctx, int64(40)).Return(order, nil)
+	s.order.On("GetByUUIDForUpdate", ctx, "ORD40").Return(order, nil)
+	s.order.On("GetProductByOrderID", ctx, int64(40)).Return([]entity.ProductInOrder{
+		{ID: 401, OrderID: 40, Quantity: 3, Backordered: 1},
+		{ID: 402, OrderID: 40, Quantity: 1},
+	}, nil)
+	return s
+}
+
+func (s *shipping) service() *purchase.Purchase {
+	return &purchase.Purchase{
+		Worker: &s.worker,
+		Begin: func(_ context.Context) (*purchase.Tx, error) {
+			return &purchase.Tx{ITransaction: s.conn, Order: &s.order, Delivery: &s.delivery, Shipment: &s.shipment}, nil
+		},
+	}
+}
+
+func TestCreatePartialShipment(t *testing.T) {
+	// one unit of item 401 already left, the backordered unit waits for
+	// stock: one unit is left to ship
+	ctx := context.Background()
+	s := newShipping(ctx, enum.OrderPartiallyShipped)
+	s.shipment.On("GetItemsByOrderID", ctx, int64(40)).Return([]entity.ShipmentItem{
+		{ShipmentID: 7, ItemID: 401, Quantity: 1},
+	}, nil)
+	s.delivery.On("GetByID", ctx, int64(4)).Return(&entity.Delivery{ID: 4, UserID: 1, AddressID: 2, Method: "ghn"}, nil)
+	s.delivery.On("Create", ctx, mock.MatchedBy(func(delivery entity.Delivery) bool {
+		return delivery.AddressID == 2 && delivery.Method == "ghn" && delivery.Status == enum.ShipmentPending.String()
+	})).Return(int64(12), nil)
+	s.shipment.On("Create", ctx, mock.MatchedBy(func(shipment entity.Shipment) bool {
+		return shipment.OrderID == 40 && shipment.DeliveryID == 12 && shipment.Actor == "admin@swipex.vn"
+	})).Return(int64(8), nil)
+	s.shipment.On("InsertItem", ctx, mock.Anything).Return(nil)
+
+	for name, items := range map[string][]dtos.ShipmentFormItem{
+		"backordered unit": {{ItemID: 401, Quantity: 2}},
+		"split lines":      {{ItemID: 401, Quantity: 1}, {ItemID: 401, Quantity: 1}},
+		"unknown item":     {{ItemID: 999, Quantity: 1}},
+	} {
+		_, err := s.service().CreateShipment(ctx, "admin@swipex.vn", "ORD40", dtos.ShipmentForm{Items: items})
+		assert.ErrorIs(t, err, purchase.ErrInvalidShipment, name)
+	}
+	s.shipment.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+
+	shipmentID, err := s.service().CreateShipment(ctx, "admin@swipex.vn", "ORD40", dtos.ShipmentForm{
+		Items: []dtos.ShipmentFormItem{{ItemID: 401, Quantity: 1}, {ItemID: 402, Quantity: 1}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, int64(8), shipmentID)
+	s.shipment.AssertCalled(t, "InsertItem", ctx, entity.ShipmentItem{ShipmentID: 8, ItemID: 401, Quantity: 1})
+	s.shipment.AssertCalled(t, "InsertItem", ctx, entity.ShipmentItem{ShipmentID: 8, ItemID: 402, Quantity: 1})
+	s.conn.AssertCalled(t, "Commit", ctx)
+}
+
+func TestShipmentMovesOrder(t *testing.T) {
+	// shipment 7 carries the two units of item 401 in stock, shipment 8 the
+	// unit of item 402 and shipment 9 the backordered unit
+	items := []entity.ShipmentItem{
+		{ShipmentID: 7, ItemID: 401, Quantity: 2},
+		{ShipmentID: 8, ItemID: 402, Quantity: 1},
+		{ShipmentID: 9, ItemID: 401, Quantity: 1},
+	}
+	for _, step := range []struct {
+		name     string
+		order    enum.OrderStatus
+		shipment int64
+		from, to enum.ShipmentStatus
+		after    [3]enum.ShipmentStatus
+		want     enum.OrderStatus
+	}{
+		{"first shipment leaves", enum.OrderPacking, 7, enum.ShipmentPending, enum.ShipmentShipping,
+			[3]enum.ShipmentStatus{enum.ShipmentShipping, enum.ShipmentPending, enum.ShipmentPending}, enum.OrderPartiallyShipped},
+		{"another part leaves", enum.OrderPartiallyShipped, 8, enum.ShipmentPending, enum.ShipmentShipping,
+			[3]enum.ShipmentStatus{enum.ShipmentShipping, enum.ShipmentShipping, enum.ShipmentPending}, ""},
+		{"last units leave", enum.OrderPartiallyShipped, 9, enum.ShipmentPending, enum.ShipmentShipping,
+			[3]enum.ShipmentStatus{enum.ShipmentShipping, enum.ShipmentShipping, enum.ShipmentShipping}, enum.OrderShipping},
+		{"a shipment arrives", enum.OrderShipping, 7, enum.ShipmentShipping, enum.ShipmentDelivered,
+			[3]enum.ShipmentStatus{enum.ShipmentDelivered, enum.ShipmentShipping, enum.ShipmentShipping}, ""},
+		{"all shipments arrived", enum.OrderShipping, 9, enum.ShipmentShipping, enum.ShipmentDelivered,
+			[3]enum.ShipmentStatus{enum.ShipmentDelivered, enum.ShipmentDelivered, enum.ShipmentDelivered}, enum.OrderDelivered},
+	} {
+		t.Run(step.name, func(t *testing.T) {
+			ctx := context.Background()
+			s := newShipping(ctx, step.order)
+			s.shipment.On("GetByIDForUpdate", ctx, step.shipment).Return(&entity.Shipment{
+				ID: step.shipment, OrderID: 40, Status: step.from.String(),
+			}, nil)
+			s.shipment.On("UpdateStatus", ctx, step.shipment, step.to.String(), mock.AnythingOfType("time.Time")).Return(nil)
+			s.shipment.On("GetByOrderID", ctx, int64(40)).Return([]entity.Shipment{
+				{ID: 7, OrderID: 40, Status: step.after[0].String()},
+				{ID: 8, OrderID: 40, Status: step.after[1].String()},
+				{ID: 9, OrderID: 40, Status: step.after[2].String()},
+			}, nil)
+			s.shipment.On("GetItemsByOrderID", ctx, int64(40)).Return(items, nil)
+			s.order.On("UpdateStatus", ctx, "ORD40", mock.Anything).Return(nil)
+			s.order.On("InsertStatusHistory", ctx, mock.Anything).Return(nil)
+			s.worker.On("Exec", ctx, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+			require.NoError(t, s.service().UpdateShipment(ctx, "admin@swipex.vn", step.shipment,
+				dtos.ShipmentUpdate{Status: step.to.String()}))
+
+			if step.want == "" {
+				s.order.AssertNotCalled(t, "UpdateStatus", mock.Anything, mock.Anything, mock.Anything)
+			} else {
+				s.order.AssertCalled(t, "UpdateStatus", ctx, "ORD40", step.want.String())
+			}
+			// the points and the invoice wait for the whole order
+			if step.want == enum.OrderDelivered {
+				s.worker.AssertCalled(t, "Exec", ctx, mock.Anything, tasks.PurchaseEarnPoints, mock.Anything)
+				s.worker.AssertCalled(t, "Exec", ctx, mock.Anything, tasks.PurchaseGenerateInvoice, mock.Anything)
+			} else {
+				s.worker.AssertNotCalled(t, "Exec", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
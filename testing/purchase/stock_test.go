@@ -0,0 +1,157 @@
+package test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/swclabs/swipex/internal/config"
+	"github.com/swclabs/swipex/internal/core/domain/dtos"
+	"github.com/swclabs/swipex/internal/core/domain/entity"
+	"github.com/swclabs/swipex/internal/core/domain/model"
+	"github.com/swclabs/swipex/internal/core/repos/addresses"
+	"github.com/swclabs/swipex/internal/core/repos/bundles"
+	"github.com/swclabs/swipex/internal/core/repos/categories"
+	"github.com/swclabs/swipex/internal/core/repos/cod"
+	"github.com/swclabs/swipex/internal/core/repos/coupons"
+	"github.com/swclabs/swipex/internal/core/repos/credits"
+	"github.com/swclabs/swipex/internal/core/repos/currencies"
+	"github.com/swclabs/swipex/internal/core/repos/deliveries"
+	"github.com/swclabs/swipex/internal/core/repos/inventories"
+	"github.com/swclabs/swipex/internal/core/repos/loyalty"
+	"github.com/swclabs/swipex/internal/core/repos/orders"
+	"github.com/swclabs/swipex/internal/core/repos/products"
+	"github.com/swclabs/swipex/internal/core/repos/users"
+	"github.com/swclabs/swipex/internal/core/service/purchase"
+	"github.com/swclabs/swipex/pkg/infra/db"
+
+	"github.com/shopspring/decimal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// checkout holds the repositories of a checkout transaction, the customer,
+// the address and the delivery of the order are already set up.
+type checkout struct {
+	conn      *db.TxMock
+	user      users.Mock
+	address   addresses.Mock
+	delivery  deliveries.Mock
+	inventory inventories.Mock
+	order     orders.Mock
+	currency  currencies.Mock
+	product   products.Mock
+	category  categories.Mock
+	coupon    coupons.Mock
+	credit    credits.Mock
+	loyalty   loyalty.Mock
+	cod       cod.Mock
+	bundle    bundles.Mock
+}
+
+func newCheckout(ctx context.Context) *checkout {
+	c := &checkout{conn: db.NewTransactionMock()}
+	c.conn.On("Rollback", ctx).Return(nil)
+	c.currency.On("GetRates", ctx).Return(model.ExchangeRates{
+		config.BaseCurrency: decimal.NewFromInt(1), "USD": decimal.NewFromInt(25000),
+	}, nil)
+	c.user.On("GetByEmail", ctx, "an@swipex.vn").Return(&entity.User{ID: 1, Email: "an@swipex.vn"}, nil)
+	c.address.On("Insert", ctx, mock.Anything).Return(int64(1), nil)
+	c.delivery.On("Create", ctx, mock.Anything).Return(int64(1), nil)
+	return c
+}
+
+func (c *checkout) service() *purchase.Purchase {
+	return &purchase.Purchase{
+		Currency: &c.currency,
+		Product:  &c.product,
+		Category: &c.category,
+		Begin: func(_ context.Context) (*purchase.Tx, error) {
+			return &purchase.Tx{
+				ITransaction: c.conn,
+				User:         &c.user,
+				Address:      &c.address,
+				Delivery:     &c.delivery,
+				Inventory:    &c.inventory,
+				Order:        &c.order,
+				Coupon:       &c.coupon,
+				Credit:       &c.credit,
+				Loyalty:      &c.loyalty,
+				COD:          &c.cod,
+				Bundle:       &c.bundle,
+			}, nil
+		},
+	}
+}
+
+func orderForm(products ...dtos.OrderFormProduct) dtos.OrderForm {
+	return dtos.OrderForm{
+		PaymentMethod: "cod",
+		Customer:      dtos.OrderFormCustomer{Email: "an@swipex.vn", FirstName: "An", LastName: "Nguyen"},
+		Address:       dtos.OrderFormAddress{City: "HCM", Ward: "1", District: "1", Street: "Le Loi"},
+		Product:       products,
+	}
+}
+
+func TestCheckoutInsufficientStock(t *testing.T) {
+	ctx := context.Background()
+	c := newCheckout(ctx)
+	c.inventory.On("GetByIDForUpdate", ctx, int64(3)).
+		Return(&entity.Inventory{ID: 3, Available: 1, Status: "active"}, nil)
+
+	_, err := c.service().CreateOrderForm(ctx, orderForm(dtos.OrderFormProduct{Code: "IP15#3", Quantity: 2}))
+
+	var stockErr *purchase.InsufficientStockError
+	assert.ErrorAs(t, err, &stockErr)
+	assert.Equal(t, purchase.InsufficientStockError{InventoryID: 3, Requested: 2, Available: 1}, *stockErr)
+	c.inventory.AssertNotCalled(t, "Reserve", mock.Anything, mock.Anything, mock.Anything)
+	c.conn.AssertCalled(t, "Rollback", ctx)
+	c.conn.AssertNotCalled(t, "Commit", mock.Anything)
+}
+
+func TestCheckoutNonPositiveQuantity(t *testing.T) {
+	// a negative line must not lower the quantity of another line of the
+	// same inventory, nor give stock back
+	ctx := context.Background()
+	c := newCheckout(ctx)
+	c.inventory.On("GetByIDForUpdate", ctx, int64(7)).
+		Return(&entity.Inventory{ID: 7, Available: 10, Status: "active"}, nil)
+
+	_, err := c.service().CreateOrderForm(ctx, orderForm(
+		dtos.OrderFormProduct{Code: "IP15#7", Quantity: 1},
+		dtos.OrderFormProduct{Code: "IP15#7", Quantity: -5},
+	))
+
+	assert.ErrorIs(t, err, purchase.ErrInvalidQuantity)
+	c.inventory.AssertNotCalled(t, "Reserve", mock.Anything, mock.Anything, mock.Anything)
+	c.conn.AssertCalled(t, "Rollback", ctx)
+}
+
+func TestCheckoutLocksInventoriesInOrder(t *testing.T) {
+	// concurrent checkouts lock the same rows in the same order, so that
+	// they cannot deadlock each other
+	ctx := context.Background()
+	c := newCheckout(ctx)
+	for _, id := range []int64{2, 5, 9} {
+		c.inventory.On("GetByIDForUpdate", ctx, id).
+			Return(&entity.Inventory{ID: id, Available: 10, Status: "active"}, nil)
+		c.inventory.On("Reserve", ctx, id, int64(1)).Return(9, nil)
+	}
+	// the order stops once the stock is reserved, when its lines are priced
+	c.product.On("GetByID", ctx, mock.Anything).Return((*entity.Product)(nil), assert.AnError)
+
+	_, err := c.service().CreateOrderForm(ctx, orderForm(
+		dtos.OrderFormProduct{Code: "IP15#9", Quantity: 1},
+		dtos.OrderFormProduct{Code: "IP15#2", Quantity: 1},
+		dtos.OrderFormProduct{Code: "IP15#5", Quantity: 1},
+	))
+	assert.ErrorIs(t, err, assert.AnError)
+
+	var locked []int64
+	for _, call := range c.inventory.Calls {
+		if call.Method == "GetByIDForUpdate" && len(locked) < 3 {
+			locked = append(locked, call.Arguments.Get(1).(int64))
+		}
+	}
+	assert.Equal(t, []int64{2, 5, 9}, locked)
+	c.inventory.AssertNumberOfCalls(t, "Reserve", 3)
+}
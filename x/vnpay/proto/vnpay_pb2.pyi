@@ -1,6 +1,7 @@
+from google.protobuf.internal import containers as _containers
 from google.protobuf import descriptor as _descriptor
 from google.protobuf import message as _message
-from typing import ClassVar as _ClassVar, Optional as _Optional
+from typing import ClassVar as _ClassVar, Mapping as _Mapping, Optional as _Optional
 
 DESCRIPTOR: _descriptor.FileDescriptor
 
@@ -33,7 +34,14 @@ class PaymentResponse(_message.Message):
     def __init__(self, payment_url: _Optional[str] = ..., message: _Optional[str] = ..., success: bool = ...) -> None: ...
 
 class PaymentReturnRequest(_message.Message):
-    __slots__ = ("vnp_TmnCode", "vnp_Amount", "vnp_BankCode", "vnp_BankTranNo", "vnp_CardType", "vnp_PayDate", "vnp_OrderInfo", "vnp_TransactionNo", "vnp_ResponseCode", "vnp_TransactionStatus", "vnp_TxnRef", "vnp_SecureHashType", "vnp_SecureHash")
+    __slots__ = ("vnp_TmnCode", "vnp_Amount", "vnp_BankCode", "vnp_BankTranNo", "vnp_CardType", "vnp_PayDate", "vnp_OrderInfo", "vnp_TransactionNo", "vnp_ResponseCode", "vnp_TransactionStatus", "vnp_TxnRef", "vnp_SecureHashType", "vnp_SecureHash", "params")
+    class ParamsEntry(_message.Message):
+        __slots__ = ("key", "value")
+        KEY_FIELD_NUMBER: _ClassVar[int]
+        VALUE_FIELD_NUMBER: _ClassVar[int]
+        key: str
+        value: str
+        def __init__(self, key: _Optional[str] = ..., value: _Optional[str] = ...) -> None: ...
     VNP_TMNCODE_FIELD_NUMBER: _ClassVar[int]
     VNP_AMOUNT_FIELD_NUMBER: _ClassVar[int]
     VNP_BANKCODE_FIELD_NUMBER: _ClassVar[int]
@@ -47,6 +55,7 @@ class PaymentReturnRequest(_message.Message):
     VNP_TXNREF_FIELD_NUMBER: _ClassVar[int]
     VNP_SECUREHASHTYPE_FIELD_NUMBER: _ClassVar[int]
     VNP_SECUREHASH_FIELD_NUMBER: _ClassVar[int]
+    PARAMS_FIELD_NUMBER: _ClassVar[int]
     vnp_TmnCode: str
     vnp_Amount: int
     vnp_BankCode: str
@@ -60,7 +69,8 @@ class PaymentReturnRequest(_message.Message):
     vnp_TxnRef: str
     vnp_SecureHashType: str
     vnp_SecureHash: str
-    def __init__(self, vnp_TmnCode: _Optional[str] = ..., vnp_Amount: _Optional[int] = ..., vnp_BankCode: _Optional[str] = ..., vnp_BankTranNo: _Optional[str] = ..., vnp_CardType: _Optional[str] = ..., vnp_PayDate: _Optional[str] = ..., vnp_OrderInfo: _Optional[str] = ..., vnp_TransactionNo: _Optional[int] = ..., vnp_ResponseCode: _Optional[str] = ..., vnp_TransactionStatus: _Optional[str] = ..., vnp_TxnRef: _Optional[str] = ..., vnp_SecureHashType: _Optional[str] = ..., vnp_SecureHash: _Optional[str] = ...) -> None: ...
+    params: _containers.ScalarMap[str, str]
+    def __init__(self, vnp_TmnCode: _Optional[str] = ..., vnp_Amount: _Optional[int] = ..., vnp_BankCode: _Optional[str] = ..., vnp_BankTranNo: _Optional[str] = ..., vnp_CardType: _Optional[str] = ..., vnp_PayDate: _Optional[str] = ..., vnp_OrderInfo: _Optional[str] = ..., vnp_TransactionNo: _Optional[int] = ..., vnp_ResponseCode: _Optional[str] = ..., vnp_TransactionStatus: _Optional[str] = ..., vnp_TxnRef: _Optional[str] = ..., vnp_SecureHashType: _Optional[str] = ..., vnp_SecureHash: _Optional[str] = ..., params: _Optional[_Mapping[str, str]] = ...) -> None: ...
 
 class PaymentReturnResponse(_message.Message):
     __slots__ = ("result", "message", "order_id", "amount", "order_desc", "vnp_TransactionNo", "vnp_ResponseCode", "success")
@@ -82,6 +92,40 @@ class PaymentReturnResponse(_message.Message):
     success: bool
     def __init__(self, result: _Optional[str] = ..., message: _Optional[str] = ..., order_id: _Optional[str] = ..., amount: _Optional[int] = ..., order_desc: _Optional[str] = ..., vnp_TransactionNo: _Optional[str] = ..., vnp_ResponseCode: _Optional[str] = ..., success: bool = ...) -> None: ...
 
+class RefundRequest(_message.Message):
+    __slots__ = ("order_id", "transaction_no", "transaction_date", "amount", "full", "order_info", "create_by", "ip_address", "request_id")
+    ORDER_ID_FIELD_NUMBER: _ClassVar[int]
+    TRANSACTION_NO_FIELD_NUMBER: _ClassVar[int]
+    TRANSACTION_DATE_FIELD_NUMBER: _ClassVar[int]
+    AMOUNT_FIELD_NUMBER: _ClassVar[int]
+    FULL_FIELD_NUMBER: _ClassVar[int]
+    ORDER_INFO_FIELD_NUMBER: _ClassVar[int]
+    CREATE_BY_FIELD_NUMBER: _ClassVar[int]
+    IP_ADDRESS_FIELD_NUMBER: _ClassVar[int]
+    REQUEST_ID_FIELD_NUMBER: _ClassVar[int]
+    order_id: str
+    transaction_no: str
+    transaction_date: str
+    amount: int
+    full: bool
+    order_info: str
+    create_by: str
+    ip_address: str
+    request_id: str
+    def __init__(self, order_id: _Optional[str] = ..., transaction_no: _Optional[str] = ..., transaction_date: _Optional[str] = ..., amount: _Optional[int] = ..., full: bool = ..., order_info: _Optional[str] = ..., create_by: _Optional[str] = ..., ip_address: _Optional[str] = ..., request_id: _Optional[str] = ...) -> None: ...
+
+class RefundResponse(_message.Message):
+    __slots__ = ("response_code", "message", "transaction_no", "success")
+    RESPONSE_CODE_FIELD_NUMBER: _ClassVar[int]
+    MESSAGE_FIELD_NUMBER: _ClassVar[int]
+    TRANSACTION_NO_FIELD_NUMBER: _ClassVar[int]
+    SUCCESS_FIELD_NUMBER: _ClassVar[int]
+    response_code: str
+    message: str
+    transaction_no: str
+    success: bool
+    def __init__(self, response_code: _Optional[str] = ..., message: _Optional[str] = ..., transaction_no: _Optional[str] = ..., success: bool = ...) -> None: ...
+
 class StatusRequest(_message.Message):
     __slots__ = ()
     def __init__(self) -> None: ...
@@ -50,6 +50,11 @@ class VNPayStub(object):
                 request_serializer=proto_dot_vnpay__pb2.PaymentReturnRequest.SerializeToString,
                 response_deserializer=proto_dot_vnpay__pb2.PaymentReturnResponse.FromString,
                 _registered_method=True)
+        self.Refund = channel.unary_unary(
+                '/payment.VNPay/Refund',
+                request_serializer=proto_dot_vnpay__pb2.RefundRequest.SerializeToString,
+                response_deserializer=proto_dot_vnpay__pb2.RefundResponse.FromString,
+                _registered_method=True)
 
 
 class VNPayServicer(object):
@@ -74,6 +79,12 @@ class VNPayServicer(object):
         context.set_details('Method not implemented!')
         raise NotImplementedError('Method not implemented!')
 
+    def Refund(self, request, context):
+        """Missing associated documentation comment in .proto file."""
+        context.set_code(grpc.StatusCode.UNIMPLEMENTED)
+        context.set_details('Method not implemented!')
+        raise NotImplementedError('Method not implemented!')
+
 
 def add_VNPayServicer_to_server(servicer, server):
     rpc_method_handlers = {
@@ -92,6 +103,11 @@ def add_VNPayServicer_to_server(servicer, server):
                     request_deserializer=proto_dot_vnpay__pb2.PaymentReturnRequest.FromString,
                     response_serializer=proto_dot_vnpay__pb2.PaymentReturnResponse.SerializeToString,
             ),
+            'Refund': grpc.unary_unary_rpc_method_handler(
+                    servicer.Refund,
+                    request_deserializer=proto_dot_vnpay__pb2.RefundRequest.FromString,
+                    response_serializer=proto_dot_vnpay__pb2.RefundResponse.SerializeToString,
+            ),
     }
     generic_handler = grpc.method_handlers_generic_handler(
             'payment.VNPay', rpc_method_handlers)
@@ -184,3 +200,30 @@ class VNPay(object):
             timeout,
             metadata,
             _registered_method=True)
+
+    @staticmethod
+    def Refund(request,
+            target,
+            options=(),
+            channel_credentials=None,
+            call_credentials=None,
+            insecure=False,
+            compression=None,
+            wait_for_ready=None,
+            timeout=None,
+            metadata=None):
+        return grpc.experimental.unary_unary(
+            request,
+            target,
+            '/payment.VNPay/Refund',
+            proto_dot_vnpay__pb2.RefundRequest.SerializeToString,
+            proto_dot_vnpay__pb2.RefundResponse.FromString,
+            options,
+            channel_credentials,
+            insecure,
+            call_credentials,
+            compression,
+            wait_for_ready,
+            timeout,
+            metadata,
+            _registered_method=True)
@@ -3,6 +3,9 @@ import proto.vnpay_pb2_grpc as vnpay_pb2_grpc
 from vnpay import vnpay
 import settings
 from datetime import datetime
+import uuid
+import grpc
+from google.protobuf.json_format import MessageToDict
 
 class VNPayServicer(vnpay_pb2_grpc.VNPayServicer):
     def CheckStatus(self, request, context):
@@ -37,9 +40,14 @@ class VNPayServicer(vnpay_pb2_grpc.VNPayServicer):
         except Exception as e:
             return vnpay_pb2.PaymentResponse(payment_url='', message=str(e), success=False)
     
-    def ProcessPaymentIPN(self, request: vnpay_pb2.PaymentReturnRequest, context):
+    def ProcessPaymentReturn(self, request: vnpay_pb2.PaymentReturnRequest, context):
         vnp = vnpay()
-        vnp.responseData = request.dict()
+        # the parameters as received are what VNPay signed, the typed fields
+        # lose the ones sent empty or zero
+        if request.params:
+            vnp.responseData = dict(request.params)
+        else:
+            vnp.responseData = MessageToDict(request, preserving_proto_field_name=True)
         if vnp.validate_response(settings.VNPAY_HASH_SECRET_KEY):
             return vnpay_pb2.PaymentReturnResponse(
                 success=True,
@@ -48,7 +56,37 @@ class VNPayServicer(vnpay_pb2_grpc.VNPayServicer):
                 amount=request.vnp_Amount, 
                 order_id=request.vnp_TxnRef, 
                 order_desc=request.vnp_OrderInfo, 
-                vnp_TransactionNo=request.vnp_TransactionNo, 
+                vnp_TransactionNo=str(request.vnp_TransactionNo),
                 vnp_ResponseCode=request.vnp_ResponseCode,
             )
-        return vnpay_pb2.PaymentReturnResponse(result="fail", message="Checksum failed", success=False)
\ No newline at end of file
+        return vnpay_pb2.PaymentReturnResponse(result="fail", message="Checksum failed", success=False)
+
+    def Refund(self, request: vnpay_pb2.RefundRequest, context):
+        vnp = vnpay()
+        vnp.requestData = {
+            'vnp_RequestId': request.request_id or uuid.uuid4().hex,
+            'vnp_Version': '2.1.0',
+            'vnp_Command': 'refund',
+            'vnp_TmnCode': settings.VNPAY_TMN_CODE,
+            # 02 refunds the whole payment, 03 a part of it
+            'vnp_TransactionType': '02' if request.full else '03',
+            'vnp_TxnRef': request.order_id,
+            'vnp_Amount': request.amount * 100,
+            'vnp_OrderInfo': request.order_info,
+            'vnp_TransactionNo': request.transaction_no,
+            'vnp_TransactionDate': request.transaction_date,
+            'vnp_CreateBy': request.create_by,
+            'vnp_CreateDate': datetime.now().strftime('%Y%m%d%H%M%S'),
+            'vnp_IpAddr': request.ip_address,
+        }
+        try:
+            answer = vnp.refund(settings.VNPAY_API_URL, settings.VNPAY_HASH_SECRET_KEY)
+        except Exception as e:
+            context.abort(grpc.StatusCode.UNAVAILABLE, str(e))
+        response_code = answer.get('vnp_ResponseCode', '')
+        return vnpay_pb2.RefundResponse(
+            response_code=response_code,
+            message=answer.get('vnp_Message', ''),
+            transaction_no=str(answer.get('vnp_TransactionNo', '')),
+            success=response_code == '00',
+        )
@@ -1,6 +1,8 @@
 import hashlib
 import hmac
+import json
 import urllib.parse
+import urllib.request
 
 class vnpay:
     requestData = {}
@@ -47,6 +49,21 @@ class vnpay:
 
         return vnp_SecureHash == hashValue
 
+    def refund(self, vnpay_api_url, secret_key):
+        # the merchant API signs the fields joined with | in this order
+        keys = ['vnp_RequestId', 'vnp_Version', 'vnp_Command', 'vnp_TmnCode', 'vnp_TransactionType',
+                'vnp_TxnRef', 'vnp_Amount', 'vnp_TransactionNo', 'vnp_TransactionDate', 'vnp_CreateBy',
+                'vnp_CreateDate', 'vnp_IpAddr', 'vnp_OrderInfo']
+        hasData = '|'.join(str(self.requestData.get(key, '')) for key in keys)
+        self.requestData['vnp_SecureHash'] = self.__hmacsha512(secret_key, hasData)
+        request = urllib.request.Request(
+            vnpay_api_url,
+            data=json.dumps(self.requestData).encode('utf-8'),
+            headers={'Content-Type': 'application/json'},
+        )
+        with urllib.request.urlopen(request, timeout=30) as response:
+            return json.loads(response.read())
+
     @staticmethod
     def __hmacsha512(key, data):
         byteKey = key.encode('utf-8')